@@ -1,6 +1,7 @@
 package root
 
 import (
+	"cmp"
 	"fmt"
 	"log/slog"
 	"os"
@@ -23,6 +24,7 @@ type apiFlags struct {
 	fakeResponses    string
 	recordPath       string
 	connectRPC       bool
+	authToken        string
 	runConfig        config.RuntimeConfig
 }
 
@@ -43,6 +45,7 @@ func newAPICmd() *cobra.Command {
 	cmd.PersistentFlags().StringVar(&flags.fakeResponses, "fake", "", "Replay AI responses from cassette file (for testing)")
 	cmd.PersistentFlags().StringVar(&flags.recordPath, "record", "", "Record AI API interactions to cassette file")
 	cmd.PersistentFlags().BoolVar(&flags.connectRPC, "connect-rpc", false, "Use Connect-RPC protocol instead of HTTP/JSON API")
+	cmd.PersistentFlags().StringVar(&flags.authToken, "auth-token", "", "Require this bearer token on every HTTP/JSON API request (defaults to $CAGENT_API_TOKEN)")
 	cmd.MarkFlagsMutuallyExclusive("fake", "record")
 	addRuntimeConfigFlags(cmd, &flags.runConfig)
 
@@ -82,6 +85,13 @@ func (f *apiFlags) runAPICommand(cmd *cobra.Command, args []string) error {
 		}()
 	}
 
+	// Read the environment here rather than in the flag default, which
+	// --help would print.
+	f.authToken = cmp.Or(f.authToken, os.Getenv("CAGENT_API_TOKEN"))
+	if f.connectRPC && f.authToken != "" {
+		return fmt.Errorf("--auth-token is not supported with --connect-rpc")
+	}
+
 	if f.pullIntervalMins > 0 && !config.IsOCIReference(agentsPath) {
 		return fmt.Errorf("--pull-interval flag can only be used with OCI references, not local files")
 	}
@@ -125,7 +135,12 @@ func (f *apiFlags) runAPICommand(cmd *cobra.Command, args []string) error {
 		return s.Serve(ctx, ln)
 	}
 
-	s, err := server.New(ctx, sessionStore, &f.runConfig, time.Duration(f.pullIntervalMins)*time.Minute, sources)
+	var opts []server.Opt
+	if f.authToken != "" {
+		opts = append(opts, server.WithAuthToken(f.authToken))
+	}
+
+	s, err := server.New(ctx, sessionStore, &f.runConfig, time.Duration(f.pullIntervalMins)*time.Minute, sources, opts...)
 	if err != nil {
 		return fmt.Errorf("creating server: %w", err)
 	}
@@ -30,6 +30,8 @@ func addRuntimeConfigFlags(cmd *cobra.Command, runConfig *config.RuntimeConfig)
 	cmd.PersistentFlags().StringSliceVar(&runConfig.EnvFiles, "env-from-file", nil, "Set environment variables from file")
 	cmd.PersistentFlags().BoolVar(&runConfig.GlobalCodeMode, "code-mode-tools", false, "Provide a single tool to call other tools via Javascript")
 	cmd.PersistentFlags().StringVar(&runConfig.WorkingDir, "working-dir", "", "Set the working directory for the session (applies to tools and relative paths)")
+	cmd.PersistentFlags().DurationVar(&runConfig.HTTPTransport.IdleConnTimeout, "http-idle-timeout", 0, "How long idle connections to the model providers are kept open (default 90s)")
+	cmd.PersistentFlags().IntVar(&runConfig.HTTPTransport.MaxIdleConnsPerHost, "http-max-idle-conns-per-host", 0, "Number of idle connections kept open per model provider host (default 2)")
 }
 
 func setupWorkingDirectory(workingDir string) error {
@@ -13,9 +13,11 @@ import (
 	"github.com/docker/cagent/pkg/creator"
 	"github.com/docker/cagent/pkg/runtime"
 	"github.com/docker/cagent/pkg/session"
+	"github.com/docker/cagent/pkg/sessiontitle"
 	"github.com/docker/cagent/pkg/telemetry"
 	"github.com/docker/cagent/pkg/tui"
 	tuiinput "github.com/docker/cagent/pkg/tui/input"
+	"github.com/docker/cagent/pkg/userconfig"
 )
 
 type newFlags struct {
@@ -85,8 +87,9 @@ func (f *newFlags) runNewCommand(cmd *cobra.Command, args []string) error {
 
 func runTUI(ctx context.Context, rt runtime.Runtime, sess *session.Session, spawner tui.SessionSpawner, cleanup func(), opts ...app.Opt) error {
 	if gen := rt.TitleGenerator(); gen != nil {
-		opts = append(opts, app.WithTitleGenerator(gen))
+		opts = append(opts, app.WithTitleGenerator(titleGenerator(gen)))
 	}
+	opts = append(opts, app.WithStreamFlushInterval(userconfig.Get().GetStreamFlushInterval()))
 
 	a := app.New(ctx, rt, sess, opts...)
 
@@ -118,3 +121,12 @@ func runTUI(ctx context.Context, rt runtime.Runtime, sess *session.Session, spaw
 	_, err := p.Run()
 	return err
 }
+
+// titleGenerator returns gen, or a generator that uses the first user message
+// as the title when the user disabled LLM title generation.
+func titleGenerator(gen *sessiontitle.Generator) *sessiontitle.Generator {
+	if !userconfig.Get().GetGenerateTitles() {
+		return sessiontitle.NewFromMessage()
+	}
+	return gen
+}
@@ -22,6 +22,7 @@ import (
 	"github.com/docker/cagent/pkg/logging"
 	"github.com/docker/cagent/pkg/paths"
 	"github.com/docker/cagent/pkg/telemetry"
+	"github.com/docker/cagent/pkg/userconfig"
 	"github.com/docker/cagent/pkg/version"
 )
 
@@ -80,8 +81,9 @@ func NewRootCmd() *cobra.Command {
 			}
 
 			telemetry.SetGlobalTelemetryDebugMode(flags.debugMode)
+			telemetry.SetUserOptOut(!userconfig.Get().GetTelemetry())
 
-			if flags.enableOtel {
+			if flags.enableOtel && !telemetry.UserOptedOut() {
 				if err := initOTelSDK(cmd.Context()); err != nil {
 					slog.Warn("Failed to initialize OpenTelemetry SDK", "error", err)
 				} else {
@@ -10,6 +10,8 @@ import (
 	"path/filepath"
 	goruntime "runtime"
 	"runtime/pprof"
+	"slices"
+	"strings"
 	"sync"
 	"time"
 
@@ -18,12 +20,15 @@ import (
 	"go.opentelemetry.io/otel"
 
 	"github.com/docker/cagent/pkg/app"
+	"github.com/docker/cagent/pkg/chat"
 	"github.com/docker/cagent/pkg/cli"
 	"github.com/docker/cagent/pkg/config"
+	"github.com/docker/cagent/pkg/model/provider"
 	"github.com/docker/cagent/pkg/paths"
 	"github.com/docker/cagent/pkg/runtime"
 	"github.com/docker/cagent/pkg/session"
 	"github.com/docker/cagent/pkg/sessiontitle"
+	"github.com/docker/cagent/pkg/team"
 	"github.com/docker/cagent/pkg/teamloader"
 	"github.com/docker/cagent/pkg/telemetry"
 	"github.com/docker/cagent/pkg/tui"
@@ -33,16 +38,20 @@ import (
 
 type runExecFlags struct {
 	agentName         string
+	agentNameSet      bool // --agent was given explicitly
 	autoApprove       bool
 	attachmentPath    string
 	remoteAddress     string
 	connectRPC        bool
 	modelOverrides    []string
 	promptFiles       []string
+	appendStdin       bool
 	dryRun            bool
 	runConfig         config.RuntimeConfig
 	sessionDB         string
 	sessionID         string
+	continueSession   bool
+	resumedAgent      string // agent that last answered in the session resumed with --continue
 	recordPath        string
 	fakeResponses     string
 	fakeStreamDelay   int
@@ -60,6 +69,15 @@ type runExecFlags struct {
 
 	// Run only
 	hideToolResults bool
+
+	// providerLimiter is shared by the runtimes of all the sessions
+	providerLimiter *provider.ConcurrencyLimiter
+	// userSettings are the user settings read at startup. New tabs read them
+	// again, so that edits to the user config apply without restarting.
+	userSettings *userconfig.Settings
+	// workspace is the workspace config of the directory of the first
+	// session. New tabs read the one of their own working directory.
+	workspace *userconfig.Workspace
 }
 
 func newRunCmd() *cobra.Command {
@@ -76,6 +94,7 @@ func newRunCmd() *cobra.Command {
   cagent run ./echo.yaml "INSTRUCTIONS"
   cagent run ./echo.yaml "First question" "Follow-up question"
   echo "INSTRUCTIONS" | cagent run ./echo.yaml -
+  git log | cagent run ./agent.yaml "Summarize these commits" --stdin
   cagent run ./agent.yaml --record  # Records session to auto-generated file`,
 		GroupID:           "core",
 		ValidArgsFunction: completeRunExec,
@@ -93,6 +112,7 @@ func addRunOrExecFlags(cmd *cobra.Command, flags *runExecFlags) {
 	cmd.PersistentFlags().StringVarP(&flags.agentName, "agent", "a", "root", "Name of the agent to run")
 	cmd.PersistentFlags().BoolVar(&flags.autoApprove, "yolo", false, "Automatically approve all tool calls without prompting")
 	cmd.PersistentFlags().BoolVar(&flags.hideToolResults, "hide-tool-results", false, "Hide tool call results")
+	cmd.PersistentFlags().BoolVar(&flags.appendStdin, "stdin", false, "Append the piped standard input to the first message")
 	cmd.PersistentFlags().StringVar(&flags.attachmentPath, "attach", "", "Attach an image file to the message")
 	cmd.PersistentFlags().StringArrayVar(&flags.promptFiles, "prompt-file", nil, "Append file contents to the prompt (repeatable)")
 	cmd.PersistentFlags().StringArrayVar(&flags.modelOverrides, "model", nil, "Override agent model: [agent=]provider/model (repeatable)")
@@ -100,7 +120,8 @@ func addRunOrExecFlags(cmd *cobra.Command, flags *runExecFlags) {
 	cmd.PersistentFlags().StringVar(&flags.remoteAddress, "remote", "", "Use remote runtime with specified address")
 	cmd.PersistentFlags().BoolVar(&flags.connectRPC, "connect-rpc", false, "Use Connect-RPC protocol for remote communication (requires --remote)")
 	cmd.PersistentFlags().StringVarP(&flags.sessionDB, "session-db", "s", filepath.Join(paths.GetHomeDir(), ".cagent", "session.db"), "Path to the session database")
-	cmd.PersistentFlags().StringVar(&flags.sessionID, "session", "", "Continue from a previous session by ID or relative offset (e.g., -1 for last session)")
+	cmd.PersistentFlags().StringVar(&flags.sessionID, "session", "", "Continue from a previous session by ID, relative offset (e.g., -1 for last session) or cagent://session/<id> reference")
+	cmd.PersistentFlags().BoolVar(&flags.continueSession, "continue", false, "Continue the most recent session, in its working directory and with its agent")
 	cmd.PersistentFlags().StringVar(&flags.fakeResponses, "fake", "", "Replay AI responses from cassette file (for testing)")
 	cmd.PersistentFlags().IntVar(&flags.fakeStreamDelay, "fake-stream", 0, "Simulate streaming with delay in ms between chunks (default 15ms if no value given)")
 	cmd.Flag("fake-stream").NoOptDefVal = "15" // --fake-stream without value uses 15ms
@@ -117,6 +138,7 @@ func addRunOrExecFlags(cmd *cobra.Command, flags *runExecFlags) {
 	cmd.PersistentFlags().BoolVar(&flags.sandbox, "sandbox", false, "Run the agent inside a Docker sandbox (requires Docker Desktop with sandbox support)")
 	cmd.PersistentFlags().StringVar(&flags.sandboxTemplate, "template", "", "Template image for the sandbox (passed to docker sandbox create -t)")
 	cmd.MarkFlagsMutuallyExclusive("fake", "record")
+	cmd.MarkFlagsMutuallyExclusive("session", "continue")
 
 	// --exec only
 	cmd.PersistentFlags().BoolVar(&flags.exec, "exec", false, "Execute without a TUI")
@@ -138,11 +160,71 @@ func (f *runExecFlags) runRunCommand(cmd *cobra.Command, args []string) error {
 
 	ctx := cmd.Context()
 	out := cli.NewPrinter(cmd.OutOrStdout())
+	f.agentNameSet = cmd.Flags().Changed("agent")
 
 	useTUI := !f.exec && (f.forceTUI || isatty.IsTerminal(os.Stdout.Fd()))
+
+	piped, err := readPipedInput(cmd.InOrStdin(), args, f.appendStdin)
+	if err != nil {
+		return err
+	}
+	args = withPipedInput(args, piped)
+
 	return f.runOrExec(ctx, out, args, useTUI)
 }
 
+// readPipedInput reads the standard input when something is piped into cagent,
+// e.g. `git log | cagent run agent.yaml`. When a message is given, stdin is
+// only read with --stdin (`git log | cagent run agent.yaml "summarize" --stdin`):
+// it may be a pipe inherited from a parent process that never gets closed.
+// It returns an empty string when stdin is a terminal or when the message is
+// explicitly read from stdin with "-".
+func readPipedInput(stdin io.Reader, args []string, appendToMessage bool) (string, error) {
+	if len(args) > 1 && (args[1] == "-" || !appendToMessage) {
+		return "", nil
+	}
+
+	file, ok := stdin.(*os.File)
+	if !ok || file == nil {
+		return "", nil
+	}
+	info, err := file.Stat()
+	if err != nil {
+		return "", nil
+	}
+	// Only read from pipes and redirected files. Terminals and /dev/null are
+	// character devices, and reading from them would block or return nothing.
+	if info.Mode()&os.ModeNamedPipe == 0 && !info.Mode().IsRegular() {
+		return "", nil
+	}
+
+	buf, err := io.ReadAll(file)
+	if err != nil {
+		return "", fmt.Errorf("failed to read from stdin: %w", err)
+	}
+	return strings.TrimSpace(string(buf)), nil
+}
+
+// withPipedInput folds the piped input into the first message. Without a
+// message, the piped input becomes the first message.
+func withPipedInput(args []string, piped string) []string {
+	if piped == "" {
+		return args
+	}
+
+	switch len(args) {
+	case 0:
+		// No agent file: use the default agent.
+		return []string{"", piped}
+	case 1:
+		return []string{args[0], piped}
+	}
+
+	result := slices.Clone(args)
+	result[1] = args[1] + "\n\n" + piped
+	return result
+}
+
 func (f *runExecFlags) runOrExec(ctx context.Context, out *cli.Printer, args []string, useTUI bool) error {
 	slog.Debug("Starting agent", "agent", f.agentName)
 
@@ -194,6 +276,8 @@ func (f *runExecFlags) runOrExec(ctx context.Context, out *cli.Printer, args []s
 		f.autoApprove = true
 		slog.Debug("Applying user settings", "YOLO", true)
 	}
+	f.providerLimiter = provider.NewConcurrencyLimiter(userSettings.ProviderConcurrency)
+	f.userSettings = userSettings
 
 	// Apply alias options if this is an alias reference
 	// Alias options only apply if the flag wasn't explicitly set by the user
@@ -245,6 +329,17 @@ func (f *runExecFlags) runOrExec(ctx context.Context, out *cli.Printer, args []s
 	}
 
 	// Local runtime
+	// The resume_last_session setting only applies to the TUI started without
+	// a message, --continue also to --exec.
+	if f.sessionID == "" && (f.continueSession || (useTUI && len(args) < 2 && userSettings.ResumeLastSession)) {
+		if err := f.resumeLatestSession(ctx); err != nil {
+			return err
+		}
+	}
+
+	wd, _ := os.Getwd()
+	f.workspace = loadWorkspace(wd)
+
 	agentSource, err := config.Resolve(agentFileName, f.runConfig.EnvProvider())
 	if err != nil {
 		return err
@@ -254,6 +349,11 @@ func (f *runExecFlags) runOrExec(ctx context.Context, out *cli.Printer, args []s
 	if err != nil {
 		return err
 	}
+	f.applyDefaultAgent(loadResult.Team)
+	// New tabs start on the workspace default agent of their own directory.
+	spawnAgent := f.agentName
+	f.agentName = f.workspaceAgent(loadResult.Team, f.workspace, spawnAgent)
+	f.applyResumedAgent(loadResult.Team)
 
 	rt, sess, err := f.createLocalRuntimeAndSession(ctx, loadResult)
 	if err != nil {
@@ -273,7 +373,7 @@ func (f *runExecFlags) runOrExec(ctx context.Context, out *cli.Printer, args []s
 	defer initialTeamCleanup()
 
 	if useTUI {
-		applyTheme()
+		applyTheme(loadResult.ThemePath)
 	}
 
 	if f.dryRun {
@@ -289,6 +389,10 @@ func (f *runExecFlags) runOrExec(ctx context.Context, out *cli.Printer, args []s
 	if err != nil {
 		return err
 	}
+	opts = append(opts, app.WithConfig(loadResult.Config))
+	if path, ok := config.FilePath(agentSource); ok {
+		opts = append(opts, app.WithConfigFile(path))
+	}
 
 	var sessStore session.Store
 	switch typedRt := rt.(type) {
@@ -298,7 +402,7 @@ func (f *runExecFlags) runOrExec(ctx context.Context, out *cli.Printer, args []s
 		sessStore = typedRt.SessionStore()
 	}
 
-	return runTUI(ctx, rt, sess, f.createSessionSpawner(agentSource, sessStore), initialTeamCleanup, opts...)
+	return runTUI(ctx, rt, sess, f.createSessionSpawner(agentSource, sessStore, spawnAgent), initialTeamCleanup, opts...)
 }
 
 func (f *runExecFlags) loadAgentFrom(ctx context.Context, agentSource config.Source) (*teamloader.LoadResult, error) {
@@ -346,6 +450,108 @@ func (f *runExecFlags) createRemoteRuntimeAndSession(ctx context.Context, origin
 	return remoteRt, sess, nil
 }
 
+// applyDefaultAgent starts on the team's configured default agent, unless an
+// agent was chosen with --agent. An unknown default agent falls back to root
+// and is reported by the runtime at startup.
+func (f *runExecFlags) applyDefaultAgent(t *team.Team) {
+	if f.agentNameSet || t.DefaultAgentName() == "" {
+		return
+	}
+	if a, err := t.DefaultAgent(); err == nil {
+		f.agentName = a.Name()
+	}
+}
+
+// workspaceAgent returns the agent to start on in a workspace: its default
+// agent, unless an agent was chosen with --agent, or else agentName. A
+// default agent the team doesn't have is reported and ignored.
+func (f *runExecFlags) workspaceAgent(t *team.Team, workspace *userconfig.Workspace, agentName string) string {
+	if f.agentNameSet || workspace.DefaultAgent == "" {
+		return agentName
+	}
+	if _, err := t.Agent(workspace.DefaultAgent); err != nil {
+		slog.Warn("Ignoring the default agent of the workspace config", "agent", workspace.DefaultAgent, "error", err)
+		return agentName
+	}
+	return workspace.DefaultAgent
+}
+
+// loadWorkspace loads the workspace config of workingDir. A config that
+// can't be read is reported and ignored, like a broken user config.
+func loadWorkspace(workingDir string) *userconfig.Workspace {
+	workspace, err := userconfig.LoadWorkspace(workingDir)
+	if err != nil {
+		slog.Warn("Ignoring the workspace config", "path", userconfig.WorkspacePath(workingDir), "error", err)
+		return &userconfig.Workspace{}
+	}
+	return workspace
+}
+
+// resumeLatestSession picks the most recent session with messages to be
+// loaded instead of a new one, and moves to its working directory unless
+// --working-dir was given. Without such a session, a new one is created.
+func (f *runExecFlags) resumeLatestSession(ctx context.Context) error {
+	sessionDB, err := expandTilde(f.sessionDB)
+	if err != nil {
+		return err
+	}
+	sessStore, err := session.NewSQLiteSessionStore(sessionDB)
+	if err != nil {
+		return fmt.Errorf("creating session store: %w", err)
+	}
+	defer sessStore.Close()
+
+	summaries, err := sessStore.GetSessionSummaries(ctx)
+	if err != nil {
+		return fmt.Errorf("getting session summaries: %w", err)
+	}
+	i := slices.IndexFunc(summaries, func(s session.Summary) bool { return s.NumMessages > 0 })
+	if i < 0 {
+		slog.Debug("No session to continue, starting a new one")
+		return nil
+	}
+
+	sess, err := sessStore.GetSession(ctx, summaries[i].ID)
+	if err != nil {
+		return fmt.Errorf("loading session %q: %w", summaries[i].ID, err)
+	}
+	f.sessionID = sess.ID
+	f.resumedAgent = lastAgentName(sess)
+
+	if f.runConfig.WorkingDir == "" && sess.WorkingDir != "" {
+		if err := setupWorkingDirectory(sess.WorkingDir); err != nil {
+			slog.Warn("Not restoring the working directory of the session", "session_id", sess.ID, "error", err)
+		} else {
+			f.runConfig.WorkingDir = sess.WorkingDir
+		}
+	}
+	return nil
+}
+
+// applyResumedAgent starts on the agent that last answered in the session
+// resumed with --continue, unless an agent was chosen with --agent or the
+// team no longer has it.
+func (f *runExecFlags) applyResumedAgent(t *team.Team) {
+	if f.agentNameSet || f.resumedAgent == "" {
+		return
+	}
+	if _, err := t.Agent(f.resumedAgent); err == nil {
+		f.agentName = f.resumedAgent
+	}
+}
+
+// lastAgentName returns the name of the agent of the last assistant message
+// of the session.
+func lastAgentName(sess *session.Session) string {
+	messages := sess.GetAllMessages()
+	for i := len(messages) - 1; i >= 0; i-- {
+		if messages[i].Message.Role == chat.MessageRoleAssistant && messages[i].AgentName != "" {
+			return messages[i].AgentName
+		}
+	}
+	return ""
+}
+
 func (f *runExecFlags) createLocalRuntimeAndSession(ctx context.Context, loadResult *teamloader.LoadResult) (runtime.Runtime, *session.Session, error) {
 	t := loadResult.Team
 
@@ -354,6 +560,12 @@ func (f *runExecFlags) createLocalRuntimeAndSession(ctx context.Context, loadRes
 		return nil, nil, err
 	}
 
+	// --session also takes cagent://session/<id> references.
+	sessionRef, err := session.ParseReference(f.sessionID)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	// Expand tilde in session database path
 	sessionDB, err := expandTilde(f.sessionDB)
 	if err != nil {
@@ -370,16 +582,19 @@ func (f *runExecFlags) createLocalRuntimeAndSession(ctx context.Context, loadRes
 		Models:             loadResult.Models,
 		Providers:          loadResult.Providers,
 		ModelsGateway:      f.runConfig.ModelsGateway,
+		HTTPTransport:      f.runConfig.HTTPTransport,
 		EnvProvider:        f.runConfig.EnvProvider(),
 		AgentDefaultModels: loadResult.AgentDefaultModels,
+		ModelAliases:       loadResult.Config.ModelAliases,
 	}
 
-	localRt, err := runtime.New(t,
+	localRt, err := runtime.New(t, append([]runtime.Opt{
 		runtime.WithSessionStore(sessStore),
 		runtime.WithCurrentAgent(f.agentName),
 		runtime.WithTracer(otel.Tracer(AppName)),
 		runtime.WithModelSwitcherConfig(modelSwitcherCfg),
-	)
+		runtime.WithProviderConcurrency(f.providerLimiter),
+	}, userSettingsOpts(f.userSettings)...)...)
 	if err != nil {
 		return nil, nil, fmt.Errorf("creating runtime: %w", err)
 	}
@@ -387,7 +602,7 @@ func (f *runExecFlags) createLocalRuntimeAndSession(ctx context.Context, loadRes
 	var sess *session.Session
 	if f.sessionID != "" {
 		// Resolve relative session references (e.g., "-1" for last session)
-		resolvedID, err := session.ResolveSessionID(ctx, sessStore, f.sessionID)
+		resolvedID, err := session.ResolveSessionID(ctx, sessStore, sessionRef.ID)
 		if err != nil {
 			return nil, nil, fmt.Errorf("resolving session %q: %w", f.sessionID, err)
 		}
@@ -414,7 +629,7 @@ func (f *runExecFlags) createLocalRuntimeAndSession(ctx context.Context, loadRes
 		slog.Debug("Loaded existing session", "session_id", resolvedID, "session_ref", f.sessionID, "agent", f.agentName)
 	} else {
 		wd, _ := os.Getwd()
-		sess = session.New(f.buildSessionOpts(agent.MaxIterations(), agent.ThinkingConfigured(), wd)...)
+		sess = session.New(f.buildSessionOpts(f.userSettings, f.workspace, agent.MaxIterations(), agent.ThinkingConfigured(), wd)...)
 		// Session is stored lazily on first UpdateSession call (when content is added)
 		// This avoids creating empty sessions in the database
 		slog.Debug("Using local runtime", "agent", f.agentName, "thinking", agent.ThinkingConfigured())
@@ -459,7 +674,7 @@ func readInitialMessage(args []string) (*string, error) {
 
 func (f *runExecFlags) launchTUI(ctx context.Context, out *cli.Printer, rt runtime.Runtime, sess *session.Session, args []string, useTUI bool) error {
 	if useTUI {
-		applyTheme()
+		applyTheme("")
 	}
 
 	if f.dryRun {
@@ -501,22 +716,54 @@ func (f *runExecFlags) buildAppOpts(args []string) ([]app.Opt, error) {
 	return opts, nil
 }
 
+// userSettingsOpts returns the runtime options set in the user config: tool
+// result limits, tool timeouts, remembered approvals and retries without
+// tools.
+func userSettingsOpts(userSettings *userconfig.Settings) []runtime.Opt {
+	opts := []runtime.Opt{
+		runtime.WithToolResultLimits(userSettings.ToolResultLimits),
+		runtime.WithToolTimeouts(userSettings.GetToolTimeouts()),
+		runtime.WithRetryWithoutTools(userSettings.RetryWithoutTools),
+	}
+	if window, ok := userSettings.GetRememberApprovals(); ok {
+		opts = append(opts, runtime.WithApprovalMemory(&runtime.ApprovalMemory{Window: window, MatchArguments: userSettings.RememberApprovalsByArguments}))
+	}
+	return opts
+}
+
 // buildSessionOpts returns the canonical set of session options derived from
-// CLI flags and agent configuration. Both the initial session and spawned
-// sessions use this method so their options never drift apart.
-func (f *runExecFlags) buildSessionOpts(maxIterations int, thinking bool, workingDir string) []session.Opt {
-	return []session.Opt{
+// CLI flags, agent configuration and the workspace config. Both the initial
+// session and spawned sessions use this method so their options never drift
+// apart.
+func (f *runExecFlags) buildSessionOpts(userSettings *userconfig.Settings, workspace *userconfig.Workspace, maxIterations int, thinking bool, workingDir string) []session.Opt {
+	opts := []session.Opt{
 		session.WithMaxIterations(maxIterations),
-		session.WithToolsApproved(f.autoApprove),
+		session.WithToolsApproved(f.autoApprove || userSettings.IsYOLODir(workingDir)),
 		session.WithHideToolResults(f.hideToolResults),
 		session.WithThinking(thinking),
 		session.WithWorkingDir(workingDir),
+		session.WithEnvOverrides(workspace.Env),
 	}
+	if perms := workspace.Permissions; perms != nil {
+		opts = append(opts, session.WithPermissions(&session.PermissionsConfig{
+			Allow:         slices.Clone(perms.Allow),
+			Ask:           slices.Clone(perms.Ask),
+			Deny:          slices.Clone(perms.Deny),
+			AllowToolsets: slices.Clone(perms.AllowToolsets),
+		}))
+	}
+	return opts
 }
 
 // createSessionSpawner creates a function that can spawn new sessions with different working directories.
-func (f *runExecFlags) createSessionSpawner(agentSource config.Source, sessStore session.Store) tui.SessionSpawner {
+// New sessions start on spawnAgent, or on the default agent of the workspace
+// config of their directory.
+func (f *runExecFlags) createSessionSpawner(agentSource config.Source, sessStore session.Store, spawnAgent string) tui.SessionSpawner {
 	return func(spawnCtx context.Context, workingDir string) (*app.App, *session.Session, func(), error) {
+		// Pick up the edits made to the user config since startup.
+		userSettings := userconfig.Get()
+		workspace := loadWorkspace(workingDir)
+
 		// Create a copy of the runtime config with the new working directory
 		runConfigCopy := f.runConfig.Clone()
 		runConfigCopy.WorkingDir = workingDir
@@ -528,7 +775,8 @@ func (f *runExecFlags) createSessionSpawner(agentSource config.Source, sessStore
 		}
 
 		team := loadResult.Team
-		agent, err := team.Agent(f.agentName)
+		agentName := f.workspaceAgent(team, workspace, spawnAgent)
+		agent, err := team.Agent(agentName)
 		if err != nil {
 			return nil, nil, nil, err
 		}
@@ -538,23 +786,26 @@ func (f *runExecFlags) createSessionSpawner(agentSource config.Source, sessStore
 			Models:             loadResult.Models,
 			Providers:          loadResult.Providers,
 			ModelsGateway:      runConfigCopy.ModelsGateway,
+			HTTPTransport:      runConfigCopy.HTTPTransport,
 			EnvProvider:        runConfigCopy.EnvProvider(),
 			AgentDefaultModels: loadResult.AgentDefaultModels,
+			ModelAliases:       loadResult.Config.ModelAliases,
 		}
 
 		// Create the local runtime
-		localRt, err := runtime.New(team,
+		localRt, err := runtime.New(team, append([]runtime.Opt{
 			runtime.WithSessionStore(sessStore),
-			runtime.WithCurrentAgent(f.agentName),
+			runtime.WithCurrentAgent(agentName),
 			runtime.WithTracer(otel.Tracer(AppName)),
 			runtime.WithModelSwitcherConfig(modelSwitcherCfg),
-		)
+			runtime.WithProviderConcurrency(f.providerLimiter),
+		}, userSettingsOpts(userSettings)...)...)
 		if err != nil {
 			return nil, nil, nil, err
 		}
 
 		// Create a new session
-		newSess := session.New(f.buildSessionOpts(agent.MaxIterations(), agent.ThinkingConfigured(), workingDir)...)
+		newSess := session.New(f.buildSessionOpts(userSettings, workspace, agent.MaxIterations(), agent.ThinkingConfigured(), workingDir)...)
 
 		// Create cleanup function
 		cleanup := func() {
@@ -562,10 +813,16 @@ func (f *runExecFlags) createSessionSpawner(agentSource config.Source, sessStore
 		}
 
 		// Create the app
-		var appOpts []app.Opt
+		appOpts := []app.Opt{
+			app.WithConfig(loadResult.Config),
+			app.WithStreamFlushInterval(userSettings.GetStreamFlushInterval()),
+		}
+		if path, ok := config.FilePath(agentSource); ok {
+			appOpts = append(appOpts, app.WithConfigFile(path))
+		}
 		if pr, ok := localRt.(*runtime.PersistentRuntime); ok {
 			if model := pr.CurrentAgent().Model(); model != nil {
-				appOpts = append(appOpts, app.WithTitleGenerator(sessiontitle.New(model)))
+				appOpts = append(appOpts, app.WithTitleGenerator(titleGenerator(sessiontitle.New(model))))
 			}
 		}
 
@@ -590,10 +847,19 @@ func stopToolSets(t toolStopper) {
 	}
 }
 
-// applyTheme applies the theme from user config, or the built-in default.
-func applyTheme() {
-	// Resolve theme from user config > built-in default
+// applyTheme applies the theme from user config, then the theme shipped with
+// the agent config at teamThemePath, if any, or the built-in default.
+func applyTheme(teamThemePath string) {
+	// Resolve theme from user config > team theme > built-in default
 	themeRef := styles.DefaultThemeRef
+	if teamThemePath != "" {
+		ref, err := styles.RegisterTeamTheme(teamThemePath)
+		if err != nil {
+			slog.Warn("Failed to register the agent config's theme", "path", teamThemePath, "error", err)
+		} else {
+			themeRef = ref
+		}
+	}
 	if userSettings := userconfig.Get(); userSettings.Theme != "" {
 		themeRef = userSettings.Theme
 	}
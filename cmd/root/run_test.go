@@ -0,0 +1,143 @@
+package root
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/docker/cagent/pkg/agent"
+	"github.com/docker/cagent/pkg/chat"
+	"github.com/docker/cagent/pkg/config/latest"
+	"github.com/docker/cagent/pkg/session"
+	"github.com/docker/cagent/pkg/team"
+	"github.com/docker/cagent/pkg/userconfig"
+)
+
+func TestWithPipedInput(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		args     []string
+		piped    string
+		expected []string
+	}{
+		{"nothing piped", []string{"agent.yaml", "hello"}, "", []string{"agent.yaml", "hello"}},
+		{"no args", nil, "log", []string{"", "log"}},
+		{"agent only", []string{"agent.yaml"}, "log", []string{"agent.yaml", "log"}},
+		{"with message", []string{"agent.yaml", "summarize"}, "log", []string{"agent.yaml", "summarize\n\nlog"}},
+		{"with follow-ups", []string{"agent.yaml", "summarize", "shorter"}, "log", []string{"agent.yaml", "summarize\n\nlog", "shorter"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.expected, withPipedInput(tt.args, tt.piped))
+		})
+	}
+}
+
+func TestReadPipedInput(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "input.txt")
+	require.NoError(t, os.WriteFile(path, []byte("  commit 1\ncommit 2\n"), 0o644))
+
+	open := func(t *testing.T) *os.File {
+		t.Helper()
+		f, err := os.Open(path)
+		require.NoError(t, err)
+		t.Cleanup(func() { f.Close() })
+		return f
+	}
+
+	piped, err := readPipedInput(open(t), []string{"agent.yaml"}, false)
+	require.NoError(t, err)
+	assert.Equal(t, "commit 1\ncommit 2", piped)
+
+	// With a message, stdin is only read when asked.
+	piped, err = readPipedInput(open(t), []string{"agent.yaml", "summarize"}, false)
+	require.NoError(t, err)
+	assert.Empty(t, piped)
+
+	piped, err = readPipedInput(open(t), []string{"agent.yaml", "summarize"}, true)
+	require.NoError(t, err)
+	assert.Equal(t, "commit 1\ncommit 2", piped)
+
+	// "-" already reads the message from stdin.
+	piped, err = readPipedInput(open(t), []string{"agent.yaml", "-"}, true)
+	require.NoError(t, err)
+	assert.Empty(t, piped)
+
+	// Only files are read.
+	piped, err = readPipedInput(strings.NewReader("text"), []string{"agent.yaml"}, false)
+	require.NoError(t, err)
+	assert.Empty(t, piped)
+}
+
+func TestResumeLatestSession(t *testing.T) {
+	t.Parallel()
+
+	dbPath := filepath.Join(t.TempDir(), "session.db")
+	f := &runExecFlags{sessionDB: dbPath}
+	f.runConfig.WorkingDir = t.TempDir() // don't change the working directory of the tests
+
+	require.NoError(t, f.resumeLatestSession(t.Context()))
+	assert.Empty(t, f.sessionID, "no session to continue")
+
+	store, err := session.NewSQLiteSessionStore(dbPath)
+	require.NoError(t, err)
+	withMessages := session.New()
+	withMessages.CreatedAt = time.Now().Add(-time.Hour)
+	withMessages.AddMessage(session.UserMessage("hello"))
+	withMessages.AddMessage(&session.Message{AgentName: "reviewer", Message: chat.Message{Role: chat.MessageRoleAssistant, Content: "hi"}})
+	require.NoError(t, store.AddSession(t.Context(), withMessages))
+	require.NoError(t, store.AddSession(t.Context(), session.New()))
+	require.NoError(t, store.Close())
+
+	require.NoError(t, f.resumeLatestSession(t.Context()))
+	assert.Equal(t, withMessages.ID, f.sessionID, "empty sessions are skipped")
+	assert.Equal(t, "reviewer", f.resumedAgent)
+}
+
+func TestWorkspaceSessionOpts(t *testing.T) {
+	t.Parallel()
+
+	workspace := &userconfig.Workspace{
+		Permissions: &latest.PermissionsConfig{Deny: []string{"shell"}, AllowToolsets: []string{"think"}},
+		Env:         map[string]string{"GOFLAGS": "-mod=vendor"},
+	}
+
+	f := &runExecFlags{}
+	sess := session.New(f.buildSessionOpts(&userconfig.Settings{}, workspace, 0, false, t.TempDir())...)
+
+	require.NotNil(t, sess.Permissions)
+	assert.Equal(t, []string{"shell"}, sess.Permissions.Deny)
+	assert.Equal(t, []string{"think"}, sess.Permissions.AllowToolsets)
+	assert.Equal(t, map[string]string{"GOFLAGS": "-mod=vendor"}, sess.GetEnvOverrides())
+
+	sess = session.New(f.buildSessionOpts(&userconfig.Settings{}, &userconfig.Workspace{}, 0, false, t.TempDir())...)
+	assert.Nil(t, sess.Permissions)
+	assert.Empty(t, sess.GetEnvOverrides())
+}
+
+func TestWorkspaceAgent(t *testing.T) {
+	t.Parallel()
+
+	tm := team.New(team.WithAgents(agent.New("root", ""), agent.New("reviewer", "")))
+	workspace := &userconfig.Workspace{DefaultAgent: "reviewer"}
+
+	f := &runExecFlags{}
+	assert.Equal(t, "reviewer", f.workspaceAgent(tm, workspace, "root"))
+	assert.Equal(t, "root", f.workspaceAgent(tm, &userconfig.Workspace{}, "root"))
+	assert.Equal(t, "root", f.workspaceAgent(tm, &userconfig.Workspace{DefaultAgent: "unknown"}, "root"))
+
+	f.agentNameSet = true
+	assert.Equal(t, "root", f.workspaceAgent(tm, workspace, "root"), "--agent wins")
+}
@@ -32,13 +32,16 @@ type Agent struct {
 	addDate                 bool
 	addEnvironmentInfo      bool
 	addDescriptionParameter bool
+	hidden                  bool
 	maxIterations           int
 	numHistoryItems         int
 	addPromptFiles          []string
+	workingDir              string // overrides the session's working directory when set
 	tools                   []tools.Tool
 	commands                types.Commands
 	pendingWarnings         []string
 	hooks                   *latest.HooksConfig
+	toolsetApproval         *latest.ToolsetApproval
 	thinkingConfigured      bool // true if thinking_budget was explicitly set in config
 }
 
@@ -85,6 +88,12 @@ func (a *Agent) AddPromptFiles() []string {
 	return a.addPromptFiles
 }
 
+// WorkingDir returns the directory the agent works in, or "" when it works in
+// the session's working directory.
+func (a *Agent) WorkingDir() string {
+	return a.workingDir
+}
+
 // ThinkingConfigured returns true if thinking_budget was explicitly set in the agent's config.
 // This is used to initialize session thinking state - thinking is only enabled by default
 // when the user explicitly configured it in their YAML.
@@ -97,6 +106,11 @@ func (a *Agent) Description() string {
 	return a.description
 }
 
+// Hidden returns true if the agent should not be offered for switching in the UI.
+func (a *Agent) Hidden() bool {
+	return a.hidden
+}
+
 // WelcomeMessage returns the agent's welcome message
 func (a *Agent) WelcomeMessage() string {
 	return a.welcomeMessage
@@ -192,6 +206,12 @@ func (a *Agent) Commands() types.Commands {
 	return a.commands
 }
 
+// ToolsetApproval returns the agent's toolset approval policy, or nil if none
+// is configured.
+func (a *Agent) ToolsetApproval() *latest.ToolsetApproval {
+	return a.toolsetApproval
+}
+
 // Hooks returns the hooks configuration for this agent.
 func (a *Agent) Hooks() *latest.HooksConfig {
 	return a.hooks
@@ -226,6 +246,60 @@ func (a *Agent) Tools(ctx context.Context) ([]tools.Tool, error) {
 	return agentTools, nil
 }
 
+// KnownTools returns the tools of the agent without starting any toolset:
+// toolsets that need to be started, like MCP servers, only contribute once
+// they have been.
+func (a *Agent) KnownTools(ctx context.Context) []tools.Tool {
+	var known []tools.Tool
+	for _, toolSet := range a.toolsets {
+		if _, startable := tools.As[tools.Startable](toolSet.ToolSet); startable && !toolSet.IsStarted() {
+			continue
+		}
+		ta, err := toolSet.Tools(ctx)
+		if err != nil {
+			continue
+		}
+		known = append(known, ta...)
+	}
+	return append(known, a.tools...)
+}
+
+// ToolNames returns the names of the tools of the agent without starting any
+// toolset, see KnownTools.
+func (a *Agent) ToolNames(ctx context.Context) []string {
+	var names []string
+	for _, t := range a.KnownTools(ctx) {
+		names = append(names, t.Name)
+	}
+	return names
+}
+
+// ToolSetStatus is the load status of one of the toolsets of an agent.
+type ToolSetStatus struct {
+	// Name describes the toolset, see tools.DescribeToolSet.
+	Name string
+	// Started is true once the toolset started, and for the toolsets that
+	// don't need to be started.
+	Started bool
+	// Err is the error of the last failed start, nil when there is none.
+	Err error
+}
+
+// ToolSetStatuses returns the load status of each toolset of the agent,
+// without starting any.
+func (a *Agent) ToolSetStatuses() []ToolSetStatus {
+	statuses := make([]ToolSetStatus, 0, len(a.toolsets))
+	for _, toolSet := range a.toolsets {
+		_, startable := tools.As[tools.Startable](toolSet.ToolSet)
+		statuses = append(statuses, ToolSetStatus{
+			Name:    tools.DescribeToolSet(toolSet),
+			Started: !startable || toolSet.IsStarted(),
+			Err:     toolSet.StartError(),
+		})
+	}
+	return statuses
+}
+
 func (a *Agent) ToolSets() []tools.ToolSet {
 	var toolSets []tools.ToolSet
 
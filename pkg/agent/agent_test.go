@@ -93,6 +93,22 @@ func TestAgentTools(t *testing.T) {
 	}
 }
 
+type listOnlyToolSet struct{ tools []tools.Tool }
+
+func (s *listOnlyToolSet) Tools(context.Context) ([]tools.Tool, error) { return s.tools, nil }
+
+func TestAgentToolNames(t *testing.T) {
+	startable := newStubToolSet(nil, []tools.Tool{{Name: "mcp_tool"}}, nil)
+	a := New("root", "test", WithToolSets(startable, &listOnlyToolSet{tools: []tools.Tool{{Name: "shell"}}}))
+
+	// Toolsets that need to be started are skipped until they are.
+	assert.Equal(t, []string{"shell"}, a.ToolNames(t.Context()))
+
+	_, err := a.Tools(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"mcp_tool", "shell"}, a.ToolNames(t.Context()))
+}
+
 // mockProvider implements provider.Provider for testing
 type mockProvider struct {
 	id string
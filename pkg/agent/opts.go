@@ -115,12 +115,26 @@ func WithAddDescriptionParameter(addDescriptionParameter bool) Opt {
 	}
 }
 
+func WithHidden(hidden bool) Opt {
+	return func(a *Agent) {
+		a.hidden = hidden
+	}
+}
+
 func WithAddPromptFiles(addPromptFiles []string) Opt {
 	return func(a *Agent) {
 		a.addPromptFiles = addPromptFiles
 	}
 }
 
+// WithWorkingDir makes the agent work in dir rather than in the session's
+// working directory.
+func WithWorkingDir(dir string) Opt {
+	return func(a *Agent) {
+		a.workingDir = dir
+	}
+}
+
 func WithMaxIterations(maxIterations int) Opt {
 	return func(a *Agent) {
 		a.maxIterations = maxIterations
@@ -147,6 +161,12 @@ func WithLoadTimeWarnings(warnings []string) Opt {
 	}
 }
 
+func WithToolsetApproval(toolsetApproval *latest.ToolsetApproval) Opt {
+	return func(a *Agent) {
+		a.toolsetApproval = toolsetApproval
+	}
+}
+
 func WithHooks(hooks *latest.HooksConfig) Opt {
 	return func(a *Agent) {
 		a.hooks = hooks
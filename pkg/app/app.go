@@ -3,10 +3,12 @@ package app
 import (
 	"context"
 	"encoding/base64"
+	"errors"
 	"fmt"
 	"log/slog"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"slices"
 	"strings"
 	"sync/atomic"
@@ -18,7 +20,10 @@ import (
 	"github.com/docker/cagent/pkg/app/transcript"
 	"github.com/docker/cagent/pkg/chat"
 	"github.com/docker/cagent/pkg/cli"
+	"github.com/docker/cagent/pkg/config"
+	"github.com/docker/cagent/pkg/config/latest"
 	"github.com/docker/cagent/pkg/config/types"
+	"github.com/docker/cagent/pkg/memory/database"
 	"github.com/docker/cagent/pkg/runtime"
 	"github.com/docker/cagent/pkg/session"
 	"github.com/docker/cagent/pkg/sessiontitle"
@@ -26,6 +31,7 @@ import (
 	"github.com/docker/cagent/pkg/tools"
 	mcptools "github.com/docker/cagent/pkg/tools/mcp"
 	"github.com/docker/cagent/pkg/tui/messages"
+	"github.com/docker/cagent/pkg/userconfig"
 )
 
 type App struct {
@@ -41,6 +47,8 @@ type App struct {
 	exitAfterFirstResponse bool                    // Exit TUI after first assistant response completes
 	titleGenerating        atomic.Bool             // True when title generation is in progress
 	titleGen               *sessiontitle.Generator // Title generator for local runtime (nil for remote)
+	config                 *latest.Config          // Resolved team configuration (nil for remote)
+	configFile             string                  // Path of the team's config file (empty unless local)
 }
 
 // Opt is an option for creating a new App.
@@ -84,12 +92,35 @@ func WithTitleGenerator(gen *sessiontitle.Generator) Opt {
 	}
 }
 
+// WithStreamFlushInterval sets how long the streamed responses are batched
+// before being sent to the TUI. 0 sends every chunk as it arrives.
+func WithStreamFlushInterval(interval time.Duration) Opt {
+	return func(a *App) {
+		a.throttleDuration = interval
+	}
+}
+
+// WithConfig sets the resolved configuration of the team, shown by EffectiveConfig.
+func WithConfig(cfg *latest.Config) Opt {
+	return func(a *App) {
+		a.config = cfg
+	}
+}
+
+// WithConfigFile sets the path of the local file the team was loaded from,
+// opened by the edit-config command.
+func WithConfigFile(path string) Opt {
+	return func(a *App) {
+		a.configFile = path
+	}
+}
+
 func New(ctx context.Context, rt runtime.Runtime, sess *session.Session, opts ...Opt) *App {
 	app := &App{
 		runtime:          rt,
 		session:          sess,
 		events:           make(chan tea.Msg, 128),
-		throttleDuration: 50 * time.Millisecond, // Throttle rapid events
+		throttleDuration: userconfig.DefaultStreamFlushInterval, // Throttle rapid events
 	}
 
 	for _, opt := range opts {
@@ -191,6 +222,111 @@ func (a *App) CurrentAgentSkills() []skills.Skill {
 	return st.Skills()
 }
 
+// CurrentAgentRetryPolicy returns how the model calls of the current agent
+// are retried. ok is false when the configuration isn't known, as with
+// remote runtimes.
+func (a *App) CurrentAgentRetryPolicy() (policy runtime.RetryPolicy, ok bool) {
+	if a.config == nil {
+		return runtime.RetryPolicy{}, false
+	}
+	agentConfig, found := a.config.Agents.Lookup(a.runtime.CurrentAgentName())
+	if !found {
+		return runtime.RetryPolicy{}, false
+	}
+	return runtime.EffectiveRetryPolicy(agentConfig.GetFallbackRetries(), agentConfig.GetFallbackCooldown()), true
+}
+
+// EffectiveConfig renders the resolved configuration of the team as YAML,
+// with secrets redacted. ok is false when the configuration isn't known, as
+// with remote runtimes.
+func (a *App) EffectiveConfig() (yaml string, ok bool, err error) {
+	if a.config == nil {
+		return "", false, nil
+	}
+	yaml, err = config.EffectiveYAML(a.config)
+	return yaml, true, err
+}
+
+// ConfigFile returns the path of the local file the team was loaded from.
+// It's empty for teams loaded from a URL, an OCI reference or a remote runtime.
+func (a *App) ConfigFile() string {
+	return a.configFile
+}
+
+// TasksDir returns the directory holding the current agent's tasks file and
+// whether any task was saved to it. dir is empty when the agent doesn't use
+// the tasks toolset.
+func (a *App) TasksDir() (dir string, hasTasks bool) {
+	tt := a.runtime.CurrentAgentTasksToolset()
+	if tt == nil {
+		return "", false
+	}
+	return filepath.Dir(tt.StoragePath()), tt.HasTasks()
+}
+
+// CurrentTaskMarkdown returns the markdown of the current agent's task in
+// progress, or of its most recently updated task. ok is false when the agent
+// doesn't use the tasks toolset or has no tasks.
+func (a *App) CurrentTaskMarkdown() (markdown string, ok bool) {
+	tt := a.runtime.CurrentAgentTasksToolset()
+	if tt == nil {
+		return "", false
+	}
+	return tt.CurrentTaskMarkdown()
+}
+
+// Memories returns the memories stored by the current agent. ok is false when
+// the agent doesn't use the memory toolset.
+func (a *App) Memories(ctx context.Context) (memories []database.UserMemory, ok bool, err error) {
+	mt := a.runtime.CurrentAgentMemoryToolset()
+	if mt == nil {
+		return nil, false, nil
+	}
+	memories, err = mt.Memories(ctx)
+	return memories, true, err
+}
+
+// DeleteMemory deletes one of the memories stored by the current agent.
+func (a *App) DeleteMemory(ctx context.Context, id string) error {
+	mt := a.runtime.CurrentAgentMemoryToolset()
+	if mt == nil {
+		return errors.New("the current agent doesn't use the memory toolset")
+	}
+	return mt.DeleteMemory(ctx, id)
+}
+
+// ClearMemories deletes all the memories stored by the current agent.
+func (a *App) ClearMemories(ctx context.Context) error {
+	mt := a.runtime.CurrentAgentMemoryToolset()
+	if mt == nil {
+		return errors.New("the current agent doesn't use the memory toolset")
+	}
+	return mt.ClearMemories(ctx)
+}
+
+// SetRequestLog starts or stops logging the model requests and responses of
+// the session to a file. When withBodies is false, only metadata is logged.
+// It returns the path of the log file.
+func (a *App) SetRequestLog(enabled, withBodies bool) (string, error) {
+	rl, ok := a.runtime.(runtime.RequestLogger)
+	if !ok {
+		return "", errors.New("request logging is not supported by this runtime")
+	}
+	rl.SetRequestLog(enabled, withBodies)
+	return runtime.RequestLogPath(a.session.ID), nil
+}
+
+// RequestLogStatus reports whether the model requests are being logged, and
+// where.
+func (a *App) RequestLogStatus() (enabled, withBodies bool, path string) {
+	rl, ok := a.runtime.(runtime.RequestLogger)
+	if !ok {
+		return false, false, ""
+	}
+	enabled, withBodies = rl.RequestLogEnabled()
+	return enabled, withBodies, runtime.RequestLogPath(a.session.ID)
+}
+
 // ResolveSkillCommand checks if the input matches a skill slash command (e.g. /skill-name args).
 // If matched, it reads the skill content and returns the resolved prompt. Otherwise returns "".
 func (a *App) ResolveSkillCommand(input string) (string, error) {
@@ -306,6 +442,9 @@ func (a *App) Run(ctx context.Context, cancel context.CancelFunc, message string
 				case att.FilePath != "":
 					// File-reference attachment: read and classify from disk.
 					a.processFileAttachment(ctx, att, &textBuilder, &binaryParts)
+				case att.URL != "":
+					// Remote attachment: fetched now.
+					a.processURLAttachment(ctx, att, &textBuilder)
 				case att.Content != "":
 					// Inline content attachment (e.g. pasted text).
 					a.processInlineAttachment(att, &textBuilder)
@@ -540,6 +679,17 @@ func (a *App) Resume(req runtime.ResumeRequest) {
 	a.runtime.Resume(context.Background(), req)
 }
 
+// StopAfterCurrentStep asks the runtime to stop the run once the current
+// step completes. It returns false when the runtime can't do it.
+func (a *App) StopAfterCurrentStep() bool {
+	stopper, ok := a.runtime.(runtime.StepStopper)
+	if !ok {
+		return false
+	}
+	stopper.StopAfterCurrentStep()
+	return true
+}
+
 // ResumeElicitation resumes an elicitation request with the given action and content
 func (a *App) ResumeElicitation(ctx context.Context, action tools.ElicitationAction, content map[string]any) error {
 	return a.runtime.ResumeElicitation(ctx, action, content)
@@ -580,11 +730,13 @@ func (a *App) PermissionsInfo() *runtime.PermissionsInfo {
 	// Get session-level permissions
 	var sessionPerms *runtime.PermissionsInfo
 	if a.session != nil && a.session.Permissions != nil {
-		if len(a.session.Permissions.Allow) > 0 || len(a.session.Permissions.Ask) > 0 || len(a.session.Permissions.Deny) > 0 {
+		perms := a.session.Permissions
+		if len(perms.Allow) > 0 || len(perms.Ask) > 0 || len(perms.Deny) > 0 || len(perms.AllowToolsets) > 0 {
 			sessionPerms = &runtime.PermissionsInfo{
-				Allow: a.session.Permissions.Allow,
-				Ask:   a.session.Permissions.Ask,
-				Deny:  a.session.Permissions.Deny,
+				Allow:         perms.Allow,
+				Ask:           perms.Ask,
+				Deny:          perms.Deny,
+				AllowToolsets: perms.AllowToolsets,
 			}
 		}
 	}
@@ -600,11 +752,13 @@ func (a *App) PermissionsInfo() *runtime.PermissionsInfo {
 		result.Allow = append(result.Allow, sessionPerms.Allow...)
 		result.Ask = append(result.Ask, sessionPerms.Ask...)
 		result.Deny = append(result.Deny, sessionPerms.Deny...)
+		result.AllowToolsets = append(result.AllowToolsets, sessionPerms.AllowToolsets...)
 	}
 	if teamPerms != nil {
 		result.Allow = append(result.Allow, teamPerms.Allow...)
 		result.Ask = append(result.Ask, teamPerms.Ask...)
 		result.Deny = append(result.Deny, teamPerms.Deny...)
+		result.AllowToolsets = append(result.AllowToolsets, teamPerms.AllowToolsets...)
 	}
 
 	return result
@@ -821,6 +975,62 @@ func (a *App) SessionStore() session.Store {
 	return a.runtime.SessionStore()
 }
 
+// SaveSession persists the current session's metadata to the session store.
+// It is a no-op when no session store is configured.
+func (a *App) SaveSession(ctx context.Context) error {
+	store := a.runtime.SessionStore()
+	if store == nil || a.session == nil {
+		return nil
+	}
+	return store.UpdateSession(ctx, a.session)
+}
+
+// ContinuationSummary summarizes the current session for a follow-up session
+// to start from: the summary of its compacted history, if any, and the last
+// response of the agent. It returns "" when the agent hasn't responded yet.
+func (a *App) ContinuationSummary() string {
+	if a.session == nil {
+		return ""
+	}
+	lastResponse := a.session.GetLastAssistantMessageContent()
+	if lastResponse == "" {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString("This session follows up on an earlier session")
+	if a.session.Title != "" {
+		fmt.Fprintf(&sb, " titled %q", a.session.Title)
+	}
+	sb.WriteString(".")
+	for _, item := range slices.Backward(a.session.Messages) {
+		if item.Summary != "" {
+			sb.WriteString("\n\nSummary of the earlier conversation:\n" + item.Summary)
+			break
+		}
+	}
+	sb.WriteString("\n\nLast response of the agent:\n" + lastResponse)
+	return sb.String()
+}
+
+// SeedSummary adds the summary of earlier work, e.g. from ContinuationSummary,
+// to the current session. The agent gets it as context on every request.
+func (a *App) SeedSummary(ctx context.Context, summary string) error {
+	if a.session == nil {
+		return fmt.Errorf("no active session")
+	}
+	a.session.Messages = append(a.session.Messages, session.Item{Summary: summary})
+
+	store := a.runtime.SessionStore()
+	if store == nil {
+		return nil
+	}
+	if err := store.UpdateSession(ctx, a.session); err != nil {
+		return err
+	}
+	return store.AddSummary(ctx, a.session.ID, summary)
+}
+
 // ReplaceSession replaces the current session with the given session.
 // This is used when loading a past session. It also re-emits startup info
 // so the sidebar displays the agent and tool information.
@@ -915,7 +1125,7 @@ func (a *App) throttleEvents(ctx context.Context, in <-chan tea.Msg) <-chan tea.
 				}
 
 				buffer = append(buffer, msg)
-				if a.shouldThrottle(msg) {
+				if a.throttleDuration > 0 && a.shouldThrottle(msg) {
 					if timerCh == nil {
 						timerCh = time.After(a.throttleDuration)
 					}
@@ -2,12 +2,16 @@ package app
 
 import (
 	"context"
+	"strings"
 	"testing"
+	"testing/synctest"
+	"time"
 
 	tea "charm.land/bubbletea/v2"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/docker/cagent/pkg/chat"
 	"github.com/docker/cagent/pkg/runtime"
 	"github.com/docker/cagent/pkg/session"
 	"github.com/docker/cagent/pkg/sessiontitle"
@@ -52,6 +56,14 @@ func (m *mockRuntime) CurrentAgentSkillsToolset() *builtin.SkillsToolset {
 	return nil
 }
 
+func (m *mockRuntime) CurrentAgentTasksToolset() *builtin.TasksTool {
+	return nil
+}
+
+func (m *mockRuntime) CurrentAgentMemoryToolset() *builtin.MemoryTool {
+	return nil
+}
+
 func (m *mockRuntime) CurrentMCPPrompts(context.Context) map[string]mcptools.PromptInfo {
 	return make(map[string]mcptools.PromptInfo)
 }
@@ -319,3 +331,49 @@ func TestApp_RegenerateSessionTitle(t *testing.T) {
 		require.ErrorIs(t, err, ErrTitleGenerating)
 	})
 }
+
+func TestApp_ContinuationSummary(t *testing.T) {
+	t.Parallel()
+
+	sess := session.New(session.WithUserMessage("Fix the build"))
+	app := &App{runtime: &mockRuntime{}, session: sess}
+	assert.Empty(t, app.ContinuationSummary(), "nothing to continue before a response")
+
+	sess.Title = "Build fix"
+	sess.Messages = append(sess.Messages, session.Item{Summary: "We looked at the CI logs."})
+	sess.AddMessage(&session.Message{AgentName: "root", Message: chat.Message{Role: chat.MessageRoleAssistant, Content: "The build is fixed."}})
+
+	summary := app.ContinuationSummary()
+	assert.Contains(t, summary, `titled "Build fix"`)
+	assert.Contains(t, summary, "We looked at the CI logs.")
+	assert.Contains(t, summary, "The build is fixed.")
+
+	next := &App{runtime: &mockRuntime{}, session: session.New()}
+	require.NoError(t, next.SeedSummary(t.Context(), summary))
+	require.Len(t, next.session.Messages, 1)
+	assert.Equal(t, summary, next.session.Messages[0].Summary)
+}
+
+func TestApp_StreamFlushInterval(t *testing.T) {
+	t.Parallel()
+
+	chunks := func(t *testing.T, interval time.Duration) []string {
+		t.Helper()
+		var contents []string
+		synctest.Test(t, func(t *testing.T) {
+			app := &App{throttleDuration: interval}
+			in := make(chan tea.Msg, 3)
+			for _, content := range []string{"Hel", "lo", "!"} {
+				in <- &runtime.AgentChoiceEvent{Content: content}
+			}
+			out := app.throttleEvents(t.Context(), in)
+			for len(strings.Join(contents, "")) < len("Hello!") {
+				contents = append(contents, (<-out).(*runtime.AgentChoiceEvent).Content)
+			}
+		})
+		return contents
+	}
+
+	assert.Equal(t, []string{"Hello!"}, chunks(t, 50*time.Millisecond))
+	assert.Equal(t, []string{"Hel", "lo", "!"}, chunks(t, 0), "0 sends every chunk right away")
+}
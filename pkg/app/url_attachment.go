@@ -0,0 +1,126 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/k3a/html2text"
+
+	"github.com/docker/cagent/pkg/runtime"
+	"github.com/docker/cagent/pkg/tui/messages"
+	"github.com/docker/cagent/pkg/useragent"
+	"github.com/docker/cagent/pkg/userconfig"
+)
+
+const (
+	// urlAttachmentTimeout bounds the time spent fetching a URL attachment.
+	urlAttachmentTimeout = 15 * time.Second
+	// maxURLAttachmentSize caps the size of a fetched URL attachment.
+	maxURLAttachmentSize = 1 << 20 // 1MB
+)
+
+// ValidateAttachmentURL checks that a URL can be attached: an absolute http
+// or https URL.
+func ValidateAttachmentURL(rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("invalid URL: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return errors.New("only http and https URLs are supported")
+	}
+	if u.Host == "" {
+		return errors.New("missing host")
+	}
+	return nil
+}
+
+// processURLAttachment fetches a URL and appends its content to textBuilder,
+// as text for HTML pages and as is otherwise.
+func (a *App) processURLAttachment(ctx context.Context, att messages.Attachment, textBuilder *strings.Builder) {
+	if userconfig.Get().DisableURLAttachments {
+		a.sendEvent(ctx, runtime.Warning(fmt.Sprintf("Skipped attachment %s: URL attachments are disabled", att.URL), ""))
+		return
+	}
+
+	content, err := fetchURLAttachment(ctx, att.URL)
+	if err != nil {
+		slog.Warn("skipping URL attachment", "url", att.URL, "error", err)
+		a.sendEvent(ctx, runtime.Warning(fmt.Sprintf("Skipped attachment %s: %v", att.URL, err), ""))
+		return
+	}
+	textBuilder.WriteString("\n\n")
+	fmt.Fprintf(textBuilder, "<attached_url url=%q>\n%s\n</attached_url>", att.URL, content)
+}
+
+// fetchURLAttachment fetches the content of a URL attachment, with a timeout
+// and a size cap. Binary content is rejected.
+func fetchURLAttachment(ctx context.Context, rawURL string) (string, error) {
+	if err := ValidateAttachmentURL(rawURL); err != nil {
+		return "", err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, urlAttachmentTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, http.NoBody)
+	if err != nil {
+		return "", fmt.Errorf("creating request: %w", err)
+	}
+	req.Header.Set("User-Agent", useragent.Header)
+	req.Header.Set("Accept", "text/html;q=1.0, text/plain;q=0.9, */*;q=0.1")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			return "", fmt.Errorf("timed out after %s", urlAttachmentTimeout)
+		}
+		return "", fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return "", fmt.Errorf("server returned %s", resp.Status)
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if !isTextMediaType(mediaType) {
+		return "", fmt.Errorf("unsupported content type %q", mediaType)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxURLAttachmentSize+1))
+	if err != nil {
+		return "", fmt.Errorf("reading response: %w", err)
+	}
+	if len(body) > maxURLAttachmentSize {
+		return "", errors.New("content too large (max 1MB)")
+	}
+
+	if mediaType == "text/html" || mediaType == "application/xhtml+xml" {
+		return html2text.HTML2Text(string(body)), nil
+	}
+	return string(body), nil
+}
+
+// isTextMediaType reports whether a media type holds text that can be
+// inlined in a message. An empty media type is assumed to be text.
+func isTextMediaType(mediaType string) bool {
+	switch {
+	case mediaType == "", strings.HasPrefix(mediaType, "text/"):
+		return true
+	case mediaType == "application/json", mediaType == "application/xml", mediaType == "application/xhtml+xml":
+		return true
+	case strings.HasSuffix(mediaType, "+json"), strings.HasSuffix(mediaType, "+xml"):
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,62 @@
+package app
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFetchURLAttachment(t *testing.T) {
+	t.Parallel()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/page", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_, _ = w.Write([]byte("<html><body><h1>Title</h1><p>Some <b>content</b>.</p></body></html>"))
+	})
+	mux.HandleFunc("/raw.md", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/markdown")
+		_, _ = w.Write([]byte("# Title\n"))
+	})
+	mux.HandleFunc("/image.png", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "image/png")
+		_, _ = w.Write([]byte{0x89, 'P', 'N', 'G'})
+	})
+	mux.HandleFunc("/large", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		_, _ = w.Write([]byte(strings.Repeat("a", maxURLAttachmentSize+1)))
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	content, err := fetchURLAttachment(t.Context(), server.URL+"/page")
+	require.NoError(t, err)
+	assert.Contains(t, content, "Some content.")
+	assert.NotContains(t, content, "<p>")
+
+	content, err = fetchURLAttachment(t.Context(), server.URL+"/raw.md")
+	require.NoError(t, err)
+	assert.Equal(t, "# Title\n", content)
+
+	_, err = fetchURLAttachment(t.Context(), server.URL+"/image.png")
+	require.ErrorContains(t, err, "unsupported content type")
+
+	_, err = fetchURLAttachment(t.Context(), server.URL+"/large")
+	require.ErrorContains(t, err, "too large")
+
+	_, err = fetchURLAttachment(t.Context(), server.URL+"/missing")
+	require.ErrorContains(t, err, "404")
+}
+
+func TestValidateAttachmentURL(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, ValidateAttachmentURL("https://example.com/docs"))
+	require.Error(t, ValidateAttachmentURL("file:///etc/passwd"))
+	require.Error(t, ValidateAttachmentURL("https://"))
+	require.Error(t, ValidateAttachmentURL("example.com"))
+}
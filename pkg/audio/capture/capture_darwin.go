@@ -28,6 +28,9 @@ static UInt32 bufferSize = 4096;
 // Audio format description
 static AudioStreamBasicDescription audioFormat;
 
+// UID of the input device to capture from, NULL for the default device
+static CFStringRef deviceUID = NULL;
+
 // Forward declaration for Go callback
 void goAudioCallback(void *data, int size);
 
@@ -75,6 +78,65 @@ static void initAudioFormat(double rate) {
     audioFormat.mBytesPerFrame = (BITS_PER_SAMPLE / 8) * CHANNELS;
 }
 
+// Set the input device of the next captures by UID, NULL for the default device
+static void setCaptureDevice(const char *uid) {
+    if (deviceUID != NULL) { CFRelease(deviceUID); deviceUID = NULL; }
+    if (uid != NULL) {
+        deviceUID = CFStringCreateWithCString(NULL, uid, kCFStringEncodingUTF8);
+    }
+}
+
+// List the devices with input streams, writing up to max UIDs and names of
+// at most len bytes each. Returns the number of devices found.
+static int listInputDevices(char *uids, char *names, int max, int len) {
+    AudioObjectPropertyAddress devicesAddr = {
+        kAudioHardwarePropertyDevices, kAudioObjectPropertyScopeGlobal, kAudioObjectPropertyElementMain };
+    UInt32 size = 0;
+    if (AudioObjectGetPropertyDataSize(kAudioObjectSystemObject, &devicesAddr, 0, NULL, &size) != noErr) return -8;
+
+    int count = size / sizeof(AudioDeviceID);
+    AudioDeviceID *ids = malloc(size);
+    if (ids == NULL) return -8;
+    if (AudioObjectGetPropertyData(kAudioObjectSystemObject, &devicesAddr, 0, NULL, &size, ids) != noErr) {
+        free(ids);
+        return -8;
+    }
+
+    AudioObjectPropertyAddress streamsAddr = {
+        kAudioDevicePropertyStreams, kAudioObjectPropertyScopeInput, kAudioObjectPropertyElementMain };
+    AudioObjectPropertyAddress uidAddr = {
+        kAudioDevicePropertyDeviceUID, kAudioObjectPropertyScopeGlobal, kAudioObjectPropertyElementMain };
+    AudioObjectPropertyAddress nameAddr = {
+        kAudioObjectPropertyName, kAudioObjectPropertyScopeGlobal, kAudioObjectPropertyElementMain };
+
+    int found = 0;
+    for (int i = 0; i < count && found < max; i++) {
+        UInt32 streamsSize = 0;
+        if (AudioObjectGetPropertyDataSize(ids[i], &streamsAddr, 0, NULL, &streamsSize) != noErr || streamsSize == 0) {
+            continue; // Output only
+        }
+
+        CFStringRef uid = NULL;
+        CFStringRef name = NULL;
+        UInt32 strSize = sizeof(CFStringRef);
+        if (AudioObjectGetPropertyData(ids[i], &uidAddr, 0, NULL, &strSize, &uid) != noErr) continue;
+        strSize = sizeof(CFStringRef);
+        if (AudioObjectGetPropertyData(ids[i], &nameAddr, 0, NULL, &strSize, &name) != noErr) {
+            CFRelease(uid);
+            continue;
+        }
+
+        CFStringGetCString(uid, uids + found * len, len, kCFStringEncodingUTF8);
+        CFStringGetCString(name, names + found * len, len, kCFStringEncodingUTF8);
+        CFRelease(uid);
+        CFRelease(name);
+        found++;
+    }
+
+    free(ids);
+    return found;
+}
+
 // Start capturing audio, optionally recording to file
 // filePath can be NULL for streaming-only mode
 static int startCapture(const char *filePath, double rate) {
@@ -102,6 +164,15 @@ static int startCapture(const char *filePath, double rate) {
         return -4;
     }
 
+    if (deviceUID != NULL) {
+        status = AudioQueueSetProperty(audioQueue, kAudioQueueProperty_CurrentDevice, &deviceUID, sizeof(deviceUID));
+        if (status != noErr) {
+            AudioQueueDispose(audioQueue, true);
+            if (audioFile) { AudioFileClose(audioFile); audioFile = NULL; }
+            return -7;
+        }
+    }
+
     // Allocate and enqueue buffers
     for (int i = 0; i < NUM_BUFFERS; i++) {
         status = AudioQueueAllocateBuffer(audioQueue, bufferSize, &buffers[i]);
@@ -153,6 +224,9 @@ import (
 	"unsafe"
 )
 
+// Supported reports whether audio capture is available on this platform.
+const Supported = true
+
 // Common sample rates
 const (
 	SampleRate44100 = 44100 // CD quality, used for WAV recording
@@ -169,6 +243,7 @@ type Capturer struct {
 	capturing  bool
 	handler    AudioHandler
 	sampleRate int
+	device     string
 }
 
 // Global instance for C callback
@@ -193,6 +268,38 @@ func NewCapturer(sampleRate int) *Capturer {
 	return &Capturer{sampleRate: sampleRate}
 }
 
+// SetDevice sets the ID of the input device the next captures use. An empty
+// ID uses the system's default input device.
+func (c *Capturer) SetDevice(id string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.device = id
+}
+
+// Devices returns the audio input devices.
+func Devices() ([]Device, error) {
+	const maxDevices, maxLen = 32, 256
+
+	uids := (*C.char)(C.malloc(maxDevices * maxLen))
+	defer C.free(unsafe.Pointer(uids))
+	names := (*C.char)(C.malloc(maxDevices * maxLen))
+	defer C.free(unsafe.Pointer(names))
+
+	n := int(C.listInputDevices(uids, names, maxDevices, maxLen))
+	if n < 0 {
+		return nil, errorFromCode(n)
+	}
+
+	devices := make([]Device, n)
+	for i := range devices {
+		devices[i] = Device{
+			ID:   C.GoString((*C.char)(unsafe.Add(unsafe.Pointer(uids), i*maxLen))),
+			Name: C.GoString((*C.char)(unsafe.Add(unsafe.Pointer(names), i*maxLen))),
+		}
+	}
+	return devices, nil
+}
+
 // Start begins capturing audio. If filePath is non-empty, audio is also saved to a WAV file.
 // The handler is called for each chunk of audio data (can be nil if only recording to file).
 func (c *Capturer) Start(filePath string, handler AudioHandler) error {
@@ -215,6 +322,13 @@ func (c *Capturer) Start(filePath string, handler AudioHandler) error {
 		defer C.free(unsafe.Pointer(cPath))
 	}
 
+	var cDevice *C.char
+	if c.device != "" {
+		cDevice = C.CString(c.device)
+		defer C.free(unsafe.Pointer(cDevice))
+	}
+	C.setCaptureDevice(cDevice)
+
 	if result := C.startCapture(cPath, C.double(c.sampleRate)); result != 0 {
 		return errorFromCode(int(result))
 	}
@@ -279,6 +393,8 @@ var errorMessages = map[int]string{
 	-4: "failed to create audio queue",
 	-5: "failed to allocate audio buffers",
 	-6: "failed to start audio queue",
+	-7: "failed to select the input device",
+	-8: "failed to list the audio devices",
 }
 
 func errorFromCode(code int) error {
@@ -9,6 +9,9 @@ import (
 	"time"
 )
 
+// Supported reports whether audio capture is available on this platform.
+const Supported = false
+
 // Common sample rates
 const (
 	SampleRate44100 = 44100 // CD quality, used for WAV recording
@@ -33,6 +36,14 @@ func NewCapturer(sampleRate int) *Capturer {
 	return &Capturer{sampleRate: sampleRate}
 }
 
+// SetDevice is a no-op on non-macOS platforms.
+func (c *Capturer) SetDevice(id string) {}
+
+// Devices returns ErrNotSupported on non-macOS platforms.
+func Devices() ([]Device, error) {
+	return nil, ErrNotSupported
+}
+
 // Start returns ErrNotSupported on non-macOS platforms.
 func (c *Capturer) Start(filePath string, handler AudioHandler) error {
 	return ErrNotSupported
@@ -0,0 +1,9 @@
+package capture
+
+// Device is an audio input device.
+type Device struct {
+	// ID identifies the device across restarts. On macOS it is the
+	// CoreAudio device UID.
+	ID   string
+	Name string
+}
@@ -1,6 +1,3 @@
-//go:build darwin && !no_audio
-
-// Package transcribe provides real-time audio transcription using OpenAI's Realtime API.
 package transcribe
 
 import (
@@ -19,11 +16,13 @@ import (
 
 const openAIRealtimeURL = "wss://api.openai.com/v1/realtime?model=gpt-4o-realtime-preview"
 
-// TranscriptHandler is called when new transcription text is received.
-type TranscriptHandler func(delta string)
+func init() {
+	Register(DefaultBackend, newOpenAI)
+}
 
-// Transcriber provides real-time audio transcription using OpenAI's Realtime API.
-type Transcriber struct {
+// openAITranscriber transcribes audio with OpenAI's Realtime API. It captures
+// audio with the capture package, so it only works where capture is supported.
+type openAITranscriber struct {
 	apiKey  string
 	conn    *websocket.Conn
 	capture *capture.Capturer
@@ -42,18 +41,21 @@ type serverEvent struct {
 	} `json:"error,omitempty"`
 }
 
-// New creates a new Transcriber with the given OpenAI API key.
-func New(apiKey string) *Transcriber {
-	return &Transcriber{
-		apiKey:  apiKey,
-		capture: capture.NewCapturer(capture.SampleRate24000),
+func newOpenAI(opts Options) Transcriber {
+	c := capture.NewCapturer(capture.SampleRate24000)
+	c.SetDevice(opts.Device)
+	return &openAITranscriber{
+		apiKey:  opts.APIKey,
+		capture: c,
 	}
 }
 
-// Start begins audio capture and transcription. The handler is called for each
-// transcription delta received. Returns an error if already running or if
-// connection fails. Call Stop to end transcription.
-func (t *Transcriber) Start(ctx context.Context, handler TranscriptHandler) error {
+// Start returns an error if already running, if audio capture is not supported
+// or if the connection fails.
+func (t *openAITranscriber) Start(ctx context.Context, handler TranscriptHandler) error {
+	if !capture.Supported {
+		return ErrNotSupported
+	}
 	if wasRunning := t.running.Swap(true); wasRunning {
 		return fmt.Errorf("transcriber already running")
 	}
@@ -115,8 +117,7 @@ func (t *Transcriber) Start(ctx context.Context, handler TranscriptHandler) erro
 	return nil
 }
 
-// Stop ends the transcription session and releases resources.
-func (t *Transcriber) Stop() {
+func (t *openAITranscriber) Stop() {
 	if wasRunning := t.running.Swap(false); !wasRunning {
 		return
 	}
@@ -141,18 +142,16 @@ func (t *Transcriber) Stop() {
 	}
 }
 
-// IsRunning returns true if transcription is currently active.
-func (t *Transcriber) IsRunning() bool {
+func (t *openAITranscriber) IsRunning() bool {
 	return t.running.Load()
 }
 
-// IsSupported returns true on macOS where audio capture is available.
-func (t *Transcriber) IsSupported() bool {
-	return true
+func (t *openAITranscriber) IsSupported() bool {
+	return capture.Supported
 }
 
 // readLoop reads messages from the WebSocket and calls the handler for transcription deltas.
-func (t *Transcriber) readLoop(ctx context.Context, handler TranscriptHandler) {
+func (t *openAITranscriber) readLoop(ctx context.Context, handler TranscriptHandler) {
 	for {
 		select {
 		case <-ctx.Done():
@@ -0,0 +1,83 @@
+// Package transcribe provides real-time speech-to-text transcription through
+// pluggable backends.
+package transcribe
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// DefaultBackend is the backend used when none is configured.
+const DefaultBackend = "openai"
+
+// ErrNotSupported is returned when transcription is not supported on the current platform.
+var ErrNotSupported = errors.New("speech-to-text is not supported on this platform")
+
+// TranscriptHandler is called when new transcription text is received.
+type TranscriptHandler func(delta string)
+
+// Transcriber transcribes the audio of an input device in real time.
+type Transcriber interface {
+	// Start begins audio capture and transcription. The handler is called
+	// for each transcription delta received. Call Stop to end transcription.
+	Start(ctx context.Context, handler TranscriptHandler) error
+	// Stop ends the transcription session and releases resources.
+	Stop()
+	// IsRunning returns true if transcription is currently active.
+	IsRunning() bool
+	// IsSupported returns true if the backend works on this platform.
+	IsSupported() bool
+}
+
+// Options configures a transcriber.
+type Options struct {
+	// APIKey authenticates with the backend's service.
+	APIKey string
+	// Device is the ID of the input device to capture. Empty uses the
+	// system's default input device.
+	Device string
+}
+
+// Backend creates transcribers with the given options.
+type Backend func(opts Options) Transcriber
+
+var (
+	backendsMu sync.RWMutex
+	backends   = map[string]Backend{}
+)
+
+// Register makes a backend available under the given name, replacing any
+// backend registered under the same name.
+func Register(name string, backend Backend) {
+	backendsMu.Lock()
+	defer backendsMu.Unlock()
+	backends[name] = backend
+}
+
+// Backends returns the names of the registered backends, sorted.
+func Backends() []string {
+	backendsMu.RLock()
+	defer backendsMu.RUnlock()
+	return slices.Sorted(maps.Keys(backends))
+}
+
+// New creates a transcriber with the named backend, or with DefaultBackend
+// when name is empty.
+func New(name string, opts Options) (Transcriber, error) {
+	if name == "" {
+		name = DefaultBackend
+	}
+
+	backendsMu.RLock()
+	backend, ok := backends[name]
+	backendsMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown speech-to-text backend %q (available: %s)", name, strings.Join(Backends(), ", "))
+	}
+	return backend(opts), nil
+}
@@ -0,0 +1,40 @@
+package transcribe
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/docker/cagent/pkg/audio/capture"
+)
+
+type fakeTranscriber struct {
+	opts Options
+}
+
+func (f *fakeTranscriber) Start(context.Context, TranscriptHandler) error { return nil }
+func (f *fakeTranscriber) Stop()                                          {}
+func (f *fakeTranscriber) IsRunning() bool                                { return false }
+func (f *fakeTranscriber) IsSupported() bool                              { return true }
+
+func TestNew(t *testing.T) {
+	Register("fake", func(opts Options) Transcriber { return &fakeTranscriber{opts: opts} })
+	assert.Contains(t, Backends(), DefaultBackend)
+	assert.Contains(t, Backends(), "fake")
+
+	tr, err := New("fake", Options{Device: "mic-2"})
+	require.NoError(t, err)
+	assert.Equal(t, "mic-2", tr.(*fakeTranscriber).opts.Device)
+
+	tr, err = New("", Options{})
+	require.NoError(t, err)
+	assert.Equal(t, capture.Supported, tr.IsSupported())
+	if !capture.Supported {
+		require.ErrorIs(t, tr.Start(t.Context(), nil), ErrNotSupported)
+	}
+
+	_, err = New("whisper.cpp", Options{})
+	require.ErrorContains(t, err, `unknown speech-to-text backend "whisper.cpp"`)
+}
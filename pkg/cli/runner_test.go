@@ -44,6 +44,8 @@ func (m *mockRuntime) SessionStore() session.Store
 func (m *mockRuntime) Summarize(context.Context, *session.Session, string, chan runtime.Event) {}
 func (m *mockRuntime) PermissionsInfo() *runtime.PermissionsInfo                               { return nil }
 func (m *mockRuntime) CurrentAgentSkillsToolset() *builtin.SkillsToolset                       { return nil }
+func (m *mockRuntime) CurrentAgentTasksToolset() *builtin.TasksTool                            { return nil }
+func (m *mockRuntime) CurrentAgentMemoryToolset() *builtin.MemoryTool                          { return nil }
 func (m *mockRuntime) CurrentMCPPrompts(context.Context) map[string]mcptools.PromptInfo {
 	return nil
 }
@@ -101,12 +101,12 @@ func validateConfig(cfg *latest.Config) error {
 		cfg.Models = map[string]latest.ModelConfig{}
 	}
 
-	for name := range cfg.Models {
-		if cfg.Models[name].ParallelToolCalls == nil {
-			m := cfg.Models[name]
-			m.ParallelToolCalls = new(true)
-			cfg.Models[name] = m
-		}
+	if err := expandModelAliases(cfg); err != nil {
+		return err
+	}
+
+	for name, m := range cfg.Models {
+		cfg.Models[name] = withModelDefaults(m)
 	}
 
 	if err := ensureModelsExist(cfg); err != nil {
@@ -38,6 +38,93 @@ func TestAutoRegisterAlloy(t *testing.T) {
 	assert.Equal(t, "claude-sonnet-4-0", cfg.Models["anthropic/claude-sonnet-4-0"].Model)
 }
 
+func TestModelAliases(t *testing.T) {
+	t.Parallel()
+
+	cfg, err := Load(t.Context(), NewFileSource("testdata/model_aliases.yaml"))
+	require.NoError(t, err)
+
+	assert.Equal(t, "openai", cfg.Models["fast"].Provider)
+	assert.Equal(t, "gpt-4o-mini", cfg.Models["fast"].Model)
+
+	// An alias to a named model inherits its full configuration.
+	assert.Equal(t, "anthropic", cfg.Models["smart"].Provider)
+	assert.Equal(t, "claude-sonnet-4-0", cfg.Models["smart"].Model)
+	require.NotNil(t, cfg.Models["smart"].MaxTokens)
+	assert.Equal(t, int64(64000), *cfg.Models["smart"].MaxTokens)
+}
+
+func TestModelAliases_KeptAfterExpansion(t *testing.T) {
+	t.Parallel()
+
+	cfg, err := Load(t.Context(), NewFileSource("testdata/model_aliases.yaml"))
+	require.NoError(t, err)
+
+	assert.Equal(t, "openai/gpt-4o-mini", cfg.ModelAliases["fast"])
+	assert.Equal(t, "sonnet", cfg.ModelAliases["smart"])
+
+	// Expanding an already expanded config is a no-op.
+	require.NoError(t, expandModelAliases(cfg))
+
+	// A model that disagrees with the alias of the same name is still rejected.
+	cfg.Models["fast"] = latest.ModelConfig{Provider: "openai", Model: "gpt-4o"}
+	require.ErrorContains(t, expandModelAliases(cfg), "model alias 'fast' conflicts with a model of the same name")
+
+	// So is one with the same model but different settings.
+	cfg.Models["fast"] = latest.ModelConfig{Provider: "openai", Model: "gpt-4o-mini", MaxTokens: new(int64(100))}
+	require.ErrorContains(t, expandModelAliases(cfg), "model alias 'fast' conflicts with a model of the same name")
+}
+
+func TestModelAliases_Chain(t *testing.T) {
+	t.Parallel()
+
+	// Whatever the map iteration order, aliases of aliases resolve.
+	for range 20 {
+		cfg := &latest.Config{
+			Models: map[string]latest.ModelConfig{},
+			ModelAliases: map[string]string{
+				"default": "smart",
+				"smart":   "fast",
+				"fast":    "openai/gpt-4o-mini",
+			},
+		}
+		require.NoError(t, expandModelAliases(cfg))
+		for _, alias := range []string{"default", "smart", "fast"} {
+			assert.Equal(t, "gpt-4o-mini", cfg.Models[alias].Model, alias)
+		}
+	}
+}
+
+func TestModelAliases_Cycle(t *testing.T) {
+	t.Parallel()
+
+	cfg := &latest.Config{
+		Models:       map[string]latest.ModelConfig{},
+		ModelAliases: map[string]string{"a": "b", "b": "a"},
+	}
+	require.ErrorContains(t, expandModelAliases(cfg), "model alias 'a' is part of a cycle: a -> b -> a")
+
+	cfg.ModelAliases = map[string]string{"a": "a"}
+	require.ErrorContains(t, expandModelAliases(cfg), "model alias 'a' is part of a cycle: a -> a")
+}
+
+func TestModelAliases_UnknownInChain(t *testing.T) {
+	t.Parallel()
+
+	cfg := &latest.Config{
+		Models:       map[string]latest.ModelConfig{},
+		ModelAliases: map[string]string{"smart": "fast", "fast": "does-not-exist"},
+	}
+	require.ErrorContains(t, expandModelAliases(cfg), "model alias 'fast' references unknown model 'does-not-exist'")
+}
+
+func TestModelAliases_Unknown(t *testing.T) {
+	t.Parallel()
+
+	_, err := Load(t.Context(), NewFileSource("testdata/model_aliases_unknown.yaml"))
+	require.ErrorContains(t, err, "model alias 'fast' references unknown model 'does-not-exist'")
+}
+
 func TestAlloyModelComposition(t *testing.T) {
 	t.Parallel()
 
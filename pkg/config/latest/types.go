@@ -17,14 +17,25 @@ const Version = "6"
 
 // Config represents the entire configuration file
 type Config struct {
-	Version     string                    `json:"version,omitempty"`
-	Agents      Agents                    `json:"agents,omitempty"`
-	Providers   map[string]ProviderConfig `json:"providers,omitempty"`
-	Models      map[string]ModelConfig    `json:"models,omitempty"`
-	MCPs        map[string]MCPToolset     `json:"mcps,omitempty"`
-	RAG         map[string]RAGConfig      `json:"rag,omitempty"`
-	Metadata    Metadata                  `json:"metadata"`
-	Permissions *PermissionsConfig        `json:"permissions,omitempty"`
+	Version   string                    `json:"version,omitempty"`
+	Agents    Agents                    `json:"agents,omitempty"`
+	Providers map[string]ProviderConfig `json:"providers,omitempty"`
+	Models    map[string]ModelConfig    `json:"models,omitempty"`
+	// ModelAliases maps short, friendly names (e.g. "fast") to a model
+	// reference: either "provider/model" or the name of an entry in Models.
+	ModelAliases map[string]string     `json:"model_aliases,omitempty"`
+	MCPs         map[string]MCPToolset `json:"mcps,omitempty"`
+	RAG          map[string]RAGConfig  `json:"rag,omitempty"`
+	Metadata     Metadata              `json:"metadata"`
+	Permissions  *PermissionsConfig    `json:"permissions,omitempty"`
+	// Theme is the path, relative to the config file, of a TUI theme file
+	// applied when running this team, unless the user has chosen a theme.
+	Theme string `json:"theme,omitempty"`
+	// DefaultAgent is the name of the agent to start on, instead of root.
+	DefaultAgent string `json:"default_agent,omitempty"`
+	// MaxTransferDepth is how many transfer_task calls can be nested before
+	// further transfers are refused. 0 uses the default.
+	MaxTransferDepth int `json:"max_transfer_depth,omitempty"`
 }
 
 // MCPToolset is a reusable MCP server definition stored in the top-level
@@ -247,8 +258,11 @@ type AgentConfig struct {
 	AddEnvironmentInfo      bool              `json:"add_environment_info,omitempty"`
 	CodeModeTools           bool              `json:"code_mode_tools,omitempty"`
 	AddDescriptionParameter bool              `json:"add_description_parameter,omitempty"`
+	Hidden                  bool              `json:"hidden,omitempty"`
+	ToolsetApproval         *ToolsetApproval  `json:"toolset_approval,omitempty"`
 	MaxIterations           int               `json:"max_iterations,omitempty"`
 	NumHistoryItems         int               `json:"num_history_items,omitempty"`
+	WorkingDir              string            `json:"working_dir,omitempty"`
 	AddPromptFiles          []string          `json:"add_prompt_files,omitempty" yaml:"add_prompt_files,omitempty"`
 	Commands                types.Commands    `json:"commands,omitempty"`
 	StructuredOutput        *StructuredOutput `json:"structured_output,omitempty"`
@@ -558,6 +572,11 @@ type Toolset struct {
 
 	Defer DeferConfig `json:"defer" yaml:"defer,omitempty"`
 
+	// CallTimeout is the number of seconds a call to one of the toolset's
+	// tools can run before it's aborted. It overrides the user's
+	// tool_timeouts setting.
+	CallTimeout int `json:"call_timeout,omitempty"`
+
 	// For the `mcp` tool
 	Command string   `json:"command,omitempty"`
 	Args    []string `json:"args,omitempty"`
@@ -1209,6 +1228,19 @@ type PermissionsConfig struct {
 	Ask []string `json:"ask,omitempty"`
 	// Deny lists tool name patterns that are always rejected
 	Deny []string `json:"deny,omitempty"`
+	// AllowToolsets lists toolset types (e.g. "think", "todo", "memory") whose
+	// tools are all auto-approved, unless a deny or ask pattern matches them
+	AllowToolsets []string `json:"allow_toolsets,omitempty"`
+}
+
+// ToolsetApproval declares, for a single agent, which types of its toolsets
+// run their tools without confirmation and which always ask, even for
+// read-only tools. Deny and ask permission patterns still take priority.
+type ToolsetApproval struct {
+	// Allow lists toolset types whose tools are auto-approved
+	Allow []string `json:"allow,omitempty"`
+	// Ask lists toolset types whose tools always require user confirmation
+	Ask []string `json:"ask,omitempty"`
 }
 
 // HooksConfig represents the hooks configuration for an agent.
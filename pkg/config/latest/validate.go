@@ -15,6 +15,10 @@ func (t *Config) UnmarshalYAML(unmarshal func(any) error) error {
 }
 
 func (t *Config) validate() error {
+	if t.MaxTransferDepth < 0 {
+		return errors.New("max_transfer_depth must be >= 0 (0 for the default)")
+	}
+
 	for i := range t.Agents {
 		agent := &t.Agents[i]
 
@@ -56,6 +60,10 @@ func (a *AgentConfig) validateFallback() error {
 }
 
 func (t *Toolset) validate() error {
+	if t.CallTimeout < 0 {
+		return errors.New("call_timeout must not be negative")
+	}
+
 	// Attributes used on the wrong toolset type.
 	if len(t.Shell) > 0 && t.Type != "script" {
 		return errors.New("shell can only be used with type 'script'")
@@ -115,3 +115,14 @@ agents:
 		})
 	}
 }
+
+func TestConfig_Validate_MaxTransferDepth(t *testing.T) {
+	t.Parallel()
+
+	var cfg Config
+	require.NoError(t, yaml.Unmarshal([]byte("max_transfer_depth: 3\n"), &cfg))
+	require.Equal(t, 3, cfg.MaxTransferDepth)
+
+	err := yaml.Unmarshal([]byte("max_transfer_depth: -1\n"), &cfg)
+	require.ErrorContains(t, err, "max_transfer_depth must be >= 0")
+}
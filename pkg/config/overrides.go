@@ -2,6 +2,9 @@ package config
 
 import (
 	"fmt"
+	"maps"
+	"reflect"
+	"slices"
 	"strings"
 
 	"github.com/docker/cagent/pkg/config/latest"
@@ -197,3 +200,82 @@ func ensureSingleModelExists(cfg *latest.Config, modelName, context string) erro
 
 	return nil
 }
+
+// expandModelAliases turns every entry of the model_aliases section into a
+// named model in cfg.Models, so aliases can be used wherever a model name is
+// accepted (agents, routing rules, the TUI model picker, ...). An alias may
+// point to another alias.
+func expandModelAliases(cfg *latest.Config) error {
+	aliases := make(map[string]string, len(cfg.ModelAliases))
+	for alias, ref := range cfg.ModelAliases {
+		aliases[strings.TrimSpace(alias)] = strings.TrimSpace(ref)
+	}
+
+	// Sorted so that errors don't depend on the map iteration order
+	for _, alias := range slices.Sorted(maps.Keys(aliases)) {
+		if alias == "auto" {
+			return fmt.Errorf("model alias 'auto' is reserved")
+		}
+		if strings.ContainsAny(alias, ",/") {
+			return fmt.Errorf("model alias '%s' must not contain ',' or '/'", alias)
+		}
+
+		target, err := resolveModelAlias(cfg, aliases, alias)
+		if err != nil {
+			return err
+		}
+
+		if existing, exists := cfg.Models[alias]; exists {
+			// A config that was already expanded (e.g. marshalled and loaded
+			// again) defines the alias in both sections, with defaults filled
+			// in. That's fine as long as both define the same model.
+			if !reflect.DeepEqual(withModelDefaults(existing), withModelDefaults(target)) {
+				return fmt.Errorf("model alias '%s' conflicts with a model of the same name", alias)
+			}
+			continue
+		}
+		cfg.Models[alias] = target
+	}
+
+	// Keep cfg.ModelAliases so that /config and the model picker can tell
+	// aliases apart from the models they point to.
+	return nil
+}
+
+// resolveModelAlias follows alias, and the aliases it points to, down to a
+// model of cfg.Models or a provider/model reference.
+func resolveModelAlias(cfg *latest.Config, aliases map[string]string, alias string) (latest.ModelConfig, error) {
+	chain := []string{alias}
+	name, ref := alias, aliases[alias]
+	for {
+		if _, isAlias := aliases[ref]; !isAlias {
+			break
+		}
+		if slices.Contains(chain, ref) {
+			return latest.ModelConfig{}, fmt.Errorf("model alias '%s' is part of a cycle: %s -> %s", alias, strings.Join(chain, " -> "), ref)
+		}
+		chain = append(chain, ref)
+		name, ref = ref, aliases[ref]
+	}
+
+	if modelCfg, exists := cfg.Models[ref]; exists {
+		return modelCfg, nil
+	}
+	providerName, model, ok := strings.Cut(ref, "/")
+	if !ok || providerName == "" || model == "" {
+		return latest.ModelConfig{}, fmt.Errorf("model alias '%s' references unknown model '%s': expected a model name or 'provider/model'", name, ref)
+	}
+	return latest.ModelConfig{
+		Provider: providerName,
+		Model:    model,
+	}, nil
+}
+
+// withModelDefaults returns m with the defaults applied to every model of
+// the config.
+func withModelDefaults(m latest.ModelConfig) latest.ModelConfig {
+	if m.ParallelToolCalls == nil {
+		m.ParallelToolCalls = new(true)
+	}
+	return m
+}
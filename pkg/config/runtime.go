@@ -7,6 +7,7 @@ import (
 
 	"github.com/docker/cagent/pkg/config/latest"
 	"github.com/docker/cagent/pkg/environment"
+	"github.com/docker/cagent/pkg/httpclient"
 )
 
 type RuntimeConfig struct {
@@ -23,6 +24,8 @@ type Config struct {
 	DefaultModel   *latest.ModelConfig
 	GlobalCodeMode bool
 	WorkingDir     string
+	// HTTPTransport tunes the connections to the model providers.
+	HTTPTransport httpclient.TransportOptions
 }
 
 func (runConfig *RuntimeConfig) Clone() *RuntimeConfig {
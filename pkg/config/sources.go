@@ -66,6 +66,16 @@ func (a fileSource) Read(context.Context) ([]byte, error) {
 	return data, nil
 }
 
+// FilePath returns the path of the file a source reads from. ok is false
+// for sources that aren't local files, such as URLs and OCI references.
+func FilePath(source Source) (path string, ok bool) {
+	fs, ok := source.(fileSource)
+	if !ok {
+		return "", false
+	}
+	return fs.path, true
+}
+
 // bytesSource is used to load an agent configuration from a []byte.
 type bytesSource struct {
 	name string
@@ -30,6 +30,20 @@ func TestURLSource_Read(t *testing.T) {
 	assert.Equal(t, "test content", string(data))
 }
 
+func TestFilePath(t *testing.T) {
+	t.Parallel()
+
+	path, ok := FilePath(NewFileSource("/agents/agent.yaml"))
+	assert.True(t, ok)
+	assert.Equal(t, "/agents/agent.yaml", path)
+
+	_, ok = FilePath(NewURLSource("https://example.com/agent.yaml", nil))
+	assert.False(t, ok)
+
+	_, ok = FilePath(NewBytesSource("default", []byte("agents: {}")))
+	assert.False(t, ok)
+}
+
 func TestURLSource_Read_HTTPError(t *testing.T) {
 	t.Parallel()
 
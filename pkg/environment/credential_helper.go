@@ -24,6 +24,7 @@ func (p *CredentialHelperProvider) Get(ctx context.Context, name string) (string
 	if !found || value == "" {
 		return "", false
 	}
+	rememberSecret(value)
 
 	return value, true
 }
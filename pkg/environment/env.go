@@ -48,6 +48,11 @@ func NewEnvFilesProvider(absEnvFiles []string) (*EnvFilesProvider, error) {
 	if err != nil {
 		return nil, err
 	}
+	for _, kv := range values {
+		if LooksSecret(kv.Key) {
+			rememberSecret(kv.Value)
+		}
+	}
 
 	return &EnvFilesProvider{
 		values: values,
@@ -0,0 +1,54 @@
+package environment
+
+import (
+	"slices"
+	"strings"
+	"sync"
+)
+
+// minSecretLength is the length under which values are not remembered as
+// secrets, to avoid redacting common words.
+const minSecretLength = 8
+
+var (
+	knownSecretsMu sync.Mutex
+	knownSecrets   = map[string]struct{}{}
+)
+
+// LooksSecret reports whether an environment variable name suggests that it
+// holds a secret.
+func LooksSecret(name string) bool {
+	name = strings.ToUpper(name)
+	for _, marker := range []string{"KEY", "TOKEN", "SECRET", "PASSWORD"} {
+		if strings.Contains(name, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// KnownSecrets returns the secret values that were resolved so far from env
+// files, credential helpers and the other providers, so that they can be
+// redacted from logs.
+func KnownSecrets() []string {
+	knownSecretsMu.Lock()
+	defer knownSecretsMu.Unlock()
+
+	secrets := make([]string, 0, len(knownSecrets))
+	for secret := range knownSecrets {
+		secrets = append(secrets, secret)
+	}
+	slices.Sort(secrets)
+	return secrets
+}
+
+func rememberSecret(value string) {
+	value = strings.TrimSpace(value)
+	if len(value) < minSecretLength {
+		return
+	}
+
+	knownSecretsMu.Lock()
+	defer knownSecretsMu.Unlock()
+	knownSecrets[value] = struct{}{}
+}
@@ -16,6 +16,9 @@ func (p *MultiProvider) Get(ctx context.Context, name string) (string, bool) {
 	for _, provider := range p.providers {
 		value, found := provider.Get(ctx, name)
 		if found {
+			if LooksSecret(name) {
+				rememberSecret(value)
+			}
 			return value, true
 		}
 	}
@@ -53,3 +53,16 @@ type alwaysFound struct{}
 func (p *alwaysFound) Get(context.Context, string) (string, bool) {
 	return "FOUND", true
 }
+
+func TestMultiProviderRemembersSecrets(t *testing.T) {
+	provider := NewMultiProvider(NewEnvListProvider([]string{
+		"SOME_VALUE=not-a-secret-value",
+		"SOME_API_KEY=remembered-secret",
+	}))
+
+	provider.Get(t.Context(), "SOME_VALUE")
+	assert.NotContains(t, KnownSecrets(), "not-a-secret-value")
+
+	provider.Get(t.Context(), "SOME_API_KEY")
+	assert.Contains(t, KnownSecrets(), "remembered-secret")
+}
@@ -0,0 +1,42 @@
+package environment
+
+import (
+	"context"
+	"maps"
+	"slices"
+)
+
+type overridesKey struct{}
+
+// WithOverrides returns a new context carrying session scoped environment
+// variable overrides. They are applied, on top of a tool's own environment,
+// to the commands started by the tools that receive this context.
+func WithOverrides(ctx context.Context, overrides map[string]string) context.Context {
+	if len(overrides) == 0 {
+		return ctx
+	}
+	return context.WithValue(ctx, overridesKey{}, maps.Clone(overrides))
+}
+
+// OverridesFromContext retrieves the environment variable overrides from the
+// context. Returns nil if no overrides are present.
+func OverridesFromContext(ctx context.Context) map[string]string {
+	overrides, _ := ctx.Value(overridesKey{}).(map[string]string)
+	return overrides
+}
+
+// ApplyOverrides returns a copy of env with the overrides stored in the
+// context appended. Appended entries take precedence over earlier ones with
+// the same name when the result is used as an exec.Cmd environment.
+func ApplyOverrides(ctx context.Context, env []string) []string {
+	overrides := OverridesFromContext(ctx)
+	if len(overrides) == 0 {
+		return env
+	}
+
+	result := slices.Clip(slices.Clone(env))
+	for _, name := range slices.Sorted(maps.Keys(overrides)) {
+		result = append(result, name+"="+overrides[name])
+	}
+	return result
+}
@@ -0,0 +1,35 @@
+package environment
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyOverrides(t *testing.T) {
+	t.Parallel()
+
+	env := []string{"PATH=/bin", "DATABASE_URL=prod"}
+
+	assert.Equal(t, env, ApplyOverrides(t.Context(), env))
+
+	ctx := WithOverrides(t.Context(), map[string]string{
+		"DATABASE_URL": "test",
+		"DEBUG":        "1",
+	})
+	got := ApplyOverrides(ctx, env)
+
+	assert.Equal(t, []string{"PATH=/bin", "DATABASE_URL=prod", "DATABASE_URL=test", "DEBUG=1"}, got)
+	assert.Equal(t, []string{"PATH=/bin", "DATABASE_URL=prod"}, env, "base env must not be modified")
+}
+
+func TestWithOverrides_CopiesMap(t *testing.T) {
+	t.Parallel()
+
+	overrides := map[string]string{"KEY": "before"}
+	ctx := WithOverrides(t.Context(), overrides)
+	overrides["KEY"] = "after"
+
+	assert.Equal(t, map[string]string{"KEY": "before"}, OverridesFromContext(ctx))
+	assert.Nil(t, OverridesFromContext(t.Context()))
+}
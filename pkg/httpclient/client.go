@@ -6,13 +6,25 @@ import (
 	"net/http"
 	"net/url"
 	"runtime"
+	"sync"
+	"time"
 
 	"github.com/docker/cagent/pkg/version"
 )
 
 type HTTPOptions struct {
-	Header http.Header
-	Query  url.Values
+	Header    http.Header
+	Query     url.Values
+	Transport TransportOptions
+}
+
+// TransportOptions tunes how the connections to a server are kept open and
+// reused. Zero values keep the defaults of the standard library.
+type TransportOptions struct {
+	// IdleConnTimeout is how long an idle connection is kept open.
+	IdleConnTimeout time.Duration
+	// MaxIdleConnsPerHost is the number of idle connections kept open per host.
+	MaxIdleConnsPerHost int
 }
 
 type Opt func(*HTTPOptions)
@@ -32,7 +44,7 @@ func NewHTTPClient(opts ...Opt) *http.Client {
 	// Disable automatic gzip: Go's default transport transparently compresses
 	// and decompresses responses, which is incompatible with SSE streaming.
 	// See https://github.com/docker/docker-agent/issues/1956
-	rt := newTransport()
+	rt := sharedTransport(httpOptions.Transport)
 
 	return &http.Client{
 		Transport: &userAgentTransport{
@@ -95,14 +107,48 @@ func WithQuery(query url.Values) Opt {
 	}
 }
 
+func WithTransport(transport TransportOptions) Opt {
+	return func(o *HTTPOptions) {
+		o.Transport = transport
+	}
+}
+
+var (
+	transportsMu sync.Mutex
+	transports   = map[TransportOptions]http.RoundTripper{}
+)
+
+// sharedTransport returns the transport for the given options, shared by all
+// the clients using the same options so they reuse each other's connections.
+// Clients are created per request for some providers, e.g. with short-lived
+// tokens, and would otherwise open a new connection every time.
+func sharedTransport(opts TransportOptions) http.RoundTripper {
+	transportsMu.Lock()
+	defer transportsMu.Unlock()
+
+	rt, ok := transports[opts]
+	if !ok {
+		rt = newTransport(opts)
+		transports[opts] = rt
+	}
+	return rt
+}
+
 // newTransport returns an HTTP transport with automatic gzip compression disabled.
-func newTransport() http.RoundTripper {
+func newTransport(opts TransportOptions) http.RoundTripper {
 	t, ok := http.DefaultTransport.(*http.Transport)
 	if !ok {
 		return http.DefaultTransport
 	}
 	transport := t.Clone()
 	transport.DisableCompression = true
+	if opts.IdleConnTimeout > 0 {
+		transport.IdleConnTimeout = opts.IdleConnTimeout
+	}
+	if opts.MaxIdleConnsPerHost > 0 {
+		transport.MaxIdleConnsPerHost = opts.MaxIdleConnsPerHost
+		transport.MaxIdleConns = max(transport.MaxIdleConns, opts.MaxIdleConnsPerHost)
+	}
 	return transport
 }
 
@@ -4,6 +4,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -85,3 +86,24 @@ func doRequest(t *testing.T, opts ...Opt) http.Header {
 
 	return capturedHeaders
 }
+
+func TestTransport(t *testing.T) {
+	t.Parallel()
+
+	transportOf := func(client *http.Client) *http.Transport {
+		t.Helper()
+		transport, ok := client.Transport.(*userAgentTransport).rt.(*http.Transport)
+		require.True(t, ok)
+		return transport
+	}
+
+	tuning := TransportOptions{IdleConnTimeout: 5 * time.Minute, MaxIdleConnsPerHost: 16}
+	tuned := transportOf(NewHTTPClient(WithTransport(tuning), WithModel("gpt-4o")))
+	assert.Equal(t, 5*time.Minute, tuned.IdleConnTimeout)
+	assert.Equal(t, 16, tuned.MaxIdleConnsPerHost)
+	assert.True(t, tuned.DisableCompression)
+
+	assert.Same(t, tuned, transportOf(NewHTTPClient(WithTransport(tuning))), "clients with the same options share connections")
+	assert.NotSame(t, tuned, transportOf(NewHTTPClient()))
+	assert.Equal(t, http.DefaultTransport.(*http.Transport).IdleConnTimeout, transportOf(NewHTTPClient()).IdleConnTimeout)
+}
@@ -149,7 +149,7 @@ func NewClient(ctx context.Context, cfg *latest.ModelConfig, env environment.Pro
 		slog.Debug("Anthropic API key found, creating client")
 		requestOptions := []option.RequestOption{
 			option.WithAPIKey(authToken),
-			option.WithHTTPClient(httpclient.NewHTTPClient()),
+			option.WithHTTPClient(httpclient.NewHTTPClient(httpclient.WithTransport(globalOptions.HTTPTransport()))),
 		}
 		if cfg.BaseURL != "" {
 			requestOptions = append(requestOptions, option.WithBaseURL(cfg.BaseURL))
@@ -186,6 +186,7 @@ func NewClient(ctx context.Context, cfg *latest.ModelConfig, env environment.Pro
 				httpclient.WithModel(cfg.Model),
 				httpclient.WithModelName(cfg.Name),
 				httpclient.WithQuery(url.Query()),
+				httpclient.WithTransport(globalOptions.HTTPTransport()),
 			}
 			if globalOptions.GeneratingTitle() {
 				httpOptions = append(httpOptions, httpclient.WithHeader("X-Cagent-GeneratingTitle", "1"))
@@ -0,0 +1,95 @@
+package provider
+
+import (
+	"context"
+	"sync"
+)
+
+// ConcurrencyLimiter caps the number of in-flight requests sent to each
+// provider. A single limiter is meant to be shared by all the runtimes of a
+// process, so that many sessions running in the background don't flood the
+// same provider.
+//
+// This is not rate limiting: a request is never delayed as long as a slot is
+// free for its provider.
+type ConcurrencyLimiter struct {
+	mu     sync.Mutex
+	limits map[string]int
+	slots  map[string]chan struct{}
+}
+
+// NewConcurrencyLimiter creates a limiter allowing at most limits[name]
+// concurrent requests to the provider called name. Providers without a
+// positive limit are not limited.
+func NewConcurrencyLimiter(limits map[string]int) *ConcurrencyLimiter {
+	l := &ConcurrencyLimiter{
+		limits: make(map[string]int, len(limits)),
+		slots:  make(map[string]chan struct{}, len(limits)),
+	}
+	for name, limit := range limits {
+		if limit > 0 {
+			l.limits[name] = limit
+		}
+	}
+	return l
+}
+
+// Limit returns the maximum number of concurrent requests to the given
+// provider, or 0 if it isn't limited.
+func (l *ConcurrencyLimiter) Limit(providerName string) int {
+	if l == nil {
+		return 0
+	}
+	return l.limits[providerName]
+}
+
+// Acquire takes a slot for the given provider, blocking until one is free or
+// ctx is done. If no slot is immediately available, onQueued is called once
+// before blocking. The returned function releases the slot; it is safe to call
+// more than once.
+//
+// A nil limiter, or a provider without a limit, never blocks.
+func (l *ConcurrencyLimiter) Acquire(ctx context.Context, providerName string, onQueued func()) (func(), error) {
+	slots := l.slotsFor(providerName)
+	if slots == nil {
+		return func() {}, nil
+	}
+
+	select {
+	case slots <- struct{}{}:
+	default:
+		if onQueued != nil {
+			onQueued()
+		}
+		select {
+		case slots <- struct{}{}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	var once sync.Once
+	return func() {
+		once.Do(func() { <-slots })
+	}, nil
+}
+
+func (l *ConcurrencyLimiter) slotsFor(providerName string) chan struct{} {
+	if l == nil {
+		return nil
+	}
+	limit, ok := l.limits[providerName]
+	if !ok {
+		return nil
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	slots, ok := l.slots[providerName]
+	if !ok {
+		slots = make(chan struct{}, limit)
+		l.slots[providerName] = slots
+	}
+	return slots
+}
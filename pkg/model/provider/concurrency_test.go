@@ -0,0 +1,69 @@
+package provider
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConcurrencyLimiter_BlocksOverLimit(t *testing.T) {
+	t.Parallel()
+
+	l := NewConcurrencyLimiter(map[string]int{"anthropic": 1})
+
+	release, err := l.Acquire(t.Context(), "anthropic", func() { t.Error("first acquire must not queue") })
+	require.NoError(t, err)
+
+	queued := make(chan struct{})
+	acquired := make(chan struct{})
+	go func() {
+		release2, err := l.Acquire(t.Context(), "anthropic", func() { close(queued) })
+		assert.NoError(t, err)
+		close(acquired)
+		release2()
+	}()
+
+	<-queued
+	select {
+	case <-acquired:
+		t.Fatal("second acquire must wait for the first one to be released")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	release()
+	release() // releasing twice is a no-op
+	<-acquired
+}
+
+func TestConcurrencyLimiter_UnlimitedProviders(t *testing.T) {
+	t.Parallel()
+
+	l := NewConcurrencyLimiter(map[string]int{"anthropic": 1, "openai": 0})
+	assert.Equal(t, 1, l.Limit("anthropic"))
+	assert.Equal(t, 0, l.Limit("openai"))
+
+	for range 3 {
+		_, err := l.Acquire(t.Context(), "openai", func() { t.Error("unlimited provider must not queue") })
+		require.NoError(t, err)
+	}
+
+	var nilLimiter *ConcurrencyLimiter
+	release, err := nilLimiter.Acquire(t.Context(), "anthropic", nil)
+	require.NoError(t, err)
+	release()
+}
+
+func TestConcurrencyLimiter_ContextCancelled(t *testing.T) {
+	t.Parallel()
+
+	l := NewConcurrencyLimiter(map[string]int{"anthropic": 1})
+	_, err := l.Acquire(t.Context(), "anthropic", nil)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	_, err = l.Acquire(ctx, "anthropic", cancel)
+	require.ErrorIs(t, err, context.Canceled)
+}
@@ -95,7 +95,7 @@ func NewClient(ctx context.Context, cfg *latest.ModelConfig, env environment.Pro
 			}
 
 			backend = genai.BackendGeminiAPI
-			httpClient = httpclient.NewHTTPClient()
+			httpClient = httpclient.NewHTTPClient(httpclient.WithTransport(globalOptions.HTTPTransport()))
 		}
 
 		client, err := genai.NewClient(ctx, &genai.ClientConfig{
@@ -142,6 +142,7 @@ func NewClient(ctx context.Context, cfg *latest.ModelConfig, env environment.Pro
 				httpclient.WithModel(cfg.Model),
 				httpclient.WithModelName(cfg.Name),
 				httpclient.WithQuery(url.Query()),
+				httpclient.WithTransport(globalOptions.HTTPTransport()),
 			}
 			if globalOptions.GeneratingTitle() {
 				httpOptions = append(httpOptions, httpclient.WithHeader("X-Cagent-GeneratingTitle", "1"))
@@ -85,7 +85,7 @@ func NewClient(ctx context.Context, cfg *latest.ModelConfig, env environment.Pro
 			clientOptions = append(clientOptions, option.WithBaseURL(cfg.BaseURL))
 		}
 
-		httpClient := httpclient.NewHTTPClient()
+		httpClient := httpclient.NewHTTPClient(httpclient.WithTransport(globalOptions.HTTPTransport()))
 		clientOptions = append(clientOptions, option.WithHTTPClient(httpClient))
 
 		client := openai.NewClient(clientOptions...)
@@ -120,6 +120,7 @@ func NewClient(ctx context.Context, cfg *latest.ModelConfig, env environment.Pro
 				httpclient.WithModel(cfg.Model),
 				httpclient.WithModelName(cfg.Name),
 				httpclient.WithQuery(url.Query()),
+				httpclient.WithTransport(globalOptions.HTTPTransport()),
 			}
 			if globalOptions.GeneratingTitle() {
 				httpOptions = append(httpOptions, httpclient.WithHeader("X-Cagent-GeneratingTitle", "1"))
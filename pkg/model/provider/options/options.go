@@ -2,6 +2,7 @@ package options
 
 import (
 	"github.com/docker/cagent/pkg/config/latest"
+	"github.com/docker/cagent/pkg/httpclient"
 )
 
 type ModelOptions struct {
@@ -11,6 +12,7 @@ type ModelOptions struct {
 	maxTokens        int64
 	providers        map[string]latest.ProviderConfig
 	thinking         *bool
+	httpTransport    httpclient.TransportOptions
 }
 
 func (c *ModelOptions) Gateway() string {
@@ -37,6 +39,11 @@ func (c *ModelOptions) Thinking() *bool {
 	return c.thinking
 }
 
+// HTTPTransport returns how the connections to the provider are kept open.
+func (c *ModelOptions) HTTPTransport() httpclient.TransportOptions {
+	return c.httpTransport
+}
+
 type Opt func(*ModelOptions)
 
 func WithGateway(gateway string) Opt {
@@ -75,6 +82,12 @@ func WithThinking(enabled bool) Opt {
 	}
 }
 
+func WithHTTPTransport(transport httpclient.TransportOptions) Opt {
+	return func(cfg *ModelOptions) {
+		cfg.httpTransport = transport
+	}
+}
+
 // FromModelOptions converts a concrete ModelOptions value into a slice of
 // Opt configuration functions. Later Opts override earlier ones when applied.
 func FromModelOptions(m ModelOptions) []Opt {
@@ -97,5 +110,8 @@ func FromModelOptions(m ModelOptions) []Opt {
 	if m.thinking != nil {
 		out = append(out, WithThinking(*m.thinking))
 	}
+	if m.httpTransport != (httpclient.TransportOptions{}) {
+		out = append(out, WithHTTPTransport(m.httpTransport))
+	}
 	return out
 }
@@ -5,6 +5,7 @@ package permissions
 import (
 	"fmt"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/docker/cagent/pkg/config/latest"
@@ -46,6 +47,7 @@ type Checker struct {
 	allowPatterns []string
 	askPatterns   []string
 	denyPatterns  []string
+	allowToolsets []string
 }
 
 // NewChecker creates a new permission checker from config
@@ -57,6 +59,7 @@ func NewChecker(cfg *latest.PermissionsConfig) *Checker {
 		allowPatterns: cfg.Allow,
 		askPatterns:   cfg.Ask,
 		denyPatterns:  cfg.Deny,
+		allowToolsets: cfg.AllowToolsets,
 	}
 }
 
@@ -108,9 +111,21 @@ func (c *Checker) CheckWithArgs(toolName string, args map[string]any) Decision {
 	return Ask
 }
 
+// AllowsToolset reports whether every tool of the given toolset type is
+// auto-approved. It is meant to be consulted once no pattern decided,
+// so that deny and ask patterns keep priority.
+func (c *Checker) AllowsToolset(toolsetType string) bool {
+	if toolsetType == "" {
+		return false
+	}
+	return slices.ContainsFunc(c.allowToolsets, func(t string) bool {
+		return strings.EqualFold(t, toolsetType)
+	})
+}
+
 // IsEmpty returns true if no permissions are configured
 func (c *Checker) IsEmpty() bool {
-	return len(c.allowPatterns) == 0 && len(c.askPatterns) == 0 && len(c.denyPatterns) == 0
+	return len(c.allowPatterns) == 0 && len(c.askPatterns) == 0 && len(c.denyPatterns) == 0 && len(c.allowToolsets) == 0
 }
 
 // AllowPatterns returns the list of allow patterns.
@@ -123,6 +138,11 @@ func (c *Checker) AskPatterns() []string {
 	return c.askPatterns
 }
 
+// AllowToolsets returns the list of auto-approved toolset types.
+func (c *Checker) AllowToolsets() []string {
+	return c.allowToolsets
+}
+
 // DenyPatterns returns the list of deny patterns.
 func (c *Checker) DenyPatterns() []string {
 	return c.denyPatterns
@@ -459,6 +459,22 @@ func TestChecker_ForceAsk(t *testing.T) {
 	}
 }
 
+func TestChecker_AllowsToolset(t *testing.T) {
+	t.Parallel()
+
+	checker := NewChecker(&latest.PermissionsConfig{
+		AllowToolsets: []string{"think", "Todo"},
+	})
+	assert.False(t, checker.IsEmpty())
+	assert.True(t, checker.AllowsToolset("think"))
+	assert.True(t, checker.AllowsToolset("todo"))
+	assert.False(t, checker.AllowsToolset("shell"))
+	assert.False(t, checker.AllowsToolset(""))
+	assert.Equal(t, Ask, checker.Check("think"), "toolset types don't affect pattern decisions")
+
+	assert.False(t, NewChecker(nil).AllowsToolset("think"))
+}
+
 func TestParsePattern(t *testing.T) {
 	t.Parallel()
 
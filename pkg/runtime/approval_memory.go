@@ -0,0 +1,61 @@
+package runtime
+
+import (
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/docker/cagent/pkg/session"
+	"github.com/docker/cagent/pkg/tools"
+)
+
+// ApprovalMemory configures the approvals that also approve the next calls
+// to the same tool without asking the user.
+type ApprovalMemory struct {
+	// Window is how long an approval is remembered. Zero remembers it for
+	// the rest of the session.
+	Window time.Duration
+	// MatchArguments only remembers an approval for the calls with the same
+	// arguments.
+	MatchArguments bool
+}
+
+// rememberApproval remembers the user's approval of a tool call in the
+// session, when approvals are remembered.
+func (r *LocalRuntime) rememberApproval(sess *session.Session, toolCall tools.ToolCall, now time.Time) {
+	if r.approvalMemory == nil {
+		return
+	}
+
+	approval := session.RememberedApproval{ToolName: toolCall.Function.Name}
+	if r.approvalMemory.MatchArguments {
+		approval.Arguments = canonicalArguments(toolCall.Function.Arguments)
+	}
+	if r.approvalMemory.Window > 0 {
+		approval.ExpiresAt = now.Add(r.approvalMemory.Window)
+	}
+	sess.RememberApproval(approval)
+	slog.Debug("Remembering tool approval", "signature", approval.Signature(), "expires_at", approval.ExpiresAt, "session_id", sess.ID)
+}
+
+// approvedByMemory returns true if a remembered approval of the session
+// applies to the tool call.
+func approvedByMemory(sess *session.Session, toolCall tools.ToolCall, now time.Time) bool {
+	_, ok := sess.RememberedApprovalFor(toolCall.Function.Name, canonicalArguments(toolCall.Function.Arguments), now)
+	return ok
+}
+
+// canonicalArguments formats JSON arguments so that calls with the same
+// arguments have the same signature, whatever their key order or spacing.
+func canonicalArguments(arguments string) string {
+	var v any
+	if err := json.Unmarshal([]byte(arguments), &v); err != nil {
+		return strings.TrimSpace(arguments)
+	}
+	canonical, err := json.Marshal(v)
+	if err != nil {
+		return strings.TrimSpace(arguments)
+	}
+	return string(canonical)
+}
@@ -0,0 +1,100 @@
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/docker/cagent/pkg/agent"
+	"github.com/docker/cagent/pkg/session"
+	"github.com/docker/cagent/pkg/team"
+	"github.com/docker/cagent/pkg/tools"
+)
+
+func TestApprovalMemory(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		memory    *ApprovalMemory
+		second    string
+		confirmed int
+	}{
+		{name: "off", memory: nil, second: `{"path":"a.txt"}`, confirmed: 2},
+		{name: "any arguments", memory: &ApprovalMemory{}, second: `{"path":"b.txt"}`, confirmed: 1},
+		{name: "same arguments", memory: &ApprovalMemory{MatchArguments: true}, second: `{ "path": "a.txt" }`, confirmed: 1},
+		{name: "other arguments", memory: &ApprovalMemory{MatchArguments: true}, second: `{"path":"b.txt"}`, confirmed: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			calls := 0
+			agentTools := []tools.Tool{{
+				Name:       "edit_file",
+				Parameters: map[string]any{},
+				Handler: func(_ context.Context, _ tools.ToolCall) (*tools.ToolCallResult, error) {
+					calls++
+					return tools.ResultSuccess("ok"), nil
+				},
+			}}
+
+			prov := &mockProvider{id: "test/mock-model", stream: &mockStream{}}
+			root := agent.New("root", "You are a test agent",
+				agent.WithModel(prov),
+				agent.WithToolSets(newStubToolSet(nil, agentTools, nil)),
+			)
+			rt, err := NewLocalRuntime(team.New(team.WithAgents(root)),
+				WithSessionCompaction(false), WithModelStore(mockModelStore{}), WithApprovalMemory(tt.memory))
+			require.NoError(t, err)
+
+			sess := session.New(session.WithUserMessage("Test"))
+			confirmed := 0
+			for i, arguments := range []string{`{"path":"a.txt"}`, tt.second} {
+				toolCalls := []tools.ToolCall{{
+					ID:       fmt.Sprintf("call_%d", i),
+					Type:     "function",
+					Function: tools.FunctionCall{Name: "edit_file", Arguments: arguments},
+				}}
+				events := make(chan Event, 10)
+				go func() {
+					rt.processToolCalls(t.Context(), sess, toolCalls, agentTools, events)
+					close(events)
+				}()
+				for ev := range events {
+					if _, ok := ev.(*ToolCallConfirmationEvent); ok {
+						confirmed++
+						rt.resumeChan <- ResumeApprove()
+					}
+				}
+			}
+
+			assert.Equal(t, 2, calls)
+			assert.Equal(t, tt.confirmed, confirmed)
+		})
+	}
+}
+
+func TestApprovalMemory_Window(t *testing.T) {
+	t.Parallel()
+
+	rt := &LocalRuntime{approvalMemory: &ApprovalMemory{Window: 10 * time.Minute}}
+	sess := session.New()
+	now := time.Now()
+	toolCall := tools.ToolCall{Function: tools.FunctionCall{Name: "shell", Arguments: `{"cmd":"ls"}`}}
+
+	rt.rememberApproval(sess, toolCall, now)
+	assert.True(t, approvedByMemory(sess, toolCall, now.Add(9*time.Minute)))
+	assert.False(t, approvedByMemory(sess, toolCall, now.Add(10*time.Minute)))
+	assert.Empty(t, sess.GetRememberedApprovals(now), "expired approvals are forgotten")
+
+	rt.rememberApproval(sess, toolCall, now)
+	approvals := sess.GetRememberedApprovals(now)
+	require.Len(t, approvals, 1)
+	sess.RevokeApproval(approvals[0].Signature())
+	assert.False(t, approvedByMemory(sess, toolCall, now))
+}
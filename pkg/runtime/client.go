@@ -59,35 +59,37 @@ func NewClient(baseURL string, opts ...ClientOption) (*Client, error) {
 			Timeout: 30 * time.Second,
 		},
 		registry: map[string]func() Event{
-			"user_message":           func() Event { return &UserMessageEvent{} },
-			"tool_call":              func() Event { return &ToolCallEvent{} },
-			"tool_call_response":     func() Event { return &ToolCallResponseEvent{} },
-			"tool_call_confirmation": func() Event { return &ToolCallConfirmationEvent{} },
-			"token_usage":            func() Event { return &TokenUsageEvent{} },
-			"stream_stopped":         func() Event { return &StreamStoppedEvent{} },
-			"stream_started":         func() Event { return &StreamStartedEvent{} },
-			"shell":                  func() Event { return &ShellOutputEvent{} },
-			"session_title":          func() Event { return &SessionTitleEvent{} },
-			"session_summary":        func() Event { return &SessionSummaryEvent{} },
-			"session_compaction":     func() Event { return &SessionCompactionEvent{} },
-			"partial_tool_call":      func() Event { return &PartialToolCallEvent{} },
-			"max_iterations_reached": func() Event { return &MaxIterationsReachedEvent{} },
-			"error":                  func() Event { return &ErrorEvent{} },
-			"elicitation_request":    func() Event { return &ElicitationRequestEvent{} },
-			"authorization_event":    func() Event { return &AuthorizationEvent{} },
-			"agent_choice":           func() Event { return &AgentChoiceEvent{} },
-			"agent_choice_reasoning": func() Event { return &AgentChoiceReasoningEvent{} },
-			"mcp_init_started":       func() Event { return &MCPInitStartedEvent{} },
-			"mcp_init_finished":      func() Event { return &MCPInitFinishedEvent{} },
-			"agent_info":             func() Event { return &AgentInfoEvent{} },
-			"team_info":              func() Event { return &TeamInfoEvent{} },
-			"toolset_info":           func() Event { return &ToolsetInfoEvent{} },
-			"agent_switching":        func() Event { return &AgentSwitchingEvent{} },
-			"warning":                func() Event { return &WarningEvent{} },
-			"hook_blocked":           func() Event { return &HookBlockedEvent{} },
-			"rag_indexing_started":   func() Event { return &RAGIndexingStartedEvent{} },
-			"rag_indexing_progress":  func() Event { return &RAGIndexingProgressEvent{} },
-			"rag_indexing_completed": func() Event { return &RAGIndexingCompletedEvent{} },
+			"user_message":            func() Event { return &UserMessageEvent{} },
+			"tool_call":               func() Event { return &ToolCallEvent{} },
+			"tool_call_response":      func() Event { return &ToolCallResponseEvent{} },
+			"tool_call_confirmation":  func() Event { return &ToolCallConfirmationEvent{} },
+			"token_usage":             func() Event { return &TokenUsageEvent{} },
+			"stream_stopped":          func() Event { return &StreamStoppedEvent{} },
+			"stream_started":          func() Event { return &StreamStartedEvent{} },
+			"shell":                   func() Event { return &ShellOutputEvent{} },
+			"session_title":           func() Event { return &SessionTitleEvent{} },
+			"session_summary":         func() Event { return &SessionSummaryEvent{} },
+			"session_compaction":      func() Event { return &SessionCompactionEvent{} },
+			"partial_tool_call":       func() Event { return &PartialToolCallEvent{} },
+			"max_iterations_reached":  func() Event { return &MaxIterationsReachedEvent{} },
+			"error":                   func() Event { return &ErrorEvent{} },
+			"elicitation_request":     func() Event { return &ElicitationRequestEvent{} },
+			"authorization_event":     func() Event { return &AuthorizationEvent{} },
+			"agent_choice":            func() Event { return &AgentChoiceEvent{} },
+			"agent_choice_reasoning":  func() Event { return &AgentChoiceReasoningEvent{} },
+			"mcp_init_started":        func() Event { return &MCPInitStartedEvent{} },
+			"mcp_init_finished":       func() Event { return &MCPInitFinishedEvent{} },
+			"agent_info":              func() Event { return &AgentInfoEvent{} },
+			"team_info":               func() Event { return &TeamInfoEvent{} },
+			"toolset_info":            func() Event { return &ToolsetInfoEvent{} },
+			"agent_switching":         func() Event { return &AgentSwitchingEvent{} },
+			"warning":                 func() Event { return &WarningEvent{} },
+			"hook_blocked":            func() Event { return &HookBlockedEvent{} },
+			"tool_timeout":            func() Event { return &ToolTimeoutEvent{} },
+			"transfer_depth_exceeded": func() Event { return &TransferDepthExceededEvent{} },
+			"rag_indexing_started":    func() Event { return &RAGIndexingStartedEvent{} },
+			"rag_indexing_progress":   func() Event { return &RAGIndexingProgressEvent{} },
+			"rag_indexing_completed":  func() Event { return &RAGIndexingCompletedEvent{} },
 		},
 	}
 
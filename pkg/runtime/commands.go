@@ -17,6 +17,38 @@ import (
 // This includes ${args}, ${args[N]}, ${args.join(...)}, ${args.length}, etc.
 var argsPlaceholderRegex = regexp.MustCompile(`\$\{args[^}]*\}`)
 
+// argIndexRegex matches the positional arguments used in placeholders, as in
+// ${args[0]} or ${args[1] || "main"}.
+var argIndexRegex = regexp.MustCompile(`\bargs\[(\d+)\]`)
+
+// CommandArgs returns the names of the arguments used by the placeholders of
+// a command instruction: one per position up to the last args[N], or a
+// single one when the instruction only uses all the arguments, as in ${args}.
+// It returns nil when the instruction takes no arguments.
+func CommandArgs(instruction string) []string {
+	if !argsPlaceholderRegex.MatchString(instruction) {
+		return nil
+	}
+
+	count := 0
+	for _, placeholder := range argsPlaceholderRegex.FindAllString(instruction, -1) {
+		for _, match := range argIndexRegex.FindAllStringSubmatch(placeholder, -1) {
+			if n, err := strconv.Atoi(match[1]); err == nil {
+				count = max(count, n+1)
+			}
+		}
+	}
+	if count == 0 {
+		return []string{"arguments"}
+	}
+
+	names := make([]string, count)
+	for i := range names {
+		names[i] = "argument " + strconv.Itoa(i+1)
+	}
+	return names
+}
+
 // ResolveCommand transforms a /command into its expanded instruction text.
 // It processes:
 // 1. Command lookup from agent commands
@@ -56,6 +56,14 @@ func (m *mockRuntime) CurrentAgentSkillsToolset() *builtin.SkillsToolset {
 	return nil
 }
 
+func (m *mockRuntime) CurrentAgentTasksToolset() *builtin.TasksTool {
+	return nil
+}
+
+func (m *mockRuntime) CurrentAgentMemoryToolset() *builtin.MemoryTool {
+	return nil
+}
+
 func (m *mockRuntime) CurrentMCPPrompts(context.Context) map[string]mcptools.PromptInfo {
 	return make(map[string]mcptools.PromptInfo)
 }
@@ -202,6 +210,18 @@ func TestResolveCommand_MissingArgs(t *testing.T) {
 	assert.Equal(t, "Fix file1 and  and ", result)
 }
 
+func TestCommandArgs(t *testing.T) {
+	t.Parallel()
+
+	assert.Nil(t, CommandArgs("Review the staged changes"))
+	assert.Nil(t, CommandArgs("Hello ${env.USER}"))
+	assert.Equal(t, []string{"arguments"}, CommandArgs("Review ${args}"))
+	assert.Equal(t, []string{"arguments"}, CommandArgs(`Review ${args.join(", ")}`))
+	assert.Equal(t, []string{"argument 1"}, CommandArgs(`First: ${args[0]}, All: ${args.join(" ")}`))
+	assert.Equal(t, []string{"argument 1", "argument 2", "argument 3"},
+		CommandArgs(`Fix ${args[0] || ""} and ${args[2] || ""}`))
+}
+
 func TestResolveCommand_ToolCommand(t *testing.T) {
 	t.Parallel()
 
@@ -123,6 +123,26 @@ func ToolCallResponse(toolCall tools.ToolCall, toolDefinition tools.Tool, result
 	}
 }
 
+// ToolTimeoutEvent is sent after the response of a tool call that was
+// aborted because it ran for longer than its timeout.
+type ToolTimeoutEvent struct {
+	Type           string         `json:"type"`
+	ToolCall       tools.ToolCall `json:"tool_call"`
+	ToolDefinition tools.Tool     `json:"tool_definition"`
+	Timeout        time.Duration  `json:"timeout"`
+	AgentContext
+}
+
+func ToolTimeout(toolCall tools.ToolCall, toolDefinition tools.Tool, timeout time.Duration, agentName string) Event {
+	return &ToolTimeoutEvent{
+		Type:           "tool_timeout",
+		ToolCall:       toolCall,
+		ToolDefinition: toolDefinition,
+		Timeout:        timeout,
+		AgentContext:   newAgentContext(agentName),
+	}
+}
+
 type StreamStartedEvent struct {
 	Type      string `json:"type"`
 	SessionID string `json:"session_id,omitempty"`
@@ -233,6 +253,48 @@ func ModelFallback(agentName, failedModel, fallbackModel, reason string, attempt
 	}
 }
 
+// QueuedForProviderEvent is emitted when a request has to wait because the
+// provider's concurrency limit is reached. The request is sent as soon as
+// another request to the same provider completes.
+type QueuedForProviderEvent struct {
+	Type     string `json:"type"`
+	Provider string `json:"provider"`
+	Model    string `json:"model"`
+	Limit    int    `json:"limit"` // Maximum number of concurrent requests to the provider
+	AgentContext
+}
+
+// QueuedForProvider creates a new QueuedForProviderEvent.
+func QueuedForProvider(agentName, providerName, model string, limit int) Event {
+	return &QueuedForProviderEvent{
+		Type:         "queued_for_provider",
+		Provider:     providerName,
+		Model:        model,
+		Limit:        limit,
+		AgentContext: AgentContext{AgentName: agentName},
+	}
+}
+
+// ToolsUnsupportedEvent is emitted when a model rejects the request because
+// it doesn't support tool calling. Retried is true when the request is sent
+// again without tools.
+type ToolsUnsupportedEvent struct {
+	Type    string `json:"type"`
+	Model   string `json:"model"`
+	Retried bool   `json:"retried"`
+	AgentContext
+}
+
+// ToolsUnsupported creates a new ToolsUnsupportedEvent.
+func ToolsUnsupported(agentName, model string, retried bool) Event {
+	return &ToolsUnsupportedEvent{
+		Type:         "tools_unsupported",
+		Model:        model,
+		Retried:      retried,
+		AgentContext: AgentContext{AgentName: agentName},
+	}
+}
+
 type TokenUsageEvent struct {
 	Type      string `json:"type"`
 	SessionID string `json:"session_id"`
@@ -256,6 +318,22 @@ type MessageUsage struct {
 	chat.RateLimit
 	Cost  float64
 	Model string
+	// TokensPerSecond is the generation speed of the message, or 0 when the
+	// response was too short to measure it.
+	TokensPerSecond float64
+}
+
+// minThroughputWindow is the shortest generation time the speed of a
+// response is computed for. Shorter responses often arrive in a single
+// chunk, which would give meaningless speeds.
+const minThroughputWindow = 100 * time.Millisecond
+
+// tokensPerSecond returns the generation speed of a response.
+func tokensPerSecond(outputTokens int64, generationTime time.Duration) float64 {
+	if outputTokens <= 0 || generationTime < minThroughputWindow {
+		return 0
+	}
+	return float64(outputTokens) / generationTime.Seconds()
 }
 
 // NewTokenUsageEvent creates a TokenUsageEvent with the given usage data.
@@ -393,6 +471,26 @@ func MaxIterationsReached(maxIterations int) Event {
 	}
 }
 
+// TransferDepthExceededEvent is sent when a transfer_task call is refused
+// because the transfers are already nested as deep as the team allows.
+type TransferDepthExceededEvent struct {
+	Type      string `json:"type"`
+	FromAgent string `json:"from_agent"`
+	ToAgent   string `json:"to_agent"`
+	MaxDepth  int    `json:"max_depth"`
+	AgentContext
+}
+
+func TransferDepthExceeded(fromAgent, toAgent string, maxDepth int) Event {
+	return &TransferDepthExceededEvent{
+		Type:         "transfer_depth_exceeded",
+		FromAgent:    fromAgent,
+		ToAgent:      toAgent,
+		MaxDepth:     maxDepth,
+		AgentContext: newAgentContext(fromAgent),
+	}
+}
+
 // MCPInitStartedEvent is for MCP initialization lifecycle events
 type MCPInitStartedEvent struct {
 	Type string `json:"type"`
@@ -446,6 +544,38 @@ type AgentDetails struct {
 	Provider    string         `json:"provider"`
 	Model       string         `json:"model"`
 	Commands    types.Commands `json:"commands,omitempty"`
+	// IsDefault is true for the team's configured default agent.
+	IsDefault bool `json:"is_default,omitempty"`
+	// ToolNames are the names of the tools known so far for the agent.
+	ToolNames []string `json:"tool_names,omitempty"`
+	// ToolDescriptions holds the first line of the description of the tools
+	// of ToolNames, by name.
+	ToolDescriptions map[string]string `json:"tool_descriptions,omitempty"`
+	// Hidden is true for agents that should not be offered for switching.
+	Hidden bool `json:"hidden,omitempty"`
+	// ApprovedToolsets are the toolset types whose tools the agent runs
+	// without confirmation.
+	ApprovedToolsets []string `json:"approved_toolsets,omitempty"`
+	// GatedToolsets are the toolset types whose tools always need confirmation.
+	GatedToolsets []string `json:"gated_toolsets,omitempty"`
+	// Toolsets is the load status of each toolset of the agent.
+	Toolsets []ToolsetStatus `json:"toolsets,omitempty"`
+}
+
+// Toolset load states, see ToolsetStatus.
+const (
+	ToolsetLoaded  = "ok"
+	ToolsetLoading = "loading"
+	ToolsetFailed  = "failed"
+)
+
+// ToolsetStatus is the load status of one of the toolsets of an agent.
+type ToolsetStatus struct {
+	Name string `json:"name"`
+	// Status is ToolsetLoaded, ToolsetLoading or ToolsetFailed.
+	Status string `json:"status"`
+	// Error is why the toolset failed to start.
+	Error string `json:"error,omitempty"`
 }
 
 // TeamInfoEvent is sent when team information is available
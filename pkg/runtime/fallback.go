@@ -227,6 +227,33 @@ func isRetryableModelError(err error) bool {
 	return false
 }
 
+// isToolsUnsupportedError reports whether err is a provider rejecting a
+// request because the model doesn't support tool calling.
+func isToolsUnsupportedError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	errMsg := strings.ToLower(err.Error())
+	patterns := []string{
+		"does not support tools",               // Ollama, OpenRouter
+		"does not support tool",                // Generic
+		"tool use is not supported",            // Anthropic-compatible gateways
+		"tools are not supported",              // Generic
+		"tool calling is not supported",        // Generic
+		"does not support function calling",    // OpenAI-compatible servers
+		"function calling is not enabled",      // Gemini
+		"no endpoints found that support tool", // OpenRouter
+		"\"auto\" tool choice requires",        // vLLM
+	}
+	for _, pattern := range patterns {
+		if strings.Contains(errMsg, pattern) {
+			return true
+		}
+	}
+	return false
+}
+
 // calculateBackoff returns the backoff duration for a given attempt (0-indexed).
 // Uses exponential backoff with jitter.
 func calculateBackoff(attempt int) time.Duration {
@@ -370,7 +397,10 @@ func (r *LocalRuntime) clearCooldownState(agentName string) {
 // getEffectiveCooldown returns the cooldown duration to use for an agent.
 // Uses the agent's configured cooldown, or DefaultFallbackCooldown if not set.
 func getEffectiveCooldown(a *agent.Agent) time.Duration {
-	cooldown := a.FallbackCooldown()
+	return effectiveCooldown(a.FallbackCooldown())
+}
+
+func effectiveCooldown(cooldown time.Duration) time.Duration {
 	if cooldown == 0 {
 		return DefaultFallbackCooldown
 	}
@@ -386,7 +416,10 @@ func getEffectiveCooldown(a *agent.Agent) time.Duration {
 // Note: Users who explicitly want 0 retries can set retries: -1 in their config
 // (though this is an edge case - most users want some retries for resilience).
 func getEffectiveRetries(a *agent.Agent) int {
-	retries := a.FallbackRetries()
+	return effectiveRetries(a.FallbackRetries())
+}
+
+func effectiveRetries(retries int) int {
 	// -1 means "explicitly no retries" (workaround for Go's zero value)
 	if retries < 0 {
 		return 0
@@ -398,6 +431,31 @@ func getEffectiveRetries(a *agent.Agent) int {
 	return retries
 }
 
+// RetryPolicy describes how the model calls of an agent are retried and
+// fallen back from.
+type RetryPolicy struct {
+	// Retries is the number of retries per model for the retryable errors
+	// (5xx, timeouts).
+	Retries int
+	// BaseDelay and MaxDelay bound the exponential backoff between retries.
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+	// Cooldown is how long a fallback model is kept after a non-retryable
+	// error, such as a rate limit, before the primary model is tried again.
+	Cooldown time.Duration
+}
+
+// EffectiveRetryPolicy returns the policy applied to an agent configured
+// with the given fallback retries and cooldown, defaults included.
+func EffectiveRetryPolicy(retries int, cooldown time.Duration) RetryPolicy {
+	return RetryPolicy{
+		Retries:   effectiveRetries(retries),
+		BaseDelay: fallbackBaseDelay,
+		MaxDelay:  fallbackMaxDelay,
+		Cooldown:  effectiveCooldown(cooldown),
+	}
+}
+
 // tryModelWithFallback attempts to create a stream and get a response using the primary model,
 // falling back to configured fallback models if the primary fails.
 //
@@ -460,14 +518,21 @@ func (r *LocalRuntime) tryModelWithFallback(
 		// Non-retryable errors (429, 4xx) skip immediately to the next model.
 		maxAttempts := 1 + fallbackRetries
 
-		for attempt := range maxAttempts {
+		// Tools are dropped for this model if it rejects them and the
+		// runtime is allowed to retry without them.
+		modelTools := agentTools
+
+		retryingWithoutTools := false
+
+		for attempt := 0; attempt < maxAttempts; attempt++ {
 			// Check context before each attempt
 			if ctx.Err() != nil {
 				return streamResult{}, nil, ctx.Err()
 			}
 
-			// Apply backoff before retry (not on first attempt of each model)
-			if attempt > 0 {
+			// Apply backoff before retry (not on first attempt of each model,
+			// nor when immediately retrying without tools)
+			if attempt > 0 && !retryingWithoutTools {
 				backoff := calculateBackoff(attempt - 1)
 				logRetryBackoff(a.Name(), modelEntry.provider.ID(), attempt, backoff)
 				if !sleepWithContext(ctx, backoff) {
@@ -476,7 +541,7 @@ func (r *LocalRuntime) tryModelWithFallback(
 			}
 
 			// Emit fallback event when transitioning to a new model (but not when starting in cooldown)
-			if chainIdx > startIndex && attempt == 0 {
+			if chainIdx > startIndex && attempt == 0 && !retryingWithoutTools {
 				logFallbackAttempt(a.Name(), modelEntry, attempt, fallbackRetries, lastErr)
 				// Get the previous model's ID for the event
 				prevModelID := modelChain[chainIdx-1].provider.ID()
@@ -494,6 +559,8 @@ func (r *LocalRuntime) tryModelWithFallback(
 				)
 			}
 
+			retryingWithoutTools = false
+
 			slog.Debug("Creating chat completion stream",
 				"agent", a.Name(),
 				"model", modelEntry.provider.ID(),
@@ -501,9 +568,25 @@ func (r *LocalRuntime) tryModelWithFallback(
 				"in_cooldown", inCooldown,
 				"attempt", attempt+1)
 
-			stream, err := modelEntry.provider.CreateChatCompletionStream(ctx, messages, agentTools)
+			release, err := r.acquireProviderSlot(ctx, a, modelEntry.provider, events)
 			if err != nil {
+				return streamResult{}, nil, err
+			}
+
+			start := time.Now()
+			r.logRequest(sess, a, modelEntry.provider.ID(), messages, modelTools)
+
+			stream, err := modelEntry.provider.CreateChatCompletionStream(ctx, messages, modelTools)
+			if err != nil && r.dropToolsIfUnsupported(a, modelEntry.provider, &modelTools, err, events) {
+				r.logResponse(sess, a, modelEntry.provider.ID(), start, streamResult{}, err)
+				start = time.Now()
+				r.logRequest(sess, a, modelEntry.provider.ID(), messages, modelTools)
+				stream, err = modelEntry.provider.CreateChatCompletionStream(ctx, messages, modelTools)
+			}
+			if err != nil {
+				release()
 				lastErr = err
+				r.logResponse(sess, a, modelEntry.provider.ID(), start, streamResult{}, err)
 
 				// Context cancellation is never retryable
 				if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
@@ -511,7 +594,7 @@ func (r *LocalRuntime) tryModelWithFallback(
 				}
 
 				// Check if error is retryable
-				if !isRetryableModelError(err) {
+				if isToolsUnsupportedError(err) || !isRetryableModelError(err) {
 					slog.Error("Non-retryable error creating stream",
 						"agent", a.Name(),
 						"model", modelEntry.provider.ID(),
@@ -537,7 +620,9 @@ func (r *LocalRuntime) tryModelWithFallback(
 
 			// Stream created successfully, now handle it
 			slog.Debug("Processing stream", "agent", a.Name(), "model", modelEntry.provider.ID())
-			res, err := r.handleStream(ctx, stream, a, agentTools, sess, m, events)
+			res, err := r.handleStream(ctx, stream, a, modelTools, sess, m, events)
+			release()
+			r.logResponse(sess, a, modelEntry.provider.ID(), start, res, err)
 			if err != nil {
 				lastErr = err
 
@@ -546,8 +631,17 @@ func (r *LocalRuntime) tryModelWithFallback(
 					return streamResult{}, nil, err
 				}
 
+				// Some providers only reject tools once streaming starts:
+				// retry right away without them if allowed.
+				if r.dropToolsIfUnsupported(a, modelEntry.provider, &modelTools, err, events) {
+					// Not counted as an attempt.
+					attempt--
+					retryingWithoutTools = true
+					continue
+				}
+
 				// Check if stream error is retryable
-				if !isRetryableModelError(err) {
+				if isToolsUnsupportedError(err) || !isRetryableModelError(err) {
 					slog.Error("Non-retryable error handling stream",
 						"agent", a.Name(),
 						"model", modelEntry.provider.ID(),
@@ -593,3 +687,41 @@ func (r *LocalRuntime) tryModelWithFallback(
 	}
 	return streamResult{}, nil, errors.New("all models failed with unknown error")
 }
+
+// dropToolsIfUnsupported checks whether err is the model rejecting the tool
+// definitions. If so, a ToolsUnsupportedEvent is emitted and, when the runtime
+// is allowed to retry without tools, *modelTools is cleared and true is
+// returned so the caller sends the request again.
+func (r *LocalRuntime) dropToolsIfUnsupported(a *agent.Agent, p provider.Provider, modelTools *[]tools.Tool, err error, events chan Event) bool {
+	if len(*modelTools) == 0 || !isToolsUnsupportedError(err) {
+		return false
+	}
+
+	slog.Warn("Model does not support tools",
+		"agent", a.Name(),
+		"model", p.ID(),
+		"retry_without_tools", r.retryWithoutTools,
+		"error", err)
+	events <- ToolsUnsupported(a.Name(), p.ID(), r.retryWithoutTools)
+	if !r.retryWithoutTools {
+		return false
+	}
+	*modelTools = nil
+	return true
+}
+
+// acquireProviderSlot waits until the provider's concurrency limit allows one
+// more request. A QueuedForProviderEvent is emitted if the request has to wait.
+// The returned function must be called once the request is complete.
+func (r *LocalRuntime) acquireProviderSlot(ctx context.Context, a *agent.Agent, p provider.Provider, events chan Event) (func(), error) {
+	if r.providerLimiter == nil {
+		return func() {}, nil
+	}
+
+	providerName := p.BaseConfig().ModelConfig.Provider
+	return r.providerLimiter.Acquire(ctx, providerName, func() {
+		limit := r.providerLimiter.Limit(providerName)
+		slog.Debug("Waiting for a free provider slot", "agent", a.Name(), "provider", providerName, "limit", limit)
+		events <- QueuedForProvider(a.Name(), providerName, p.ID(), limit)
+	})
+}
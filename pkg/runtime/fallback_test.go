@@ -2,9 +2,13 @@ package runtime
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"testing/synctest"
 	"time"
@@ -16,6 +20,7 @@ import (
 	"github.com/docker/cagent/pkg/chat"
 	"github.com/docker/cagent/pkg/model/provider"
 	"github.com/docker/cagent/pkg/model/provider/base"
+	"github.com/docker/cagent/pkg/paths"
 	"github.com/docker/cagent/pkg/session"
 	"github.com/docker/cagent/pkg/team"
 	"github.com/docker/cagent/pkg/tools"
@@ -780,6 +785,22 @@ func TestGetEffectiveRetries(t *testing.T) {
 	assert.Equal(t, 0, retries, "retries=-1 should return 0 (no retries)")
 }
 
+func TestEffectiveRetryPolicy(t *testing.T) {
+	t.Parallel()
+
+	policy := EffectiveRetryPolicy(0, 0)
+	assert.Equal(t, DefaultFallbackRetries, policy.Retries)
+	assert.Equal(t, DefaultFallbackCooldown, policy.Cooldown)
+	assert.Equal(t, fallbackBaseDelay, policy.BaseDelay)
+	assert.Equal(t, fallbackMaxDelay, policy.MaxDelay)
+
+	policy = EffectiveRetryPolicy(5, 3*time.Minute)
+	assert.Equal(t, 5, policy.Retries)
+	assert.Equal(t, 3*time.Minute, policy.Cooldown)
+
+	assert.Zero(t, EffectiveRetryPolicy(-1, 0).Retries)
+}
+
 // trackingConfigProvider tracks how many times BaseConfig() is called.
 // This is used to verify that fallback providers are cloned (via CloneWithOptions)
 // which calls BaseConfig() to get the config to clone from.
@@ -911,3 +932,132 @@ var (
 	_ provider.Provider = (*countingProvider)(nil)
 	_ provider.Provider = (*trackingConfigProvider)(nil)
 )
+
+// toolRejectingProvider fails like providers serving models without tool
+// calling support whenever tools are sent.
+type toolRejectingProvider struct {
+	id        string
+	stream    chat.MessageStream
+	toolCalls []int
+}
+
+func (p *toolRejectingProvider) ID() string { return p.id }
+func (p *toolRejectingProvider) CreateChatCompletionStream(_ context.Context, _ []chat.Message, tools []tools.Tool) (chat.MessageStream, error) {
+	p.toolCalls = append(p.toolCalls, len(tools))
+	if len(tools) > 0 {
+		return nil, errors.New(`POST "/api/chat": 400 Bad Request {"error":"registry.ollama.ai/library/gemma:2b does not support tools"}`)
+	}
+	return p.stream, nil
+}
+func (p *toolRejectingProvider) BaseConfig() base.Config { return base.Config{} }
+func (p *toolRejectingProvider) MaxTokens() int          { return 0 }
+
+func TestIsToolsUnsupportedError(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, isToolsUnsupportedError(errors.New("registry.ollama.ai/library/gemma:2b does not support tools")))
+	assert.True(t, isToolsUnsupportedError(errors.New("400: Function calling is not enabled for models/gemma-3")))
+	assert.True(t, isToolsUnsupportedError(errors.New("No endpoints found that support tool use")))
+	assert.False(t, isToolsUnsupportedError(errors.New("401 unauthorized")))
+	assert.False(t, isToolsUnsupportedError(nil))
+}
+
+func TestToolsUnsupported(t *testing.T) {
+	agentTools := []tools.Tool{{Name: "search", Parameters: map[string]any{}}}
+
+	run := func(t *testing.T, opts ...Opt) (*toolRejectingProvider, []Event) {
+		t.Helper()
+		p := &toolRejectingProvider{
+			id:     "ollama/gemma:2b",
+			stream: newStreamBuilder().AddContent("Hello").AddStopWithUsage(10, 5).Build(),
+		}
+		root := agent.New("root", "test",
+			agent.WithModel(p),
+			agent.WithToolSets(newStubToolSet(nil, agentTools, nil)),
+		)
+		rt, err := NewLocalRuntime(team.New(team.WithAgents(root)),
+			append([]Opt{WithSessionCompaction(false), WithModelStore(mockModelStore{})}, opts...)...)
+		require.NoError(t, err)
+
+		sess := session.New(session.WithUserMessage("hi"))
+		sess.Title = "Tools Unsupported Test"
+
+		var events []Event
+		for ev := range rt.RunStream(t.Context(), sess) {
+			events = append(events, ev)
+		}
+		return p, events
+	}
+
+	t.Run("fails by default", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			p, events := run(t)
+
+			assert.Equal(t, []int{1}, p.toolCalls, "the request must not be retried")
+			var unsupported *ToolsUnsupportedEvent
+			var gotError bool
+			for _, ev := range events {
+				switch ev := ev.(type) {
+				case *ToolsUnsupportedEvent:
+					unsupported = ev
+				case *ErrorEvent:
+					gotError = true
+				}
+			}
+			require.NotNil(t, unsupported)
+			assert.Equal(t, "ollama/gemma:2b", unsupported.Model)
+			assert.False(t, unsupported.Retried)
+			assert.True(t, gotError)
+		})
+	})
+
+	t.Run("retries without tools", func(t *testing.T) {
+		synctest.Test(t, func(t *testing.T) {
+			p, events := run(t, WithRetryWithoutTools(true))
+
+			assert.Equal(t, []int{1, 0}, p.toolCalls)
+			var retried, gotContent bool
+			for _, ev := range events {
+				switch ev := ev.(type) {
+				case *ToolsUnsupportedEvent:
+					retried = ev.Retried
+				case *AgentChoiceEvent:
+					gotContent = gotContent || ev.Content == "Hello"
+				case *ErrorEvent:
+					t.Errorf("unexpected error: %s", ev.Error)
+				}
+			}
+			assert.True(t, retried)
+			assert.True(t, gotContent)
+		})
+	})
+
+	t.Run("logs the request retried without tools", func(t *testing.T) {
+		paths.SetDataDir(t.TempDir())
+		t.Cleanup(func() { paths.SetDataDir("") })
+
+		synctest.Test(t, func(t *testing.T) {
+			logRequests := func(r *LocalRuntime) { r.SetRequestLog(true, false) }
+			run(t, WithRetryWithoutTools(true), logRequests)
+		})
+
+		logs, err := filepath.Glob(filepath.Join(paths.GetDataDir(), "sessions", "*", "requests.jsonl"))
+		require.NoError(t, err)
+		require.Len(t, logs, 1)
+		data, err := os.ReadFile(logs[0])
+		require.NoError(t, err)
+
+		var entries []requestLogEntry
+		for line := range strings.SplitSeq(strings.TrimSpace(string(data)), "\n") {
+			var entry requestLogEntry
+			require.NoError(t, json.Unmarshal([]byte(line), &entry))
+			entries = append(entries, entry)
+		}
+		require.Len(t, entries, 4)
+		assert.Equal(t, []string{"search"}, entries[0].Tools)
+		assert.Contains(t, entries[1].Error, "does not support tools")
+		assert.Equal(t, "request", entries[2].Type)
+		assert.Empty(t, entries[2].Tools)
+		assert.Empty(t, entries[3].Error)
+	})
+}
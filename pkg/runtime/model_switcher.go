@@ -9,6 +9,7 @@ import (
 
 	"github.com/docker/cagent/pkg/config/latest"
 	"github.com/docker/cagent/pkg/environment"
+	"github.com/docker/cagent/pkg/httpclient"
 	"github.com/docker/cagent/pkg/model/provider"
 	"github.com/docker/cagent/pkg/model/provider/options"
 )
@@ -23,6 +24,9 @@ type ModelChoice struct {
 	Provider string
 	// Model is the specific model name (e.g., "gpt-4o", "claude-sonnet-4-0")
 	Model string
+	// AliasOf is the reference a model alias points to (e.g., "openai/gpt-4o"),
+	// empty for models that aren't aliases
+	AliasOf string
 	// IsDefault indicates this is the agent's configured default model
 	IsDefault bool
 	// IsCurrent indicates this is the currently active model for the agent
@@ -31,6 +35,9 @@ type ModelChoice struct {
 	IsCustom bool
 	// IsCatalog indicates this is a model from the models.dev catalog
 	IsCatalog bool
+	// NoTools indicates models.dev reports that the model doesn't support
+	// tool calling
+	NoTools bool
 }
 
 // ModelSwitcher is an optional interface for runtimes that support changing the model
@@ -58,10 +65,15 @@ type ModelSwitcherConfig struct {
 	Providers map[string]latest.ProviderConfig
 	// ModelsGateway is the gateway URL if configured
 	ModelsGateway string
+	// HTTPTransport tunes the connections to the model providers
+	HTTPTransport httpclient.TransportOptions
 	// EnvProvider provides access to environment variables
 	EnvProvider environment.Provider
 	// AgentDefaultModels maps agent names to their configured default model references
 	AgentDefaultModels map[string]string
+	// ModelAliases maps the model aliases from the config to the references
+	// they point to
+	ModelAliases map[string]string
 }
 
 // SetAgentModel implements ModelSwitcher for LocalRuntime.
@@ -275,7 +287,9 @@ func (r *LocalRuntime) AvailableModels(ctx context.Context) []ModelChoice {
 			Ref:       name,
 			Provider:  cfg.Provider,
 			Model:     cfg.DisplayOrModel(),
+			AliasOf:   strings.TrimSpace(r.modelSwitcherCfg.ModelAliases[name]),
 			IsDefault: name == currentAgentDefault,
+			NoTools:   r.modelLacksTools(ctx, cfg.Provider, cfg.Model),
 		})
 	}
 
@@ -286,6 +300,19 @@ func (r *LocalRuntime) AvailableModels(ctx context.Context) []ModelChoice {
 	return choices
 }
 
+// modelLacksTools reports whether models.dev knows the model doesn't support
+// tool calling. Unknown models are assumed to support tools.
+func (r *LocalRuntime) modelLacksTools(ctx context.Context, providerName, modelName string) bool {
+	if providerName == "" || modelName == "" {
+		return false
+	}
+	model, err := r.modelsStore.GetModel(ctx, providerName+"/"+modelName)
+	if err != nil || model == nil {
+		return false
+	}
+	return !model.ToolCall
+}
+
 // buildCatalogChoices builds ModelChoice entries from the models.dev catalog,
 // filtered by supported providers and available credentials.
 func (r *LocalRuntime) buildCatalogChoices(ctx context.Context) []ModelChoice {
@@ -344,6 +371,7 @@ func (r *LocalRuntime) buildCatalogChoices(ctx context.Context) []ModelChoice {
 				Provider:  cagentProvider,
 				Model:     modelID,
 				IsCatalog: true,
+				NoTools:   !model.ToolCall,
 			})
 		}
 	}
@@ -445,6 +473,7 @@ func (r *LocalRuntime) getAvailableProviders(ctx context.Context) map[string]boo
 func (r *LocalRuntime) createProviderFromConfig(ctx context.Context, cfg *latest.ModelConfig) (provider.Provider, error) {
 	opts := []options.Opt{
 		options.WithGateway(r.modelSwitcherCfg.ModelsGateway),
+		options.WithHTTPTransport(r.modelSwitcherCfg.HTTPTransport),
 		options.WithProviders(r.modelSwitcherCfg.Providers),
 	}
 
@@ -285,8 +285,16 @@ func TestBuildCatalogChoices(t *testing.T) {
 				Name: "Anthropic",
 				Models: map[string]modelsdev.Model{
 					"claude-sonnet-4-0": {
-						ID:   "claude-sonnet-4-0",
-						Name: "Claude Sonnet 4",
+						ID:       "claude-sonnet-4-0",
+						Name:     "Claude Sonnet 4",
+						ToolCall: true,
+						Modalities: modelsdev.Modalities{
+							Output: []string{"text"},
+						},
+					},
+					"claude-2.1": {
+						ID:   "claude-2.1",
+						Name: "Claude 2.1",
 						Modalities: modelsdev.Modalities{
 							Output: []string{"text"},
 						},
@@ -327,10 +335,14 @@ func TestBuildCatalogChoices(t *testing.T) {
 	// Should include Claude Sonnet (not a duplicate)
 	var foundClaude bool
 	for _, c := range choices {
-		if c.Ref == "anthropic/claude-sonnet-4-0" {
+		switch c.Ref {
+		case "anthropic/claude-sonnet-4-0":
 			foundClaude = true
 			assert.True(t, c.IsCatalog)
 			assert.Equal(t, "Claude Sonnet 4", c.Name)
+			assert.False(t, c.NoTools)
+		case "anthropic/claude-2.1":
+			assert.True(t, c.NoTools, "models without tool calling support should be flagged")
 		}
 	}
 	require.True(t, foundClaude, "should include Claude Sonnet from catalog")
@@ -131,6 +131,11 @@ func (r *RemoteRuntime) agentDetailsFromConfig(ctx context.Context) []AgentDetai
 			Name:        agent.Name,
 			Description: agent.Description,
 			Commands:    agent.Commands,
+			Hidden:      agent.Hidden,
+		}
+		if agent.ToolsetApproval != nil {
+			info.ApprovedToolsets = agent.ToolsetApproval.Allow
+			info.GatedToolsets = agent.ToolsetApproval.Ask
 		}
 
 		if provider, model, found := strings.Cut(agent.Model, "/"); found {
@@ -219,6 +224,13 @@ func (r *RemoteRuntime) Resume(ctx context.Context, req ResumeRequest) {
 		return
 	}
 
+	// The API can't carry edited arguments. Never run the original tool call
+	// when the user approved an edited version of it.
+	if req.Arguments != "" {
+		slog.Warn("Edited tool call arguments are not supported by remote runtimes, rejecting the tool call", "session_id", r.sessionID)
+		req = ResumeReject("The user edited the tool call arguments, which this runtime doesn't support.")
+	}
+
 	if err := r.client.ResumeSession(ctx, r.sessionID, string(req.Type), req.Reason, req.ToolName); err != nil {
 		slog.Error("Failed to resume remote session", "error", err, "session_id", r.sessionID)
 	}
@@ -439,6 +451,16 @@ func (r *RemoteRuntime) CurrentAgentSkillsToolset() *builtin.SkillsToolset {
 	return nil
 }
 
+// CurrentAgentTasksToolset returns nil for remote runtimes since tasks are stored server-side.
+func (r *RemoteRuntime) CurrentAgentTasksToolset() *builtin.TasksTool {
+	return nil
+}
+
+// CurrentAgentMemoryToolset returns nil for remote runtimes since memories are stored server-side.
+func (r *RemoteRuntime) CurrentAgentMemoryToolset() *builtin.MemoryTool {
+	return nil
+}
+
 // UpdateSessionTitle updates the title of the current session on the remote server.
 func (r *RemoteRuntime) UpdateSessionTitle(ctx context.Context, sess *session.Session, title string) error {
 	sess.Title = title
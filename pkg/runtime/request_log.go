@@ -0,0 +1,273 @@
+package runtime
+
+import (
+	"encoding/json"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/docker/cagent/pkg/agent"
+	"github.com/docker/cagent/pkg/chat"
+	"github.com/docker/cagent/pkg/environment"
+	"github.com/docker/cagent/pkg/paths"
+	"github.com/docker/cagent/pkg/session"
+	"github.com/docker/cagent/pkg/tools"
+)
+
+// RequestLogger is an optional interface for runtimes that can log the
+// requests sent to the models, and their responses, to a file per session.
+type RequestLogger interface {
+	// SetRequestLog starts or stops logging. When withBodies is false, only
+	// metadata is logged: no message contents and no tool call arguments.
+	SetRequestLog(enabled, withBodies bool)
+	// RequestLogEnabled reports whether requests are being logged.
+	RequestLogEnabled() (enabled, withBodies bool)
+}
+
+// RequestLogPath returns the path of the file where the requests of the
+// session are logged.
+func RequestLogPath(sessionID string) string {
+	return filepath.Join(paths.GetDataDir(), "sessions", sessionID, "requests.jsonl")
+}
+
+// requestLogEntry is one line of the request log.
+type requestLogEntry struct {
+	Time      time.Time `json:"time"`
+	Type      string    `json:"type"` // "request" or "response"
+	SessionID string    `json:"session_id"`
+	Agent     string    `json:"agent"`
+	Model     string    `json:"model"`
+
+	// Requests
+	MessageCount int                 `json:"message_count,omitempty"`
+	Tools        []string            `json:"tools,omitempty"`
+	NewMessages  []requestLogMessage `json:"new_messages,omitempty"`
+
+	// Responses
+	DurationMS  int64                `json:"duration_ms,omitempty"`
+	ActualModel string               `json:"actual_model,omitempty"`
+	Content     string               `json:"content,omitempty"`
+	ToolCalls   []requestLogToolCall `json:"tool_calls,omitempty"`
+	Usage       *chat.Usage          `json:"usage,omitempty"`
+	Error       string               `json:"error,omitempty"`
+}
+
+type requestLogMessage struct {
+	Role       string `json:"role"`
+	Content    string `json:"content,omitempty"`
+	ToolCallID string `json:"tool_call_id,omitempty"`
+}
+
+type requestLogToolCall struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	Arguments string `json:"arguments,omitempty"`
+}
+
+// requestLog appends requests and responses to the log file of their session.
+type requestLog struct {
+	mu         sync.Mutex
+	withBodies bool
+	// secrets are the values of the secret looking environment variables,
+	// redacted from the log along with well-known API key formats and the
+	// secrets resolved from env files and credential helpers.
+	secrets []string
+}
+
+func newRequestLog(withBodies bool) *requestLog {
+	return &requestLog{
+		withBodies: withBodies,
+		secrets:    secretEnvValues(os.Environ()),
+	}
+}
+
+// SetRequestLog starts or stops logging the model requests and responses.
+func (r *LocalRuntime) SetRequestLog(enabled, withBodies bool) {
+	r.requestLogMu.Lock()
+	defer r.requestLogMu.Unlock()
+
+	if !enabled {
+		r.requestLog = nil
+		return
+	}
+	r.requestLog = newRequestLog(withBodies)
+}
+
+// RequestLogEnabled reports whether the model requests are being logged.
+func (r *LocalRuntime) RequestLogEnabled() (enabled, withBodies bool) {
+	l := r.currentRequestLog()
+	if l == nil {
+		return false, false
+	}
+	return true, l.withBodies
+}
+
+func (r *LocalRuntime) currentRequestLog() *requestLog {
+	r.requestLogMu.Lock()
+	defer r.requestLogMu.Unlock()
+	return r.requestLog
+}
+
+// logRequest logs a request about to be sent to the model, if logging is on.
+func (r *LocalRuntime) logRequest(sess *session.Session, a *agent.Agent, model string, messages []chat.Message, agentTools []tools.Tool) {
+	l := r.currentRequestLog()
+	if l == nil {
+		return
+	}
+
+	entry := requestLogEntry{
+		Time:         time.Now(),
+		Type:         "request",
+		SessionID:    sess.ID,
+		Agent:        a.Name(),
+		Model:        model,
+		MessageCount: len(messages),
+	}
+	for _, t := range agentTools {
+		entry.Tools = append(entry.Tools, t.Name)
+	}
+	if l.withBodies {
+		// The whole history is sent every time: only log what's new since
+		// the model last answered.
+		for _, msg := range messages[lastAssistantIndex(messages)+1:] {
+			entry.NewMessages = append(entry.NewMessages, requestLogMessage{
+				Role:       string(msg.Role),
+				Content:    msg.Content,
+				ToolCallID: msg.ToolCallID,
+			})
+		}
+	}
+
+	l.write(entry)
+}
+
+// logResponse logs the response of the model, or the error, if logging is on.
+func (r *LocalRuntime) logResponse(sess *session.Session, a *agent.Agent, model string, start time.Time, res streamResult, err error) {
+	l := r.currentRequestLog()
+	if l == nil {
+		return
+	}
+
+	entry := requestLogEntry{
+		Time:        time.Now(),
+		Type:        "response",
+		SessionID:   sess.ID,
+		Agent:       a.Name(),
+		Model:       model,
+		DurationMS:  time.Since(start).Milliseconds(),
+		ActualModel: res.ActualModel,
+		Usage:       res.Usage,
+	}
+	if err != nil {
+		entry.Error = err.Error()
+	}
+	if l.withBodies {
+		entry.Content = res.Content
+	}
+	for _, call := range res.Calls {
+		tc := requestLogToolCall{ID: call.ID, Name: call.Function.Name}
+		if l.withBodies {
+			tc.Arguments = call.Function.Arguments
+		}
+		entry.ToolCalls = append(entry.ToolCalls, tc)
+	}
+
+	l.write(entry)
+}
+
+func (l *requestLog) write(entry requestLogEntry) {
+	// Redact before encoding: JSON escaping would hide secrets that contain
+	// quotes or backslashes from the redaction.
+	entry.redact(append(slices.Clone(l.secrets), environment.KnownSecrets()...))
+
+	line, err := json.Marshal(entry)
+	if err != nil {
+		slog.Warn("Failed to encode request log entry", "error", err)
+		return
+	}
+	line = append(line, '\n')
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	path := RequestLogPath(entry.SessionID)
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		slog.Warn("Failed to create request log directory", "path", path, "error", err)
+		return
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		slog.Warn("Failed to open request log", "path", path, "error", err)
+		return
+	}
+	defer f.Close()
+	if _, err := f.Write(line); err != nil {
+		slog.Warn("Failed to write request log", "path", path, "error", err)
+	}
+}
+
+// redact removes the secrets from every free-form field of the entry.
+func (e *requestLogEntry) redact(secrets []string) {
+	e.Content = redactSecrets(e.Content, secrets)
+	e.Error = redactSecrets(e.Error, secrets)
+	for i := range e.NewMessages {
+		e.NewMessages[i].Content = redactSecrets(e.NewMessages[i].Content, secrets)
+	}
+	for i := range e.ToolCalls {
+		e.ToolCalls[i].Arguments = redactSecrets(e.ToolCalls[i].Arguments, secrets)
+	}
+}
+
+func lastAssistantIndex(messages []chat.Message) int {
+	for i, msg := range slices.Backward(messages) {
+		if msg.Role == chat.MessageRoleAssistant {
+			return i
+		}
+	}
+	return -1
+}
+
+// secretPatterns match well-known API key and token formats.
+var secretPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`sk-[A-Za-z0-9_\-]{20,}`),
+	regexp.MustCompile(`AKIA[0-9A-Z]{16}`),
+	regexp.MustCompile(`gh[pousr]_[A-Za-z0-9]{36,}`),
+	regexp.MustCompile(`github_pat_[A-Za-z0-9_]{22,}`),
+	regexp.MustCompile(`AIza[0-9A-Za-z_\-]{35}`),
+	regexp.MustCompile(`xox[abprs]-[A-Za-z0-9\-]{10,}`),
+	regexp.MustCompile(`(?i)bearer [A-Za-z0-9._\-]{16,}`),
+}
+
+const redacted = "[REDACTED]"
+
+func redactSecrets(s string, secrets []string) string {
+	for _, secret := range secrets {
+		s = strings.ReplaceAll(s, secret, redacted)
+	}
+	for _, re := range secretPatterns {
+		s = re.ReplaceAllString(s, redacted)
+	}
+	return s
+}
+
+// secretEnvValues returns the values of the environment variables that look
+// like they hold secrets. Short values are ignored to avoid redacting common
+// words.
+func secretEnvValues(environ []string) []string {
+	var secrets []string
+	for _, kv := range environ {
+		name, value, ok := strings.Cut(kv, "=")
+		if !ok || len(value) < 8 {
+			continue
+		}
+		if environment.LooksSecret(name) {
+			secrets = append(secrets, value)
+		}
+	}
+	return secrets
+}
@@ -24,6 +24,7 @@ import (
 	"github.com/docker/cagent/pkg/chat"
 	"github.com/docker/cagent/pkg/config/latest"
 	"github.com/docker/cagent/pkg/config/types"
+	"github.com/docker/cagent/pkg/environment"
 	"github.com/docker/cagent/pkg/hooks"
 	"github.com/docker/cagent/pkg/model/provider"
 	"github.com/docker/cagent/pkg/model/provider/options"
@@ -69,9 +70,10 @@ const (
 // ResumeRequest carries the user's confirmation decision along with an optional
 // reason (used when rejecting a tool call to help the model understand why).
 type ResumeRequest struct {
-	Type     ResumeType
-	Reason   string // Optional; primarily used with ResumeTypeReject
-	ToolName string // Optional; used with ResumeTypeApproveTool to specify which tool to always allow
+	Type      ResumeType
+	Reason    string // Optional; primarily used with ResumeTypeReject
+	ToolName  string // Optional; used with ResumeTypeApproveTool to specify which tool to always allow
+	Arguments string // Optional; used with the approve types to run the tool call with arguments edited by the user
 }
 
 // ResumeApprove creates a ResumeRequest to approve a single tool call.
@@ -79,6 +81,12 @@ func ResumeApprove() ResumeRequest {
 	return ResumeRequest{Type: ResumeTypeApprove}
 }
 
+// ResumeApproveWithArguments creates a ResumeRequest to approve a single tool
+// call, running it with the given arguments instead of the model's.
+func ResumeApproveWithArguments(arguments string) ResumeRequest {
+	return ResumeRequest{Type: ResumeTypeApprove, Arguments: arguments}
+}
+
 // ResumeApproveSession creates a ResumeRequest to approve all tool calls for the session.
 func ResumeApproveSession() ResumeRequest {
 	return ResumeRequest{Type: ResumeTypeApproveSession}
@@ -139,6 +147,12 @@ type Runtime interface {
 	// CurrentAgentSkillsToolset returns the skills toolset for the current agent, or nil if skills are not enabled.
 	CurrentAgentSkillsToolset() *builtin.SkillsToolset
 
+	// CurrentAgentTasksToolset returns the tasks toolset for the current agent, or nil if it doesn't use one.
+	CurrentAgentTasksToolset() *builtin.TasksTool
+
+	// CurrentAgentMemoryToolset returns the memory toolset for the current agent, or nil if it doesn't use one.
+	CurrentAgentMemoryToolset() *builtin.MemoryTool
+
 	// CurrentMCPPrompts returns MCP prompts available from the current agent's toolsets.
 	// Returns an empty map if no MCP prompts are available.
 	CurrentMCPPrompts(ctx context.Context) map[string]mcptools.PromptInfo
@@ -159,9 +173,10 @@ type Runtime interface {
 
 // PermissionsInfo contains the allow, ask, and deny patterns for tool permissions.
 type PermissionsInfo struct {
-	Allow []string
-	Ask   []string
-	Deny  []string
+	Allow         []string
+	Ask           []string
+	Deny          []string
+	AllowToolsets []string
 }
 
 type CurrentAgentInfo struct {
@@ -190,6 +205,13 @@ type ToolsChangeSubscriber interface {
 	OnToolsChanged(handler func(Event))
 }
 
+// StepStopper is implemented by runtimes that can stop a run at a clean
+// point: the current step, a model call and the tool calls it asked for,
+// completes but no further model call is made.
+type StepStopper interface {
+	StopAfterCurrentStep()
+}
+
 // LocalRuntime manages the execution of agents
 type LocalRuntime struct {
 	toolMap                     map[string]ToolHandlerFunc
@@ -221,6 +243,34 @@ type LocalRuntime struct {
 	onToolsChanged func(Event)
 
 	bgAgents *agenttool.Handler
+
+	// providerLimiter caps concurrent requests per provider; it may be
+	// shared with other runtimes.
+	providerLimiter *provider.ConcurrencyLimiter
+
+	// toolResultLimits caps the size, in bytes, of the tool results the
+	// model sees, keyed by toolset type ("*" for all the others).
+	toolResultLimits map[string]int
+
+	// toolTimeouts is how long tool calls can run, keyed by toolset type
+	// ("*" for all the others). Tool calls don't time out when unset.
+	toolTimeouts map[string]time.Duration
+
+	// approvalMemory remembers the approved tool calls to approve the next
+	// matching ones without asking, nil when off.
+	approvalMemory *ApprovalMemory
+
+	// retryWithoutTools sends the request again without tools when the
+	// model rejects tool definitions.
+	retryWithoutTools bool
+
+	// requestLog logs the model requests and responses, nil when off.
+	requestLog   *requestLog
+	requestLogMu sync.Mutex
+
+	// stopAfterStep stops the run before the next model call, once the
+	// tool calls of the current step completed.
+	stopAfterStep atomic.Bool
 }
 
 type streamResult struct {
@@ -233,6 +283,9 @@ type streamResult struct {
 	ActualModel       string      // The actual model used (may differ from configured model with routing)
 	Usage             *chat.Usage // Token usage for this stream
 	RateLimit         *chat.RateLimit
+	// GenerationTime is the time between the first chunk of the response and
+	// the end of the stream. It doesn't include the time to the first chunk.
+	GenerationTime time.Duration
 }
 
 type Opt func(*LocalRuntime)
@@ -249,6 +302,52 @@ func WithManagedOAuth(managed bool) Opt {
 	}
 }
 
+// WithProviderConcurrency limits the number of concurrent requests sent to
+// each provider. Share the same limiter between runtimes to apply the limits
+// across all their sessions.
+func WithProviderConcurrency(limiter *provider.ConcurrencyLimiter) Opt {
+	return func(r *LocalRuntime) {
+		r.providerLimiter = limiter
+	}
+}
+
+// WithToolResultLimits caps the size, in bytes, of the tool results sent to
+// the model, per toolset type (e.g. "shell": 16384). The "*" key applies to
+// all the toolset types without their own limit. The session and the events
+// keep the full results.
+func WithToolResultLimits(limits map[string]int) Opt {
+	return func(r *LocalRuntime) {
+		r.toolResultLimits = limits
+	}
+}
+
+// WithToolTimeouts sets how long tool calls can run before they're aborted,
+// per toolset type (e.g. "mcp": 2*time.Minute). The "*" key applies to all
+// the toolset types without their own timeout, except the interactive ones,
+// and 0 disables the timeout. Tool calls don't time out by default.
+func WithToolTimeouts(timeouts map[string]time.Duration) Opt {
+	return func(r *LocalRuntime) {
+		r.toolTimeouts = timeouts
+	}
+}
+
+// WithApprovalMemory makes the runtime remember the tool calls approved by
+// the user, and approve the next calls to the same tool without asking.
+func WithApprovalMemory(memory *ApprovalMemory) Opt {
+	return func(r *LocalRuntime) {
+		r.approvalMemory = memory
+	}
+}
+
+// WithRetryWithoutTools makes the runtime send a request again without tools
+// when the model rejects it because it doesn't support tool calling, instead
+// of failing over to the next model.
+func WithRetryWithoutTools(retry bool) Opt {
+	return func(r *LocalRuntime) {
+		r.retryWithoutTools = retry
+	}
+}
+
 // WithTracer sets a custom OpenTelemetry tracer; if not provided, tracing is disabled (no-op).
 func WithTracer(t trace.Tracer) Opt {
 	return func(r *LocalRuntime) {
@@ -473,6 +572,12 @@ func (r *LocalRuntime) SetCurrentAgent(agentName string) error {
 	return nil
 }
 
+// StopAfterCurrentStep stops the running sessions before their next model
+// call, letting the running tool calls complete.
+func (r *LocalRuntime) StopAfterCurrentStep() {
+	r.stopAfterStep.Store(true)
+}
+
 func (r *LocalRuntime) CurrentAgentCommands(context.Context) types.Commands {
 	return r.CurrentAgent().Commands()
 }
@@ -570,6 +675,34 @@ func (r *LocalRuntime) CurrentAgentSkillsToolset() *builtin.SkillsToolset {
 	return nil
 }
 
+// CurrentAgentTasksToolset returns the tasks toolset for the current agent, or nil if it doesn't use one.
+func (r *LocalRuntime) CurrentAgentTasksToolset() *builtin.TasksTool {
+	a := r.CurrentAgent()
+	if a == nil {
+		return nil
+	}
+	for _, ts := range a.ToolSets() {
+		if tt, ok := tools.As[*builtin.TasksTool](ts); ok {
+			return tt
+		}
+	}
+	return nil
+}
+
+// CurrentAgentMemoryToolset returns the memory toolset for the current agent, or nil if it doesn't use one.
+func (r *LocalRuntime) CurrentAgentMemoryToolset() *builtin.MemoryTool {
+	a := r.CurrentAgent()
+	if a == nil {
+		return nil
+	}
+	for _, ts := range a.ToolSets() {
+		if mt, ok := tools.As[*builtin.MemoryTool](ts); ok {
+			return mt
+		}
+	}
+	return nil
+}
+
 // ExecuteMCPPrompt executes an MCP prompt with provided arguments and returns the content.
 func (r *LocalRuntime) ExecuteMCPPrompt(ctx context.Context, promptName string, arguments map[string]string) (string, error) {
 	currentAgent := r.CurrentAgent()
@@ -688,18 +821,19 @@ func (r *LocalRuntime) getEffectiveModelID(a *agent.Agent) string {
 // agentDetailsFromTeam converts team agent info to AgentDetails for events.
 // It accounts for active fallback cooldowns, returning the effective model
 // instead of the configured model when a fallback is in effect.
-func (r *LocalRuntime) agentDetailsFromTeam() []AgentDetails {
+func (r *LocalRuntime) agentDetailsFromTeam(ctx context.Context) []AgentDetails {
 	agentsInfo := r.team.AgentsInfo()
 	details := make([]AgentDetails, len(agentsInfo))
 	for i, info := range agentsInfo {
 		providerName := info.Provider
 		modelName := info.Model
+		a, _ := r.team.Agent(info.Name)
 
 		// Check if this agent has an active fallback cooldown
 		cooldownState := r.getCooldownState(info.Name)
 		if cooldownState != nil {
 			// Get the agent to access fallback models
-			if a, err := r.team.Agent(info.Name); err == nil && a != nil {
+			if a != nil {
 				fallbacks := a.FallbackModels()
 				if cooldownState.fallbackIndex >= 0 && cooldownState.fallbackIndex < len(fallbacks) {
 					fb := fallbacks[cooldownState.fallbackIndex]
@@ -716,16 +850,59 @@ func (r *LocalRuntime) agentDetailsFromTeam() []AgentDetails {
 		}
 
 		details[i] = AgentDetails{
-			Name:        info.Name,
-			Description: info.Description,
-			Provider:    providerName,
-			Model:       modelName,
-			Commands:    info.Commands,
+			Name:             info.Name,
+			Description:      info.Description,
+			Provider:         providerName,
+			Model:            modelName,
+			Commands:         info.Commands,
+			IsDefault:        info.Name == r.team.DefaultAgentName(),
+			Hidden:           info.Hidden,
+			ApprovedToolsets: info.ApprovedToolsets,
+			GatedToolsets:    info.GatedToolsets,
+		}
+		if a != nil {
+			details[i].ToolNames, details[i].ToolDescriptions = toolNamesAndDescriptions(a.KnownTools(ctx))
+			details[i].Toolsets = toolsetStatuses(a)
 		}
 	}
 	return details
 }
 
+// toolNamesAndDescriptions returns the names of agentTools, and the first
+// line of their descriptions by name.
+func toolNamesAndDescriptions(agentTools []tools.Tool) ([]string, map[string]string) {
+	if len(agentTools) == 0 {
+		return nil, nil
+	}
+	names := make([]string, 0, len(agentTools))
+	descriptions := make(map[string]string, len(agentTools))
+	for _, t := range agentTools {
+		names = append(names, t.Name)
+		if description, _, _ := strings.Cut(strings.TrimSpace(t.Description), "\n"); description != "" {
+			descriptions[t.Name] = strings.TrimSpace(description)
+		}
+	}
+	return names, descriptions
+}
+
+// toolsetStatuses returns the load status of the toolsets of a. A toolset
+// is loading until it starts, unless its last start failed.
+func toolsetStatuses(a *agent.Agent) []ToolsetStatus {
+	var statuses []ToolsetStatus
+	for _, s := range a.ToolSetStatuses() {
+		status := ToolsetStatus{Name: s.Name, Status: ToolsetLoading}
+		switch {
+		case s.Started:
+			status.Status = ToolsetLoaded
+		case s.Err != nil:
+			status.Status = ToolsetFailed
+			status.Error = s.Err.Error()
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses
+}
+
 // SessionStore returns the session store for browsing/loading past sessions.
 func (r *LocalRuntime) SessionStore() session.Store {
 	return r.sessionStore
@@ -757,9 +934,10 @@ func (r *LocalRuntime) PermissionsInfo() *PermissionsInfo {
 		return nil
 	}
 	return &PermissionsInfo{
-		Allow: permChecker.AllowPatterns(),
-		Ask:   permChecker.AskPatterns(),
-		Deny:  permChecker.DenyPatterns(),
+		Allow:         permChecker.AllowPatterns(),
+		Ask:           permChecker.AskPatterns(),
+		Deny:          permChecker.DenyPatterns(),
+		AllowToolsets: permChecker.AllowToolsets(),
 	}
 }
 
@@ -832,9 +1010,14 @@ func (r *LocalRuntime) EmitStartupInfo(ctx context.Context, sess *session.Sessio
 	if !send(AgentInfo(a.Name(), modelID, a.Description(), a.WelcomeMessage())) {
 		return
 	}
-	if !send(TeamInfo(r.agentDetailsFromTeam(), r.CurrentAgentName())) {
+	if !send(TeamInfo(r.agentDetailsFromTeam(ctx), r.CurrentAgentName())) {
 		return
 	}
+	if name := r.team.DefaultAgentName(); name != "" && !slices.Contains(r.team.AgentNames(), name) {
+		if !send(Warning(fmt.Sprintf("Default agent '%s' not found, starting on '%s' instead.", name, a.Name()), a.Name())) {
+			return
+		}
+	}
 
 	// When restoring a session that already has token data, emit a
 	// TokenUsageEvent so the sidebar can show the context usage percentage.
@@ -916,7 +1099,11 @@ func (r *LocalRuntime) emitToolsProgressively(ctx context.Context, a *agent.Agen
 	}
 
 	// Emit final state (not loading)
-	send(ToolsetInfo(totalTools, false, r.CurrentAgentName()))
+	if !send(ToolsetInfo(totalTools, false, r.CurrentAgentName())) {
+		return
+	}
+	// The toolsets are no longer loading, they started or failed.
+	send(TeamInfo(r.agentDetailsFromTeam(ctx), r.CurrentAgentName()))
 }
 
 // registerDefaultTools registers the runtime-managed tool handlers.
@@ -1006,7 +1193,7 @@ func (r *LocalRuntime) RunStream(ctx context.Context, sess *session.Session) <-c
 		events <- AgentInfo(a.Name(), r.getEffectiveModelID(a), a.Description(), a.WelcomeMessage())
 
 		// Emit team information
-		events <- TeamInfo(r.agentDetailsFromTeam(), r.CurrentAgentName())
+		events <- TeamInfo(r.agentDetailsFromTeam(ctx), r.CurrentAgentName())
 
 		// Initialize RAG and forward events
 		r.InitializeRAG(ctx, events)
@@ -1034,11 +1221,22 @@ func (r *LocalRuntime) RunStream(ctx context.Context, sess *session.Session) <-c
 
 		r.registerDefaultTools()
 
+		// A new run isn't affected by a stop asked during the previous one.
+		// Sub-sessions run within the step of their parent and keep it.
+		if !sess.IsSubSession() {
+			r.stopAfterStep.Store(false)
+		}
+
 		iteration := 0
 		// Use a runtime copy of maxIterations so we don't modify the session's persistent config
 		runtimeMaxIterations := sess.MaxIterations
 
 		for {
+			if iteration > 0 && r.stopAfterStep.Load() {
+				slog.Debug("Stopping after the current step", "session_id", sess.ID)
+				return
+			}
+
 			// Set elicitation handler on all MCP toolsets before getting tools
 			a := r.CurrentAgent()
 
@@ -1050,6 +1248,10 @@ func (r *LocalRuntime) RunStream(ctx context.Context, sess *session.Session) <-c
 				events <- Error(fmt.Sprintf("failed to get tools: %v", err))
 				return
 			}
+			if iteration == 0 && len(a.ToolSets()) > 0 {
+				// The toolsets of the agent started, or failed to, with this run.
+				events <- TeamInfo(r.agentDetailsFromTeam(ctx), r.CurrentAgentName())
+			}
 
 			// Emit updated tool count. After a ToolListChanged MCP notification
 			// the cache is invalidated, so getTools above re-fetches from the
@@ -1150,7 +1352,7 @@ func (r *LocalRuntime) RunStream(ctx context.Context, sess *session.Session) <-c
 				}
 			}
 
-			messages := sess.GetMessages(a)
+			messages := r.capToolResults(sess.GetMessages(a), agentTools)
 			slog.Debug("Retrieved messages for processing", "agent", a.Name(), "message_count", len(messages))
 
 			// Strip image content from messages if the model doesn't support image input.
@@ -1239,9 +1441,10 @@ func (r *LocalRuntime) RunStream(ctx context.Context, sess *session.Session) <-c
 				// Build per-message usage for the event
 				if res.Usage != nil {
 					msgUsage = &MessageUsage{
-						Usage: *res.Usage,
-						Cost:  messageCost,
-						Model: messageModel,
+						Usage:           *res.Usage,
+						Cost:            messageCost,
+						Model:           messageModel,
+						TokensPerSecond: tokensPerSecond(res.Usage.OutputTokens, res.GenerationTime),
 					}
 					if res.RateLimit != nil {
 						msgUsage.RateLimit = *res.RateLimit
@@ -1421,6 +1624,13 @@ func (r *LocalRuntime) handleStream(ctx context.Context, stream chat.MessageStre
 	var actualModel string
 	var messageUsage *chat.Usage
 	var messageRateLimit *chat.RateLimit
+	var firstChunkAt time.Time
+	generationTime := func() time.Duration {
+		if firstChunkAt.IsZero() {
+			return 0
+		}
+		return time.Since(firstChunkAt)
+	}
 
 	toolCallIndex := make(map[string]int)   // toolCallID -> index in toolCalls slice
 	emittedPartial := make(map[string]bool) // toolCallID -> whether we've emitted a partial event
@@ -1471,6 +1681,9 @@ func (r *LocalRuntime) handleStream(ctx context.Context, stream chat.MessageStre
 		if len(response.Choices) == 0 {
 			continue
 		}
+		if firstChunkAt.IsZero() {
+			firstChunkAt = time.Now()
+		}
 		choice := response.Choices[0]
 
 		if len(choice.Delta.ThoughtSignature) > 0 {
@@ -1494,6 +1707,7 @@ func (r *LocalRuntime) handleStream(ctx context.Context, stream chat.MessageStre
 				ActualModel:       actualModel,
 				Usage:             messageUsage,
 				RateLimit:         messageRateLimit,
+				GenerationTime:    generationTime(),
 			}, nil
 		}
 
@@ -1569,6 +1783,7 @@ func (r *LocalRuntime) handleStream(ctx context.Context, stream chat.MessageStre
 		ActualModel:       actualModel,
 		Usage:             messageUsage,
 		RateLimit:         messageRateLimit,
+		GenerationTime:    generationTime(),
 	}, nil
 }
 
@@ -1610,11 +1825,11 @@ func (r *LocalRuntime) processToolCalls(ctx context.Context, sess *session.Sessi
 
 		// Pick the handler: runtime-managed tools (transfer_task, handoff)
 		// have dedicated handlers; everything else goes through the toolset.
-		var runTool func()
+		var runTool func(tools.ToolCall)
 		if handler, exists := r.toolMap[toolCall.Function.Name]; exists {
-			runTool = func() { r.runAgentTool(callCtx, handler, sess, toolCall, tool, events, a) }
+			runTool = func(toolCall tools.ToolCall) { r.runAgentTool(callCtx, handler, sess, toolCall, tool, events, a) }
 		} else {
-			runTool = func() { r.runTool(callCtx, tool, toolCall, events, sess, a) }
+			runTool = func(toolCall tools.ToolCall) { r.runTool(callCtx, tool, toolCall, events, sess, a) }
 		}
 
 		// Execute tool with approval check
@@ -1638,8 +1853,11 @@ func (r *LocalRuntime) processToolCalls(ctx context.Context, sess *session.Sessi
 //  1. sess.ToolsApproved (--yolo flag) - auto-approve everything, takes precedence
 //  2. Session-level permissions (if configured) - pattern-based Allow/Ask/Deny rules
 //  3. Team-level permissions config - checked second
-//  4. Read-only hint - auto-approve
-//  5. Default: ask for user confirmation
+//  4. The agent's toolset approval policy - auto-approve or ask
+//  5. Toolset types allowlisted in the team config - auto-approve
+//  6. Read-only hint - auto-approve
+//  7. Approvals remembered in the session - auto-approve
+//  8. Default: ask for user confirmation
 func (r *LocalRuntime) executeWithApproval(
 	ctx context.Context,
 	sess *session.Session,
@@ -1647,14 +1865,14 @@ func (r *LocalRuntime) executeWithApproval(
 	tool tools.Tool,
 	events chan Event,
 	a *agent.Agent,
-	runTool func(),
+	runTool func(tools.ToolCall),
 ) (canceled bool) {
 	toolName := toolCall.Function.Name
 
 	// --yolo flag takes absolute precedence: auto-approve everything.
 	if sess.ToolsApproved {
 		slog.Debug("Tool auto-approved by --yolo flag", "tool", toolName, "session_id", sess.ID)
-		runTool()
+		runTool(toolCall)
 		return false
 	}
 
@@ -1678,7 +1896,7 @@ func (r *LocalRuntime) executeWithApproval(
 			return false
 		case permissions.Allow:
 			slog.Debug("Tool auto-approved by permissions", "tool", toolName, "source", pc.source, "session_id", sess.ID)
-			runTool()
+			runTool(toolCall)
 			return false
 		case permissions.ForceAsk:
 			slog.Debug("Tool requires confirmation (ask pattern)", "tool", toolName, "source", pc.source, "session_id", sess.ID)
@@ -1688,9 +1906,39 @@ func (r *LocalRuntime) executeWithApproval(
 		}
 	}
 
-	// No permission rule matched. Auto-approve if the tool is read-only.
+	// No permission rule matched. The agent's own toolset policy comes next:
+	// gated toolsets always ask, even for read-only tools.
+	if approval := a.ToolsetApproval(); approval != nil && tool.Toolset != "" {
+		switch {
+		case containsFold(approval.Ask, tool.Toolset):
+			slog.Debug("Tool requires confirmation (gated toolset)", "tool", toolName, "toolset", tool.Toolset, "agent", a.Name(), "session_id", sess.ID)
+			return r.askUserForConfirmation(ctx, sess, toolCall, tool, events, a, runTool)
+		case containsFold(approval.Allow, tool.Toolset):
+			slog.Debug("Tool auto-approved by the agent's toolset policy", "tool", toolName, "toolset", tool.Toolset, "agent", a.Name(), "session_id", sess.ID)
+			runTool(toolCall)
+			return false
+		}
+	}
+
+	// Auto-approve tools from allowlisted toolset types.
+	for _, pc := range checkers {
+		if pc.checker.AllowsToolset(tool.Toolset) {
+			slog.Debug("Tool auto-approved by toolset type", "tool", toolName, "toolset", tool.Toolset, "source", pc.source, "session_id", sess.ID)
+			runTool(toolCall)
+			return false
+		}
+	}
+
+	// Auto-approve if the tool is read-only.
 	if tool.Annotations.ReadOnlyHint {
-		runTool()
+		runTool(toolCall)
+		return false
+	}
+
+	// Auto-approve if the user already approved a matching call.
+	if approvedByMemory(sess, toolCall, time.Now()) {
+		slog.Debug("Tool auto-approved by a remembered approval", "tool", toolName, "session_id", sess.ID)
+		runTool(toolCall)
 		return false
 	}
 
@@ -1698,6 +1946,13 @@ func (r *LocalRuntime) executeWithApproval(
 	return r.askUserForConfirmation(ctx, sess, toolCall, tool, events, a, runTool)
 }
 
+// containsFold reports whether values contains s, ignoring case.
+func containsFold(values []string, s string) bool {
+	return slices.ContainsFunc(values, func(v string) bool {
+		return strings.EqualFold(v, s)
+	})
+}
+
 // permissionChecker pairs a checker with a human-readable source label.
 type permissionChecker struct {
 	checker *permissions.Checker
@@ -1710,9 +1965,10 @@ func (r *LocalRuntime) permissionCheckers(sess *session.Session) []permissionChe
 	if sess.Permissions != nil {
 		checkers = append(checkers, permissionChecker{
 			checker: permissions.NewChecker(&latest.PermissionsConfig{
-				Allow: sess.Permissions.Allow,
-				Ask:   sess.Permissions.Ask,
-				Deny:  sess.Permissions.Deny,
+				Allow:         sess.Permissions.Allow,
+				Ask:           sess.Permissions.Ask,
+				Deny:          sess.Permissions.Deny,
+				AllowToolsets: sess.Permissions.AllowToolsets,
 			}),
 			source: "session permissions",
 		})
@@ -1735,7 +1991,7 @@ func (r *LocalRuntime) askUserForConfirmation(
 	tool tools.Tool,
 	events chan Event,
 	a *agent.Agent,
-	runTool func(),
+	runTool func(tools.ToolCall),
 ) (canceled bool) {
 	toolName := toolCall.Function.Name
 	slog.Debug("Tools not approved, waiting for resume", "tool", toolName, "session_id", sess.ID)
@@ -1745,14 +2001,24 @@ func (r *LocalRuntime) askUserForConfirmation(
 
 	select {
 	case req := <-r.resumeChan:
+		// The user may have edited the call before approving it, however it's approved.
+		edited := req.Arguments != "" && req.Type != ResumeTypeReject
+		if edited {
+			toolCall.Function.Arguments = req.Arguments
+		}
+
 		switch req.Type {
 		case ResumeTypeApprove:
-			slog.Debug("Resume signal received, approving tool", "tool", toolName, "session_id", sess.ID)
-			runTool()
+			slog.Debug("Resume signal received, approving tool", "tool", toolName, "session_id", sess.ID, "edited", edited)
+			if !edited {
+				// Edited calls are not remembered, the model's arguments weren't approved.
+				r.rememberApproval(sess, toolCall, time.Now())
+			}
+			runTool(toolCall)
 		case ResumeTypeApproveSession:
 			slog.Debug("Resume signal received, approving session", "tool", toolName, "session_id", sess.ID)
 			sess.ToolsApproved = true
-			runTool()
+			runTool(toolCall)
 		case ResumeTypeApproveTool:
 			// Add the tool to session's allow list for future auto-approval
 			approvedTool := req.ToolName
@@ -1766,7 +2032,7 @@ func (r *LocalRuntime) askUserForConfirmation(
 				sess.Permissions.Allow = append(sess.Permissions.Allow, approvedTool)
 			}
 			slog.Debug("Resume signal received, approving tool permanently", "tool", approvedTool, "session_id", sess.ID)
-			runTool()
+			runTool(toolCall)
 		case ResumeTypeReject:
 			slog.Debug("Resume signal received, rejecting tool", "tool", toolName, "session_id", sess.ID, "reason", req.Reason)
 			rejectMsg := "The user rejected the tool call."
@@ -1809,8 +2075,13 @@ func (r *LocalRuntime) executeToolWithHandler(
 
 	telemetry.RecordToolCall(ctx, toolCall.Function.Name, sess.ID, a.Name(), duration, err)
 
+	var timeoutErr *toolTimeoutError
 	if err != nil {
-		if errors.Is(err, context.Canceled) || errors.Is(ctx.Err(), context.Canceled) {
+		if errors.As(err, &timeoutErr) {
+			slog.Warn("Tool call timed out", "tool", toolCall.Function.Name, "agent", a.Name(), "session_id", sess.ID, "timeout", timeoutErr.timeout)
+			res = tools.ResultError(fmt.Sprintf("The tool call timed out after %s and was aborted.", timeoutErr.timeout))
+			span.SetStatus(codes.Error, "tool handler timed out")
+		} else if errors.Is(err, context.Canceled) || errors.Is(ctx.Err(), context.Canceled) {
 			slog.Debug("Tool handler canceled by context", "tool", toolCall.Function.Name, "agent", a.Name(), "session_id", sess.ID)
 			res = tools.ResultError("The tool call was canceled by the user.")
 			span.SetStatus(codes.Ok, "tool handler canceled by user")
@@ -1826,6 +2097,9 @@ func (r *LocalRuntime) executeToolWithHandler(
 	}
 
 	events <- ToolCallResponse(toolCall, tool, res, res.Output, a.Name())
+	if timeoutErr != nil {
+		events <- ToolTimeout(toolCall, tool, timeoutErr.timeout, a.Name())
+	}
 
 	// Ensure tool response content is not empty for API compatibility
 	content := res.Output
@@ -1908,7 +2182,10 @@ func (r *LocalRuntime) runTool(ctx context.Context, tool tools.Tool, toolCall to
 
 	r.executeToolWithHandler(ctx, toolCall, tool, events, sess, a, "runtime.tool.handler",
 		func(ctx context.Context) (*tools.ToolCallResult, time.Duration, error) {
-			res, err := tool.Handler(ctx, toolCall)
+			// Session scoped env overrides travel with the context so that
+			// toolsets shared between sessions never see each other's values.
+			ctx = environment.WithOverrides(ctx, sess.GetEnvOverrides())
+			res, err := callWithTimeout(ctx, r.toolTimeout(tool), tool.Handler, toolCall)
 			return res, 0, err
 		})
 
@@ -1972,9 +2249,10 @@ func (r *LocalRuntime) addToolErrorResponse(_ context.Context, sess *session.Ses
 	addAgentMessage(sess, a, &toolResponseMsg, events)
 }
 
-// startSpan wraps tracer.Start, returning a no-op span if the tracer is nil.
+// startSpan wraps tracer.Start, returning a no-op span if the tracer is nil
+// or the user opted out of telemetry.
 func (r *LocalRuntime) startSpan(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
-	if r.tracer == nil {
+	if r.tracer == nil || telemetry.UserOptedOut() {
 		return ctx, trace.SpanFromContext(ctx)
 	}
 	return r.tracer.Start(ctx, name, opts...)
@@ -2009,6 +2287,13 @@ func (r *LocalRuntime) RunAgent(ctx context.Context, params agenttool.RunParams)
 
 	sess := params.ParentSession
 
+	// Background agents count as a transfer, so that they can't be used to
+	// get around the team's maximum transfer depth.
+	if maxDepth := r.team.MaxTransferDepth(); sess.TransferDepth >= maxDepth {
+		slog.Warn("Transfer depth exceeded", "to_agent", params.AgentName, "max_depth", maxDepth, "session_id", sess.ID)
+		return &agenttool.RunResult{ErrMsg: fmt.Sprintf("cannot run agent %s: the maximum transfer depth of %d is reached", params.AgentName, maxDepth)}
+	}
+
 	// Background tasks run with tools pre-approved because there is no user present
 	// to respond to interactive approval prompts during async execution. This is a
 	// deliberate design trade-off: the user implicitly authorises all tool calls made
@@ -2027,7 +2312,10 @@ func (r *LocalRuntime) RunAgent(ctx context.Context, params agenttool.RunParams)
 		session.WithSendUserMessage(false),
 		session.WithParentID(sess.ID),
 		session.WithAgentName(params.AgentName),
+		session.WithTransferDepth(sess.TransferDepth+1),
 	)
+	s.SetDateOverride(sess.GetDateOverride())
+	s.EnvOverrides = sess.GetEnvOverrides()
 
 	var errMsg string
 	events := r.RunStream(ctx, s)
@@ -2088,6 +2376,14 @@ func (r *LocalRuntime) handleTaskTransfer(ctx context.Context, sess *session.Ses
 		return tools.ResultError(errorMsg), nil
 	}
 
+	// Refuse to nest transfers deeper than the team allows, to stop runaway
+	// delegation chains.
+	if maxDepth := r.team.MaxTransferDepth(); sess.TransferDepth >= maxDepth {
+		slog.Warn("Transfer depth exceeded", "from_agent", a.Name(), "to_agent", params.Agent, "max_depth", maxDepth, "session_id", sess.ID)
+		evts <- TransferDepthExceeded(a.Name(), params.Agent, maxDepth)
+		return tools.ResultError(fmt.Sprintf("Agent %s cannot transfer task to %s: the maximum transfer depth of %d is reached. Complete the task without transferring it.", a.Name(), params.Agent, maxDepth)), nil
+	}
+
 	// Span for task transfer (optional)
 	ctx, span := r.startSpan(ctx, "runtime.task_transfer", trace.WithAttributes(
 		attribute.String("from.agent", a.Name()),
@@ -2143,7 +2439,10 @@ func (r *LocalRuntime) handleTaskTransfer(ctx context.Context, sess *session.Ses
 		session.WithThinking(sess.Thinking),
 		session.WithSendUserMessage(false),
 		session.WithParentID(sess.ID),
+		session.WithTransferDepth(sess.TransferDepth+1),
 	)
+	s.SetDateOverride(sess.GetDateOverride())
+	s.EnvOverrides = sess.GetEnvOverrides()
 
 	return r.runSubSession(ctx, sess, s, span, evts, a.Name())
 }
@@ -17,6 +17,7 @@ import (
 	"github.com/docker/cagent/pkg/agent"
 	"github.com/docker/cagent/pkg/chat"
 	"github.com/docker/cagent/pkg/config/latest"
+	"github.com/docker/cagent/pkg/model/provider"
 	"github.com/docker/cagent/pkg/model/provider/base"
 	"github.com/docker/cagent/pkg/modelsdev"
 	"github.com/docker/cagent/pkg/permissions"
@@ -27,6 +28,7 @@ import (
 	"github.com/docker/cagent/pkg/session"
 	"github.com/docker/cagent/pkg/team"
 	"github.com/docker/cagent/pkg/tools"
+	agenttool "github.com/docker/cagent/pkg/tools/builtin/agent"
 )
 
 type stubToolSet struct {
@@ -825,6 +827,44 @@ func TestGetTools_WarningHandling(t *testing.T) {
 	}
 }
 
+func TestAgentDetails_ToolsetStatuses(t *testing.T) {
+	good := newStubToolSet(nil, []tools.Tool{{Name: "good", Parameters: map[string]any{}}}, nil)
+	bad := newStubToolSet(errors.New("boom"), nil, nil)
+	root := agent.New("root", "test", agent.WithToolSets(good, bad), agent.WithModel(&mockProvider{}))
+	rt, err := NewLocalRuntime(team.New(team.WithAgents(root)), WithModelStore(mockModelStore{}))
+	require.NoError(t, err)
+
+	statuses := func() []string {
+		var statuses []string
+		for _, ts := range rt.agentDetailsFromTeam(t.Context())[0].Toolsets {
+			statuses = append(statuses, ts.Status+" "+ts.Error)
+		}
+		return statuses
+	}
+	assert.Equal(t, []string{"loading ", "loading "}, statuses())
+
+	_, err = rt.getTools(t.Context(), root, trace.SpanFromContext(t.Context()), make(chan Event, 10))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ok ", "failed boom"}, statuses())
+}
+
+func TestAgentDetails_ToolDescriptions(t *testing.T) {
+	toolSet := newStubToolSet(nil, []tools.Tool{
+		{Name: "read", Description: "  Read a file.\n\nThe path is relative to the working directory.", Parameters: map[string]any{}},
+		{Name: "undocumented", Parameters: map[string]any{}},
+	}, nil)
+	root := agent.New("root", "test", agent.WithToolSets(toolSet), agent.WithModel(&mockProvider{}))
+	rt, err := NewLocalRuntime(team.New(team.WithAgents(root)), WithModelStore(mockModelStore{}))
+	require.NoError(t, err)
+
+	_, err = rt.getTools(t.Context(), root, trace.SpanFromContext(t.Context()), make(chan Event, 10))
+	require.NoError(t, err)
+
+	details := rt.agentDetailsFromTeam(t.Context())[0]
+	assert.Equal(t, []string{"read", "undocumented"}, details.ToolNames)
+	assert.Equal(t, map[string]string{"read": "Read a file."}, details.ToolDescriptions)
+}
+
 func TestNewRuntime_NoAgentsError(t *testing.T) {
 	tm := team.New()
 
@@ -1189,6 +1229,167 @@ func TestPermissions_AllowAutoApprovesTool(t *testing.T) {
 	require.True(t, executed, "expected tool to be auto-approved and executed")
 }
 
+func TestPermissions_AllowToolsetAutoApproves(t *testing.T) {
+	permChecker := permissions.NewChecker(&latest.PermissionsConfig{
+		AllowToolsets: []string{"think"},
+	})
+
+	var executed bool
+	agentTools := []tools.Tool{{
+		Name:       "think",
+		Category:   "think",
+		Toolset:    "think",
+		Parameters: map[string]any{},
+		Handler: func(ctx context.Context, tc tools.ToolCall) (*tools.ToolCallResult, error) {
+			executed = true
+			return tools.ResultSuccess("executed"), nil
+		},
+	}}
+
+	prov := &mockProvider{id: "test/mock-model", stream: &mockStream{}}
+	root := agent.New("root", "You are a test agent",
+		agent.WithModel(prov),
+		agent.WithToolSets(newStubToolSet(nil, agentTools, nil)),
+	)
+	tm := team.New(
+		team.WithAgents(root),
+		team.WithPermissions(permChecker),
+	)
+
+	rt, err := NewLocalRuntime(tm, WithSessionCompaction(false), WithModelStore(mockModelStore{}))
+	require.NoError(t, err)
+
+	sess := session.New(session.WithUserMessage("Test"))
+	require.False(t, sess.ToolsApproved)
+
+	calls := []tools.ToolCall{{
+		ID:       "call_1",
+		Type:     "function",
+		Function: tools.FunctionCall{Name: "think", Arguments: "{}"},
+	}}
+
+	events := make(chan Event, 10)
+	rt.processToolCalls(t.Context(), sess, calls, agentTools, events)
+	close(events)
+
+	require.True(t, executed, "expected tool from an allowlisted toolset to be auto-approved")
+}
+
+func TestPermissions_AllowToolsetMatchesToolsetType(t *testing.T) {
+	permChecker := permissions.NewChecker(&latest.PermissionsConfig{
+		AllowToolsets: []string{"mcp", "rag"},
+	})
+
+	var executed []string
+	handler := func(_ context.Context, tc tools.ToolCall) (*tools.ToolCallResult, error) {
+		executed = append(executed, tc.Function.Name)
+		return tools.ResultSuccess("executed"), nil
+	}
+	// The toolset type, not the category, decides: MCP tools have no
+	// category and RAG tools are in the "knowledge" one.
+	agentTools := []tools.Tool{
+		{Name: "github_search", Toolset: "mcp", Parameters: map[string]any{}, Handler: handler},
+		{Name: "search_docs", Category: "knowledge", Toolset: "rag", Parameters: map[string]any{}, Handler: handler},
+		{Name: "shell", Category: "shell", Toolset: "shell", Parameters: map[string]any{}, Handler: handler},
+	}
+
+	prov := &mockProvider{id: "test/mock-model", stream: &mockStream{}}
+	root := agent.New("root", "You are a test agent",
+		agent.WithModel(prov),
+		agent.WithToolSets(newStubToolSet(nil, agentTools, nil)),
+	)
+	tm := team.New(
+		team.WithAgents(root),
+		team.WithPermissions(permChecker),
+	)
+
+	rt, err := NewLocalRuntime(tm, WithSessionCompaction(false), WithModelStore(mockModelStore{}))
+	require.NoError(t, err)
+
+	sess := session.New(session.WithUserMessage("Test"))
+
+	calls := []tools.ToolCall{
+		{ID: "call_1", Type: "function", Function: tools.FunctionCall{Name: "github_search", Arguments: "{}"}},
+		{ID: "call_2", Type: "function", Function: tools.FunctionCall{Name: "search_docs", Arguments: "{}"}},
+		{ID: "call_3", Type: "function", Function: tools.FunctionCall{Name: "shell", Arguments: "{}"}},
+	}
+
+	events := make(chan Event, 10)
+	go func() {
+		rt.processToolCalls(t.Context(), sess, calls, agentTools, events)
+		close(events)
+	}()
+
+	var confirmed []string
+	for ev := range events {
+		if c, ok := ev.(*ToolCallConfirmationEvent); ok {
+			confirmed = append(confirmed, c.ToolCall.Function.Name)
+			rt.resumeChan <- ResumeReject("")
+		}
+	}
+
+	require.Equal(t, []string{"github_search", "search_docs"}, executed)
+	require.Equal(t, []string{"shell"}, confirmed)
+}
+
+func TestPermissions_AgentToolsetApproval(t *testing.T) {
+	var executed []string
+	handler := func(_ context.Context, tc tools.ToolCall) (*tools.ToolCallResult, error) {
+		executed = append(executed, tc.Function.Name)
+		return tools.ResultSuccess("executed"), nil
+	}
+	agentTools := []tools.Tool{
+		{Name: "shell", Category: "shell", Toolset: "shell", Parameters: map[string]any{}, Handler: handler},
+		{Name: "read_file", Category: "filesystem", Toolset: "filesystem", Parameters: map[string]any{}, Handler: handler, Annotations: tools.ToolAnnotations{ReadOnlyHint: true}},
+		// MCP tools have no category: they are matched on their toolset type
+		{Name: "create_issue", Toolset: "mcp", Parameters: map[string]any{}, Handler: handler},
+		// Script tools use the shell category but are not a shell toolset
+		{Name: "deploy", Category: "shell", Toolset: "script", Parameters: map[string]any{}, Handler: handler},
+	}
+
+	prov := &mockProvider{id: "test/mock-model", stream: &mockStream{}}
+	root := agent.New("root", "You are a test agent",
+		agent.WithModel(prov),
+		agent.WithToolSets(newStubToolSet(nil, agentTools, nil)),
+		agent.WithToolsetApproval(&latest.ToolsetApproval{
+			Allow: []string{"shell", "mcp"},
+			Ask:   []string{"filesystem"},
+		}),
+	)
+	tm := team.New(team.WithAgents(root))
+
+	rt, err := NewLocalRuntime(tm, WithSessionCompaction(false), WithModelStore(mockModelStore{}))
+	require.NoError(t, err)
+
+	sess := session.New(session.WithUserMessage("Test"))
+
+	calls := []tools.ToolCall{
+		{ID: "call_1", Type: "function", Function: tools.FunctionCall{Name: "shell", Arguments: "{}"}},
+		{ID: "call_2", Type: "function", Function: tools.FunctionCall{Name: "read_file", Arguments: "{}"}},
+		{ID: "call_3", Type: "function", Function: tools.FunctionCall{Name: "create_issue", Arguments: "{}"}},
+		{ID: "call_4", Type: "function", Function: tools.FunctionCall{Name: "deploy", Arguments: "{}"}},
+	}
+
+	events := make(chan Event, 10)
+	go func() {
+		rt.processToolCalls(t.Context(), sess, calls, agentTools, events)
+		close(events)
+	}()
+
+	// The approved toolsets run right away, the gated read-only tool and the
+	// tool from an unlisted toolset ask
+	var confirmed []string
+	for ev := range events {
+		if c, ok := ev.(*ToolCallConfirmationEvent); ok {
+			confirmed = append(confirmed, c.ToolCall.Function.Name)
+			rt.resumeChan <- ResumeReject("")
+		}
+	}
+
+	require.Equal(t, []string{"shell", "create_issue"}, executed)
+	require.Equal(t, []string{"read_file", "deploy"}, confirmed)
+}
+
 func TestPermissions_DenyTakesPriorityOverAllow(t *testing.T) {
 	// Test that deny patterns take priority over allow patterns
 	permChecker := permissions.NewChecker(&latest.PermissionsConfig{
@@ -1330,6 +1531,45 @@ func TestSessionPermissions_AllowAutoApprovesTool(t *testing.T) {
 	require.True(t, executed, "expected tool to be auto-approved by session permissions")
 }
 
+func TestSessionPermissions_AllowToolsetAutoApproves(t *testing.T) {
+	var executed bool
+	agentTools := []tools.Tool{{
+		Name:       "think",
+		Toolset:    "think",
+		Parameters: map[string]any{},
+		Handler: func(ctx context.Context, tc tools.ToolCall) (*tools.ToolCallResult, error) {
+			executed = true
+			return tools.ResultSuccess("executed"), nil
+		},
+	}}
+
+	prov := &mockProvider{id: "test/mock-model", stream: &mockStream{}}
+	root := agent.New("root", "You are a test agent",
+		agent.WithModel(prov),
+		agent.WithToolSets(newStubToolSet(nil, agentTools, nil)),
+	)
+
+	rt, err := NewLocalRuntime(team.New(team.WithAgents(root)), WithSessionCompaction(false), WithModelStore(mockModelStore{}))
+	require.NoError(t, err)
+
+	sess := session.New(
+		session.WithUserMessage("Test"),
+		session.WithPermissions(&session.PermissionsConfig{AllowToolsets: []string{"think"}}),
+	)
+
+	calls := []tools.ToolCall{{
+		ID:       "call_1",
+		Type:     "function",
+		Function: tools.FunctionCall{Name: "think", Arguments: "{}"},
+	}}
+
+	events := make(chan Event, 10)
+	rt.processToolCalls(t.Context(), sess, calls, agentTools, events)
+	close(events)
+
+	require.True(t, executed, "expected tool from a toolset allowed by the session to be auto-approved")
+}
+
 func TestSessionPermissions_TakePriorityOverTeamPermissions(t *testing.T) {
 	// Test that session permissions are evaluated before team permissions
 	// Team allows everything, but session denies specific tool
@@ -1498,6 +1738,62 @@ func TestToolRejectionWithoutReason(t *testing.T) {
 	require.NotContains(t, toolResponse.Response, "Reason:")
 }
 
+func TestToolApprovalWithEditedArguments(t *testing.T) {
+	// Test that a tool call approved with edited arguments runs with them,
+	// however it's approved.
+	for _, approval := range []ResumeRequest{
+		ResumeApprove(),
+		ResumeApproveTool("edit_file"),
+		ResumeApproveSession(),
+	} {
+		t.Run(string(approval.Type), func(t *testing.T) {
+			var gotArguments string
+			agentTools := []tools.Tool{{
+				Name:       "edit_file",
+				Parameters: map[string]any{},
+				Handler: func(_ context.Context, toolCall tools.ToolCall) (*tools.ToolCallResult, error) {
+					gotArguments = toolCall.Function.Arguments
+					return tools.ResultSuccess("ok"), nil
+				},
+			}}
+
+			prov := &mockProvider{id: "test/mock-model", stream: &mockStream{}}
+			root := agent.New("root", "You are a test agent",
+				agent.WithModel(prov),
+				agent.WithToolSets(newStubToolSet(nil, agentTools, nil)),
+			)
+			tm := team.New(team.WithAgents(root))
+
+			rt, err := NewLocalRuntime(tm, WithSessionCompaction(false), WithModelStore(mockModelStore{}))
+			require.NoError(t, err)
+
+			sess := session.New(session.WithUserMessage("Test"))
+
+			calls := []tools.ToolCall{{
+				ID:       "call_1",
+				Type:     "function",
+				Function: tools.FunctionCall{Name: "edit_file", Arguments: `{"path":"a.txt"}`},
+			}}
+
+			events := make(chan Event, 10)
+
+			go func() {
+				rt.processToolCalls(t.Context(), sess, calls, agentTools, events)
+				close(events)
+			}()
+
+			for ev := range events {
+				if _, ok := ev.(*ToolCallConfirmationEvent); ok {
+					approval.Arguments = `{"path":"b.txt"}`
+					rt.resumeChan <- approval
+				}
+			}
+
+			require.JSONEq(t, `{"path":"b.txt"}`, gotArguments)
+		})
+	}
+}
+
 func TestTransferTaskRejectsNonSubAgent(t *testing.T) {
 	// root has librarian as sub-agent but NOT planner.
 	// planner exists in the team. transfer_task to planner should be rejected.
@@ -1535,6 +1831,67 @@ func TestTransferTaskRejectsNonSubAgent(t *testing.T) {
 	assert.Equal(t, "root", rt.currentAgent, "current agent should remain root")
 }
 
+func TestTransferTaskRefusedBeyondMaxDepth(t *testing.T) {
+	prov := &mockProvider{id: "test/mock-model", stream: &mockStream{}}
+
+	librarian := agent.New("librarian", "Library agent", agent.WithModel(prov))
+	root := agent.New("root", "Root agent", agent.WithModel(prov))
+	agent.WithSubAgents(librarian)(root)
+
+	tm := team.New(team.WithAgents(root, librarian), team.WithMaxTransferDepth(2))
+
+	rt, err := NewLocalRuntime(tm, WithSessionCompaction(false), WithModelStore(mockModelStore{}))
+	require.NoError(t, err)
+
+	sess := session.New(session.WithUserMessage("Test"), session.WithTransferDepth(2))
+	evts := make(chan Event, 128)
+
+	toolCall := tools.ToolCall{
+		ID:   "call_1",
+		Type: "function",
+		Function: tools.FunctionCall{
+			Name:      "transfer_task",
+			Arguments: `{"agent":"librarian","task":"do something","expected_output":""}`,
+		},
+	}
+
+	result, err := rt.handleTaskTransfer(t.Context(), sess, toolCall, evts)
+	require.NoError(t, err)
+	require.NotNil(t, result)
+	assert.True(t, result.IsError)
+	assert.Contains(t, result.Output, "maximum transfer depth of 2")
+	assert.Equal(t, "root", rt.currentAgent, "current agent should remain root")
+
+	require.Len(t, evts, 1)
+	exceeded, ok := (<-evts).(*TransferDepthExceededEvent)
+	require.True(t, ok)
+	assert.Equal(t, "librarian", exceeded.ToAgent)
+	assert.Equal(t, 2, exceeded.MaxDepth)
+}
+
+func TestRunAgentIncrementsTransferDepth(t *testing.T) {
+	prov := &mockProvider{id: "test/mock-model", stream: newStreamBuilder().AddContent("done").AddStopWithUsage(10, 5).Build()}
+
+	librarian := agent.New("librarian", "Library agent", agent.WithModel(prov))
+	root := agent.New("root", "Root agent", agent.WithModel(prov))
+	tm := team.New(team.WithAgents(root, librarian), team.WithMaxTransferDepth(2))
+
+	rt, err := NewLocalRuntime(tm, WithSessionCompaction(false), WithModelStore(mockModelStore{}))
+	require.NoError(t, err)
+
+	sess := session.New(session.WithUserMessage("Test"), session.WithTransferDepth(1))
+	result := rt.RunAgent(t.Context(), agenttool.RunParams{AgentName: "librarian", Task: "do something", ParentSession: sess})
+	require.Empty(t, result.ErrMsg)
+
+	child := sess.Messages[len(sess.Messages)-1].SubSession
+	require.NotNil(t, child)
+	assert.Equal(t, 2, child.TransferDepth)
+
+	// The background agent is at the maximum depth: it can't start another one.
+	result = rt.RunAgent(t.Context(), agenttool.RunParams{AgentName: "librarian", Task: "do something", ParentSession: child})
+	assert.Contains(t, result.ErrMsg, "maximum transfer depth of 2")
+}
+
 func TestTransferTaskAllowsSubAgent(t *testing.T) {
 	// Verify that transfer_task to a valid sub-agent is NOT rejected by the validation.
 	// We can't fully run the child session without a real model, so we just confirm
@@ -1823,3 +2180,56 @@ func TestStripImageContent(t *testing.T) {
 		})
 	}
 }
+
+type namedProvider struct {
+	mockProvider
+	name string
+}
+
+func (p *namedProvider) BaseConfig() base.Config {
+	return base.Config{ModelConfig: latest.ModelConfig{Provider: p.name}}
+}
+
+func TestAcquireProviderSlot_EmitsQueuedEvent(t *testing.T) {
+	limiter := provider.NewConcurrencyLimiter(map[string]int{"test": 1})
+	prov := &namedProvider{mockProvider: mockProvider{id: "test/mock-model"}, name: "test"}
+	root := agent.New("root", "You are a test agent", agent.WithModel(prov))
+
+	rt, err := NewLocalRuntime(team.New(team.WithAgents(root)),
+		WithSessionCompaction(false),
+		WithModelStore(mockModelStore{}),
+		WithProviderConcurrency(limiter),
+	)
+	require.NoError(t, err)
+
+	events := make(chan Event, 10)
+	release, err := rt.acquireProviderSlot(t.Context(), root, prov, events)
+	require.NoError(t, err)
+	require.Empty(t, events)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		release2, err := rt.acquireProviderSlot(t.Context(), root, prov, events)
+		assert.NoError(t, err)
+		release2()
+	}()
+
+	ev := <-events
+	queued, ok := ev.(*QueuedForProviderEvent)
+	require.True(t, ok, "expected a QueuedForProviderEvent, got %T", ev)
+	assert.Equal(t, "test", queued.Provider)
+	assert.Equal(t, "test/mock-model", queued.Model)
+	assert.Equal(t, 1, queued.Limit)
+
+	release()
+	<-done
+}
+
+func TestTokensPerSecond(t *testing.T) {
+	t.Parallel()
+
+	assert.InDelta(t, 50.0, tokensPerSecond(100, 2*time.Second), 0.001)
+	assert.Zero(t, tokensPerSecond(0, 2*time.Second))
+	assert.Zero(t, tokensPerSecond(100, 10*time.Millisecond), "too short to be measured")
+}
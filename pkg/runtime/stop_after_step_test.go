@@ -0,0 +1,74 @@
+package runtime
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/docker/cagent/pkg/agent"
+	"github.com/docker/cagent/pkg/chat"
+	"github.com/docker/cagent/pkg/model/provider/base"
+	"github.com/docker/cagent/pkg/session"
+	"github.com/docker/cagent/pkg/team"
+	"github.com/docker/cagent/pkg/tools"
+)
+
+// toolLoopProvider asks for the same tool call on every model call.
+type toolLoopProvider struct {
+	calls int
+}
+
+func (p *toolLoopProvider) ID() string { return "test/tool-loop" }
+
+func (p *toolLoopProvider) CreateChatCompletionStream(context.Context, []chat.Message, []tools.Tool) (chat.MessageStream, error) {
+	p.calls++
+	return newStreamBuilder().
+		AddToolCallName("call_1", "work").
+		AddToolCallArguments("call_1", "{}").
+		AddStopWithUsage(5, 5).
+		Build(), nil
+}
+
+func (p *toolLoopProvider) BaseConfig() base.Config { return base.Config{} }
+
+func (p *toolLoopProvider) MaxTokens() int { return 0 }
+
+func TestStopAfterCurrentStep(t *testing.T) {
+	prov := &toolLoopProvider{}
+	var rt *LocalRuntime
+	var toolRuns int
+	agentTools := []tools.Tool{{
+		Name:       "work",
+		Parameters: map[string]any{},
+		Handler: func(context.Context, tools.ToolCall) (*tools.ToolCallResult, error) {
+			toolRuns++
+			rt.StopAfterCurrentStep()
+			return tools.ResultSuccess("done"), nil
+		},
+	}}
+	root := agent.New("root", "test",
+		agent.WithModel(prov),
+		agent.WithToolSets(newStubToolSet(nil, agentTools, nil)),
+	)
+
+	var err error
+	rt, err = NewLocalRuntime(team.New(team.WithAgents(root)), WithSessionCompaction(false), WithModelStore(mockModelStore{}))
+	require.NoError(t, err)
+
+	run := func() {
+		sess := session.New(session.WithUserMessage("work"), session.WithToolsApproved(true))
+		sess.Title = "Stop After Step Test"
+		for range rt.RunStream(t.Context(), sess) {
+		}
+	}
+
+	run()
+	assert.Equal(t, 1, prov.calls, "no model call after the step that asked to stop")
+	assert.Equal(t, 1, toolRuns, "the tool call of the current step completes")
+
+	run()
+	assert.Equal(t, 2, prov.calls, "a new run starts afresh")
+	assert.Equal(t, 2, toolRuns)
+}
@@ -0,0 +1,92 @@
+package runtime
+
+import (
+	"fmt"
+	"log/slog"
+	"slices"
+	"unicode/utf8"
+
+	"github.com/docker/go-units"
+
+	"github.com/docker/cagent/pkg/chat"
+	"github.com/docker/cagent/pkg/tools"
+)
+
+// anyToolsetType is the key of the tool result limit applied to toolset
+// types that don't have their own.
+const anyToolsetType = "*"
+
+// toolResultLimit returns the maximum size, in bytes, of the results of tools
+// from the given toolset type, or 0 if they aren't limited.
+func (r *LocalRuntime) toolResultLimit(toolsetType string) int {
+	if limit, ok := r.toolResultLimits[toolsetType]; ok {
+		return limit
+	}
+	return r.toolResultLimits[anyToolsetType]
+}
+
+// capToolResults returns the messages to send to the model with the tool
+// results capped to the limit of the toolset type of the tool that produced
+// them. The session keeps the full results.
+func (r *LocalRuntime) capToolResults(messages []chat.Message, agentTools []tools.Tool) []chat.Message {
+	if len(r.toolResultLimits) == 0 {
+		return messages
+	}
+
+	toolsetTypes := make(map[string]string, len(agentTools))
+	for _, tool := range agentTools {
+		toolsetTypes[tool.Name] = tool.Toolset
+	}
+	toolNames := make(map[string]string)
+	for _, msg := range messages {
+		for _, call := range msg.ToolCalls {
+			toolNames[call.ID] = call.Function.Name
+		}
+	}
+
+	var result []chat.Message
+	for i, msg := range messages {
+		if msg.Role != chat.MessageRoleTool {
+			continue
+		}
+		toolName := toolNames[msg.ToolCallID]
+		limit := r.toolResultLimit(toolsetTypes[toolName])
+		if limit <= 0 || len(msg.Content) <= limit {
+			continue
+		}
+
+		slog.Debug("Truncating tool result", "tool", toolName, "toolset", toolsetTypes[toolName], "size", len(msg.Content), "limit", limit)
+		if result == nil {
+			result = slices.Clone(messages)
+		}
+		result[i].Content = capToolResult(msg.Content, limit)
+		if len(msg.MultiContent) > 0 {
+			parts := slices.Clone(msg.MultiContent)
+			for j := range parts {
+				if parts[j].Type == chat.MessagePartTypeText {
+					parts[j].Text = capToolResult(parts[j].Text, limit)
+				}
+			}
+			result[i].MultiContent = parts
+		}
+	}
+
+	if result == nil {
+		return messages
+	}
+	return result
+}
+
+// capToolResult truncates content to at most limit bytes, on a rune
+// boundary, and appends a notice with the size of what was dropped.
+func capToolResult(content string, limit int) string {
+	if limit <= 0 || len(content) <= limit {
+		return content
+	}
+
+	cut := limit
+	for cut > 0 && !utf8.RuneStart(content[cut]) {
+		cut--
+	}
+	return fmt.Sprintf("%s\n…[%s truncated]", content[:cut], units.HumanSize(float64(len(content)-cut)))
+}
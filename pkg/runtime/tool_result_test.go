@@ -0,0 +1,105 @@
+package runtime
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/docker/cagent/pkg/agent"
+	"github.com/docker/cagent/pkg/chat"
+	"github.com/docker/cagent/pkg/session"
+	"github.com/docker/cagent/pkg/team"
+	"github.com/docker/cagent/pkg/tools"
+)
+
+func TestCapToolResult(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "short", capToolResult("short", 10))
+	assert.Equal(t, "unlimited", capToolResult("unlimited", 0))
+
+	capped := capToolResult(strings.Repeat("a", 12100), 100)
+	assert.Equal(t, strings.Repeat("a", 100)+"\n…[12kB truncated]", capped)
+
+	// Never cut in the middle of a rune
+	capped = capToolResult("aé", 2)
+	assert.True(t, strings.HasPrefix(capped, "a\n…["), capped)
+}
+
+func TestToolResultLimits_CapsWhatTheModelSees(t *testing.T) {
+	t.Parallel()
+
+	output := strings.Repeat("x", 1000)
+	agentTools := []tools.Tool{
+		{
+			Name:        "run",
+			Category:    "shell",
+			Toolset:     "shell",
+			Parameters:  map[string]any{},
+			Annotations: tools.ToolAnnotations{ReadOnlyHint: true},
+			Handler: func(context.Context, tools.ToolCall) (*tools.ToolCallResult, error) {
+				return tools.ResultSuccess(output), nil
+			},
+		},
+		{
+			Name:        "think",
+			Category:    "think",
+			Toolset:     "think",
+			Parameters:  map[string]any{},
+			Annotations: tools.ToolAnnotations{ReadOnlyHint: true},
+			Handler: func(context.Context, tools.ToolCall) (*tools.ToolCallResult, error) {
+				return tools.ResultSuccess(output), nil
+			},
+		},
+	}
+
+	prov := &mockProvider{id: "test/mock-model", stream: &mockStream{}}
+	root := agent.New("root", "You are a test agent",
+		agent.WithModel(prov),
+		agent.WithToolSets(newStubToolSet(nil, agentTools, nil)),
+	)
+	rt, err := NewLocalRuntime(team.New(team.WithAgents(root)),
+		WithSessionCompaction(false),
+		WithModelStore(mockModelStore{}),
+		WithToolResultLimits(map[string]int{"shell": 100, "*": 500}),
+	)
+	require.NoError(t, err)
+
+	sess := session.New(session.WithUserMessage("Test"))
+	calls := []tools.ToolCall{
+		{ID: "call_1", Type: "function", Function: tools.FunctionCall{Name: "run", Arguments: "{}"}},
+		{ID: "call_2", Type: "function", Function: tools.FunctionCall{Name: "think", Arguments: "{}"}},
+	}
+	sess.AddMessage(session.NewAgentMessage(root, &chat.Message{Role: chat.MessageRoleAssistant, ToolCalls: calls}))
+
+	events := make(chan Event, 20)
+	rt.processToolCalls(t.Context(), sess, calls, agentTools, events)
+	close(events)
+
+	for ev := range events {
+		if resp, ok := ev.(*ToolCallResponseEvent); ok {
+			assert.Equal(t, output, resp.Response, "the UI gets the full result")
+		}
+	}
+
+	stored := map[string]string{}
+	for _, msg := range sess.GetAllMessages() {
+		if msg.Message.Role == chat.MessageRoleTool {
+			stored[msg.Message.ToolCallID] = msg.Message.Content
+		}
+	}
+	assert.Equal(t, output, stored["call_1"], "the session keeps the full result")
+	assert.Equal(t, output, stored["call_2"], "the session keeps the full result")
+
+	sent := map[string]string{}
+	for _, msg := range rt.capToolResults(sess.GetMessages(root), agentTools) {
+		if msg.Role == chat.MessageRoleTool {
+			sent[msg.ToolCallID] = msg.Content
+		}
+	}
+	assert.Equal(t, strings.Repeat("x", 100)+"\n…[900B truncated]", sent["call_1"])
+	assert.Equal(t, strings.Repeat("x", 500)+"\n…[500B truncated]", sent["call_2"])
+}
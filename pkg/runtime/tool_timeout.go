@@ -0,0 +1,77 @@
+package runtime
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/docker/cagent/pkg/tools"
+)
+
+// interactiveToolsetTypes wait for the user, who can take as long as they
+// want: the "*" timeout doesn't apply to them.
+var interactiveToolsetTypes = []string{"user_prompt"}
+
+// toolTimeoutError is returned by a tool call that ran for longer than the
+// timeout of its toolset type.
+type toolTimeoutError struct {
+	timeout time.Duration
+}
+
+func (e *toolTimeoutError) Error() string {
+	return fmt.Sprintf("the tool call timed out after %s", e.timeout)
+}
+
+// toolTimeout returns how long a tool can run, or 0 if it can run forever.
+// The timeout of its toolset in the agent config comes first, then the one of
+// its toolset type and finally the "*" one. Tools don't time out by default.
+func (r *LocalRuntime) toolTimeout(tool tools.Tool) time.Duration {
+	if tool.Timeout > 0 {
+		return tool.Timeout
+	}
+	if timeout, ok := r.toolTimeouts[tool.Toolset]; ok {
+		return timeout
+	}
+	if slices.Contains(interactiveToolsetTypes, tool.Toolset) {
+		return 0
+	}
+	return r.toolTimeouts[anyToolsetType]
+}
+
+// callWithTimeout calls the handler of a tool and gives up after timeout.
+// The context of the handler is then canceled, but a handler that ignores
+// it is left running in the background until it returns: the conversation
+// doesn't wait for it.
+func callWithTimeout(ctx context.Context, timeout time.Duration, handler tools.ToolHandler, toolCall tools.ToolCall) (*tools.ToolCallResult, error) {
+	if timeout <= 0 {
+		return handler(ctx, toolCall)
+	}
+
+	timeoutErr := &toolTimeoutError{timeout: timeout}
+	ctx, cancel := context.WithTimeoutCause(ctx, timeout, timeoutErr)
+	defer cancel()
+
+	type result struct {
+		res *tools.ToolCallResult
+		err error
+	}
+	done := make(chan result, 1)
+	go func() {
+		res, err := handler(ctx, toolCall)
+		done <- result{res, err}
+	}()
+
+	select {
+	case r := <-done:
+		if r.err != nil && context.Cause(ctx) == timeoutErr {
+			return nil, timeoutErr
+		}
+		return r.res, r.err
+	case <-ctx.Done():
+		if context.Cause(ctx) == timeoutErr {
+			return nil, timeoutErr
+		}
+		return nil, ctx.Err()
+	}
+}
@@ -0,0 +1,120 @@
+package runtime
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/docker/cagent/pkg/agent"
+	"github.com/docker/cagent/pkg/chat"
+	"github.com/docker/cagent/pkg/session"
+	"github.com/docker/cagent/pkg/team"
+	"github.com/docker/cagent/pkg/tools"
+)
+
+func TestToolTimeout(t *testing.T) {
+	t.Parallel()
+
+	r := &LocalRuntime{}
+	assert.Equal(t, time.Duration(0), r.toolTimeout(tools.Tool{Toolset: "shell"}), "no timeout by default")
+
+	r.toolTimeouts = map[string]time.Duration{"mcp": time.Minute, "think": 0, "*": time.Hour}
+	assert.Equal(t, time.Minute, r.toolTimeout(tools.Tool{Toolset: "mcp"}))
+	assert.Equal(t, time.Duration(0), r.toolTimeout(tools.Tool{Toolset: "think"}))
+	assert.Equal(t, time.Hour, r.toolTimeout(tools.Tool{Toolset: "shell"}))
+
+	// Interactive tools only time out when asked explicitly.
+	assert.Equal(t, time.Duration(0), r.toolTimeout(tools.Tool{Toolset: "user_prompt"}))
+	r.toolTimeouts["user_prompt"] = time.Second
+	assert.Equal(t, time.Second, r.toolTimeout(tools.Tool{Toolset: "user_prompt"}))
+
+	// The timeout of the toolset from the agent config comes first.
+	assert.Equal(t, 5*time.Second, r.toolTimeout(tools.Tool{Toolset: "mcp", Timeout: 5 * time.Second}))
+}
+
+func TestToolTimeouts_AbortHangingTools(t *testing.T) {
+	t.Parallel()
+
+	release := make(chan struct{})
+	t.Cleanup(func() { close(release) })
+
+	agentTools := []tools.Tool{
+		{
+			// Ignores the cancellation of its context
+			Name:        "hang",
+			Toolset:     "mcp",
+			Parameters:  map[string]any{},
+			Annotations: tools.ToolAnnotations{ReadOnlyHint: true},
+			Handler: func(context.Context, tools.ToolCall) (*tools.ToolCallResult, error) {
+				<-release
+				return tools.ResultSuccess("too late"), nil
+			},
+		},
+		{
+			Name:        "wait",
+			Toolset:     "shell",
+			Parameters:  map[string]any{},
+			Annotations: tools.ToolAnnotations{ReadOnlyHint: true},
+			Handler: func(ctx context.Context, _ tools.ToolCall) (*tools.ToolCallResult, error) {
+				<-ctx.Done()
+				return nil, ctx.Err()
+			},
+		},
+		{
+			Name:        "quick",
+			Toolset:     "think",
+			Parameters:  map[string]any{},
+			Annotations: tools.ToolAnnotations{ReadOnlyHint: true},
+			Handler: func(context.Context, tools.ToolCall) (*tools.ToolCallResult, error) {
+				return tools.ResultSuccess("done"), nil
+			},
+		},
+	}
+
+	prov := &mockProvider{id: "test/mock-model", stream: &mockStream{}}
+	root := agent.New("root", "You are a test agent",
+		agent.WithModel(prov),
+		agent.WithToolSets(newStubToolSet(nil, agentTools, nil)),
+	)
+	rt, err := NewLocalRuntime(team.New(team.WithAgents(root)),
+		WithSessionCompaction(false),
+		WithModelStore(mockModelStore{}),
+		WithToolTimeouts(map[string]time.Duration{"*": 50 * time.Millisecond}),
+	)
+	require.NoError(t, err)
+
+	sess := session.New(session.WithUserMessage("Test"))
+	calls := []tools.ToolCall{
+		{ID: "call_1", Type: "function", Function: tools.FunctionCall{Name: "hang", Arguments: "{}"}},
+		{ID: "call_2", Type: "function", Function: tools.FunctionCall{Name: "wait", Arguments: "{}"}},
+		{ID: "call_3", Type: "function", Function: tools.FunctionCall{Name: "quick", Arguments: "{}"}},
+	}
+
+	events := make(chan Event, 30)
+	rt.processToolCalls(t.Context(), sess, calls, agentTools, events)
+	close(events)
+
+	var timedOut []string
+	for ev := range events {
+		if timeout, ok := ev.(*ToolTimeoutEvent); ok {
+			assert.Equal(t, 50*time.Millisecond, timeout.Timeout)
+			timedOut = append(timedOut, timeout.ToolCall.ID)
+		}
+	}
+	assert.Equal(t, []string{"call_1", "call_2"}, timedOut)
+
+	results := map[string]chat.Message{}
+	for _, msg := range sess.GetAllMessages() {
+		if msg.Message.Role == chat.MessageRoleTool {
+			results[msg.Message.ToolCallID] = msg.Message
+		}
+	}
+	for _, id := range []string{"call_1", "call_2"} {
+		assert.True(t, results[id].IsError)
+		assert.Equal(t, "The tool call timed out after 50ms and was aborted.", results[id].Content)
+	}
+	assert.Equal(t, "done", results["call_3"].Content)
+}
@@ -3,6 +3,7 @@ package server
 import (
 	"cmp"
 	"context"
+	"crypto/subtle"
 	"encoding/json"
 	"fmt"
 	"log/slog"
@@ -25,10 +26,33 @@ type Server struct {
 	sm *SessionManager
 }
 
-func New(ctx context.Context, sessionStore session.Store, runConfig *config.RuntimeConfig, refreshInterval time.Duration, agentSources config.Sources) (*Server, error) {
+type options struct {
+	authToken string
+}
+
+// Opt configures optional server behavior.
+type Opt func(*options)
+
+// WithAuthToken requires every API request, except the health check, to carry
+// an "Authorization: Bearer <token>" header.
+func WithAuthToken(token string) Opt {
+	return func(o *options) {
+		o.authToken = token
+	}
+}
+
+func New(ctx context.Context, sessionStore session.Store, runConfig *config.RuntimeConfig, refreshInterval time.Duration, agentSources config.Sources, opts ...Opt) (*Server, error) {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+
 	e := echo.New()
 	e.Use(middleware.RequestLogger())
 	e.Use(echo.WrapMiddleware(upstream.Handler))
+	if o.authToken != "" {
+		e.Use(bearerAuth(o.authToken))
+	}
 
 	s := &Server{
 		e:  e,
@@ -76,6 +100,21 @@ func New(ctx context.Context, sessionStore session.Store, runConfig *config.Runt
 	return s, nil
 }
 
+// bearerAuth rejects requests that don't present the expected bearer token.
+func bearerAuth(token string) echo.MiddlewareFunc {
+	return middleware.KeyAuthWithConfig(middleware.KeyAuthConfig{
+		Skipper: func(c echo.Context) bool {
+			return c.Path() == "/api/ping"
+		},
+		Validator: func(key string, _ echo.Context) (bool, error) {
+			return subtle.ConstantTimeCompare([]byte(key), []byte(token)) == 1, nil
+		},
+		ErrorHandler: func(error, echo.Context) error {
+			return echo.NewHTTPError(http.StatusUnauthorized, "Unauthorized")
+		},
+	})
+}
+
 func (s *Server) Serve(ctx context.Context, ln net.Listener) error {
 	srv := http.Server{
 		Handler: s.e,
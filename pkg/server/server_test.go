@@ -7,6 +7,7 @@ import (
 	"io"
 	"net"
 	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"strings"
@@ -235,3 +236,25 @@ func (s mockStore) GetSessions(context.Context) ([]*session.Session, error) {
 func (s mockStore) GetSessionSummaries(context.Context) ([]session.Summary, error) {
 	return nil, nil
 }
+
+func TestServer_AuthToken(t *testing.T) {
+	t.Parallel()
+
+	srv, err := New(t.Context(), mockStore{}, &config.RuntimeConfig{}, 0, nil, WithAuthToken("s3cret"))
+	require.NoError(t, err)
+
+	do := func(path, authorization string) int {
+		req := httptest.NewRequest(http.MethodGet, path, http.NoBody)
+		if authorization != "" {
+			req.Header.Set("Authorization", authorization)
+		}
+		rec := httptest.NewRecorder()
+		srv.e.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	assert.Equal(t, http.StatusOK, do("/api/ping", ""), "health check stays open")
+	assert.Equal(t, http.StatusUnauthorized, do("/api/sessions", ""))
+	assert.Equal(t, http.StatusUnauthorized, do("/api/sessions", "Bearer wrong"))
+	assert.Equal(t, http.StatusOK, do("/api/sessions", "Bearer s3cret"))
+}
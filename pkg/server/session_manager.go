@@ -20,6 +20,7 @@ import (
 	"github.com/docker/cagent/pkg/team"
 	"github.com/docker/cagent/pkg/teamloader"
 	"github.com/docker/cagent/pkg/tools"
+	"github.com/docker/cagent/pkg/userconfig"
 )
 
 type activeRuntimes struct {
@@ -373,7 +374,12 @@ func (sm *SessionManager) runtimeForSession(ctx context.Context, sess *session.S
 		return nil, nil, err
 	}
 
-	titleGen := sessiontitle.New(agent.Model(), agent.FallbackModels()...)
+	// Without LLM title generation, the first user message is the title,
+	// as in the TUI and cagent run.
+	titleGen := sessiontitle.NewFromMessage()
+	if userconfig.Get().GetGenerateTitles() {
+		titleGen = sessiontitle.New(agent.Model(), agent.FallbackModels()...)
+	}
 
 	sm.runtimeSessions.Store(sess.ID, &activeRuntimes{
 		runtime:  run,
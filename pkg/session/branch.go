@@ -42,6 +42,34 @@ func BranchSession(parent *Session, branchAtPosition int) (*Session, error) {
 	return branched, nil
 }
 
+// DuplicateSession creates an independent deep copy of the session, with a
+// new ID and creation time and a title marked as a copy.
+func DuplicateSession(src *Session) (*Session, error) {
+	if src == nil {
+		return nil, fmt.Errorf("session is nil")
+	}
+
+	duplicated := New()
+	title := src.Title
+	if title != "" {
+		title += " (copy)"
+	}
+	copySessionMetadata(duplicated, src, title)
+
+	duplicated.Messages = make([]Item, 0, len(src.Messages))
+	for _, item := range src.Messages {
+		cloned, err := cloneSessionItem(item)
+		if err != nil {
+			return nil, err
+		}
+		duplicated.Messages = append(duplicated.Messages, cloned)
+	}
+
+	setParentIDs(duplicated)
+	recalculateSessionTotals(duplicated)
+	return duplicated, nil
+}
+
 func cloneSessionItem(item Item) (Item, error) {
 	switch {
 	case item.Message != nil:
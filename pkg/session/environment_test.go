@@ -7,6 +7,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/docker/cagent/pkg/agent"
 )
 
 func TestGetEnvironmentInfo(t *testing.T) {
@@ -95,3 +97,17 @@ func TestGetEnvironmentInfoIntegration(t *testing.T) {
 		assert.Contains(t, info, "Is directory a git repo: No")
 	}
 }
+
+func TestEnvironmentInfoUsesAgentWorkingDir(t *testing.T) {
+	sess := New(WithWorkingDir("/work/project"))
+
+	a := agent.New("docs", "Write the docs", agent.WithAddEnvironmentInfo(true), agent.WithWorkingDir("/work/project/docs"))
+	messages := buildContextSpecificSystemMessages(a, sess)
+	require.NotEmpty(t, messages)
+	assert.Contains(t, messages[0].Content, "Working directory: /work/project/docs")
+
+	a = agent.New("root", "Be good", agent.WithAddEnvironmentInfo(true))
+	messages = buildContextSpecificSystemMessages(a, sess)
+	require.NotEmpty(t, messages)
+	assert.Contains(t, messages[0].Content, "Working directory: /work/project\n")
+}
@@ -332,6 +332,18 @@ func getAllMigrations() []Migration {
 			Description: "Add index on session_items(session_id, item_type) to speed up session summary message counts",
 			UpSQL:       `CREATE INDEX IF NOT EXISTS idx_session_items_session_type ON session_items(session_id, item_type)`,
 		},
+		{
+			ID:          19,
+			Name:        "019_add_cost_reset_position_column",
+			Description: "Add cost_reset_position column to sessions table for persisting cost resets",
+			UpSQL:       `ALTER TABLE sessions ADD COLUMN cost_reset_position INTEGER DEFAULT 0`,
+		},
+		{
+			ID:          20,
+			Name:        "020_add_date_override_column",
+			Description: "Add date_override column to sessions table for persisting the /date override",
+			UpSQL:       `ALTER TABLE sessions ADD COLUMN date_override TEXT`,
+		},
 	}
 }
 
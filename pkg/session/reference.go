@@ -0,0 +1,42 @@
+package session
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// ReferenceScheme is the URI scheme of shareable session references.
+const ReferenceScheme = "cagent"
+
+// Reference identifies a session so it can be opened again. It is always
+// looked up in the session store given on the command line: references
+// never name a store, so opening one can't touch another database.
+type Reference struct {
+	// ID is a session ID or a relative reference such as "-1".
+	ID string
+}
+
+// URI returns the reference as a cagent://session/<id> URI.
+func (r Reference) URI() string {
+	u := url.URL{Scheme: ReferenceScheme, Host: "session", Path: "/" + r.ID}
+	return u.String()
+}
+
+// ParseReference parses a session reference: either a cagent://session/<id>
+// URI or a plain session ID or relative reference, returned as is.
+func ParseReference(ref string) (Reference, error) {
+	if !strings.HasPrefix(ref, ReferenceScheme+"://") {
+		return Reference{ID: ref}, nil
+	}
+
+	u, err := url.Parse(ref)
+	if err != nil {
+		return Reference{}, fmt.Errorf("invalid session reference %q: %w", ref, err)
+	}
+	id := strings.TrimPrefix(u.Path, "/")
+	if u.Host != "session" || id == "" || strings.Contains(id, "/") {
+		return Reference{}, fmt.Errorf("invalid session reference %q: expected %s://session/<id>", ref, ReferenceScheme)
+	}
+	return Reference{ID: id}, nil
+}
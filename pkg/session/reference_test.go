@@ -0,0 +1,35 @@
+package session
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReference_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	ref := Reference{ID: "3f2a-41"}
+	uri := ref.URI()
+	assert.Equal(t, "cagent://session/3f2a-41", uri)
+
+	parsed, err := ParseReference(uri)
+	require.NoError(t, err)
+	assert.Equal(t, ref, parsed)
+}
+
+func TestParseReference(t *testing.T) {
+	t.Parallel()
+
+	for _, plain := range []string{"3f2a-41", "-1"} {
+		ref, err := ParseReference(plain)
+		require.NoError(t, err)
+		assert.Equal(t, Reference{ID: plain}, ref)
+	}
+
+	for _, invalid := range []string{"cagent://session/", "cagent://other/3f2a", "cagent://session/a/b"} {
+		_, err := ParseReference(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
@@ -1,8 +1,11 @@
 package session
 
 import (
+	"cmp"
 	"log/slog"
+	"maps"
 	"os"
+	"slices"
 	"strings"
 	"sync"
 	"time"
@@ -52,7 +55,8 @@ func (si *Item) IsSubSession() bool {
 
 // Session represents the agent's state including conversation history and variables
 type Session struct {
-	// mu protects Messages from concurrent read/write access.
+	// mu protects Messages, EnvOverrides, Variables, RememberedApprovals
+	// and DateOverride from concurrent read/write access.
 	mu sync.RWMutex `json:"-"`
 
 	// ID is the unique identifier for the session
@@ -92,6 +96,30 @@ type Session struct {
 	// If 0, there is no limit
 	MaxIterations int `json:"max_iterations"`
 
+	// DateOverride, when set, replaces the current date injected into the
+	// system prompt. This makes date-dependent agents reproducible and lets
+	// users run "as of" a given day. Controlled by the /date command in the TUI.
+	DateOverride *time.Time `json:"date_override,omitempty"`
+
+	// EnvOverrides holds environment variables set for this session only.
+	// They are applied to the commands run by the shell and script tools.
+	// Controlled by the /env command in the TUI. Use SetEnvOverride and
+	// GetEnvOverrides to access it concurrently with a running agent. It's
+	// never marshalled: overrides commonly carry credentials, and sessions
+	// are sent as is to the API clients.
+	EnvOverrides map[string]string `json:"-"`
+
+	// Variables holds the $NAME variables substituted in the messages the
+	// user sends in this session. Controlled by the /set command in the TUI.
+	// Use SetVariable and GetVariables to access it concurrently.
+	Variables map[string]string `json:"variables,omitempty"`
+
+	// RememberedApprovals holds the tool call approvals that also apply to
+	// the next matching calls, keyed by their signature. Use
+	// RememberApproval, RememberedApprovalFor, GetRememberedApprovals and
+	// RevokeApproval to access it concurrently with a running agent.
+	RememberedApprovals map[string]RememberedApproval `json:"remembered_approvals,omitempty"`
+
 	// Starred indicates if this session has been starred by the user
 	Starred bool `json:"starred"`
 
@@ -99,6 +127,12 @@ type Session struct {
 	OutputTokens int64   `json:"output_tokens"`
 	Cost         float64 `json:"cost"`
 
+	// CostResetPosition is the number of items the session had when its cost
+	// was last reset. The items before it keep their cost but aren't counted
+	// by TotalCost, OwnCost and CostItems. Controlled by the /reset-cost
+	// command in the TUI.
+	CostResetPosition int `json:"cost_reset_position,omitempty"`
+
 	// Permissions holds session-level permission overrides.
 	// When set, these are evaluated before team-level permissions.
 	Permissions *PermissionsConfig `json:"permissions,omitempty"`
@@ -133,6 +167,10 @@ type Session struct {
 	// within the parent session's Messages array.
 	ParentID string `json:"-"`
 
+	// TransferDepth is the number of transfer_task calls this session is
+	// nested in, 0 for a top-level session.
+	TransferDepth int `json:"-"`
+
 	// MessageUsageHistory stores per-message usage data for remote mode.
 	// In remote mode, messages are managed server-side, so we track usage separately.
 	// This is not persisted (json:"-") as it's only needed for the current session display.
@@ -148,6 +186,31 @@ type MessageUsageRecord struct {
 	Usage     chat.Usage `json:"usage"`
 }
 
+// RememberedApproval is the approval of a tool call that also approves the
+// next calls to the same tool without asking the user.
+type RememberedApproval struct {
+	ToolName string `json:"tool_name"`
+	// Arguments restricts the approval to the calls with these arguments.
+	// Empty when it applies to every call of the tool.
+	Arguments string `json:"arguments,omitempty"`
+	// ExpiresAt is when the approval stops applying. Zero when it lasts
+	// for the rest of the session.
+	ExpiresAt time.Time `json:"expires_at,omitzero"`
+}
+
+// Signature identifies the tool calls the approval applies to.
+func (a RememberedApproval) Signature() string {
+	if a.Arguments == "" {
+		return a.ToolName
+	}
+	return a.ToolName + " " + a.Arguments
+}
+
+// Expired returns true if the approval no longer applies at the given time.
+func (a RememberedApproval) Expired(now time.Time) bool {
+	return !a.ExpiresAt.IsZero() && !now.Before(a.ExpiresAt)
+}
+
 // PermissionsConfig defines session-level tool permission overrides
 // using pattern-based rules (Allow/Ask/Deny arrays).
 type PermissionsConfig struct {
@@ -158,6 +221,9 @@ type PermissionsConfig struct {
 	Ask []string `json:"ask,omitempty"`
 	// Deny lists tool name patterns that are always rejected.
 	Deny []string `json:"deny,omitempty"`
+	// AllowToolsets lists toolset types whose tools are all auto-approved,
+	// unless a deny or ask pattern matches them.
+	AllowToolsets []string `json:"allow_toolsets,omitempty"`
 }
 
 // Message is a message from an agent
@@ -351,6 +417,28 @@ func (s *Session) GetLastUserMessageContent() string {
 	return s.getLastMessageContentByRole(chat.MessageRoleUser)
 }
 
+// GetLastToolResult returns the content of the most recent tool result that
+// holds text, and the name of the tool that produced it.
+func (s *Session) GetLastToolResult() (toolName, content string) {
+	messages := s.GetAllMessages()
+	for i := len(messages) - 1; i >= 0; i-- {
+		msg := messages[i].Message
+		if msg.Role != chat.MessageRoleTool || strings.TrimSpace(msg.Content) == "" {
+			continue
+		}
+		for j := i - 1; j >= 0 && toolName == ""; j-- {
+			for _, call := range messages[j].Message.ToolCalls {
+				if call.ID == msg.ToolCallID {
+					toolName = call.Function.Name
+					break
+				}
+			}
+		}
+		return toolName, strings.TrimSpace(msg.Content)
+	}
+	return "", ""
+}
+
 // GetLastUserMessages returns up to n most recent user messages, ordered from oldest to newest.
 // Returns nil if n <= 0.
 func (s *Session) GetLastUserMessages(n int) []string {
@@ -465,6 +553,13 @@ func WithPermissions(perms *PermissionsConfig) Opt {
 	}
 }
 
+// WithDateOverride pins the date injected into the system prompt.
+func WithDateOverride(date time.Time) Opt {
+	return func(s *Session) {
+		s.DateOverride = &date
+	}
+}
+
 // WithAgentName pins this session to a specific agent. When set, RunStream
 // resolves the agent from the session rather than the shared runtime state,
 // which is required for concurrent background agent tasks.
@@ -482,6 +577,155 @@ func WithParentID(parentID string) Opt {
 	}
 }
 
+// WithTransferDepth sets the number of transfer_task calls the session is
+// nested in.
+func WithTransferDepth(depth int) Opt {
+	return func(s *Session) {
+		s.TransferDepth = depth
+	}
+}
+
+// WithEnvOverrides sets environment variables for this session's tool
+// executions.
+func WithEnvOverrides(env map[string]string) Opt {
+	return func(s *Session) {
+		for name, value := range env {
+			s.SetEnvOverride(name, value)
+		}
+	}
+}
+
+// SetEnvOverride sets an environment variable for this session's tool
+// executions. An empty value removes the override.
+func (s *Session) SetEnvOverride(name, value string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if value == "" {
+		delete(s.EnvOverrides, name)
+		return
+	}
+	if s.EnvOverrides == nil {
+		s.EnvOverrides = make(map[string]string)
+	}
+	s.EnvOverrides[name] = value
+}
+
+// GetEnvOverrides returns a copy of the session's environment overrides.
+func (s *Session) GetEnvOverrides() map[string]string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return maps.Clone(s.EnvOverrides)
+}
+
+// SetVariable sets a variable substituted in the messages the user sends in
+// this session. An empty value removes the variable.
+func (s *Session) SetVariable(name, value string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if value == "" {
+		delete(s.Variables, name)
+		return
+	}
+	if s.Variables == nil {
+		s.Variables = make(map[string]string)
+	}
+	s.Variables[name] = value
+}
+
+// GetVariables returns a copy of the session's variables.
+func (s *Session) GetVariables() map[string]string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return maps.Clone(s.Variables)
+}
+
+// RememberApproval remembers an approval, replacing the one with the same
+// signature.
+func (s *Session) RememberApproval(approval RememberedApproval) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.RememberedApprovals == nil {
+		s.RememberedApprovals = make(map[string]RememberedApproval)
+	}
+	s.RememberedApprovals[approval.Signature()] = approval
+}
+
+// RememberedApprovalFor returns the approval that applies to a call of the
+// tool with the given arguments at the given time, if any. Expired
+// approvals are forgotten.
+func (s *Session) RememberedApprovalFor(toolName, arguments string, now time.Time) (RememberedApproval, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, signature := range []string{toolName, RememberedApproval{ToolName: toolName, Arguments: arguments}.Signature()} {
+		approval, ok := s.RememberedApprovals[signature]
+		if !ok {
+			continue
+		}
+		if approval.Expired(now) {
+			delete(s.RememberedApprovals, signature)
+			continue
+		}
+		return approval, true
+	}
+	return RememberedApproval{}, false
+}
+
+// GetRememberedApprovals returns the approvals that still apply at the
+// given time, sorted by signature.
+func (s *Session) GetRememberedApprovals(now time.Time) []RememberedApproval {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var approvals []RememberedApproval
+	for _, signature := range slices.Sorted(maps.Keys(s.RememberedApprovals)) {
+		if approval := s.RememberedApprovals[signature]; !approval.Expired(now) {
+			approvals = append(approvals, approval)
+		}
+	}
+	return approvals
+}
+
+// RevokeApproval forgets the approval with the given signature.
+func (s *Session) RevokeApproval(signature string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.RememberedApprovals, signature)
+}
+
+// SetDateOverride pins the date injected into the system prompt, or clears
+// the override when date is nil.
+func (s *Session) SetDateOverride(date *time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.DateOverride = date
+}
+
+// GetDateOverride returns the date injected into the system prompt instead of
+// the current date, or nil when there is none.
+func (s *Session) GetDateOverride() *time.Time {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.DateOverride
+}
+
+// CurrentDate returns the date the agent should consider as "today":
+// the DateOverride when set, the wall clock otherwise.
+func (s *Session) CurrentDate() time.Time {
+	if date := s.GetDateOverride(); date != nil {
+		return *date
+	}
+	return time.Now()
+}
+
 // IsSubSession returns true if this session is a sub-session (has a parent).
 func (s *Session) IsSubSession() bool {
 	return s.ParentID != ""
@@ -501,6 +745,33 @@ func (s *Session) MessageCount() int {
 	return n
 }
 
+// ResetCost zeroes the cost and token counters of the session. The message
+// history is left untouched: the items so far keep their cost, they're only
+// no longer counted.
+func (s *Session) ResetCost() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.CostResetPosition = len(s.Messages)
+	s.InputTokens = 0
+	s.OutputTokens = 0
+	s.Cost = 0
+	s.MessageUsageHistory = nil
+}
+
+// CostItems returns the items counted in the cost of the session: the ones
+// added since its cost was last reset.
+func (s *Session) CostItems() []Item {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.costItems()
+}
+
+func (s *Session) costItems() []Item {
+	return s.Messages[min(s.CostResetPosition, len(s.Messages)):]
+}
+
 // TotalCost computes the total cost of a session by walking all messages,
 // sub-sessions, and summary items. It does not use the session-level Cost
 // field, which exists only for backward-compatible persistence.
@@ -509,7 +780,7 @@ func (s *Session) TotalCost() float64 {
 	defer s.mu.RUnlock()
 
 	var cost float64
-	for _, item := range s.Messages {
+	for _, item := range s.costItems() {
 		switch {
 		case item.IsMessage():
 			cost += item.Message.Message.Cost
@@ -530,7 +801,7 @@ func (s *Session) OwnCost() float64 {
 	defer s.mu.RUnlock()
 
 	var cost float64
-	for _, item := range s.Messages {
+	for _, item := range s.costItems() {
 		if item.IsMessage() {
 			cost += item.Message.Message.Cost
 		}
@@ -656,11 +927,11 @@ func buildContextSpecificSystemMessages(a *agent.Agent, s *Session) []chat.Messa
 	if a.AddDate() {
 		messages = append(messages, chat.Message{
 			Role:    chat.MessageRoleSystem,
-			Content: "Today's date: " + time.Now().Format("2006-01-02"),
+			Content: "Today's date: " + s.CurrentDate().Format("2006-01-02"),
 		})
 	}
 
-	wd := s.WorkingDir
+	wd := cmp.Or(a.WorkingDir(), s.WorkingDir)
 	if wd == "" {
 		var err error
 		wd, err = os.Getwd()
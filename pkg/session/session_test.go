@@ -1,8 +1,10 @@
 package session
 
 import (
+	"encoding/json"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -218,6 +220,44 @@ func TestGetMessages_CacheControlWithSummary(t *testing.T) {
 	assert.Contains(t, messages[checkpointIndices[1]].Content, "Today's date", "checkpoint #2 should be on date message")
 }
 
+func TestGetMessages_DateOverride(t *testing.T) {
+	testAgent := agent.New("root", "instructions", agent.WithAddDate(true))
+
+	s := New(WithDateOverride(time.Date(2020, time.January, 2, 0, 0, 0, 0, time.UTC)))
+	messages := s.GetMessages(testAgent)
+
+	require.Len(t, messages, 2)
+	assert.Equal(t, "Today's date: 2020-01-02", messages[1].Content)
+
+	s.SetDateOverride(nil)
+	messages = s.GetMessages(testAgent)
+	assert.Equal(t, "Today's date: "+time.Now().Format("2006-01-02"), messages[1].Content)
+}
+
+func TestEnvOverrides(t *testing.T) {
+	t.Parallel()
+
+	s := New()
+	assert.Nil(t, s.GetEnvOverrides())
+
+	s.SetEnvOverride("DATABASE_URL", "postgres://test")
+	s.SetEnvOverride("DEBUG", "1")
+	overrides := s.GetEnvOverrides()
+	assert.Equal(t, map[string]string{"DATABASE_URL": "postgres://test", "DEBUG": "1"}, overrides)
+
+	overrides["DEBUG"] = "changed"
+	assert.Equal(t, "1", s.GetEnvOverrides()["DEBUG"], "returned map must be a copy")
+
+	s.SetEnvOverride("DEBUG", "")
+	assert.Equal(t, map[string]string{"DATABASE_URL": "postgres://test"}, s.GetEnvOverrides())
+
+	assert.Empty(t, New().GetEnvOverrides(), "overrides must not leak into other sessions")
+
+	data, err := json.Marshal(s)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "postgres://test", "overrides must not be sent to API clients")
+}
+
 func TestGetLastUserMessages(t *testing.T) {
 	t.Parallel()
 
@@ -332,3 +372,69 @@ func TestTransferTaskPromptExcludesParents(t *testing.T) {
 	assert.Contains(t, subAgentMsg, "librarian", "should list librarian as a valid sub-agent")
 	assert.NotContains(t, subAgentMsg, "planner", "should NOT list parent agent planner as a valid transfer target")
 }
+
+func TestGetLastToolResult(t *testing.T) {
+	t.Parallel()
+
+	testAgent := &agent.Agent{}
+
+	s := New()
+	toolName, content := s.GetLastToolResult()
+	assert.Empty(t, toolName)
+	assert.Empty(t, content)
+
+	s.AddMessage(NewAgentMessage(testAgent, &chat.Message{
+		Role: chat.MessageRoleAssistant,
+		ToolCalls: []tools.ToolCall{
+			{ID: "call_1", Function: tools.FunctionCall{Name: "shell"}},
+			{ID: "call_2", Function: tools.FunctionCall{Name: "read_file"}},
+		},
+	}))
+	s.AddMessage(NewAgentMessage(testAgent, &chat.Message{
+		Role:       chat.MessageRoleTool,
+		ToolCallID: "call_1",
+		Content:    "FAIL: TestSomething\n",
+	}))
+	s.AddMessage(NewAgentMessage(testAgent, &chat.Message{
+		Role:       chat.MessageRoleTool,
+		ToolCallID: "call_2",
+		MultiContent: []chat.MessagePart{
+			{Type: chat.MessagePartTypeImageURL},
+		},
+	}))
+
+	// Results without text, like images, are skipped.
+	toolName, content = s.GetLastToolResult()
+	assert.Equal(t, "shell", toolName)
+	assert.Equal(t, "FAIL: TestSomething", content)
+}
+
+func TestResetCost(t *testing.T) {
+	t.Parallel()
+
+	costly := func(cost float64) Item {
+		return NewMessageItem(&Message{Message: chat.Message{Role: chat.MessageRoleAssistant, Content: "answer", Cost: cost}})
+	}
+
+	sub := New()
+	sub.Messages = append(sub.Messages, costly(0.5))
+
+	s := New()
+	s.Messages = append(s.Messages, NewMessageItem(UserMessage("question")), costly(1), NewSubSessionItem(sub))
+	s.InputTokens, s.OutputTokens, s.Cost = 100, 50, 1.5
+	require.InDelta(t, 1.5, s.TotalCost(), 1e-9)
+
+	s.ResetCost()
+
+	assert.Len(t, s.Messages, 3, "the history is kept")
+	assert.Zero(t, s.TotalCost())
+	assert.Zero(t, s.OwnCost())
+	assert.Empty(t, s.CostItems())
+	assert.Zero(t, s.InputTokens)
+	assert.Zero(t, s.OutputTokens)
+	assert.Zero(t, s.Cost)
+
+	s.Messages = append(s.Messages, costly(0.25))
+	assert.InDelta(t, 0.25, s.TotalCost(), 1e-9)
+	assert.Len(t, s.CostItems(), 1)
+}
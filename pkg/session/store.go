@@ -72,6 +72,33 @@ type Summary struct {
 	Starred               bool
 	BranchParentSessionID string
 	NumMessages           int
+	// Cost is the cost of the session's own messages, without the
+	// sub-sessions'.
+	Cost       float64
+	WorkingDir string
+}
+
+// AverageCost returns the average cost of the sessions of summaries other
+// than excludeID, and how many sessions it's computed over. Only the sessions
+// started in workingDir are counted unless it's empty. Sessions that cost
+// nothing, e.g. the ones never sent a message, are left out.
+func AverageCost(summaries []Summary, excludeID, workingDir string) (float64, int) {
+	var total float64
+	var count int
+	for _, summary := range summaries {
+		if summary.ID == excludeID || summary.Cost <= 0 {
+			continue
+		}
+		if workingDir != "" && summary.WorkingDir != workingDir {
+			continue
+		}
+		total += summary.Cost
+		count++
+	}
+	if count == 0 {
+		return 0, 0
+	}
+	return total / float64(count), count
 }
 
 // Store defines the interface for session storage
@@ -85,6 +112,10 @@ type Store interface {
 	UpdateSession(ctx context.Context, session *Session) error // Updates metadata only (not messages/items)
 	SetSessionStarred(ctx context.Context, id string, starred bool) error
 
+	// CopySession stores a deep copy of the session with the given ID, see
+	// DuplicateSession, and returns it.
+	CopySession(ctx context.Context, id string) (*Session, error)
+
 	// === Granular item operations ===
 
 	// AddMessage adds a message to a session at the next position.
@@ -114,6 +145,23 @@ type Store interface {
 	Close() error
 }
 
+// copySession loads the session with the given ID from the store, and adds
+// a deep copy of it.
+func copySession(ctx context.Context, s Store, id string) (*Session, error) {
+	src, err := s.GetSession(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	duplicated, err := DuplicateSession(src)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.AddSession(ctx, duplicated); err != nil {
+		return nil, err
+	}
+	return duplicated, nil
+}
+
 type InMemorySessionStore struct {
 	sessions  *concurrent.Map[string, *Session]
 	messageID int64 // simple counter for message IDs
@@ -166,6 +214,8 @@ func (s *InMemorySessionStore) GetSessionSummaries(_ context.Context) ([]Summary
 			Starred:               value.Starred,
 			BranchParentSessionID: value.BranchParentSessionID,
 			NumMessages:           value.MessageCount(),
+			Cost:                  value.Cost,
+			WorkingDir:            value.WorkingDir,
 		})
 		return true
 	})
@@ -213,6 +263,8 @@ func (s *InMemorySessionStore) UpdateSession(_ context.Context, session *Session
 		InputTokens:           session.InputTokens,
 		OutputTokens:          session.OutputTokens,
 		Cost:                  session.Cost,
+		CostResetPosition:     session.CostResetPosition,
+		DateOverride:          session.GetDateOverride(),
 		Permissions:           session.Permissions,
 		AgentModelOverrides:   session.AgentModelOverrides,
 		CustomModelsUsed:      session.CustomModelsUsed,
@@ -248,6 +300,11 @@ func (s *InMemorySessionStore) SetSessionStarred(_ context.Context, id string, s
 	return nil
 }
 
+// CopySession stores a deep copy of the session with the given ID.
+func (s *InMemorySessionStore) CopySession(ctx context.Context, id string) (*Session, error) {
+	return copySession(ctx, s, id)
+}
+
 // AddMessage adds a message to a session at the next position.
 // Returns the ID of the created message (for in-memory, this is a simple counter).
 func (s *InMemorySessionStore) AddMessage(_ context.Context, sessionID string, msg *Message) (int64, error) {
@@ -539,6 +596,7 @@ func (s *SQLiteSessionStore) AddSession(ctx context.Context, session *Session) e
 	if session.BranchCreatedAt != nil {
 		branchCreatedAt = session.BranchCreatedAt.Format(time.RFC3339)
 	}
+	dateOverride := formatDateOverride(session.GetDateOverride())
 
 	// Use a transaction to insert session and its items
 	tx, err := s.db.BeginTx(ctx, nil)
@@ -552,12 +610,13 @@ func (s *SQLiteSessionStore) AddSession(ctx context.Context, session *Session) e
 			id, tools_approved, input_tokens, output_tokens, title, cost, send_user_message,
 			max_iterations, working_dir, created_at, permissions, agent_model_overrides,
 			custom_models_used, thinking, parent_id, branch_parent_session_id,
-			branch_parent_position, branch_created_at
-		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			branch_parent_position, branch_created_at, date_override
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
 		session.ID, session.ToolsApproved, session.InputTokens, session.OutputTokens, session.Title,
 		session.Cost, session.SendUserMessage, session.MaxIterations, session.WorkingDir,
 		session.CreatedAt.Format(time.RFC3339), permissionsJSON, agentModelOverridesJSON,
-		customModelsUsedJSON, session.Thinking, parentID, branchParentID, branchParentPosition, branchCreatedAt)
+		customModelsUsedJSON, session.Thinking, parentID, branchParentID, branchParentPosition, branchCreatedAt,
+		dateOverride)
 	if err != nil {
 		return err
 	}
@@ -587,8 +646,10 @@ func scanSession(scanner interface {
 	var branchParentPosition sql.NullInt64
 	var branchCreatedAt sql.NullString
 	var splitDiffView sql.NullBool // column kept for backward compat, value ignored
+	var costResetPosition sql.NullInt64
+	var dateOverride sql.NullString
 
-	err := scanner.Scan(&sessionID, &toolsApprovedStr, &inputTokensStr, &outputTokensStr, &titleStr, &costStr, &sendUserMessageStr, &maxIterationsStr, &workingDir, &createdAtStr, &starredStr, &permissionsJSON, &agentModelOverridesJSON, &customModelsUsedJSON, &thinkingStr, &parentID, &branchParentID, &branchParentPosition, &branchCreatedAt, &splitDiffView)
+	err := scanner.Scan(&sessionID, &toolsApprovedStr, &inputTokensStr, &outputTokensStr, &titleStr, &costStr, &sendUserMessageStr, &maxIterationsStr, &workingDir, &createdAtStr, &starredStr, &permissionsJSON, &agentModelOverridesJSON, &customModelsUsedJSON, &thinkingStr, &parentID, &branchParentID, &branchParentPosition, &branchCreatedAt, &splitDiffView, &costResetPosition, &dateOverride)
 	if err != nil {
 		return nil, err
 	}
@@ -678,6 +739,15 @@ func scanSession(scanner interface {
 		branchCreatedAtPtr = &parsed
 	}
 
+	var dateOverridePtr *time.Time
+	if dateOverride.Valid && dateOverride.String != "" {
+		parsed, err := time.Parse(time.RFC3339, dateOverride.String)
+		if err != nil {
+			return nil, err
+		}
+		dateOverridePtr = &parsed
+	}
+
 	return &Session{
 		ID:                    sessionID,
 		Title:                 titleStr,
@@ -687,6 +757,8 @@ func scanSession(scanner interface {
 		InputTokens:           inputTokens,
 		OutputTokens:          outputTokens,
 		Cost:                  cost,
+		CostResetPosition:     int(costResetPosition.Int64),
+		DateOverride:          dateOverridePtr,
 		SendUserMessage:       sendUserMessage,
 		MaxIterations:         maxIterations,
 		CreatedAt:             createdAt,
@@ -709,7 +781,7 @@ func (s *SQLiteSessionStore) GetSession(ctx context.Context, id string) (*Sessio
 	}
 
 	row := s.db.QueryRowContext(ctx,
-		"SELECT id, tools_approved, input_tokens, output_tokens, title, cost, send_user_message, max_iterations, working_dir, created_at, starred, permissions, agent_model_overrides, custom_models_used, thinking, parent_id, branch_parent_session_id, branch_parent_position, branch_created_at, split_diff_view FROM sessions WHERE id = ?", id)
+		"SELECT id, tools_approved, input_tokens, output_tokens, title, cost, send_user_message, max_iterations, working_dir, created_at, starred, permissions, agent_model_overrides, custom_models_used, thinking, parent_id, branch_parent_session_id, branch_parent_position, branch_created_at, split_diff_view, cost_reset_position, date_override FROM sessions WHERE id = ?", id)
 
 	sess, err := scanSession(row)
 	if err != nil {
@@ -825,7 +897,7 @@ func (s *SQLiteSessionStore) loadSessionItemsWith(ctx context.Context, q querier
 // loadSessionWith loads a session using the provided querier.
 func (s *SQLiteSessionStore) loadSessionWith(ctx context.Context, q querier, id string) (*Session, error) {
 	row := q.QueryRowContext(ctx,
-		"SELECT id, tools_approved, input_tokens, output_tokens, title, cost, send_user_message, max_iterations, working_dir, created_at, starred, permissions, agent_model_overrides, custom_models_used, thinking, parent_id, branch_parent_session_id, branch_parent_position, branch_created_at, split_diff_view FROM sessions WHERE id = ?", id)
+		"SELECT id, tools_approved, input_tokens, output_tokens, title, cost, send_user_message, max_iterations, working_dir, created_at, starred, permissions, agent_model_overrides, custom_models_used, thinking, parent_id, branch_parent_session_id, branch_parent_position, branch_created_at, split_diff_view, cost_reset_position, date_override FROM sessions WHERE id = ?", id)
 
 	sess, err := scanSession(row)
 	if err != nil {
@@ -880,7 +952,7 @@ func (s *SQLiteSessionStore) loadMessagesFromLegacyColumn(ctx context.Context, s
 // GetSessions retrieves all root sessions (excludes sub-sessions)
 func (s *SQLiteSessionStore) GetSessions(ctx context.Context) ([]*Session, error) {
 	rows, err := s.db.QueryContext(ctx,
-		"SELECT id, tools_approved, input_tokens, output_tokens, title, cost, send_user_message, max_iterations, working_dir, created_at, starred, permissions, agent_model_overrides, custom_models_used, thinking, parent_id, branch_parent_session_id, branch_parent_position, branch_created_at, split_diff_view FROM sessions WHERE parent_id IS NULL OR parent_id = '' ORDER BY created_at DESC")
+		"SELECT id, tools_approved, input_tokens, output_tokens, title, cost, send_user_message, max_iterations, working_dir, created_at, starred, permissions, agent_model_overrides, custom_models_used, thinking, parent_id, branch_parent_session_id, branch_parent_position, branch_created_at, split_diff_view, cost_reset_position, date_override FROM sessions WHERE parent_id IS NULL OR parent_id = '' ORDER BY created_at DESC")
 	if err != nil {
 		return nil, err
 	}
@@ -916,7 +988,8 @@ func (s *SQLiteSessionStore) GetSessions(ctx context.Context) ([]*Session, error
 func (s *SQLiteSessionStore) GetSessionSummaries(ctx context.Context) ([]Summary, error) {
 	rows, err := s.db.QueryContext(ctx,
 		`SELECT s.id, s.title, s.created_at, s.starred, s.branch_parent_session_id,
-		        (SELECT COUNT(*) FROM session_items si WHERE si.session_id = s.id AND si.item_type = 'message')
+		        (SELECT COUNT(*) FROM session_items si WHERE si.session_id = s.id AND si.item_type = 'message'),
+		        COALESCE(s.cost, 0), COALESCE(s.working_dir, '')
 		 FROM sessions s
 		 WHERE s.parent_id IS NULL OR s.parent_id = ''
 		 ORDER BY s.created_at DESC`)
@@ -927,10 +1000,11 @@ func (s *SQLiteSessionStore) GetSessionSummaries(ctx context.Context) ([]Summary
 
 	var summaries []Summary
 	for rows.Next() {
-		var id, title, createdAtStr, starredStr string
+		var id, title, createdAtStr, starredStr, workingDir string
 		var branchParentID sql.NullString
 		var numMessages int
-		if err := rows.Scan(&id, &title, &createdAtStr, &starredStr, &branchParentID, &numMessages); err != nil {
+		var cost float64
+		if err := rows.Scan(&id, &title, &createdAtStr, &starredStr, &branchParentID, &numMessages, &cost, &workingDir); err != nil {
 			return nil, err
 		}
 		createdAt, err := time.Parse(time.RFC3339, createdAtStr)
@@ -948,6 +1022,8 @@ func (s *SQLiteSessionStore) GetSessionSummaries(ctx context.Context) ([]Summary
 			Starred:               starred,
 			BranchParentSessionID: branchParentID.String,
 			NumMessages:           numMessages,
+			Cost:                  cost,
+			WorkingDir:            workingDir,
 		})
 	}
 
@@ -1049,9 +1125,9 @@ func (s *SQLiteSessionStore) UpdateSession(ctx context.Context, session *Session
 			id, tools_approved, input_tokens, output_tokens, title, cost, send_user_message,
 			max_iterations, working_dir, created_at, starred, permissions, agent_model_overrides,
 			custom_models_used, thinking, parent_id, branch_parent_session_id,
-			branch_parent_position, branch_created_at
+			branch_parent_position, branch_created_at, cost_reset_position, date_override
 		)
-		 VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		 VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 		 ON CONFLICT(id) DO UPDATE SET
 		   title = excluded.title,
 		   tools_approved = excluded.tools_approved,
@@ -1069,11 +1145,14 @@ func (s *SQLiteSessionStore) UpdateSession(ctx context.Context, session *Session
 		   parent_id = excluded.parent_id,
 		   branch_parent_session_id = excluded.branch_parent_session_id,
 		   branch_parent_position = excluded.branch_parent_position,
-		   branch_created_at = excluded.branch_created_at`,
+		   branch_created_at = excluded.branch_created_at,
+		   cost_reset_position = excluded.cost_reset_position,
+		   date_override = excluded.date_override`,
 		session.ID, session.ToolsApproved, session.InputTokens, session.OutputTokens,
 		session.Title, session.Cost, session.SendUserMessage, session.MaxIterations, session.WorkingDir,
 		session.CreatedAt.Format(time.RFC3339), session.Starred, permissionsJSON, agentModelOverridesJSON,
-		customModelsUsedJSON, session.Thinking, parentID, branchParentID, branchParentPosition, branchCreatedAt)
+		customModelsUsedJSON, session.Thinking, parentID, branchParentID, branchParentPosition, branchCreatedAt,
+		session.CostResetPosition, formatDateOverride(session.GetDateOverride()))
 	if err != nil {
 		return err
 	}
@@ -1084,6 +1163,12 @@ func (s *SQLiteSessionStore) UpdateSession(ctx context.Context, session *Session
 	return tx.Commit()
 }
 
+// CopySession stores a deep copy of the session with the given ID, along
+// with its messages and sub-sessions.
+func (s *SQLiteSessionStore) CopySession(ctx context.Context, id string) (*Session, error) {
+	return copySession(ctx, s, id)
+}
+
 // SetSessionStarred sets the starred status of a session.
 func (s *SQLiteSessionStore) SetSessionStarred(ctx context.Context, id string, starred bool) error {
 	if id == "" {
@@ -1384,3 +1469,12 @@ func (s *SQLiteSessionStore) UpdateSessionTitle(ctx context.Context, sessionID,
 		title, sessionID)
 	return err
 }
+
+// formatDateOverride returns the value of the date_override column: NULL
+// when the session uses the wall clock.
+func formatDateOverride(date *time.Time) any {
+	if date == nil {
+		return nil
+	}
+	return date.Format(time.RFC3339)
+}
@@ -197,7 +197,9 @@ func TestGetSessionSummaries(t *testing.T) {
 				Content: "Another long message that should not be loaded when getting summaries",
 			})),
 		},
-		CreatedAt: session2Time,
+		CreatedAt:  session2Time,
+		Cost:       0.25,
+		WorkingDir: "/work/project",
 	}
 
 	// Store the sessions
@@ -216,11 +218,41 @@ func TestGetSessionSummaries(t *testing.T) {
 	assert.Equal(t, "Second Session", summaries[0].Title)
 	assert.Equal(t, session2Time, summaries[0].CreatedAt)
 	assert.Equal(t, 1, summaries[0].NumMessages)
+	assert.InDelta(t, 0.25, summaries[0].Cost, 1e-9)
+	assert.Equal(t, "/work/project", summaries[0].WorkingDir)
 
 	assert.Equal(t, "session-1", summaries[1].ID)
 	assert.Equal(t, "First Session", summaries[1].Title)
 	assert.Equal(t, session1Time, summaries[1].CreatedAt)
 	assert.Equal(t, 1, summaries[1].NumMessages)
+	assert.Zero(t, summaries[1].Cost)
+}
+
+func TestAverageCost(t *testing.T) {
+	t.Parallel()
+
+	summaries := []Summary{
+		{ID: "current", Cost: 5, WorkingDir: "/a"},
+		{ID: "s1", Cost: 0.1, WorkingDir: "/a"},
+		{ID: "s2", Cost: 0.3, WorkingDir: "/a"},
+		{ID: "s3", Cost: 0.8, WorkingDir: "/b"},
+		{ID: "empty", WorkingDir: "/a"},
+	}
+
+	avg, n := AverageCost(summaries, "current", "")
+	assert.Equal(t, 3, n)
+	assert.InDelta(t, 0.4, avg, 1e-9)
+
+	avg, n = AverageCost(summaries, "current", "/a")
+	assert.Equal(t, 2, n)
+	assert.InDelta(t, 0.2, avg, 1e-9)
+
+	avg, n = AverageCost(summaries, "current", "/c")
+	assert.Zero(t, n)
+	assert.Zero(t, avg)
+
+	_, n = AverageCost(nil, "current", "")
+	assert.Zero(t, n)
 }
 
 func TestBranchSessionCopiesPrefix(t *testing.T) {
@@ -316,6 +348,53 @@ func TestBranchSessionClonesSubSession(t *testing.T) {
 	assert.Equal(t, "Sub message", subItem.SubSession.Messages[0].Message.Message.Content)
 }
 
+func TestCopySession(t *testing.T) {
+	tempDB := filepath.Join(t.TempDir(), "test_copy.db")
+
+	store, err := NewSQLiteSessionStore(tempDB)
+	require.NoError(t, err)
+	defer store.(*SQLiteSessionStore).Close()
+
+	original := &Session{
+		ID:        "original-session",
+		Title:     "Original",
+		CreatedAt: time.Now().Add(-time.Hour),
+		Messages: []Item{
+			NewMessageItem(UserMessage("Start")),
+			NewSubSessionItem(&Session{
+				ID:        "sub-session",
+				CreatedAt: time.Now(),
+				Messages:  []Item{NewMessageItem(UserMessage("Sub message"))},
+			}),
+		},
+	}
+	require.NoError(t, store.AddSession(t.Context(), original))
+
+	copied, err := store.CopySession(t.Context(), original.ID)
+	require.NoError(t, err)
+	assert.NotEqual(t, original.ID, copied.ID)
+
+	loaded, err := store.GetSession(t.Context(), copied.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Original (copy)", loaded.Title)
+	assert.True(t, loaded.CreatedAt.After(original.CreatedAt))
+	assert.Empty(t, loaded.BranchParentSessionID)
+	require.Len(t, loaded.Messages, 2)
+	assert.Equal(t, "Start", loaded.Messages[0].Message.Message.Content)
+	require.NotNil(t, loaded.Messages[1].SubSession)
+	assert.NotEqual(t, "sub-session", loaded.Messages[1].SubSession.ID)
+	assert.Equal(t, loaded.ID, loaded.Messages[1].SubSession.ParentID)
+
+	// The original is left untouched.
+	originalLoaded, err := store.GetSession(t.Context(), original.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Original", originalLoaded.Title)
+	assert.Len(t, originalLoaded.Messages, 2)
+
+	_, err = store.CopySession(t.Context(), "missing")
+	require.Error(t, err)
+}
+
 func TestStoreAgentNameJSON(t *testing.T) {
 	tempDB := filepath.Join(t.TempDir(), "test_store_json.db")
 
@@ -544,6 +623,63 @@ func TestUpdateSession_Permissions(t *testing.T) {
 	assert.Equal(t, []string{"dangerous_*"}, retrieved.Permissions.Deny)
 }
 
+func TestUpdateSession_CostReset(t *testing.T) {
+	tempDB := filepath.Join(t.TempDir(), "test_cost_reset.db")
+
+	store, err := NewSQLiteSessionStore(tempDB)
+	require.NoError(t, err)
+	defer store.(*SQLiteSessionStore).Close()
+
+	session := &Session{
+		ID:        "cost-reset-session",
+		CreatedAt: time.Now(),
+	}
+	require.NoError(t, store.AddSession(t.Context(), session))
+
+	msg := &Message{Message: chat.Message{Role: chat.MessageRoleAssistant, Content: "answer", Cost: 2}}
+	_, err = store.AddMessage(t.Context(), session.ID, msg)
+	require.NoError(t, err)
+	session.Messages = append(session.Messages, NewMessageItem(msg))
+
+	session.ResetCost()
+	require.NoError(t, store.UpdateSession(t.Context(), session))
+
+	retrieved, err := store.GetSession(t.Context(), session.ID)
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, retrieved.CostResetPosition)
+	assert.Len(t, retrieved.Messages, 1)
+	assert.Zero(t, retrieved.TotalCost())
+}
+
+func TestUpdateSession_DateOverride(t *testing.T) {
+	tempDB := filepath.Join(t.TempDir(), "test_date_override.db")
+
+	store, err := NewSQLiteSessionStore(tempDB)
+	require.NoError(t, err)
+	defer store.(*SQLiteSessionStore).Close()
+
+	date := time.Date(2020, time.January, 2, 0, 0, 0, 0, time.UTC)
+	session := &Session{
+		ID:        "date-override-session",
+		CreatedAt: time.Now(),
+	}
+	session.SetDateOverride(&date)
+	require.NoError(t, store.AddSession(t.Context(), session))
+
+	retrieved, err := store.GetSession(t.Context(), session.ID)
+	require.NoError(t, err)
+	require.NotNil(t, retrieved.DateOverride)
+	assert.True(t, date.Equal(*retrieved.DateOverride))
+
+	session.SetDateOverride(nil)
+	require.NoError(t, store.UpdateSession(t.Context(), session))
+
+	retrieved, err = store.GetSession(t.Context(), session.ID)
+	require.NoError(t, err)
+	assert.Nil(t, retrieved.DateOverride)
+}
+
 func TestAgentModelOverrides_SQLite(t *testing.T) {
 	tempDB := filepath.Join(t.TempDir(), "test_model_overrides.db")
 
@@ -0,0 +1,67 @@
+package session
+
+import "strings"
+
+// IsVariableName reports whether name can be used as a session variable:
+// a letter or underscore followed by letters, digits or underscores.
+func IsVariableName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for i, r := range name {
+		if !isVariableRune(r, i == 0) {
+			return false
+		}
+	}
+	return true
+}
+
+func isVariableRune(r rune, first bool) bool {
+	switch {
+	case r == '_', r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z':
+		return true
+	case r >= '0' && r <= '9':
+		return !first
+	}
+	return false
+}
+
+// ExpandVariables replaces the $NAME references of text with the values of
+// vars. References to undefined variables are kept as is so that shell
+// snippets and prices go through untouched. $$NAME escapes a defined
+// variable and produces a literal $NAME; any other $$, such as a shell's PID,
+// is kept as is.
+func ExpandVariables(text string, vars map[string]string) string {
+	if len(vars) == 0 || !strings.Contains(text, "$") {
+		return text
+	}
+
+	var b strings.Builder
+	b.Grow(len(text))
+	for i := 0; i < len(text); i++ {
+		if text[i] != '$' {
+			b.WriteByte(text[i])
+			continue
+		}
+
+		escaped := i+1 < len(text) && text[i+1] == '$'
+		start := i + 1
+		if escaped {
+			start++
+		}
+		end := start
+		for end < len(text) && isVariableRune(rune(text[end]), end == start) {
+			end++
+		}
+		if value, ok := vars[text[start:end]]; ok && end > start {
+			if escaped {
+				value = text[i+1 : end]
+			}
+			b.WriteString(value)
+			i = end - 1
+			continue
+		}
+		b.WriteByte('$')
+	}
+	return b.String()
+}
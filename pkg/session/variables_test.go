@@ -0,0 +1,62 @@
+package session
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpandVariables(t *testing.T) {
+	t.Parallel()
+
+	vars := map[string]string{"PROJECT": "cagent", "V_2": "two"}
+
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{name: "no variables", text: "hello", want: "hello"},
+		{name: "defined", text: "work on $PROJECT now", want: "work on cagent now"},
+		{name: "adjacent punctuation", text: "$PROJECT/$V_2.", want: "cagent/two."},
+		{name: "undefined kept", text: "echo $HOME and $PROJECTX", want: "echo $HOME and $PROJECTX"},
+		{name: "escaped", text: "not $$PROJECT but $PROJECT", want: "not $PROJECT but cagent"},
+		{name: "other double dollars kept", text: "costs $$5, pid $$, $$HOME", want: "costs $$5, pid $$, $$HOME"},
+		{name: "lone dollar", text: "$ and $1 and trailing $", want: "$ and $1 and trailing $"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, ExpandVariables(tt.text, vars))
+		})
+	}
+}
+
+func TestExpandVariables_NoVariables(t *testing.T) {
+	t.Parallel()
+
+	text := "echo $$ and $$PROJECT costs $$5"
+	assert.Equal(t, text, ExpandVariables(text, nil))
+	assert.Equal(t, text, ExpandVariables(text, map[string]string{}))
+}
+
+func TestIsVariableName(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, IsVariableName("PROJECT"))
+	assert.True(t, IsVariableName("_my_var2"))
+	assert.False(t, IsVariableName(""))
+	assert.False(t, IsVariableName("2VAR"))
+	assert.False(t, IsVariableName("MY-VAR"))
+}
+
+func TestSetVariable(t *testing.T) {
+	t.Parallel()
+
+	s := New()
+	s.SetVariable("PROJECT", "cagent")
+	assert.Equal(t, map[string]string{"PROJECT": "cagent"}, s.GetVariables())
+
+	s.SetVariable("PROJECT", "")
+	assert.Empty(t, s.GetVariables())
+}
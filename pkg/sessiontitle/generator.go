@@ -25,11 +25,23 @@ const (
 	// Title generation should be quick since we disable thinking and use low max_tokens.
 	// If the API is slow or hanging (e.g., due to server-side thinking), we should timeout.
 	titleGenerationTimeout = 30 * time.Second
+
+	// maxTitleLength is the maximum length, in runes, of a title derived
+	// from a user message.
+	maxTitleLength = 50
 )
 
 // Generator generates session titles using a one-shot LLM completion.
 type Generator struct {
-	models []provider.Provider
+	models      []provider.Provider
+	fromMessage bool
+}
+
+// NewFromMessage creates a title Generator that never calls a model: titles
+// are the first user message, truncated. Useful with cheap or local models
+// where the extra LLM call isn't worth its latency and cost.
+func NewFromMessage() *Generator {
+	return &Generator{fromMessage: true}
 }
 
 // New creates a new title Generator with the given model provider.
@@ -60,6 +72,10 @@ func (g *Generator) Generate(ctx context.Context, sessionID string, userMessages
 		return "", nil
 	}
 
+	if g != nil && g.fromMessage {
+		return FromMessage(userMessages[0]), nil
+	}
+
 	// Apply timeout to prevent hanging on slow or unresponsive models
 	ctx, cancel := context.WithTimeout(ctx, titleGenerationTimeout)
 	defer cancel()
@@ -184,3 +200,13 @@ func sanitizeTitle(title string) string {
 	}
 	return ""
 }
+
+// FromMessage derives a title from a user message: its first non-empty line,
+// truncated to maxTitleLength runes.
+func FromMessage(message string) string {
+	title := sanitizeTitle(message)
+	if runes := []rune(title); len(runes) > maxTitleLength {
+		title = strings.TrimSpace(string(runes[:maxTitleLength-1])) + "…"
+	}
+	return title
+}
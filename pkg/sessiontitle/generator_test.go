@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"io"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -157,3 +158,19 @@ func TestGenerator_Generate_FallsBackOnEmptyOutput(t *testing.T) {
 	assert.Equal(t, 1, primary.calls)
 	assert.Equal(t, 1, fallback.calls)
 }
+
+func TestGenerator_Generate_FromMessage(t *testing.T) {
+	t.Parallel()
+
+	gen := NewFromMessage()
+
+	title, err := gen.Generate(t.Context(), "sess", []string{"\n  Fix the flaky login test  \nwith more details"})
+	require.NoError(t, err)
+	assert.Equal(t, "Fix the flaky login test", title)
+
+	long := "Investigate why the integration tests time out on the CI runners every night"
+	title, err = gen.Generate(t.Context(), "sess", []string{long})
+	require.NoError(t, err)
+	assert.Equal(t, 50, len([]rune(title)))
+	assert.True(t, strings.HasSuffix(title, "…"))
+}
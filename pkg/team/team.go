@@ -13,10 +13,19 @@ import (
 	"github.com/docker/cagent/pkg/rag"
 )
 
+// DefaultMaxTransferDepth is how many transfer_task calls can be nested
+// when the team doesn't configure it.
+const DefaultMaxTransferDepth = 10
+
 type Team struct {
 	agents      []*agent.Agent
 	ragManagers map[string]*rag.Manager
 	permissions *permissions.Checker
+	// defaultAgent is the name of the agent to start on, as configured.
+	defaultAgent string
+	// maxTransferDepth is how many transfer_task calls can be nested, 0
+	// for DefaultMaxTransferDepth.
+	maxTransferDepth int
 }
 
 type Opt func(*Team)
@@ -39,6 +48,20 @@ func WithPermissions(checker *permissions.Checker) Opt {
 	}
 }
 
+// WithDefaultAgent sets the agent to start on instead of root.
+func WithDefaultAgent(name string) Opt {
+	return func(t *Team) {
+		t.defaultAgent = name
+	}
+}
+
+// WithMaxTransferDepth sets how many transfer_task calls can be nested.
+func WithMaxTransferDepth(depth int) Opt {
+	return func(t *Team) {
+		t.maxTransferDepth = depth
+	}
+}
+
 func New(opts ...Opt) *Team {
 	t := &Team{
 		ragManagers: make(map[string]*rag.Manager),
@@ -64,6 +87,10 @@ type AgentInfo struct {
 	Provider    string
 	Model       string
 	Commands    types.Commands
+	Hidden      bool
+	// ApprovedToolsets and GatedToolsets are the agent's toolset approval policy.
+	ApprovedToolsets []string
+	GatedToolsets    []string
 }
 
 // AgentsInfo returns information about all agents in the team
@@ -74,6 +101,11 @@ func (t *Team) AgentsInfo() []AgentInfo {
 			Name:        a.Name(),
 			Description: a.Description(),
 			Commands:    a.Commands(),
+			Hidden:      a.Hidden(),
+		}
+		if approval := a.ToolsetApproval(); approval != nil {
+			info.ApprovedToolsets = approval.Allow
+			info.GatedToolsets = approval.Ask
 		}
 		if model := a.Model(); model != nil {
 			modelID := model.ID()
@@ -89,11 +121,22 @@ func (t *Team) AgentsInfo() []AgentInfo {
 	return infos
 }
 
+// DefaultAgent returns the agent to start on: the configured default agent if
+// it exists, otherwise root, otherwise the first agent.
 func (t *Team) DefaultAgent() (*agent.Agent, error) {
 	if t.Size() == 0 {
 		return nil, errors.New("no agents loaded; ensure your agent configuration defines at least one agent")
 	}
 
+	if t.defaultAgent != "" {
+		for _, a := range t.agents {
+			if a.Name() == t.defaultAgent {
+				return a, nil
+			}
+		}
+		slog.Warn("Default agent not found, falling back to root", "agent", t.defaultAgent, "available", t.AgentNames())
+	}
+
 	// Before v4, the default agent was the one named "root". If it exists, return it.
 	for _, a := range t.agents {
 		if a.Name() == "root" {
@@ -105,6 +148,20 @@ func (t *Team) DefaultAgent() (*agent.Agent, error) {
 	return t.agents[0], nil
 }
 
+// DefaultAgentName returns the name of the configured default agent, or ""
+// when none is configured. The agent might not exist in the team.
+func (t *Team) DefaultAgentName() string {
+	return t.defaultAgent
+}
+
+// MaxTransferDepth returns how many transfer_task calls can be nested.
+func (t *Team) MaxTransferDepth() int {
+	if t.maxTransferDepth <= 0 {
+		return DefaultMaxTransferDepth
+	}
+	return t.maxTransferDepth
+}
+
 func (t *Team) Agent(name string) (*agent.Agent, error) {
 	if t.Size() == 0 {
 		return nil, errors.New("no agents loaded; ensure your agent configuration defines at least one agent")
@@ -6,8 +6,11 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"os"
+	"path/filepath"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/docker/cagent/pkg/agent"
 	"github.com/docker/cagent/pkg/config"
@@ -83,6 +86,11 @@ type LoadResult struct {
 	Providers map[string]latest.ProviderConfig
 	// AgentDefaultModels maps agent names to their configured default model references
 	AgentDefaultModels map[string]string
+	// ThemePath is the path of the TUI theme shipped with the config, if any
+	ThemePath string
+	// Config is the configuration the team was loaded from, after model
+	// aliases and overrides were applied
+	Config *latest.Config
 }
 
 // Load loads an agent team from the given source
@@ -177,6 +185,8 @@ func LoadWithConfig(ctx context.Context, agentSource config.Source, runConfig *c
 			agent.WithAddDate(agentConfig.AddDate),
 			agent.WithAddEnvironmentInfo(agentConfig.AddEnvironmentInfo),
 			agent.WithAddDescriptionParameter(agentConfig.AddDescriptionParameter),
+			agent.WithHidden(agentConfig.Hidden),
+			agent.WithToolsetApproval(agentConfig.ToolsetApproval),
 			agent.WithAddPromptFiles(promptFiles),
 			agent.WithMaxIterations(agentConfig.MaxIterations),
 			agent.WithNumHistoryItems(agentConfig.NumHistoryItems),
@@ -214,7 +224,16 @@ func LoadWithConfig(ctx context.Context, agentSource config.Source, runConfig *c
 			)
 		}
 
-		agentTools, warnings := getToolsForAgent(ctx, &agentConfig, parentDir, runConfig, loadOpts.toolsetRegistry)
+		// The tools of an agent with its own working directory work in it
+		toolsRunConfig, err := runConfigForAgent(&agentConfig, runConfig)
+		if err != nil {
+			return nil, fmt.Errorf("agent %q: %w", agentConfig.Name, err)
+		}
+		if agentConfig.WorkingDir != "" {
+			opts = append(opts, agent.WithWorkingDir(toolsRunConfig.WorkingDir))
+		}
+
+		agentTools, warnings := getToolsForAgent(ctx, &agentConfig, parentDir, toolsRunConfig, loadOpts.toolsetRegistry)
 		if len(warnings) > 0 {
 			opts = append(opts, agent.WithLoadTimeWarnings(warnings))
 		}
@@ -285,13 +304,26 @@ func LoadWithConfig(ctx context.Context, agentSource config.Source, runConfig *c
 			team.WithAgents(agents...),
 			team.WithRAGManagers(ragManagers),
 			team.WithPermissions(permChecker),
+			team.WithDefaultAgent(cfg.DefaultAgent),
+			team.WithMaxTransferDepth(cfg.MaxTransferDepth),
 		),
 		Models:             cfg.Models,
 		Providers:          cfg.Providers,
 		AgentDefaultModels: agentDefaultModels,
+		ThemePath:          resolveThemePath(cfg.Theme, parentDir),
+		Config:             cfg,
 	}, nil
 }
 
+// resolveThemePath returns the path of the theme file referenced by the
+// config, relative to the directory of the config file.
+func resolveThemePath(theme, parentDir string) string {
+	if theme == "" || filepath.IsAbs(theme) {
+		return theme
+	}
+	return filepath.Join(parentDir, theme)
+}
+
 func getModelsForAgent(ctx context.Context, cfg *latest.Config, a *latest.AgentConfig, autoModelFn func() latest.ModelConfig, runConfig *config.RuntimeConfig) ([]provider.Provider, bool, error) {
 	var models []provider.Provider
 	thinkingConfigured := false
@@ -333,6 +365,7 @@ func getModelsForAgent(ctx context.Context, cfg *latest.Config, a *latest.AgentC
 
 		opts := []options.Opt{
 			options.WithGateway(runConfig.ModelsGateway),
+			options.WithHTTPTransport(runConfig.HTTPTransport),
 			options.WithStructuredOutput(a.StructuredOutput),
 			options.WithProviders(cfg.Providers),
 		}
@@ -397,6 +430,7 @@ func getFallbackModelsForAgent(ctx context.Context, cfg *latest.Config, a *lates
 
 		opts := []options.Opt{
 			options.WithGateway(runConfig.ModelsGateway),
+			options.WithHTTPTransport(runConfig.HTTPTransport),
 			options.WithStructuredOutput(a.StructuredOutput),
 			options.WithProviders(cfg.Providers),
 		}
@@ -440,7 +474,9 @@ func getToolsForAgent(ctx context.Context, a *latest.AgentConfig, parentDir stri
 			continue
 		}
 
-		wrapped := WithToolsFilter(tool, toolset.Tools...)
+		wrapped := WithToolsetType(tool, toolset.Type)
+		wrapped = WithCallTimeout(wrapped, time.Duration(toolset.CallTimeout)*time.Second)
+		wrapped = WithToolsFilter(wrapped, toolset.Tools...)
 		wrapped = WithInstructions(wrapped, toolset.Instruction)
 		wrapped = WithToon(wrapped, toolset.Toon)
 
@@ -480,6 +516,37 @@ func getToolsForAgent(ctx context.Context, a *latest.AgentConfig, parentDir stri
 	return toolSets, warnings
 }
 
+// runConfigForAgent returns the runtime config the tools of the agent are
+// created with: runConfig itself, or a copy working in the agent's
+// working_dir when it has one. A relative working_dir is resolved against the
+// session working directory, or the current one when the session has none.
+func runConfigForAgent(a *latest.AgentConfig, runConfig *config.RuntimeConfig) (*config.RuntimeConfig, error) {
+	if a.WorkingDir == "" {
+		return runConfig, nil
+	}
+
+	dir := a.WorkingDir
+	if !filepath.IsAbs(dir) {
+		dir = filepath.Join(runConfig.WorkingDir, dir)
+	}
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve working_dir: %w", err)
+	}
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil, fmt.Errorf("invalid working_dir: %w", err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("invalid working_dir: %s is not a directory", dir)
+	}
+
+	agentRunConfig := runConfig.Clone()
+	agentRunConfig.EnvProviderForTests = runConfig.EnvProviderForTests
+	agentRunConfig.WorkingDir = dir
+	return agentRunConfig, nil
+}
+
 // resolveAgentRefs resolves a list of agent references to agent instances.
 // References that match a locally-defined agent name are looked up directly.
 // References that are external (OCI or URL) are loaded on-demand and cached
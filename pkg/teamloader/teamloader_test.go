@@ -401,3 +401,116 @@ func TestExternalDepthContext(t *testing.T) {
 	ctx = contextWithExternalDepth(ctx, 7)
 	assert.Equal(t, 7, externalDepthFromContext(ctx))
 }
+
+func TestThemePath(t *testing.T) {
+	t.Setenv("OPENAI_API_KEY", "dummy")
+
+	dir := t.TempDir()
+	configFile := filepath.Join(dir, "agent.yaml")
+	require.NoError(t, os.WriteFile(configFile, []byte(`agents:
+  root:
+    model: openai/gpt-4o
+    instruction: Be good
+theme: themes/acme.yaml
+`), 0o644))
+
+	agentSource, err := config.Resolve(configFile, nil)
+	require.NoError(t, err)
+
+	result, err := LoadWithConfig(t.Context(), agentSource, &config.RuntimeConfig{})
+	require.NoError(t, err)
+
+	assert.Equal(t, filepath.Join(dir, "themes", "acme.yaml"), result.ThemePath)
+	assert.Equal(t, "/etc/acme.yaml", resolveThemePath("/etc/acme.yaml", dir))
+	assert.Empty(t, resolveThemePath("", dir))
+}
+
+func TestDefaultAgent(t *testing.T) {
+	t.Setenv("OPENAI_API_KEY", "dummy")
+
+	load := func(t *testing.T, defaultAgent string) *LoadResult {
+		t.Helper()
+
+		configFile := filepath.Join(t.TempDir(), "agent.yaml")
+		require.NoError(t, os.WriteFile(configFile, []byte(`agents:
+  root:
+    model: openai/gpt-4o
+    instruction: Be good
+    sub_agents: [planner]
+  planner:
+    model: openai/gpt-4o
+    instruction: Plan
+default_agent: `+defaultAgent+`
+`), 0o644))
+
+		agentSource, err := config.Resolve(configFile, nil)
+		require.NoError(t, err)
+
+		result, err := LoadWithConfig(t.Context(), agentSource, &config.RuntimeConfig{})
+		require.NoError(t, err)
+		return result
+	}
+
+	result := load(t, "planner")
+	a, err := result.Team.DefaultAgent()
+	require.NoError(t, err)
+	assert.Equal(t, "planner", a.Name())
+
+	// Unknown default agents fall back to root.
+	result = load(t, "unknown")
+	assert.Equal(t, "unknown", result.Team.DefaultAgentName())
+	a, err = result.Team.DefaultAgent()
+	require.NoError(t, err)
+	assert.Equal(t, "root", a.Name())
+}
+
+func TestAgentWorkingDir(t *testing.T) {
+	t.Setenv("OPENAI_API_KEY", "dummy")
+
+	sessionDir := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(sessionDir, "docs"), 0o755))
+
+	load := func(t *testing.T, workingDir string) (*LoadResult, error) {
+		t.Helper()
+
+		configFile := filepath.Join(t.TempDir(), "agent.yaml")
+		require.NoError(t, os.WriteFile(configFile, []byte(`agents:
+  root:
+    model: openai/gpt-4o
+    instruction: Be good
+    sub_agents: [docs]
+  docs:
+    model: openai/gpt-4o
+    instruction: Write the docs
+    working_dir: `+workingDir+`
+    toolsets:
+      - type: filesystem
+`), 0o644))
+
+		agentSource, err := config.Resolve(configFile, nil)
+		require.NoError(t, err)
+
+		return LoadWithConfig(t.Context(), agentSource, &config.RuntimeConfig{
+			Config: config.Config{WorkingDir: sessionDir},
+		})
+	}
+
+	result, err := load(t, "docs")
+	require.NoError(t, err)
+	docs, err := result.Team.Agent("docs")
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(sessionDir, "docs"), docs.WorkingDir(), "relative to the session working directory")
+	root, err := result.Team.Agent("root")
+	require.NoError(t, err)
+	assert.Empty(t, root.WorkingDir())
+
+	absolute := t.TempDir()
+	result, err = load(t, absolute)
+	require.NoError(t, err)
+	docs, err = result.Team.Agent("docs")
+	require.NoError(t, err)
+	assert.Equal(t, absolute, docs.WorkingDir())
+
+	_, err = load(t, "missing")
+	require.ErrorContains(t, err, `agent "docs": invalid working_dir`)
+}
@@ -0,0 +1,91 @@
+package teamloader
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/docker/cagent/pkg/tools"
+)
+
+type typedTools struct {
+	tools.ToolSet
+	toolsetType string
+}
+
+// Verify interface compliance
+var _ tools.Unwrapper = (*typedTools)(nil)
+
+func (f *typedTools) Tools(ctx context.Context) ([]tools.Tool, error) {
+	allTools, err := f.ToolSet.Tools(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Toolsets may return their cached slice: don't modify it in place.
+	allTools = slices.Clone(allTools)
+	for i := range allTools {
+		allTools[i].Toolset = f.toolsetType
+	}
+
+	return allTools, nil
+}
+
+// Unwrap implements tools.Unwrapper.
+func (f *typedTools) Unwrap() tools.ToolSet {
+	return f.ToolSet
+}
+
+// WithToolsetType stamps the tools of a toolset with the type it was
+// configured with, so that permissions and limits keyed on toolset types
+// apply to every tool of that toolset.
+func WithToolsetType(inner tools.ToolSet, toolsetType string) tools.ToolSet {
+	if toolsetType == "" {
+		return inner
+	}
+
+	return &typedTools{
+		ToolSet:     inner,
+		toolsetType: toolsetType,
+	}
+}
+
+type timedTools struct {
+	tools.ToolSet
+	timeout time.Duration
+}
+
+// Verify interface compliance
+var _ tools.Unwrapper = (*timedTools)(nil)
+
+func (f *timedTools) Tools(ctx context.Context) ([]tools.Tool, error) {
+	allTools, err := f.ToolSet.Tools(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	allTools = slices.Clone(allTools)
+	for i := range allTools {
+		allTools[i].Timeout = f.timeout
+	}
+
+	return allTools, nil
+}
+
+// Unwrap implements tools.Unwrapper.
+func (f *timedTools) Unwrap() tools.ToolSet {
+	return f.ToolSet
+}
+
+// WithCallTimeout sets how long calls to the tools of a toolset can run
+// before they're aborted.
+func WithCallTimeout(inner tools.ToolSet, timeout time.Duration) tools.ToolSet {
+	if timeout <= 0 {
+		return inner
+	}
+
+	return &timedTools{
+		ToolSet: inner,
+		timeout: timeout,
+	}
+}
@@ -0,0 +1,58 @@
+package teamloader
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/docker/cagent/pkg/tools"
+)
+
+func TestWithToolsetType(t *testing.T) {
+	t.Parallel()
+
+	cached := []tools.Tool{
+		{Name: "search", Category: ""},
+		{Name: "query_docs", Category: "knowledge"},
+	}
+	inner := &mockToolSet{
+		toolsFunc: func(context.Context) ([]tools.Tool, error) {
+			return cached, nil
+		},
+	}
+
+	result, err := WithToolsetType(inner, "mcp").Tools(t.Context())
+	require.NoError(t, err)
+
+	require.Len(t, result, 2)
+	assert.Equal(t, "mcp", result[0].Toolset)
+	assert.Equal(t, "mcp", result[1].Toolset)
+	assert.Equal(t, "knowledge", result[1].Category, "the category is left alone")
+	assert.Empty(t, cached[0].Toolset, "the inner toolset's slice must not be modified")
+}
+
+func TestWithToolsetType_Empty(t *testing.T) {
+	t.Parallel()
+
+	inner := &mockToolSet{}
+	assert.Same(t, inner, WithToolsetType(inner, ""))
+}
+
+func TestWithCallTimeout(t *testing.T) {
+	t.Parallel()
+
+	inner := &mockToolSet{
+		toolsFunc: func(context.Context) ([]tools.Tool, error) {
+			return []tools.Tool{{Name: "search"}}, nil
+		},
+	}
+	assert.Same(t, inner, WithCallTimeout(inner, 0))
+
+	result, err := WithCallTimeout(inner, 2*time.Minute).Tools(t.Context())
+	require.NoError(t, err)
+	require.Len(t, result, 1)
+	assert.Equal(t, 2*time.Minute, result[0].Timeout)
+}
@@ -26,31 +26,31 @@ func FromContext(ctx context.Context) *Client {
 }
 
 func RecordError(ctx context.Context, err string) {
-	if client := FromContext(ctx); client != nil {
+	if client := FromContext(ctx); client != nil && !UserOptedOut() {
 		client.RecordError(ctx, err)
 	}
 }
 
 func RecordToolCall(ctx context.Context, toolName, sessionID, agentName string, duration time.Duration, err error) {
-	if client := FromContext(ctx); client != nil {
+	if client := FromContext(ctx); client != nil && !UserOptedOut() {
 		client.RecordToolCall(ctx, toolName, sessionID, agentName, duration, err)
 	}
 }
 
 func RecordSessionEnd(ctx context.Context) {
-	if client := FromContext(ctx); client != nil {
+	if client := FromContext(ctx); client != nil && !UserOptedOut() {
 		client.RecordSessionEnd(ctx)
 	}
 }
 
 func RecordSessionStart(ctx context.Context, sessionID, agentName string) {
-	if client := FromContext(ctx); client != nil {
+	if client := FromContext(ctx); client != nil && !UserOptedOut() {
 		client.RecordSessionStart(ctx, sessionID, agentName)
 	}
 }
 
 func RecordTokenUsage(ctx context.Context, model string, inputTokens, outputTokens int64, cost float64) {
-	if client := FromContext(ctx); client != nil {
+	if client := FromContext(ctx); client != nil && !UserOptedOut() {
 		client.RecordTokenUsage(ctx, model, inputTokens, outputTokens, cost)
 	}
 }
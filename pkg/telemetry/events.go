@@ -19,7 +19,7 @@ func (tc *Client) Track(_ context.Context, structuredEvent StructuredEvent) {
 		return
 	}
 
-	if !tc.enabled {
+	if !tc.enabled || UserOptedOut() {
 		return
 	}
 
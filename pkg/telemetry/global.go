@@ -37,6 +37,14 @@ func GetGlobalTelemetryClient() *Client {
 	return globalToolTelemetryClient
 }
 
+// EventsEnabled reports whether usage events are being sent. The global
+// client is created disabled when telemetry is off at startup, so enabling it
+// back only takes effect for events at the next start.
+func EventsEnabled() bool {
+	client := GetGlobalTelemetryClient()
+	return client != nil && client.enabled && !UserOptedOut()
+}
+
 // SetGlobalTelemetryVersion sets the version for automatic telemetry initialization
 // This should be called by the root package to provide the correct version
 func SetGlobalTelemetryVersion(version string) {
@@ -73,7 +81,7 @@ func EnsureGlobalTelemetryInitialized() {
 		logger := slog.Default()
 
 		// Get telemetry enabled setting
-		enabled := GetTelemetryEnabled()
+		enabled := Enabled()
 
 		client := newClient(logger, enabled, debugMode, version)
 
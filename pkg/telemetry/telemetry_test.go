@@ -938,3 +938,22 @@ func TestNon2xxHTTPResponseHandling(t *testing.T) {
 		return mockHTTP.GetRequestCount() >= 2
 	}, time.Second, 5*time.Millisecond, "Expected at least 2 HTTP requests (500 + 404)")
 }
+
+func TestUserOptOut(t *testing.T) {
+	t.Cleanup(func() { SetUserOptOut(false) })
+
+	mockHTTP := NewMockHTTPClient()
+	client := newClient(slog.Default(), true, false, "test-version", mockHTTP.Client)
+	ctx := WithClient(t.Context(), client)
+
+	SetUserOptOut(true)
+	assert.True(t, UserOptedOut())
+	assert.False(t, Enabled())
+
+	RecordSessionStart(ctx, "agent", "session")
+	RecordError(ctx, "boom")
+	client.Track(ctx, &CommandEvent{Action: "run"})
+	assert.Empty(t, client.session.ID, "no session should be recorded when opted out")
+	assert.Empty(t, client.session.Error, "no error should be recorded when opted out")
+	assert.Zero(t, mockHTTP.GetRequestCount(), "no event should be sent when opted out")
+}
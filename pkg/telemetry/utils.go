@@ -9,6 +9,7 @@ import (
 	"path/filepath"
 	"runtime"
 	"strings"
+	"sync/atomic"
 
 	"github.com/google/uuid"
 
@@ -30,6 +31,26 @@ func GetTelemetryEnabled() bool {
 	return getTelemetryEnabledFromEnv()
 }
 
+// userOptOut is set when the user disabled telemetry in their settings.
+var userOptOut atomic.Bool
+
+// SetUserOptOut disables, or enables back, all telemetry: usage events
+// and OpenTelemetry spans.
+func SetUserOptOut(optOut bool) {
+	userOptOut.Store(optOut)
+}
+
+// UserOptedOut reports whether the user disabled telemetry in their settings.
+func UserOptedOut() bool {
+	return userOptOut.Load()
+}
+
+// Enabled reports whether telemetry is currently enabled, taking both the
+// TELEMETRY_ENABLED environment variable and the user's opt-out into account.
+func Enabled() bool {
+	return !UserOptedOut() && GetTelemetryEnabled()
+}
+
 // getTelemetryEnabledFromEnv checks only the environment variable,
 // without the test detection bypass. This allows testing the env var logic.
 func getTelemetryEnabledFromEnv() bool {
@@ -174,7 +174,7 @@ type EditFileArgs struct {
 }
 
 func (t *FilesystemTool) Tools(context.Context) ([]tools.Tool, error) {
-	return []tools.Tool{
+	toolList := []tools.Tool{
 		{
 			Name:        ToolNameDirectoryTree,
 			Category:    "filesystem",
@@ -307,7 +307,11 @@ func (t *FilesystemTool) Tools(context.Context) ([]tools.Tool, error) {
 				Title: "Remove Directory",
 			},
 		},
-	}, nil
+	}
+	for i := range toolList {
+		toolList[i].WorkingDir = t.workingDir
+	}
+	return toolList, nil
 }
 
 // executePostEditCommands executes any matching post-edit commands for the given file path
@@ -341,11 +345,17 @@ func (t *FilesystemTool) executePostEditCommands(ctx context.Context, filePath s
 // Relative paths (including ".") are joined with the working directory.
 // Absolute paths and paths starting with ".." are used as-is.
 func (t *FilesystemTool) resolvePath(path string) string {
+	return ResolvePath(t.workingDir, path)
+}
+
+// ResolvePath resolves a path taken by the filesystem tools the way they do:
+// relative paths are joined with workingDir, absolute paths are used as-is.
+func ResolvePath(workingDir, path string) string {
 	if filepath.IsAbs(path) {
 		return filepath.Clean(path)
 	}
 
-	return filepath.Clean(filepath.Join(t.workingDir, path))
+	return filepath.Clean(filepath.Join(workingDir, path))
 }
 
 // initGitignoreMatcher initializes the gitignore matcher for the working directory.
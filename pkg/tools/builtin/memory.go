@@ -149,3 +149,30 @@ func (t *MemoryTool) handleDeleteMemory(ctx context.Context, args DeleteMemoryAr
 
 	return tools.ResultSuccess(fmt.Sprintf("Memory with ID %s deleted successfully", args.ID)), nil
 }
+
+// Memories returns all the stored memories.
+func (t *MemoryTool) Memories(ctx context.Context) ([]database.UserMemory, error) {
+	return t.db.GetMemories(ctx)
+}
+
+// DeleteMemory deletes the memory with the given ID.
+func (t *MemoryTool) DeleteMemory(ctx context.Context, id string) error {
+	if id == "" {
+		return database.ErrEmptyID
+	}
+	return t.db.DeleteMemory(ctx, database.UserMemory{ID: id})
+}
+
+// ClearMemories deletes all the stored memories.
+func (t *MemoryTool) ClearMemories(ctx context.Context) error {
+	memories, err := t.db.GetMemories(ctx)
+	if err != nil {
+		return err
+	}
+	for _, memory := range memories {
+		if err := t.db.DeleteMemory(ctx, memory); err != nil {
+			return err
+		}
+	}
+	return nil
+}
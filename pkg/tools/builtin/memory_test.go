@@ -144,3 +144,18 @@ func TestMemoryTool_ParametersAreObjects(t *testing.T) {
 		assert.Equal(t, "object", m["type"])
 	}
 }
+
+func TestMemoryTool_ClearMemories(t *testing.T) {
+	manager := new(MockDB)
+	tool := NewMemoryTool(manager)
+
+	memories := []database.UserMemory{{ID: "1", Memory: "first"}, {ID: "2", Memory: "second"}}
+	manager.On("GetMemories", mock.Anything).Return(memories, nil)
+	manager.On("DeleteMemory", mock.Anything, memories[0]).Return(nil)
+	manager.On("DeleteMemory", mock.Anything, memories[1]).Return(nil)
+
+	require.NoError(t, tool.ClearMemories(t.Context()))
+	manager.AssertExpectations(t)
+
+	require.ErrorIs(t, tool.DeleteMemory(t.Context(), ""), database.ErrEmptyID)
+}
@@ -12,6 +12,7 @@ import (
 	"strings"
 
 	"github.com/docker/cagent/pkg/config/latest"
+	"github.com/docker/cagent/pkg/environment"
 	"github.com/docker/cagent/pkg/tools"
 )
 
@@ -145,7 +146,7 @@ func (t *ScriptShellTool) execute(ctx context.Context, toolConfig *latest.Script
 
 	cmd := exec.CommandContext(ctx, shell, "-c", toolConfig.Cmd)
 	cmd.Dir = toolConfig.WorkingDir
-	cmd.Env = t.env
+	cmd.Env = environment.ApplyOverrides(ctx, t.env)
 	for key, value := range params {
 		if value != nil {
 			cmd.Env = append(cmd.Env, fmt.Sprintf("%s=%s", key, value))
@@ -18,6 +18,7 @@ import (
 
 	"github.com/docker/cagent/pkg/concurrent"
 	"github.com/docker/cagent/pkg/config"
+	"github.com/docker/cagent/pkg/environment"
 	"github.com/docker/cagent/pkg/tools"
 )
 
@@ -158,7 +159,7 @@ func (h *shellHandler) RunShell(ctx context.Context, params RunShellArgs) (*tool
 
 func (h *shellHandler) runNativeCommand(timeoutCtx, ctx context.Context, command, cwd string, timeout time.Duration) *tools.ToolCallResult {
 	cmd := exec.Command(h.shell, append(h.shellArgsPrefix, command)...)
-	cmd.Env = h.env
+	cmd.Env = environment.ApplyOverrides(ctx, h.env)
 	cmd.Dir = cwd
 	cmd.SysProcAttr = platformSpecificSysProcAttr()
 
@@ -191,12 +192,12 @@ func (h *shellHandler) runNativeCommand(timeoutCtx, ctx context.Context, command
 	return tools.ResultSuccess(limitOutput(output))
 }
 
-func (h *shellHandler) RunShellBackground(_ context.Context, params RunShellBackgroundArgs) (*tools.ToolCallResult, error) {
+func (h *shellHandler) RunShellBackground(ctx context.Context, params RunShellBackgroundArgs) (*tools.ToolCallResult, error) {
 	counter := h.jobCounter.Add(1)
 	jobID := fmt.Sprintf("job_%d_%d", time.Now().Unix(), counter)
 
 	cmd := exec.Command(h.shell, append(h.shellArgsPrefix, params.Cmd)...)
-	cmd.Env = h.env
+	cmd.Env = environment.ApplyOverrides(ctx, h.env)
 	cmd.Dir = h.resolveWorkDir(params.Cwd)
 	cmd.SysProcAttr = platformSpecificSysProcAttr()
 
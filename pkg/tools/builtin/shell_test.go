@@ -8,6 +8,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/docker/cagent/pkg/config"
+	"github.com/docker/cagent/pkg/environment"
 	"github.com/docker/cagent/pkg/tools"
 )
 
@@ -38,6 +39,23 @@ func TestShellTool_HandlerEcho(t *testing.T) {
 	assert.Contains(t, result.Output, "hello world")
 }
 
+func TestShellTool_HandlerEnvOverrides(t *testing.T) {
+	tool := NewShellTool([]string{"CAGENT_TEST_VAR=base"}, &config.RuntimeConfig{Config: config.Config{WorkingDir: t.TempDir()}})
+
+	ctx := environment.WithOverrides(t.Context(), map[string]string{"CAGENT_TEST_VAR": "override"})
+	result, err := tool.handler.RunShell(ctx, RunShellArgs{
+		Cmd: "echo $CAGENT_TEST_VAR",
+	})
+	require.NoError(t, err)
+	assert.Contains(t, result.Output, "override")
+
+	result, err = tool.handler.RunShell(t.Context(), RunShellArgs{
+		Cmd: "echo $CAGENT_TEST_VAR",
+	})
+	require.NoError(t, err)
+	assert.Contains(t, result.Output, "base")
+}
+
 func TestShellTool_HandlerWithCwd(t *testing.T) {
 	tool := NewShellTool(nil, &config.RuntimeConfig{Config: config.Config{WorkingDir: t.TempDir()}})
 	tmpDir := t.TempDir()
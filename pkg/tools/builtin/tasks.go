@@ -115,6 +115,80 @@ Tasks are saved to a JSON file and survive across sessions. A task is automatica
 Workflow: create_task → list_tasks/next_task → update_task as work progresses. Use add_dependency/remove_dependency to manage ordering.`
 }
 
+// StoragePath returns the path of the JSON file the tasks are saved to.
+func (t *TasksTool) StoragePath() string {
+	return t.filePath
+}
+
+// HasTasks reports whether at least one task has been saved.
+func (t *TasksTool) HasTasks() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return len(t.load().Tasks) > 0
+}
+
+// CurrentTaskMarkdown returns the markdown of the task being worked on, or of
+// the most recently updated task when none is in progress. It returns false
+// when there are no tasks.
+func (t *TasksTool) CurrentTaskMarkdown() (string, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	store := t.load()
+	var current Task
+	found := false
+	for _, task := range store.Tasks {
+		if !found || isMoreCurrent(task, current) {
+			current, found = task, true
+		}
+	}
+	if !found {
+		return "", false
+	}
+	return buildTaskMarkdown(current, store.Tasks), true
+}
+
+// isMoreCurrent reports whether a is a better pick than b for the current
+// task: tasks in progress first, then the most recently updated one.
+func isMoreCurrent(a, b Task) bool {
+	if (a.Status == StatusInProgress) != (b.Status == StatusInProgress) {
+		return a.Status == StatusInProgress
+	}
+	if a.UpdatedAt != b.UpdatedAt {
+		return a.UpdatedAt > b.UpdatedAt
+	}
+	return a.ID < b.ID
+}
+
+// buildTaskMarkdown renders a task, with its dependencies, as markdown.
+func buildTaskMarkdown(task Task, tasks map[string]Task) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "# %s\n\n", task.Title)
+	fmt.Fprintf(&sb, "- **Status:** %s\n", effectiveStatus(task, tasks))
+	fmt.Fprintf(&sb, "- **Priority:** %s\n", task.Priority)
+	fmt.Fprintf(&sb, "- **ID:** %s\n", task.ID)
+
+	if desc := strings.TrimSpace(task.Description); desc != "" {
+		fmt.Fprintf(&sb, "\n%s\n", desc)
+	}
+
+	if len(task.Dependencies) > 0 {
+		sb.WriteString("\n## Dependencies\n\n")
+		for _, depID := range task.Dependencies {
+			dep, ok := tasks[depID]
+			switch {
+			case !ok:
+				fmt.Fprintf(&sb, "- [ ] %s (missing)\n", depID)
+			case dep.Status == StatusDone:
+				fmt.Fprintf(&sb, "- [x] %s\n", dep.Title)
+			default:
+				fmt.Fprintf(&sb, "- [ ] %s\n", dep.Title)
+			}
+		}
+	}
+	return sb.String()
+}
+
 func (t *TasksTool) load() taskStore {
 	data, err := os.ReadFile(t.filePath)
 	if err != nil {
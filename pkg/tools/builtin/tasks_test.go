@@ -30,6 +30,49 @@ func TestTasksTool_DisplayNames(t *testing.T) {
 	}
 }
 
+func TestTasksTool_HasTasks(t *testing.T) {
+	tool := newTestTasksTool(t)
+	assert.Equal(t, "tasks.json", filepath.Base(tool.StoragePath()))
+	assert.False(t, tool.HasTasks())
+
+	_, err := tool.createTask(t.Context(), CreateTaskArgs{Title: "Build feature"})
+	require.NoError(t, err)
+	assert.True(t, tool.HasTasks())
+}
+
+func TestTasksTool_CurrentTaskMarkdown(t *testing.T) {
+	tool := newTestTasksTool(t)
+	_, ok := tool.CurrentTaskMarkdown()
+	assert.False(t, ok)
+
+	result, err := tool.createTask(t.Context(), CreateTaskArgs{Title: "Design API", Description: "Sketch the endpoints"})
+	require.NoError(t, err)
+	var design Task
+	require.NoError(t, json.Unmarshal([]byte(result.Output), &design))
+
+	result, err = tool.createTask(t.Context(), CreateTaskArgs{Title: "Build API", Priority: "high", Dependencies: []string{design.ID}})
+	require.NoError(t, err)
+	var build Task
+	require.NoError(t, json.Unmarshal([]byte(result.Output), &build))
+
+	// The task in progress wins over more recently updated ones
+	_, err = tool.updateTask(t.Context(), UpdateTaskArgs{ID: design.ID, Status: "in_progress"})
+	require.NoError(t, err)
+	markdown, ok := tool.CurrentTaskMarkdown()
+	require.True(t, ok)
+	assert.Equal(t, "# Design API\n\n- **Status:** in_progress\n- **Priority:** medium\n- **ID:** "+design.ID+"\n\nSketch the endpoints\n", markdown)
+
+	_, err = tool.updateTask(t.Context(), UpdateTaskArgs{ID: design.ID, Status: "done"})
+	require.NoError(t, err)
+	_, err = tool.updateTask(t.Context(), UpdateTaskArgs{ID: build.ID, Status: "in_progress"})
+	require.NoError(t, err)
+	markdown, ok = tool.CurrentTaskMarkdown()
+	require.True(t, ok)
+	assert.Contains(t, markdown, "# Build API\n")
+	assert.Contains(t, markdown, "- **Priority:** high\n")
+	assert.Contains(t, markdown, "## Dependencies\n\n- [x] Design API\n")
+}
+
 func TestTasksTool_CreateTask(t *testing.T) {
 	tool := newTestTasksTool(t)
 
@@ -10,13 +10,16 @@ import (
 	"io"
 	"iter"
 	"log/slog"
+	"maps"
 	"net/url"
+	"slices"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 
+	"github.com/docker/cagent/pkg/environment"
 	"github.com/docker/cagent/pkg/tools"
 )
 
@@ -37,6 +40,14 @@ type mcpClient interface {
 	Close(ctx context.Context) error
 }
 
+// envOverridable is implemented by clients that start a local server
+// process, whose environment can carry the session scoped overrides.
+type envOverridable interface {
+	// withEnvOverrides returns a client starting its own server process,
+	// with the overrides applied on top of the configured environment.
+	withEnvOverrides(overrides map[string]string) mcpClient
+}
+
 // Toolset represents a set of MCP tools
 type Toolset struct {
 	name         string
@@ -48,6 +59,11 @@ type Toolset struct {
 	started      bool
 	stopping     bool // true when Stop() has been called
 
+	// overridden holds the servers started for the sessions with env
+	// overrides, keyed by the overrides. The shared server never runs with
+	// a session's environment. They are stopped with the toolset.
+	overridden map[string]*Toolset
+
 	// Cached tools and prompts, invalidated via MCP notifications.
 	// cacheGen is bumped on each invalidation so that a concurrent
 	// Tools()/ListPrompts() call can detect that its result is stale.
@@ -413,9 +429,62 @@ func (ts *Toolset) refreshPromptCache(ctx context.Context) {
 	}
 }
 
+// clientFor returns the client calling the tools for the session scoped env
+// overrides carried by ctx. Without overrides, that's the shared server's.
+// With overrides, it's a server of its own started with them, shared only by
+// the sessions with the same overrides.
+func (ts *Toolset) clientFor(ctx context.Context) (mcpClient, error) {
+	overridable, ok := ts.mcpClient.(envOverridable)
+	overrides := environment.OverridesFromContext(ctx)
+	if !ok || len(overrides) == 0 {
+		return ts.mcpClient, nil
+	}
+	key := envOverridesKey(overrides)
+
+	ts.mu.Lock()
+	if ts.stopping {
+		ts.mu.Unlock()
+		return nil, errors.New("toolset stopped")
+	}
+	overridden, exists := ts.overridden[key]
+	if !exists {
+		overridden = &Toolset{
+			name:        ts.name,
+			mcpClient:   overridable.withEnvOverrides(overrides),
+			logID:       ts.logID,
+			description: ts.description,
+		}
+		if ts.overridden == nil {
+			ts.overridden = make(map[string]*Toolset)
+		}
+		ts.overridden[key] = overridden
+	}
+	ts.mu.Unlock()
+
+	// Started outside of ts.mu so that the other sessions don't wait for it
+	if err := overridden.Start(ctx); err != nil {
+		return nil, fmt.Errorf("failed to start MCP server with the session environment: %w", err)
+	}
+	return overridden.mcpClient, nil
+}
+
+// envOverridesKey identifies a set of env overrides.
+func envOverridesKey(overrides map[string]string) string {
+	var key strings.Builder
+	for _, name := range slices.Sorted(maps.Keys(overrides)) {
+		key.WriteString(name + "=" + overrides[name] + "\x00")
+	}
+	return key.String()
+}
+
 func (ts *Toolset) callTool(ctx context.Context, toolCall tools.ToolCall) (*tools.ToolCallResult, error) {
 	slog.Debug("Calling MCP tool", "tool", toolCall.Function.Name, "arguments", toolCall.Function.Arguments)
 
+	client, err := ts.clientFor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	toolCall.Function.Arguments = cmp.Or(toolCall.Function.Arguments, "{}")
 	var args map[string]any
 	if err := json.Unmarshal([]byte(toolCall.Function.Arguments), &args); err != nil {
@@ -437,7 +506,7 @@ func (ts *Toolset) callTool(ctx context.Context, toolCall tools.ToolCall) (*tool
 	request.Name = toolCall.Function.Name
 	request.Arguments = args
 
-	resp, err := ts.mcpClient.CallTool(ctx, request)
+	resp, err := client.CallTool(ctx, request)
 	if err != nil {
 		if errors.Is(err, context.Canceled) || errors.Is(ctx.Err(), context.Canceled) {
 			slog.Debug("CallTool canceled by context", "tool", toolCall.Function.Name)
@@ -459,8 +528,16 @@ func (ts *Toolset) Stop(ctx context.Context) error {
 	ts.mu.Lock()
 	ts.stopping = true
 	ts.started = false
+	overridden := ts.overridden
+	ts.overridden = nil
 	ts.mu.Unlock()
 
+	for _, o := range overridden {
+		if err := o.Stop(ctx); err != nil {
+			slog.Warn("Failed to stop MCP server started with session environment", "server", ts.logID, "error", err)
+		}
+	}
+
 	if err := ts.mcpClient.Close(context.WithoutCancel(ctx)); err != nil {
 		if ctx.Err() != nil {
 			return nil
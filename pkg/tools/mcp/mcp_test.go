@@ -1,14 +1,17 @@
 package mcp
 
 import (
+	"cmp"
 	"context"
 	"iter"
+	"sync"
 	"testing"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/docker/cagent/pkg/environment"
 	"github.com/docker/cagent/pkg/tools"
 )
 
@@ -110,6 +113,92 @@ func TestCallToolStripsNullArguments(t *testing.T) {
 	}
 }
 
+// envMockMCPClient is a mockMCPClient backed by a local server process,
+// answering tool calls with the DB_URL it was started with, if any.
+type envMockMCPClient struct {
+	mockMCPClient
+	overrides  map[string]string
+	overridden []*envMockMCPClient
+	done       chan struct{}
+	closeOnce  sync.Once
+}
+
+func newEnvMockMCPClient(overrides map[string]string) *envMockMCPClient {
+	m := &envMockMCPClient{overrides: overrides, done: make(chan struct{})}
+	m.callToolFn = func(context.Context, *mcp.CallToolParams) (*mcp.CallToolResult, error) {
+		return callToolResult(&mcp.TextContent{Text: cmp.Or(m.overrides["DB_URL"], "shared")}), nil
+	}
+	return m
+}
+
+func (m *envMockMCPClient) Wait() error {
+	<-m.done
+	return nil
+}
+
+func (m *envMockMCPClient) Close(context.Context) error {
+	m.closeOnce.Do(func() { close(m.done) })
+	return nil
+}
+
+func (m *envMockMCPClient) withEnvOverrides(overrides map[string]string) mcpClient {
+	client := newEnvMockMCPClient(overrides)
+	m.overridden = append(m.overridden, client)
+	return client
+}
+
+func TestCallToolRunsSessionEnvOverridesOnTheirOwnServer(t *testing.T) {
+	t.Parallel()
+
+	client := newEnvMockMCPClient(nil)
+	ts := &Toolset{mcpClient: client, started: true}
+	call := tools.ToolCall{Function: tools.FunctionCall{Name: "test_tool", Arguments: "{}"}}
+	callWith := func(overrides map[string]string) string {
+		t.Helper()
+		result, err := ts.callTool(environment.WithOverrides(t.Context(), overrides), call)
+		require.NoError(t, err)
+		return result.Output
+	}
+
+	// No overrides: the shared server answers.
+	assert.Equal(t, "shared", callWith(nil))
+	assert.Empty(t, client.overridden)
+
+	// Each set of overrides gets a server of its own, and the shared one
+	// keeps its environment.
+	assert.Equal(t, "postgres://a", callWith(map[string]string{"DB_URL": "postgres://a"}))
+	assert.Equal(t, "postgres://b", callWith(map[string]string{"DB_URL": "postgres://b"}))
+	assert.Equal(t, "shared", callWith(nil))
+	assert.Nil(t, client.overrides)
+	assert.Len(t, client.overridden, 2)
+
+	// The same overrides reuse their server.
+	assert.Equal(t, "postgres://a", callWith(map[string]string{"DB_URL": "postgres://a"}))
+	assert.Len(t, client.overridden, 2)
+
+	// Stopping the toolset stops them all.
+	require.NoError(t, ts.Stop(t.Context()))
+	for _, overridden := range append(client.overridden, client) {
+		assert.NoError(t, overridden.Wait())
+	}
+}
+
+func TestStdioEnvironIncludesSessionOverrides(t *testing.T) {
+	t.Parallel()
+
+	client := newStdioCmdClient("server", nil, []string{"FOO=config"}, "")
+	assert.Equal(t, []string{"FOO=config"}, client.environ())
+
+	overridden := client.withEnvOverrides(map[string]string{"FOO": "session", "BAR": "1"}).(*stdioMCPClient)
+	assert.Equal(t, []string{"FOO=config", "BAR=1", "FOO=session"}, overridden.environ())
+	assert.Equal(t, []string{"FOO=config"}, client.environ())
+
+	// A nil environment inherits the parent's before the overrides are appended.
+	inherit := newStdioCmdClient("server", nil, nil, "").withEnvOverrides(map[string]string{"BAR": "1"}).(*stdioMCPClient)
+	assert.Greater(t, len(inherit.environ()), 1)
+	assert.Equal(t, "BAR=1", inherit.environ()[len(inherit.environ())-1])
+}
+
 func TestProcessMCPContent(t *testing.T) {
 	t.Parallel()
 
@@ -3,12 +3,15 @@ package mcp
 import (
 	"context"
 	"errors"
+	"maps"
+	"os"
 	"os/exec"
 	"runtime"
 
 	gomcp "github.com/modelcontextprotocol/go-sdk/mcp"
 
 	"github.com/docker/cagent/pkg/desktop"
+	"github.com/docker/cagent/pkg/environment"
 )
 
 type stdioMCPClient struct {
@@ -17,6 +20,10 @@ type stdioMCPClient struct {
 	args    []string
 	env     []string
 	cwd     string
+
+	// overrides holds the session scoped environment overrides the server
+	// is started with. Set only on the clients made by withEnvOverrides.
+	overrides map[string]string
 }
 
 func newStdioCmdClient(command string, args, env []string, cwd string) *stdioMCPClient {
@@ -50,7 +57,7 @@ func (c *stdioMCPClient) Initialize(ctx context.Context, _ *gomcp.InitializeRequ
 	}, opts)
 
 	cmd := exec.CommandContext(ctx, c.command, c.args...)
-	cmd.Env = c.env
+	cmd.Env = c.environ()
 	cmd.Dir = c.cwd
 	session, err := client.Connect(ctx, &gomcp.CommandTransport{
 		Command: cmd,
@@ -63,3 +70,31 @@ func (c *stdioMCPClient) Initialize(ctx context.Context, _ *gomcp.InitializeRequ
 
 	return session.InitializeResult(), nil
 }
+
+// withEnvOverrides returns a client starting the same server, with the
+// session scoped overrides, and answering its elicitations the same way.
+func (c *stdioMCPClient) withEnvOverrides(overrides map[string]string) mcpClient {
+	c.mu.RLock()
+	handler := c.elicitationHandler
+	c.mu.RUnlock()
+
+	client := newStdioCmdClient(c.command, c.args, c.env, c.cwd)
+	client.overrides = maps.Clone(overrides)
+	client.SetElicitationHandler(handler)
+	return client
+}
+
+// environ returns the environment of the server process: the configured
+// environment with the session scoped overrides appended.
+func (c *stdioMCPClient) environ() []string {
+	if len(c.overrides) == 0 {
+		return c.env
+	}
+	env := c.env
+	if env == nil {
+		// A nil environment means "inherit"; keep doing so once overrides
+		// are appended.
+		env = os.Environ()
+	}
+	return environment.ApplyOverrides(environment.WithOverrides(context.Background(), c.overrides), env)
+}
@@ -33,8 +33,9 @@ func DescribeToolSet(ts ToolSet) string {
 type StartableToolSet struct {
 	ToolSet
 
-	mu      sync.Mutex
-	started bool
+	mu       sync.Mutex
+	started  bool
+	startErr error
 }
 
 // NewStartable wraps a ToolSet for lazy initialization.
@@ -50,6 +51,14 @@ func (s *StartableToolSet) IsStarted() bool {
 	return s.started
 }
 
+// StartError returns the error of the last start attempt, nil when it
+// succeeded or none was made.
+func (s *StartableToolSet) StartError() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.startErr
+}
+
 // Start starts the toolset with single-flight semantics.
 // Concurrent callers block until the start attempt completes.
 // If start fails, a future call will retry.
@@ -64,10 +73,12 @@ func (s *StartableToolSet) Start(ctx context.Context) error {
 
 	if startable, ok := As[Startable](s.ToolSet); ok {
 		if err := startable.Start(ctx); err != nil {
+			s.startErr = err
 			return err
 		}
 	}
 	s.started = true
+	s.startErr = nil
 	return nil
 }
 
@@ -3,6 +3,7 @@ package tools
 import (
 	"context"
 	"encoding/json"
+	"time"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
@@ -87,8 +88,18 @@ func ResultSuccess(output string) *ToolCallResult {
 type ToolType string
 
 type Tool struct {
-	Name                    string          `json:"name"`
-	Category                string          `json:"category"`
+	Name     string `json:"name"`
+	Category string `json:"category"`
+	// Toolset is the type of the configured toolset the tool comes from
+	// (e.g. "mcp", "rag", "shell"). Unlike Category, which is a display
+	// grouping chosen by the tool, it matches the agent config.
+	Toolset string `json:"toolset,omitempty"`
+	// Timeout is how long a call can run before it's aborted, when the
+	// toolset sets its own. Zero leaves it to the runtime.
+	Timeout time.Duration `json:"-"`
+	// WorkingDir is the directory relative paths in the arguments are
+	// resolved against, for tools that take file paths.
+	WorkingDir              string          `json:"working_dir,omitempty"`
 	Description             string          `json:"description,omitempty"`
 	Parameters              any             `json:"parameters"`
 	Annotations             ToolAnnotations `json:"annotations"`
@@ -0,0 +1,31 @@
+package tui
+
+import (
+	"context"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+// autosaveTickMsg is sent every autosave interval.
+type autosaveTickMsg struct{}
+
+// autosaveCmd schedules the next autosave, or returns nil when autosave is
+// disabled.
+func (m *appModel) autosaveCmd() tea.Cmd {
+	if m.autosaveInterval <= 0 {
+		return nil
+	}
+	return tea.Tick(m.autosaveInterval, func(time.Time) tea.Msg {
+		return autosaveTickMsg{}
+	})
+}
+
+// handleAutosave saves the sessions that changed since the last save and
+// schedules the next autosave. It saves on the UI loop so that it never races
+// with the changes made to a session from the TUI. A save only writes the
+// session's row, not its messages.
+func (m *appModel) handleAutosave() (tea.Model, tea.Cmd) {
+	m.supervisor.SaveDirtySessions(context.Background())
+	return m, m.autosaveCmd()
+}
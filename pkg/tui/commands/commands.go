@@ -4,13 +4,16 @@ import (
 	"context"
 	"fmt"
 	"slices"
+	"strconv"
 	"strings"
+	"unicode"
 
 	tea "charm.land/bubbletea/v2"
 
 	"github.com/docker/cagent/pkg/app"
 	"github.com/docker/cagent/pkg/feedback"
 	"github.com/docker/cagent/pkg/modelsdev"
+	"github.com/docker/cagent/pkg/tui/components/notification"
 	"github.com/docker/cagent/pkg/tui/components/toolcommon"
 	"github.com/docker/cagent/pkg/tui/core"
 	"github.com/docker/cagent/pkg/tui/messages"
@@ -37,6 +40,16 @@ type Item struct {
 
 func builtInSessionCommands() []Item {
 	cmds := []Item{
+		{
+			ID:           "session.agent",
+			Label:        "Agent",
+			SlashCommand: "/agent",
+			Description:  "Search the agents by name, description or tool and switch to one",
+			Category:     "Session",
+			Execute: func(string) tea.Cmd {
+				return core.CmdHandler(messages.OpenAgentPickerMsg{})
+			},
+		},
 		{
 			ID:           "session.attach",
 			Label:        "Attach",
@@ -47,6 +60,36 @@ func builtInSessionCommands() []Item {
 				return core.CmdHandler(messages.AttachFileMsg{FilePath: arg})
 			},
 		},
+		{
+			ID:           "session.attach-url",
+			Label:        "Attach URL",
+			SlashCommand: "/attach-url",
+			Description:  "Attach a web page, fetched when the message is sent (usage: /attach-url <url>)",
+			Category:     "Session",
+			Execute: func(arg string) tea.Cmd {
+				return core.CmdHandler(messages.AttachURLMsg{URL: arg})
+			},
+		},
+		{
+			ID:           "session.bookmark",
+			Label:        "Bookmark",
+			SlashCommand: "/bookmark",
+			Description:  "Bookmark the current scroll position (usage: /bookmark [label])",
+			Category:     "Session",
+			Execute: func(arg string) tea.Cmd {
+				return core.CmdHandler(messages.AddBookmarkMsg{Label: strings.TrimSpace(arg)})
+			},
+		},
+		{
+			ID:           "session.bookmarks",
+			Label:        "Bookmarks",
+			SlashCommand: "/bookmarks",
+			Description:  "Jump to a bookmarked position of the conversation",
+			Category:     "Session",
+			Execute: func(string) tea.Cmd {
+				return core.CmdHandler(messages.ShowBookmarksDialogMsg{})
+			},
+		},
 		{
 			ID:           "session.compact",
 			Label:        "Compact",
@@ -57,6 +100,26 @@ func builtInSessionCommands() []Item {
 				return core.CmdHandler(messages.CompactSessionMsg{AdditionalPrompt: arg})
 			},
 		},
+		{
+			ID:           "session.config",
+			Label:        "Config",
+			SlashCommand: "/config",
+			Description:  "Show the effective configuration of the team, with secrets redacted",
+			Category:     "Session",
+			Execute: func(string) tea.Cmd {
+				return core.CmdHandler(messages.ShowEffectiveConfigMsg{})
+			},
+		},
+		{
+			ID:           "session.continue",
+			Label:        "Continue in New Tab",
+			SlashCommand: "/continue",
+			Description:  "Start a new tab from a summary of this session",
+			Category:     "Session",
+			Execute: func(string) tea.Cmd {
+				return core.CmdHandler(messages.ContinueInNewTabMsg{})
+			},
+		},
 		{
 			ID:           "session.clipboard",
 			Label:        "Copy",
@@ -77,6 +140,26 @@ func builtInSessionCommands() []Item {
 				return core.CmdHandler(messages.CopyLastResponseToClipboardMsg{})
 			},
 		},
+		{
+			ID:           "session.copy_link",
+			Label:        "Copy Link",
+			SlashCommand: "/copy-link",
+			Description:  "Copy a link to the session, to open it with --session (Alt+K)",
+			Category:     "Session",
+			Execute: func(string) tea.Cmd {
+				return core.CmdHandler(messages.CopySessionLinkMsg{})
+			},
+		},
+		{
+			ID:           "session.copy_task",
+			Label:        "Copy Task",
+			SlashCommand: "/copy-task",
+			Description:  "Copy the current task as markdown to the clipboard",
+			Category:     "Session",
+			Execute: func(string) tea.Cmd {
+				return core.CmdHandler(messages.CopyTaskToClipboardMsg{})
+			},
+		},
 		{
 			ID:           "session.cost",
 			Label:        "Cost",
@@ -87,6 +170,46 @@ func builtInSessionCommands() []Item {
 				return core.CmdHandler(messages.ShowCostDialogMsg{})
 			},
 		},
+		{
+			ID:           "session.date",
+			Label:        "Date",
+			SlashCommand: "/date",
+			Description:  "Set or clear the date the agent sees as today (usage: /date [YYYY-MM-DD])",
+			Category:     "Session",
+			Execute: func(arg string) tea.Cmd {
+				return core.CmdHandler(messages.SetDateOverrideMsg{Date: strings.TrimSpace(arg)})
+			},
+		},
+		{
+			ID:           "session.duplicate",
+			Label:        "Duplicate",
+			SlashCommand: "/duplicate",
+			Description:  "Open an independent copy of the current session in a new tab",
+			Category:     "Session",
+			Execute: func(string) tea.Cmd {
+				return core.CmdHandler(messages.DuplicateSessionMsg{})
+			},
+		},
+		{
+			ID:           "session.edit-config",
+			Label:        "Edit Config",
+			SlashCommand: "/edit-config",
+			Description:  "Open the team's config file in $EDITOR and reload the team (usage: /edit-config [user])",
+			Category:     "Session",
+			Execute: func(arg string) tea.Cmd {
+				return core.CmdHandler(messages.EditConfigMsg{User: strings.TrimSpace(arg) == "user"})
+			},
+		},
+		{
+			ID:           "session.env",
+			Label:        "Env",
+			SlashCommand: "/env",
+			Description:  "Set an environment variable for this session's tools (usage: /env KEY=VALUE, /env KEY= to unset)",
+			Category:     "Session",
+			Execute: func(arg string) tea.Cmd {
+				return core.CmdHandler(messages.SetEnvOverrideMsg{Assignment: strings.TrimSpace(arg)})
+			},
+		},
 		{
 			ID:           "session.eval",
 			Label:        "Eval",
@@ -117,6 +240,46 @@ func builtInSessionCommands() []Item {
 				return core.CmdHandler(messages.ExportSessionMsg{Filename: arg})
 			},
 		},
+		{
+			ID:           "session.insert",
+			Label:        "Insert File",
+			SlashCommand: "/insert",
+			Description:  "Insert a file's contents into your message as a code block (usage: /insert [path])",
+			Category:     "Session",
+			Execute: func(arg string) tea.Cmd {
+				return core.CmdHandler(messages.InsertFileContentsMsg{FilePath: arg})
+			},
+		},
+		{
+			ID:           "session.insert-tool-result",
+			Label:        "Insert Tool Result",
+			SlashCommand: "/insert-tool-result",
+			Description:  "Insert the output of the last tool call into your message as a code block",
+			Category:     "Session",
+			Execute: func(string) tea.Cmd {
+				return core.CmdHandler(messages.InsertLastToolResultMsg{})
+			},
+		},
+		{
+			ID:           "session.log",
+			Label:        "Log Requests",
+			SlashCommand: "/log",
+			Description:  "Log the model requests and responses to a file (usage: /log on|metadata|off)",
+			Category:     "Session",
+			Execute: func(arg string) tea.Cmd {
+				return core.CmdHandler(messages.SetRequestLogMsg{Mode: strings.TrimSpace(arg)})
+			},
+		},
+		{
+			ID:           "session.memory",
+			Label:        "Memory",
+			SlashCommand: "/memory",
+			Description:  "View and delete the memories stored by the current agent",
+			Category:     "Session",
+			Execute: func(string) tea.Cmd {
+				return core.CmdHandler(messages.ShowMemoryDialogMsg{})
+			},
+		},
 		{
 			ID:           "session.model",
 			Label:        "Model",
@@ -137,6 +300,46 @@ func builtInSessionCommands() []Item {
 				return core.CmdHandler(messages.NewSessionMsg{})
 			},
 		},
+		{
+			ID:           "session.open-reference",
+			Label:        "Open Reference",
+			SlashCommand: "/open-ref",
+			Description:  "Open the last file:line reference in view in $EDITOR (also Alt+O, or Ctrl+click a reference)",
+			Category:     "Session",
+			Execute: func(string) tea.Cmd {
+				return core.CmdHandler(messages.OpenFileReferenceMsg{})
+			},
+		},
+		{
+			ID:           "session.overview",
+			Label:        "Overview",
+			SlashCommand: "/overview",
+			Description:  "Show all open sessions with their status and usage",
+			Category:     "Session",
+			Execute: func(string) tea.Cmd {
+				return core.CmdHandler(messages.ShowSessionsOverviewMsg{})
+			},
+		},
+		{
+			ID:           "session.paste",
+			Label:        "Paste",
+			SlashCommand: "/paste",
+			Description:  "Insert the clipboard into your message as a code block",
+			Category:     "Session",
+			Execute: func(string) tea.Cmd {
+				return core.CmdHandler(messages.PasteClipboardMsg{})
+			},
+		},
+		{
+			ID:           "session.paste-attach",
+			Label:        "Paste as Attachment",
+			SlashCommand: "/paste-attach",
+			Description:  "Attach the clipboard to your message",
+			Category:     "Session",
+			Execute: func(string) tea.Cmd {
+				return core.CmdHandler(messages.PasteClipboardMsg{Attach: true})
+			},
+		},
 		{
 			ID:           "session.permissions",
 			Label:        "Permissions",
@@ -147,6 +350,121 @@ func builtInSessionCommands() []Item {
 				return core.CmdHandler(messages.ShowPermissionsDialogMsg{})
 			},
 		},
+		{
+			ID:           "session.prompts",
+			Label:        "Prompts",
+			SlashCommand: "/prompts",
+			Description:  "Insert a prompt template from the prompt library",
+			Category:     "Session",
+			Execute: func(string) tea.Cmd {
+				return core.CmdHandler(messages.ShowPromptLibraryMsg{})
+			},
+		},
+		{
+			ID:           "session.queue",
+			Label:        "Queue",
+			SlashCommand: "/queue",
+			Description:  "Review, reorder, edit or remove queued messages",
+			Category:     "Session",
+			Execute: func(string) tea.Cmd {
+				return core.CmdHandler(messages.ShowQueueDialogMsg{})
+			},
+		},
+		{
+			ID:           "session.redirect",
+			Label:        "Redirect",
+			SlashCommand: "/redirect",
+			Description:  "Interrupt the running agent and continue with a new message (usage: /redirect <message>)",
+			Category:     "Session",
+			Execute: func(arg string) tea.Cmd {
+				return core.CmdHandler(messages.RedirectMsg{Content: strings.TrimSpace(arg)})
+			},
+		},
+		{
+			ID:           "session.stop-after-step",
+			Label:        "Stop After Step",
+			SlashCommand: "/stop-after-step",
+			Description:  "Let the running tool calls finish, then stop the agent (Alt+X)",
+			Category:     "Session",
+			Execute: func(string) tea.Cmd {
+				return core.CmdHandler(messages.StopAfterStepMsg{})
+			},
+		},
+		{
+			ID:           "session.pause-rendering",
+			Label:        "Pause Rendering",
+			SlashCommand: "/pause",
+			Description:  "Freeze the transcript while the agent keeps working (Alt+P)",
+			Category:     "Session",
+			Execute: func(string) tea.Cmd {
+				return core.CmdHandler(messages.TogglePauseRenderingMsg{})
+			},
+		},
+		{
+			ID:           "session.reload",
+			Label:        "Reload",
+			SlashCommand: "/reload",
+			Description:  "Reload the session from the session store, discarding in-memory changes (F5)",
+			Category:     "Session",
+			Execute: func(string) tea.Cmd {
+				return core.CmdHandler(messages.ReloadSessionMsg{})
+			},
+		},
+		{
+			ID:           "session.replay",
+			Label:        "Replay",
+			SlashCommand: "/replay",
+			Description:  "Step through the session message by message (usage: /replay [position])",
+			Category:     "Session",
+			Execute: func(arg string) tea.Cmd {
+				arg = strings.TrimSpace(arg)
+				if arg == "" {
+					return core.CmdHandler(messages.ReplaySessionMsg{})
+				}
+				position, err := strconv.Atoi(arg)
+				if err != nil || position < 1 {
+					return notification.ErrorCmd("Usage: /replay [position]")
+				}
+				return core.CmdHandler(messages.ReplaySessionMsg{Position: position})
+			},
+		},
+		{
+			ID:           "session.reset-cost",
+			Label:        "Reset Cost",
+			SlashCommand: "/reset-cost",
+			Description:  "Reset the cost and token counters of the session, keeping its messages",
+			Category:     "Session",
+			Execute: func(string) tea.Cmd {
+				return core.CmdHandler(messages.ResetCostMsg{})
+			},
+		},
+		{
+			ID:           "session.save-prompt",
+			Label:        "Save Prompt",
+			SlashCommand: "/save-prompt",
+			Description:  "Save a prompt template, {placeholders} are asked on insert (usage: /save-prompt <name> <prompt>)",
+			Category:     "Session",
+			Execute: func(arg string) tea.Cmd {
+				name, content := strings.TrimSpace(arg), ""
+				if i := strings.IndexFunc(name, unicode.IsSpace); i >= 0 {
+					name, content = name[:i], strings.TrimSpace(name[i:])
+				}
+				if name == "" || content == "" {
+					return notification.ErrorCmd("Usage: /save-prompt <name> <prompt>")
+				}
+				return core.CmdHandler(messages.SavePromptTemplateMsg{Name: name, Content: content})
+			},
+		},
+		{
+			ID:           "session.scratchpad",
+			Label:        "Scratchpad",
+			SlashCommand: "/scratchpad",
+			Description:  "Open a notes tab that isn't sent to any agent",
+			Category:     "Session",
+			Execute: func(string) tea.Cmd {
+				return core.CmdHandler(messages.OpenScratchpadMsg{})
+			},
+		},
 		{
 			ID:           "session.history",
 			Label:        "Sessions",
@@ -157,6 +475,16 @@ func builtInSessionCommands() []Item {
 				return core.CmdHandler(messages.OpenSessionBrowserMsg{})
 			},
 		},
+		{
+			ID:           "session.set",
+			Label:        "Set",
+			SlashCommand: "/set",
+			Description:  "Define a $NAME variable replaced in the messages you send (usage: /set NAME=value, /set NAME= to unset)",
+			Category:     "Session",
+			Execute: func(arg string) tea.Cmd {
+				return core.CmdHandler(messages.SetVariableMsg{Assignment: strings.TrimSpace(arg)})
+			},
+		},
 		{
 			ID:           "session.shell",
 			Label:        "Shell",
@@ -167,6 +495,16 @@ func builtInSessionCommands() []Item {
 				return core.CmdHandler(messages.StartShellMsg{})
 			},
 		},
+		{
+			ID:           "session.speed",
+			Label:        "Speed",
+			SlashCommand: "/speed",
+			Description:  "Show the generation speed of the responses in tokens per second",
+			Category:     "Session",
+			Execute: func(string) tea.Cmd {
+				return core.CmdHandler(messages.ShowThroughputMsg{})
+			},
+		},
 		{
 			ID:           "session.star",
 			Label:        "Star",
@@ -177,6 +515,26 @@ func builtInSessionCommands() []Item {
 				return core.CmdHandler(messages.ToggleSessionStarMsg{})
 			},
 		},
+		{
+			ID:           "session.tasks",
+			Label:        "Tasks",
+			SlashCommand: "/tasks",
+			Description:  "Open the folder holding the agent's tasks file",
+			Category:     "Session",
+			Execute: func(string) tea.Cmd {
+				return core.CmdHandler(messages.OpenTasksDirMsg{})
+			},
+		},
+		{
+			ID:           "session.tools",
+			Label:        "Tools",
+			SlashCommand: "/tools",
+			Description:  "List the tools the current agent can use (Alt+T)",
+			Category:     "Session",
+			Execute: func(string) tea.Cmd {
+				return core.CmdHandler(messages.ShowToolsDialogMsg{})
+			},
+		},
 		{
 			ID:           "session.think",
 			Label:        "Think",
@@ -215,21 +573,109 @@ func builtInSessionCommands() []Item {
 		},
 	}
 
-	// Add speak command on supported platforms (macOS only)
-	if speak := speakCommand(); speak != nil {
-		cmds = append(cmds, *speak)
-	}
+	// Add the speech-to-text commands on supported platforms (macOS only)
+	cmds = append(cmds, speakCommands()...)
 
 	return cmds
 }
 
 func builtInSettingsCommands() []Item {
 	return []Item{
+		{
+			ID:           "settings.settings",
+			Label:        "Settings",
+			SlashCommand: "/settings",
+			Description:  "Show and toggle TUI settings",
+			Category:     "Settings",
+			Execute: func(string) tea.Cmd {
+				return core.CmdHandler(messages.ShowSettingsDialogMsg{})
+			},
+		},
+		{
+			ID:           "settings.enter-newline",
+			Label:        "Enter Newline",
+			SlashCommand: "/enter-newline",
+			Description:  "Toggle Enter inserting a newline and Shift+Enter sending",
+			Category:     "Settings",
+			Execute: func(string) tea.Cmd {
+				return core.CmdHandler(messages.ToggleEnterNewlineMsg{})
+			},
+		},
+		{
+			ID:           "settings.focus-mode",
+			Label:        "Focus Mode",
+			SlashCommand: "/focus",
+			Description:  "Toggle hiding the tab bar and the status bar (Alt+Z)",
+			Category:     "Settings",
+			Execute: func(string) tea.Cmd {
+				return core.CmdHandler(messages.ToggleFocusModeMsg{})
+			},
+		},
+		{
+			ID:           "settings.home-paths",
+			Label:        "Home Paths",
+			SlashCommand: "/home-paths",
+			Description:  "Toggle showing paths under the home directory as ~/...",
+			Category:     "Settings",
+			Execute: func(string) tea.Cmd {
+				return core.CmdHandler(messages.ToggleHomeRelativePathsMsg{})
+			},
+		},
+		{
+			ID:           "settings.prompt-prefix",
+			Label:        "Prompt Prefix",
+			SlashCommand: "/prompt-prefix",
+			Description:  "Prepend text to every message you send (usage: /prompt-prefix [text|clear])",
+			Category:     "Settings",
+			Execute: func(arg string) tea.Cmd {
+				return core.CmdHandler(messages.SetPromptPrefixMsg{Text: strings.TrimSpace(arg)})
+			},
+		},
+		{
+			ID:           "settings.prompt-suffix",
+			Label:        "Prompt Suffix",
+			SlashCommand: "/prompt-suffix",
+			Description:  "Append text to every message you send (usage: /prompt-suffix [text|clear])",
+			Category:     "Settings",
+			Execute: func(arg string) tea.Cmd {
+				return core.CmdHandler(messages.SetPromptSuffixMsg{Text: strings.TrimSpace(arg)})
+			},
+		},
+		{
+			ID:           "settings.raw-markdown",
+			Label:        "Raw Markdown",
+			SlashCommand: "/raw",
+			Description:  "Toggle showing the markdown source of messages instead of rendering it",
+			Category:     "Settings",
+			Execute: func(string) tea.Cmd {
+				return core.CmdHandler(messages.ToggleRawMarkdownMsg{})
+			},
+		},
+		{
+			ID:           "settings.send-and-stay",
+			Label:        "Send and Stay",
+			SlashCommand: "/send-and-stay",
+			Description:  "Toggle keeping your message in the editor after sending",
+			Category:     "Settings",
+			Execute: func(string) tea.Cmd {
+				return core.CmdHandler(messages.ToggleSendAndStayMsg{})
+			},
+		},
+		{
+			ID:           "settings.soft-wrap",
+			Label:        "Soft Wrap",
+			SlashCommand: "/soft-wrap",
+			Description:  "Toggle wrapping long lines in the editor",
+			Category:     "Settings",
+			Execute: func(string) tea.Cmd {
+				return core.CmdHandler(messages.ToggleSoftWrapMsg{})
+			},
+		},
 		{
 			ID:           "settings.split-diff",
 			Label:        "Split Diff",
 			SlashCommand: "/split-diff",
-			Description:  "Toggle split diff view mode",
+			Description:  "Toggle between the unified and split diff view, for all tabs (Alt+S)",
 			Category:     "Settings",
 			Execute: func(string) tea.Cmd {
 				return core.CmdHandler(messages.ToggleSplitDiffMsg{})
@@ -324,8 +770,12 @@ func BuildCommandCategories(ctx context.Context, application *app.App) []Categor
 				Description:  toolcommon.TruncateText(cmd.DisplayText(), 60),
 				Category:     "Agent Commands",
 				SlashCommand: "/" + name,
-				Execute: func(string) tea.Cmd {
-					return core.CmdHandler(messages.AgentCommandMsg{Command: "/" + name})
+				Execute: func(arg string) tea.Cmd {
+					input := "/" + name
+					if arg = strings.TrimSpace(arg); arg != "" {
+						input += " " + arg
+					}
+					return core.CmdHandler(messages.AgentCommandMsg{Command: input})
 				},
 			})
 		}
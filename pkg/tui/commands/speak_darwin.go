@@ -9,15 +9,27 @@ import (
 	"github.com/docker/cagent/pkg/tui/messages"
 )
 
-func speakCommand() *Item {
-	return &Item{
-		ID:           "session.speak",
-		Label:        "Speak",
-		SlashCommand: "/speak",
-		Description:  "Start speech-to-text transcription (press Enter or Escape to stop)",
-		Category:     "Session",
-		Execute: func(string) tea.Cmd {
-			return core.CmdHandler(messages.StartSpeakMsg{})
+func speakCommands() []Item {
+	return []Item{
+		{
+			ID:           "session.speak",
+			Label:        "Speak",
+			SlashCommand: "/speak",
+			Description:  "Start speech-to-text transcription (press Enter or Escape to stop)",
+			Category:     "Session",
+			Execute: func(string) tea.Cmd {
+				return core.CmdHandler(messages.StartSpeakMsg{})
+			},
+		},
+		{
+			ID:           "session.input_device",
+			Label:        "Input Device",
+			SlashCommand: "/input-device",
+			Description:  "Choose the microphone /speak listens to",
+			Category:     "Session",
+			Execute: func(string) tea.Cmd {
+				return core.CmdHandler(messages.ShowInputDevicesMsg{})
+			},
 		},
 	}
 }
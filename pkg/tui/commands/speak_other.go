@@ -2,6 +2,6 @@
 
 package commands
 
-func speakCommand() *Item {
+func speakCommands() []Item {
 	return nil
 }
@@ -0,0 +1,100 @@
+package editor
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/atotto/clipboard"
+	"github.com/docker/go-units"
+)
+
+const (
+	// maxClipboardInline is the largest clipboard content inserted in the
+	// editor as a code block. Larger content is attached instead.
+	maxClipboardInline = 16 * 1024
+	// maxClipboardContext caps the clipboard content added to a message.
+	maxClipboardContext = 512 * 1024
+)
+
+// ErrClipboardEmpty is returned by PasteClipboard when there is nothing to paste.
+var ErrClipboardEmpty = errors.New("clipboard is empty")
+
+// readClipboard is replaced in tests.
+var readClipboard = clipboard.ReadAll
+
+// PasteClipboard adds the content of the system clipboard to the message,
+// either inserted in the editor as a fenced code block or as an attachment.
+// It returns a notice to show the user when the content had to be capped or
+// was attached because it is too large to be inserted.
+func (e *editor) PasteClipboard(attach bool) (string, error) {
+	content, err := readClipboard()
+	if err != nil {
+		return "", fmt.Errorf("reading clipboard: %w", err)
+	}
+	content = strings.TrimRight(content, "\n")
+	if strings.TrimSpace(content) == "" {
+		return "", ErrClipboardEmpty
+	}
+
+	var notices []string
+	if len(content) > maxClipboardContext {
+		notices = append(notices, fmt.Sprintf("Clipboard truncated from %s to %s",
+			units.HumanSize(float64(len(content))), units.HumanSize(maxClipboardContext)))
+		content = truncateBytes(content, maxClipboardContext)
+	}
+	if !attach && len(content) > maxClipboardInline {
+		notices = append(notices, "Clipboard too large to insert, attached instead")
+		attach = true
+	}
+
+	if attach {
+		if err := e.attachText(content); err != nil {
+			return "", err
+		}
+	} else {
+		e.InsertText(fenceCodeBlock(content, ""))
+	}
+	return strings.Join(notices, " · "), nil
+}
+
+// attachText buffers content to a paste attachment and inserts its
+// placeholder in the editor.
+func (e *editor) attachText(content string) error {
+	e.pasteCounter++
+	att, err := createPasteAttachment(content, e.pasteCounter)
+	if err != nil {
+		return fmt.Errorf("attaching clipboard: %w", err)
+	}
+	e.attachments = append(e.attachments, att)
+	e.InsertText(att.placeholder + " ")
+	return nil
+}
+
+// fenceCodeBlock wraps content in a fenced code block, using a fence longer
+// than any run of backticks in content. info is the block's info string.
+func fenceCodeBlock(content, info string) string {
+	longest, run := 0, 0
+	for _, r := range content {
+		if r == '`' {
+			run++
+			longest = max(longest, run)
+		} else {
+			run = 0
+		}
+	}
+	fence := strings.Repeat("`", max(3, longest+1))
+	return fence + info + "\n" + content + "\n" + fence + "\n"
+}
+
+// truncateBytes cuts s to at most n bytes without splitting a rune.
+func truncateBytes(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
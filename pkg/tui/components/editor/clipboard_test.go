@@ -0,0 +1,72 @@
+package editor
+
+import (
+	"strings"
+	"testing"
+
+	"charm.land/bubbles/v2/textarea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFenceCodeBlock(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "```\nerror: boom\n```\n", fenceCodeBlock("error: boom", ""))
+	assert.Equal(t, "````\nuse ```go\n````\n", fenceCodeBlock("use ```go", ""))
+	assert.Equal(t, "```main.go\npackage main\n```\n", fenceCodeBlock("package main", "main.go"))
+}
+
+func TestTruncateBytes(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "abc", truncateBytes("abc", 5))
+	assert.Equal(t, "a", truncateBytes("aé", 2))
+}
+
+// Not parallel: replaces readClipboard.
+func TestPasteClipboard(t *testing.T) {
+	clip := ""
+	orig := readClipboard
+	readClipboard = func() (string, error) { return clip, nil }
+	t.Cleanup(func() { readClipboard = orig })
+
+	newEditor := func() *editor {
+		return &editor{textarea: textarea.New(), banner: newAttachmentBanner()}
+	}
+
+	e := newEditor()
+	_, err := e.PasteClipboard(false)
+	require.ErrorIs(t, err, ErrClipboardEmpty)
+
+	clip = "panic: oops\n"
+	notice, err := e.PasteClipboard(false)
+	require.NoError(t, err)
+	assert.Empty(t, notice)
+	assert.Equal(t, "```\npanic: oops\n```\n", e.textarea.Value())
+
+	e = newEditor()
+	_, err = e.PasteClipboard(true)
+	require.NoError(t, err)
+	require.Len(t, e.attachments, 1)
+	t.Cleanup(e.Cleanup)
+	assert.Contains(t, e.textarea.Value(), e.attachments[0].placeholder)
+
+	// Too large to be inserted: attached instead
+	clip = strings.Repeat("x", maxClipboardInline+1)
+	e = newEditor()
+	notice, err = e.PasteClipboard(false)
+	require.NoError(t, err)
+	t.Cleanup(e.Cleanup)
+	assert.Contains(t, notice, "attached instead")
+	require.Len(t, e.attachments, 1)
+
+	// Over the cap: truncated
+	clip = strings.Repeat("x", maxClipboardContext+1)
+	e = newEditor()
+	notice, err = e.PasteClipboard(true)
+	require.NoError(t, err)
+	t.Cleanup(e.Cleanup)
+	assert.Contains(t, notice, "truncated")
+	assert.Equal(t, maxClipboardContext, e.attachments[0].sizeBytes)
+}
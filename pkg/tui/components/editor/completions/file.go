@@ -4,7 +4,9 @@ import (
 	"context"
 	"sort"
 	"sync"
+	"time"
 
+	"github.com/docker/cagent/pkg/app"
 	"github.com/docker/cagent/pkg/fsx"
 	"github.com/docker/cagent/pkg/tui/components/completion"
 )
@@ -15,14 +17,36 @@ const (
 	initialMaxDepth = 2
 )
 
+// refreshInterval is how long the files of a directory are reused before they
+// are walked again. Typing @ repeatedly doesn't rescan large repositories, but
+// new files still show up.
+const refreshInterval = 30 * time.Second
+
 type fileCompletion struct {
-	mu     sync.Mutex
-	items  []completion.Item
-	loaded bool
+	// workingDir returns the directory whose files are completed.
+	workingDir func() string
+
+	mu       sync.Mutex
+	items    []completion.Item
+	loadedIn string
+	loadedAt time.Time
 }
 
-func NewFileCompletion() Completion {
-	return &fileCompletion{}
+// NewFileCompletion completes @ file references with the files of the
+// directory returned by workingDir, skipping the ones ignored by git.
+func NewFileCompletion(workingDir func() string) Completion {
+	return &fileCompletion{workingDir: workingDir}
+}
+
+// WorkingDir returns the working directory of the app's session, or the
+// current directory when the session has none.
+func WorkingDir(a *app.App) string {
+	if a != nil {
+		if sess := a.Session(); sess != nil && sess.WorkingDir != "" {
+			return sess.WorkingDir
+		}
+	}
+	return "."
 }
 
 func (c *fileCompletion) AutoSubmit() bool {
@@ -38,104 +62,43 @@ func (c *fileCompletion) Trigger() string {
 }
 
 func (c *fileCompletion) Items() []completion.Item {
-	c.mu.Lock()
-	defer c.mu.Unlock()
-
-	// Return cached items if already loaded
-	if c.loaded {
-		return c.items
+	dir := c.workingDir()
+	if items, ok := c.cached(dir); ok {
+		return items
 	}
 
-	// Try to create VCS matcher for current directory
-	vcsMatcher, _ := fsx.NewVCSMatcher(".")
-
-	// Prepare shouldIgnore function
-	var shouldIgnore func(string) bool
-	if vcsMatcher != nil {
-		shouldIgnore = vcsMatcher.ShouldIgnore
-	}
-
-	// Use bounded walker to avoid scanning huge directories
-	files, err := fsx.WalkFiles(context.Background(), ".", fsx.WalkFilesOptions{
-		ShouldIgnore: shouldIgnore,
-	})
+	items, err := walkFileItems(context.Background(), dir, fsx.WalkFilesOptions{})
 	if err != nil {
-		// Do not mark as loaded on error, allow retry
+		// Do not cache on error, allow retry
 		return nil
 	}
-
-	// Sort files by name
-	sort.Strings(files)
-
-	items := make([]completion.Item, len(files))
-	for i, f := range files {
-		items[i] = completion.Item{
-			Label: f,
-			Value: "@" + f,
-		}
-	}
-
-	c.items = items
-	c.loaded = true
-
-	return c.items
+	c.store(dir, items)
+	return items
 }
 
 // LoadInitialItemsAsync loads a shallow set of items quickly for immediate display.
 // It scans 2 levels deep with a max of 100 files for a snappy initial UX.
 func (c *fileCompletion) LoadInitialItemsAsync(ctx context.Context) <-chan []completion.Item {
 	ch := make(chan []completion.Item, 1)
+	dir := c.workingDir()
 
 	go func() {
 		defer close(ch)
 
 		// Check if we already have full items cached
-		c.mu.Lock()
-		if c.loaded {
-			items := c.items
-			c.mu.Unlock()
-			select {
-			case ch <- items:
-			case <-ctx.Done():
+		items, ok := c.cached(dir)
+		if !ok {
+			// Don't cache initial items - we'll cache full items later
+			var err error
+			items, err = walkFileItems(ctx, dir, fsx.WalkFilesOptions{
+				MaxFiles: initialMaxFiles,
+				MaxDepth: initialMaxDepth,
+			})
+			if err != nil {
+				items = nil
 			}
-			return
 		}
-		c.mu.Unlock()
-
-		// Try to create VCS matcher for current directory
-		vcsMatcher, _ := fsx.NewVCSMatcher(".")
 
-		var shouldIgnore func(string) bool
-		if vcsMatcher != nil {
-			shouldIgnore = vcsMatcher.ShouldIgnore
-		}
-
-		// Shallow scan: 2 levels deep, max 100 files
-		files, err := fsx.WalkFiles(ctx, ".", fsx.WalkFilesOptions{
-			MaxFiles:     initialMaxFiles,
-			MaxDepth:     initialMaxDepth,
-			ShouldIgnore: shouldIgnore,
-		})
-		if err != nil || ctx.Err() != nil {
-			select {
-			case ch <- nil:
-			case <-ctx.Done():
-			}
-			return
-		}
-
-		// Sort files by name
-		sort.Strings(files)
-
-		items := make([]completion.Item, len(files))
-		for i, f := range files {
-			items[i] = completion.Item{
-				Label: f,
-				Value: "@" + f,
-			}
-		}
-
-		// Don't cache initial items - we'll cache full items later
 		select {
 		case ch <- items:
 		case <-ctx.Done():
@@ -149,62 +112,24 @@ func (c *fileCompletion) LoadInitialItemsAsync(ctx context.Context) <-chan []com
 // It returns a channel that receives the items when loading is complete.
 func (c *fileCompletion) LoadItemsAsync(ctx context.Context) <-chan []completion.Item {
 	ch := make(chan []completion.Item, 1)
+	dir := c.workingDir()
 
 	go func() {
 		defer close(ch)
 
-		c.mu.Lock()
-		// Return cached items if already loaded
-		if c.loaded {
-			items := c.items
-			c.mu.Unlock()
-			select {
-			case ch <- items:
-			case <-ctx.Done():
+		items, ok := c.cached(dir)
+		if !ok {
+			// Full scan with default limits
+			var err error
+			items, err = walkFileItems(ctx, dir, fsx.WalkFilesOptions{})
+			if err != nil {
+				// Return nil on error or cancellation
+				items = nil
+			} else {
+				c.store(dir, items)
 			}
-			return
-		}
-		c.mu.Unlock()
-
-		// Try to create VCS matcher for current directory
-		vcsMatcher, _ := fsx.NewVCSMatcher(".")
-
-		// Prepare shouldIgnore function
-		var shouldIgnore func(string) bool
-		if vcsMatcher != nil {
-			shouldIgnore = vcsMatcher.ShouldIgnore
 		}
 
-		// Full scan with default limits
-		files, err := fsx.WalkFiles(ctx, ".", fsx.WalkFilesOptions{
-			ShouldIgnore: shouldIgnore,
-		})
-		if err != nil || ctx.Err() != nil {
-			// Return nil on error or cancellation
-			select {
-			case ch <- nil:
-			case <-ctx.Done():
-			}
-			return
-		}
-
-		// Sort files by name
-		sort.Strings(files)
-
-		items := make([]completion.Item, len(files))
-		for i, f := range files {
-			items[i] = completion.Item{
-				Label: f,
-				Value: "@" + f,
-			}
-		}
-
-		// Cache the results
-		c.mu.Lock()
-		c.items = items
-		c.loaded = true
-		c.mu.Unlock()
-
 		select {
 		case ch <- items:
 		case <-ctx.Done():
@@ -217,3 +142,50 @@ func (c *fileCompletion) LoadItemsAsync(ctx context.Context) <-chan []completion
 func (c *fileCompletion) MatchMode() completion.MatchMode {
 	return completion.MatchFuzzy
 }
+
+// cached returns the items of dir if they were walked recently enough.
+func (c *fileCompletion) cached(dir string) ([]completion.Item, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.loadedAt.IsZero() || c.loadedIn != dir || time.Since(c.loadedAt) > refreshInterval {
+		return nil, false
+	}
+	return c.items, true
+}
+
+func (c *fileCompletion) store(dir string, items []completion.Item) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.items = items
+	c.loadedIn = dir
+	c.loadedAt = time.Now()
+}
+
+// walkFileItems walks dir with the bounded walker, skipping the files ignored
+// by git, and returns its files as items sorted by path.
+func walkFileItems(ctx context.Context, dir string, opts fsx.WalkFilesOptions) ([]completion.Item, error) {
+	if vcsMatcher, _ := fsx.NewVCSMatcher(dir); vcsMatcher != nil {
+		opts.ShouldIgnore = vcsMatcher.ShouldIgnore
+	}
+
+	files, err := fsx.WalkFiles(ctx, dir, opts)
+	if err != nil {
+		return nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	sort.Strings(files)
+
+	items := make([]completion.Item, len(files))
+	for i, f := range files {
+		items[i] = completion.Item{
+			Label: f,
+			Value: "@" + f,
+		}
+	}
+	return items, nil
+}
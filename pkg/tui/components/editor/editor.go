@@ -1,12 +1,15 @@
 package editor
 
 import (
+	"cmp"
 	"context"
 	"fmt"
 	"log/slog"
+	"net/url"
 	"os"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"strings"
 	"time"
 
@@ -36,20 +39,31 @@ import (
 var ansiRegexp = regexp.MustCompile(`\x1b\[[0-9;]*[A-Za-z]`)
 
 const (
-	// maxInlinePasteLines is the maximum number of lines for inline paste.
-	// Pastes exceeding this are buffered to a temp file attachment.
+	// maxInlinePasteLines is the default maximum number of lines for inline
+	// paste. Pastes exceeding this are buffered to a temp file attachment.
 	maxInlinePasteLines = 5
-	// maxInlinePasteChars is the character limit for inline pastes.
+	// maxInlinePasteChars is the default character limit for inline pastes.
 	// This catches very long single-line pastes that would clutter the editor.
 	maxInlinePasteChars = 500
 )
 
+// What the editor does with the pastes over the limits, see SetLargePaste.
+const (
+	largePasteCollapse = "collapse"
+	largePasteInline   = "inline"
+)
+
+// expandPastesKey expands the collapsed pastes in the editor.
+var expandPastesKey = key.NewBinding(key.WithKeys("alt+e"))
+
 type attachment struct {
 	path        string // Path to file (temp for pastes, real for file refs)
 	placeholder string // @paste-1 or @filename
 	label       string // Display label like "paste-1 (21.1 KB)"
 	sizeBytes   int
-	isTemp      bool // True for paste temp files that need cleanup
+	isTemp      bool   // True for paste temp files that need cleanup
+	url         string // Remote URL fetched when the message is sent, path is empty
+	text        string // Content of a collapsed paste, expanded in place when sent
 }
 
 // AttachmentPreview describes an attachment and its contents for dialog display.
@@ -74,9 +88,23 @@ type Editor interface {
 	InsertText(text string)
 	// AttachFile adds a file as an attachment and inserts @filepath into the editor
 	AttachFile(filePath string) error
+	// AttachURL adds a remote URL as an attachment and inserts @url into the editor
+	AttachURL(rawURL string)
+	// PasteClipboard adds the clipboard content as a code block, or as an
+	// attachment when attach is true, and returns a notice for the user if any
+	PasteClipboard(attach bool) (string, error)
+	// InsertFile inserts the content of a file as a code block and returns a
+	// notice for the user if any
+	InsertFile(path string) (string, error)
+	// InsertCodeBlock inserts content as a fenced code block with the given
+	// info string, capped in size, and returns a notice for the user if any
+	InsertCodeBlock(content, info string) string
 	Cleanup()
 	GetSize() (width, height int)
 	BannerHeight() int
+	// ContentLines returns the number of visual lines the current content
+	// needs once soft-wrapped to the editor width
+	ContentLines() int
 	AttachmentAt(x int) (AttachmentPreview, bool)
 	// SetRecording sets the recording mode which shows animated dots as the cursor
 	SetRecording(recording bool) tea.Cmd
@@ -88,6 +116,18 @@ type Editor interface {
 	EnterHistorySearch() (layout.Model, tea.Cmd)
 	// SendContent triggers sending the current editor content
 	SendContent() tea.Cmd
+	// SetSoftWrap sets whether long lines are soft-wrapped or scroll
+	// horizontally.
+	SetSoftWrap(on bool)
+	// SetSendAndStay sets whether the content is kept in the editor after
+	// sending, so it can be tweaked and sent again
+	SetSendAndStay(stay bool)
+	// SetEnterInsertsNewline sets whether Enter inserts a newline and
+	// Shift+Enter sends, when the terminal supports keyboard enhancements
+	SetEnterInsertsNewline(on bool)
+	// SetLargePaste sets what is done with the pastes over maxLines lines or
+	// maxChars characters: "attach", "collapse" or "inline"
+	SetLargePaste(mode string, maxLines, maxChars int)
 }
 
 // fileLoadResultMsg is sent when async file loading completes.
@@ -117,6 +157,8 @@ type editor struct {
 	working  bool
 	// completions are the available completions
 	completions []completions.Completion
+	// workingDir returns the directory @ file references are relative to
+	workingDir func() string
 
 	// completionWord stores the word being completed
 	completionWord    string
@@ -137,6 +179,12 @@ type editor struct {
 	attachments []attachment
 	// pasteCounter tracks the next paste number for display purposes.
 	pasteCounter int
+	// largePaste is what is done with the pastes over pasteMaxLines lines or
+	// pasteMaxChars characters. Zero values mean attaching them over the
+	// default limits.
+	largePaste    string
+	pasteMaxLines int
+	pasteMaxChars int
 	// recording tracks whether the editor is in recording mode (speech-to-text)
 	recording bool
 	// recordingDotPhase tracks the animation phase for the recording dots cursor
@@ -151,6 +199,22 @@ type editor struct {
 	// fileLoadCancel cancels any in-progress file loading
 	fileLoadCancel context.CancelFunc
 
+	// sendAndStay keeps the content (and its attachments) in the editor after sending
+	sendAndStay bool
+
+	// enterInsertsNewline swaps Enter and Shift+Enter when keyboard
+	// enhancements are supported
+	enterInsertsNewline bool
+
+	// noWrap turns soft wrapping off: long lines scroll horizontally instead
+	noWrap bool
+	// visibleWidth is the number of columns the textarea is shown in
+	visibleWidth int
+	// hScroll is the first visible column when soft wrapping is off
+	hScroll int
+	// wrapCache holds the wrapped rows of the content, for the wrap indicators
+	wrapCache wrapRows
+
 	// historySearch holds state for history search mode
 	historySearch historySearchState
 	// searchInput is the input field for history search queries
@@ -164,7 +228,7 @@ func New(a *app.App, hist *history.History) Editor {
 	ta.Placeholder = "Type your message here…"
 	ta.Prompt = ""
 	ta.CharLimit = -1
-	ta.SetWidth(50)
+	ta.MaxWidth = 0
 	ta.SetHeight(3) // Set minimum 3 lines for multi-line input
 	ta.Focus()
 	ta.ShowLineNumbers = false
@@ -186,11 +250,13 @@ func New(a *app.App, hist *history.History) Editor {
 		searchInput:                   si,
 		hist:                          hist,
 		completions:                   completions.Completions(a),
+		workingDir:                    func() string { return completions.WorkingDir(a) },
 		keyboardEnhancementsSupported: false,
 		banner:                        newAttachmentBanner(),
 	}
 
 	e.configureNewlineKeybinding()
+	e.setTextareaWidth(50)
 
 	return e
 }
@@ -568,25 +634,69 @@ func (e *editor) ScrollByWheel(delta int) {
 
 // resetAndSend prepares a message for sending: processes pending file refs,
 // collects attachments, resets editor state, and returns the SendMsg command.
+// In send-and-stay mode the content is left in the editor, except for slash
+// commands.
 func (e *editor) resetAndSend(content string) tea.Cmd {
 	e.tryAddFileRef(e.pendingFileRef)
 	e.pendingFileRef = ""
-	attachments := e.collectAttachments(content)
-	e.textarea.Reset()
-	e.userTyped = false
+	stay := e.sendAndStay && !strings.HasPrefix(content, "/")
+	content = e.expandCollapsedPastes(content)
+	var attachments []messages.Attachment
+	if stay {
+		attachments = e.peekAttachments(content)
+	} else {
+		attachments = e.collectAttachments(content)
+		e.textarea.Reset()
+		e.userTyped = false
+	}
 	e.clearSuggestion()
 	return core.CmdHandler(messages.SendMsg{Content: content, Attachments: attachments})
 }
 
+// SetSendAndStay sets whether the content is kept in the editor after sending.
+func (e *editor) SetSendAndStay(stay bool) {
+	e.sendAndStay = stay
+}
+
+// SetLargePaste sets what is done with the pastes over maxLines lines or
+// maxChars characters: they're attached, collapsed to a token expanded when
+// sending, or inserted as is.
+func (e *editor) SetLargePaste(mode string, maxLines, maxChars int) {
+	e.largePaste = mode
+	e.pasteMaxLines = maxLines
+	e.pasteMaxChars = maxChars
+}
+
+// SetEnterInsertsNewline sets whether Enter inserts a newline and Shift+Enter
+// sends. Without keyboard enhancements Shift+Enter can't be told apart from
+// Enter, so Enter keeps sending and Ctrl+J inserts newlines.
+func (e *editor) SetEnterInsertsNewline(on bool) {
+	e.enterInsertsNewline = on
+	e.configureNewlineKeybinding()
+}
+
+// sendKey returns the key sending the content.
+func (e *editor) sendKey() string {
+	if e.keyboardEnhancementsSupported && e.enterInsertsNewline {
+		return "shift+enter"
+	}
+	return "enter"
+}
+
 // configureNewlineKeybinding sets up the appropriate newline keybinding
 // based on terminal keyboard enhancement support.
 func (e *editor) configureNewlineKeybinding() {
 	// Configure textarea's InsertNewline binding based on terminal capabilities
-	if e.keyboardEnhancementsSupported {
+	switch {
+	case e.keyboardEnhancementsSupported && e.enterInsertsNewline:
+		// Modern terminals, with Enter and Shift+Enter swapped:
+		e.textarea.KeyMap.InsertNewline.SetKeys("enter", "ctrl+j")
+		e.textarea.KeyMap.InsertNewline.SetEnabled(true)
+	case e.keyboardEnhancementsSupported:
 		// Modern terminals:
 		e.textarea.KeyMap.InsertNewline.SetKeys("shift+enter", "ctrl+j")
 		e.textarea.KeyMap.InsertNewline.SetEnabled(true)
-	} else {
+	default:
 		// Legacy terminals:
 		e.textarea.KeyMap.InsertNewline.SetKeys("ctrl+j")
 		e.textarea.KeyMap.InsertNewline.SetEnabled(true)
@@ -625,7 +735,7 @@ func (e *editor) Update(msg tea.Msg) (layout.Model, tea.Cmd) {
 		e.textarea.SetStyles(styles.InputStyle)
 		return e, nil
 	case tea.WindowSizeMsg:
-		e.textarea.SetWidth(msg.Width - 2)
+		e.setTextareaWidth(msg.Width - 2)
 		return e, nil
 
 	case tea.MouseClickMsg, tea.MouseMotionMsg, tea.MouseReleaseMsg:
@@ -741,6 +851,12 @@ func (e *editor) Update(msg tea.Msg) (layout.Model, tea.Cmd) {
 			return e.handleClipboardPaste()
 		}
 
+		if key.Matches(msg, expandPastesKey) && e.hasCollapsedPastes() {
+			e.textarea.SetValue(e.expandCollapsedPastes(e.textarea.Value()))
+			e.updateAttachmentBanner()
+			return e, nil
+		}
+
 		// Handle backspace with grapheme cluster awareness.
 		// The default textarea.Model only deletes a single rune, which breaks
 		// multi-codepoint characters like emoji (e.g., ⚠️ = U+26A0 + U+FE0F).
@@ -752,7 +868,9 @@ func (e *editor) Update(msg tea.Msg) (layout.Model, tea.Cmd) {
 		// - Enter: submit current input (if textarea inserted a newline, submit previous buffer).
 		// - Shift+Enter: insert newline when keyboard enhancements are supported.
 		// - Ctrl+J: fallback to insert '\n' when keyboard enhancements are not supported.
-		if msg.String() == "enter" || key.Matches(msg, e.textarea.KeyMap.InsertNewline) {
+		// Enter and Shift+Enter are swapped when enterInsertsNewline is set.
+		sendKey := e.sendKey()
+		if msg.String() == sendKey || key.Matches(msg, e.textarea.KeyMap.InsertNewline) {
 			if !e.textarea.Focused() {
 				return e, nil
 			}
@@ -763,13 +881,13 @@ func (e *editor) Update(msg tea.Msg) (layout.Model, tea.Cmd) {
 			value := e.textarea.Value()
 
 			// If textarea inserted a newline, just refresh and return
-			if value != prev && msg.String() != "enter" {
+			if value != prev && msg.String() != sendKey {
 				e.refreshSuggestion()
 				return e, nil
 			}
 
-			// If plain enter and textarea inserted a newline, submit the previous value
-			if value != prev && msg.String() == "enter" {
+			// If the send key made textarea insert a newline, submit the previous value
+			if value != prev && msg.String() == sendKey {
 				if prev != "" {
 					e.textarea.SetValue(prev)
 					e.textarea.MoveToEnd()
@@ -779,8 +897,9 @@ func (e *editor) Update(msg tea.Msg) (layout.Model, tea.Cmd) {
 				return e, nil
 			}
 
-			// Normal enter submit: send current value
-			if value != "" {
+			// Normal enter submit: send current value. A newline key that
+			// didn't insert anything (e.g. at the character limit) doesn't send.
+			if value != "" && msg.String() == sendKey {
 				cmd := e.resetAndSend(value)
 				return e, cmd
 			}
@@ -1149,11 +1268,15 @@ func (e *editor) getPasteCompletionItems() []completion.Item {
 
 // View renders the component
 func (e *editor) View() string {
+	if e.noWrap {
+		e.syncTextareaWidth()
+	}
 	view := e.textarea.View()
 
 	if e.textarea.Focused() && e.hasSuggestion && e.suggestion != "" {
 		view = e.applySuggestionOverlay(view)
 	}
+	view = e.renderWrap(view)
 
 	bannerView := e.banner.View()
 	if bannerView != "" {
@@ -1172,7 +1295,7 @@ func (e *editor) SetSize(width, height int) tea.Cmd {
 	e.width = width
 	e.height = max(height, 1)
 
-	e.textarea.SetWidth(max(width, 10))
+	e.setTextareaWidth(max(width, 10))
 	e.searchInput.SetWidth(max(width, 10))
 	e.updateTextareaHeight()
 
@@ -1201,6 +1324,17 @@ func (e *editor) BannerHeight() int {
 	return e.banner.Height()
 }
 
+// ContentLines returns the number of visual lines the current content
+// needs once soft-wrapped to the editor width (at least 1).
+func (e *editor) ContentLines() int {
+	width := max(e.textarea.Width(), 1)
+	lines := 0
+	for line := range strings.SplitSeq(e.textarea.Value(), "\n") {
+		lines += max(1, (lipgloss.Width(line)+width-1)/width)
+	}
+	return lines
+}
+
 // GetSize returns the rendered dimensions including EditorStyle padding.
 func (e *editor) GetSize() (width, height int) {
 	return e.width + styles.EditorStyle.GetHorizontalFrameSize(),
@@ -1223,6 +1357,16 @@ func (e *editor) AttachmentAt(x int) (AttachmentPreview, bool) {
 			continue
 		}
 
+		if att.url != "" {
+			return AttachmentPreview{
+				Title:   item.label,
+				Content: att.url + "\n\nFetched when the message is sent.",
+			}, true
+		}
+		if att.text != "" {
+			return AttachmentPreview{Title: item.label, Content: att.text}, true
+		}
+
 		data, err := os.ReadFile(att.path)
 		if err != nil {
 			slog.Warn("failed to read attachment preview", "path", att.path, "error", err)
@@ -1289,6 +1433,27 @@ func (e *editor) AttachFile(filePath string) error {
 	return nil
 }
 
+// AttachURL adds a remote URL as an attachment and inserts @url into the editor.
+// The URL is fetched when the message is sent.
+func (e *editor) AttachURL(rawURL string) {
+	placeholder := "@" + rawURL
+	if !slices.ContainsFunc(e.attachments, func(att attachment) bool { return att.placeholder == placeholder }) {
+		label := rawURL
+		if u, err := url.Parse(rawURL); err == nil {
+			label = u.Host + u.Path
+		}
+		e.attachments = append(e.attachments, attachment{
+			placeholder: placeholder,
+			label:       "🔗 " + strings.TrimSuffix(label, "/"),
+			url:         rawURL,
+		})
+	}
+	e.textarea.SetValue(e.textarea.Value() + placeholder + " ")
+	e.textarea.MoveToEnd()
+	e.userTyped = true
+	e.updateAttachmentBanner()
+}
+
 // tryAddFileRef checks if word is a valid @filepath and adds it as attachment.
 // Called when cursor leaves a word to detect manually-typed file references.
 func (e *editor) tryAddFileRef(word string) {
@@ -1319,7 +1484,11 @@ func (e *editor) addFileAttachment(placeholder string) error {
 	path := strings.TrimPrefix(placeholder, "@")
 
 	// Resolve to absolute path so the attachment carries a fully qualified
-	// path regardless of the working directory at send time.
+	// path regardless of the working directory at send time. Relative paths
+	// are relative to the session's working directory, like the completions.
+	if !filepath.IsAbs(path) && e.workingDir != nil {
+		path = filepath.Join(e.workingDir(), path)
+	}
 	absPath, err := filepath.Abs(path)
 	if err != nil {
 		return fmt.Errorf("cannot resolve path %s: %w", path, err)
@@ -1367,6 +1536,9 @@ func (e *editor) collectAttachments(content string) []messages.Attachment {
 
 	var result []messages.Attachment
 	for _, att := range e.attachments {
+		if att.text != "" {
+			continue
+		}
 		if !strings.Contains(content, att.placeholder) {
 			if att.isTemp {
 				_ = os.Remove(att.path)
@@ -1374,6 +1546,11 @@ func (e *editor) collectAttachments(content string) []messages.Attachment {
 			continue
 		}
 
+		if att.url != "" {
+			result = append(result, messages.Attachment{Name: att.url, URL: att.url})
+			continue
+		}
+
 		if att.isTemp {
 			// Paste attachment: read into memory and remove the temp file.
 			data, err := os.ReadFile(att.path)
@@ -1399,6 +1576,38 @@ func (e *editor) collectAttachments(content string) []messages.Attachment {
 	return result
 }
 
+// peekAttachments is like collectAttachments but leaves the attachments (and
+// their temp files) in place so the same content can be sent again.
+func (e *editor) peekAttachments(content string) []messages.Attachment {
+	var result []messages.Attachment
+	for _, att := range e.attachments {
+		if att.text != "" || !strings.Contains(content, att.placeholder) {
+			continue
+		}
+		if att.url != "" {
+			result = append(result, messages.Attachment{Name: att.url, URL: att.url})
+			continue
+		}
+		if !att.isTemp {
+			result = append(result, messages.Attachment{
+				Name:     filepath.Base(att.path),
+				FilePath: att.path,
+			})
+			continue
+		}
+		data, err := os.ReadFile(att.path)
+		if err != nil {
+			slog.Warn("failed to read paste attachment", "path", att.path, "error", err)
+			continue
+		}
+		result = append(result, messages.Attachment{
+			Name:    strings.TrimPrefix(att.placeholder, "@"),
+			Content: string(data),
+		})
+	}
+	return result
+}
+
 // Cleanup removes any temporary paste files that haven't been sent yet.
 func (e *editor) Cleanup() {
 	for _, att := range e.attachments {
@@ -1481,11 +1690,19 @@ func (e *editor) handlePaste(content string) bool {
 	}
 
 	// Allow inline if within both limits
-	if lines <= maxInlinePasteLines && len(content) <= maxInlinePasteChars {
+	maxLines, maxChars := cmp.Or(e.pasteMaxLines, maxInlinePasteLines), cmp.Or(e.pasteMaxChars, maxInlinePasteChars)
+	if e.largePaste == largePasteInline || (lines <= maxLines && len(content) <= maxChars) {
 		return false
 	}
 
 	e.pasteCounter++
+	if e.largePaste == largePasteCollapse {
+		att := collapsedPaste(content, lines, e.pasteCounter)
+		e.textarea.InsertString(att.placeholder)
+		e.attachments = append(e.attachments, att)
+		return true
+	}
+
 	att, err := createPasteAttachment(content, e.pasteCounter)
 	if err != nil {
 		slog.Warn("failed to buffer paste", "error", err)
@@ -1510,7 +1727,7 @@ func (e *editor) removeLastNAttachments(n int) {
 	value := e.textarea.Value()
 	removed := 0
 	for i := len(e.attachments) - 1; i >= 0 && removed < n; i-- {
-		if !e.attachments[i].isTemp {
+		if !e.attachments[i].isTemp && e.attachments[i].url == "" && e.attachments[i].text == "" {
 			// Strip the placeholder text ("@/path/file.png ") that AttachFile inserted
 			value = strings.Replace(value, e.attachments[i].placeholder+" ", "", 1)
 			e.attachments = append(e.attachments[:i], e.attachments[i+1:]...)
@@ -1568,6 +1785,40 @@ func createPasteAttachment(content string, num int) (attachment, error) {
 	}, nil
 }
 
+// collapsedPaste returns the attachment of a paste shown as a token in the
+// editor, such as "[pasted 120 lines #1]", and expanded in place when sent.
+func collapsedPaste(content string, lines, num int) attachment {
+	size := fmt.Sprintf("%d lines", lines)
+	if lines == 1 {
+		size = units.HumanSize(float64(len(content)))
+	}
+	return attachment{
+		placeholder: fmt.Sprintf("[pasted %s #%d]", size, num),
+		label:       fmt.Sprintf("paste-%d (%s)", num, size),
+		sizeBytes:   len(content),
+		text:        content,
+	}
+}
+
+// hasCollapsedPastes returns whether the editor shows a collapsed paste.
+func (e *editor) hasCollapsedPastes() bool {
+	value := e.textarea.Value()
+	return slices.ContainsFunc(e.attachments, func(att attachment) bool {
+		return att.text != "" && strings.Contains(value, att.placeholder)
+	})
+}
+
+// expandCollapsedPastes replaces the tokens of the collapsed pastes in value
+// with their content.
+func (e *editor) expandCollapsedPastes(value string) string {
+	for _, att := range e.attachments {
+		if att.text != "" {
+			value = strings.ReplaceAll(value, att.placeholder, att.text)
+		}
+	}
+	return value
+}
+
 func (e *editor) EnterHistorySearch() (layout.Model, tea.Cmd) {
 	e.historySearch = historySearchState{
 		active:                   true,
@@ -3,8 +3,10 @@ package editor
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
+	"charm.land/bubbles/v2/textarea"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -167,6 +169,24 @@ func TestCollectAttachments(t *testing.T) {
 		assert.Equal(t, "/nonexistent/file.txt", result[1].FilePath)
 		assert.Nil(t, e.attachments, "attachments should be cleared after collection")
 	})
+
+	t.Run("URL attachment keeps the URL", func(t *testing.T) {
+		t.Parallel()
+
+		e := New(nil, nil).(*editor)
+		e.AttachURL("https://example.com/docs/")
+		e.AttachURL("https://example.com/docs/")
+
+		assert.Equal(t, "@https://example.com/docs/ @https://example.com/docs/ ", e.textarea.Value())
+		require.Len(t, e.attachments, 1)
+		assert.Equal(t, "🔗 example.com/docs", e.attachments[0].label)
+
+		result := e.collectAttachments("summarize @https://example.com/docs/")
+
+		require.Len(t, result, 1)
+		assert.Equal(t, "https://example.com/docs/", result[0].URL)
+		assert.Empty(t, result[0].FilePath)
+	})
 }
 
 func TestTryAddFileRef(t *testing.T) {
@@ -338,3 +358,31 @@ func TestDeleteLastGraphemeCluster(t *testing.T) {
 		})
 	}
 }
+
+func TestContentLines(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		value    string
+		expected int
+	}{
+		{name: "empty", value: "", expected: 1},
+		{name: "short line", value: "hello", expected: 1},
+		{name: "explicit newlines", value: "a\nb\nc", expected: 3},
+		{name: "wrapped line", value: strings.Repeat("x", 25), expected: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ta := textarea.New()
+			ta.Prompt = ""
+			ta.ShowLineNumbers = false
+			ta.SetWidth(10)
+			ta.SetValue(tt.value)
+			e := &editor{textarea: ta}
+			assert.Equal(t, tt.expected, e.ContentLines())
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package editor
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/docker/go-units"
+)
+
+// maxFileInline caps the content of a file inserted in the editor.
+const maxFileInline = 32 * 1024
+
+// ErrNotTextFile is returned by InsertFile for files that don't hold text.
+var ErrNotTextFile = errors.New("not a text file")
+
+// InsertFile inserts the content of a file in the editor as a fenced code
+// block, with the file name as the info string. Unlike AttachFile, the file is
+// read right away. It returns a notice to show the user when the content had
+// to be capped.
+func (e *editor) InsertFile(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return "", err
+	}
+	if info.IsDir() {
+		return "", fmt.Errorf("%s is a directory", path)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(f, maxFileInline+1))
+	if err != nil {
+		return "", err
+	}
+
+	var notice string
+	content := string(data)
+	if len(content) > maxFileInline {
+		notice = fmt.Sprintf("%s truncated from %s to %s", filepath.Base(path),
+			units.HumanSize(float64(info.Size())), units.HumanSize(maxFileInline))
+		content = truncateBytes(content, maxFileInline)
+	}
+	if strings.ContainsRune(content, 0) || !utf8.ValidString(content) {
+		return "", ErrNotTextFile
+	}
+
+	e.InsertText(fenceCodeBlock(strings.TrimRight(content, "\n"), filepath.Base(path)))
+	return notice, nil
+}
+
+// InsertCodeBlock inserts content in the editor as a fenced code block with
+// the given info string. Content larger than a file inserted with InsertFile
+// is capped, and a notice is returned.
+func (e *editor) InsertCodeBlock(content, info string) string {
+	var notice string
+	if len(content) > maxFileInline {
+		notice = fmt.Sprintf("Content truncated from %s to %s", units.HumanSize(float64(len(content))), units.HumanSize(maxFileInline))
+		content = truncateBytes(content, maxFileInline)
+	}
+	e.InsertText(fenceCodeBlock(strings.TrimRight(content, "\n"), info))
+	return notice
+}
@@ -0,0 +1,62 @@
+package editor
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"charm.land/bubbles/v2/textarea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInsertFile(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+		return path
+	}
+	newEditor := func() *editor {
+		return &editor{textarea: textarea.New(), banner: newAttachmentBanner()}
+	}
+
+	e := newEditor()
+	notice, err := e.InsertFile(write("config.yaml", "name: demo\n"))
+	require.NoError(t, err)
+	assert.Empty(t, notice)
+	assert.Equal(t, "```config.yaml\nname: demo\n```\n", e.textarea.Value())
+	assert.Empty(t, e.attachments)
+
+	// Too large: capped
+	e = newEditor()
+	notice, err = e.InsertFile(write("big.log", strings.Repeat("x", maxFileInline+10)))
+	require.NoError(t, err)
+	assert.Contains(t, notice, "big.log truncated")
+	assert.Contains(t, e.textarea.Value(), strings.Repeat("x", maxFileInline)+"\n```")
+
+	_, err = newEditor().InsertFile(write("image.png", "\x89PNG\x00\x00"))
+	require.ErrorIs(t, err, ErrNotTextFile)
+
+	_, err = newEditor().InsertFile(dir)
+	require.Error(t, err)
+
+	_, err = newEditor().InsertFile(filepath.Join(dir, "missing.txt"))
+	require.Error(t, err)
+}
+
+func TestInsertCodeBlock(t *testing.T) {
+	t.Parallel()
+
+	e := &editor{textarea: textarea.New(), banner: newAttachmentBanner()}
+	assert.Empty(t, e.InsertCodeBlock("ok\n", "shell"))
+	assert.Equal(t, "```shell\nok\n```\n", e.textarea.Value())
+
+	e = &editor{textarea: textarea.New(), banner: newAttachmentBanner()}
+	notice := e.InsertCodeBlock(strings.Repeat("x", maxFileInline+10), "")
+	assert.Contains(t, notice, "Content truncated")
+	assert.Contains(t, e.textarea.Value(), strings.Repeat("x", maxFileInline)+"\n```")
+}
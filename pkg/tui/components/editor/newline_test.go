@@ -0,0 +1,86 @@
+package editor
+
+import (
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/docker/cagent/pkg/tui/messages"
+)
+
+// pressEnter types text in the editor then presses Enter, with or without
+// Shift, and returns the content sent, if any.
+func pressEnter(t *testing.T, e *editor, text string, mod tea.KeyMod) (sent string, ok bool) {
+	t.Helper()
+
+	e.textarea.SetValue(text)
+	e.textarea.MoveToEnd()
+	_, cmd := e.Update(tea.KeyPressMsg{Code: tea.KeyEnter, Mod: mod})
+	if cmd == nil {
+		return "", false
+	}
+	msg, ok := cmd().(messages.SendMsg)
+	return msg.Content, ok
+}
+
+func newFocusedEditor(t *testing.T, enhancements bool) *editor {
+	t.Helper()
+
+	e := New(nil, nil).(*editor)
+	e.SetSize(40, 5)
+	e.Focus()
+	var flags int
+	if enhancements {
+		flags = 1
+	}
+	_, _ = e.Update(tea.KeyboardEnhancementsMsg{Flags: flags})
+	return e
+}
+
+func TestEnterSendsByDefault(t *testing.T) {
+	t.Parallel()
+
+	e := newFocusedEditor(t, true)
+
+	_, sent := pressEnter(t, e, "hello", tea.ModShift)
+	assert.False(t, sent)
+	assert.Equal(t, "hello\n", e.textarea.Value())
+
+	content, sent := pressEnter(t, e, "hello", 0)
+	require.True(t, sent)
+	assert.Equal(t, "hello", content)
+}
+
+func TestEnterInsertsNewline(t *testing.T) {
+	t.Parallel()
+
+	e := newFocusedEditor(t, true)
+	e.SetEnterInsertsNewline(true)
+
+	_, sent := pressEnter(t, e, "hello", 0)
+	assert.False(t, sent)
+	assert.Equal(t, "hello\n", e.textarea.Value())
+
+	content, sent := pressEnter(t, e, "hello\nworld", tea.ModShift)
+	require.True(t, sent)
+	assert.Equal(t, "hello\nworld", content)
+}
+
+func TestEnterInsertsNewline_WithoutKeyboardEnhancements(t *testing.T) {
+	t.Parallel()
+
+	// Shift+Enter can't be told apart from Enter, so Enter keeps sending.
+	e := newFocusedEditor(t, false)
+	e.SetEnterInsertsNewline(true)
+
+	content, sent := pressEnter(t, e, "hello", 0)
+	require.True(t, sent)
+	assert.Equal(t, "hello", content)
+
+	e.textarea.SetValue("hello")
+	e.textarea.MoveToEnd()
+	_, _ = e.Update(tea.KeyPressMsg{Code: 'j', Mod: tea.ModCtrl})
+	assert.Equal(t, "hello\n", e.textarea.Value())
+}
@@ -8,9 +8,13 @@ import (
 	"testing"
 
 	"charm.land/bubbles/v2/textarea"
+	tea "charm.land/bubbletea/v2"
 	"github.com/docker/go-units"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/docker/cagent/pkg/tui/components/editor/completions"
+	"github.com/docker/cagent/pkg/tui/messages"
 )
 
 func TestHandlePaste_SmallContent(t *testing.T) {
@@ -475,6 +479,30 @@ func TestAddFileAttachment_SizeLimit(t *testing.T) {
 	}
 }
 
+func TestFileReferencesRelativeToWorkingDir(t *testing.T) {
+	t.Parallel()
+
+	workingDir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(workingDir, "pkg", "server"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(workingDir, "pkg", "server", "handler.go"), []byte("package server"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(workingDir, "README.md"), []byte("# readme"), 0o644))
+
+	// The completions walk the session's working directory, not the
+	// directory cagent was started from.
+	fileCompletion := completions.NewFileCompletion(func() string { return workingDir })
+	var values []string
+	for _, item := range fileCompletion.Items() {
+		values = append(values, item.Value)
+	}
+	assert.Equal(t, []string{"@README.md", "@" + filepath.Join("pkg", "server", "handler.go")}, values)
+
+	// Selecting one attaches the file from the working directory.
+	e := &editor{workingDir: func() string { return workingDir }}
+	require.NoError(t, e.addFileAttachment(values[1]))
+	require.Len(t, e.attachments, 1)
+	assert.Equal(t, filepath.Join(workingDir, "pkg", "server", "handler.go"), e.attachments[0].path)
+}
+
 func newPasteTestEditor() *editor {
 	ta := textarea.New()
 	ta.Focus()
@@ -667,3 +695,81 @@ func TestAttachFile_SetsCorrectLabel(t *testing.T) {
 	expectedLabel := fmt.Sprintf("labeled.png (%s)", units.HumanSize(float64(len(data))))
 	assert.Equal(t, expectedLabel, e.attachments[0].label)
 }
+
+func TestResetAndSend_SendAndStay(t *testing.T) {
+	t.Parallel()
+
+	att, err := createPasteAttachmentInDir(filepath.Join(t.TempDir(), "pastes"), "pasted")
+	require.NoError(t, err)
+
+	ta := textarea.New()
+	e := &editor{textarea: ta, banner: newAttachmentBanner(), attachments: []attachment{att}, sendAndStay: true}
+	input := "Summarize " + att.placeholder
+	e.textarea.SetValue(input)
+
+	for range 2 {
+		msg, ok := e.resetAndSend(input)().(messages.SendMsg)
+		require.True(t, ok)
+		assert.Equal(t, input, msg.Content)
+		require.Len(t, msg.Attachments, 1)
+		assert.Equal(t, "pasted", msg.Attachments[0].Content)
+		assert.Equal(t, input, e.textarea.Value(), "content stays in the editor")
+		assert.FileExists(t, att.path, "paste is kept so it can be sent again")
+	}
+
+	e.SetSendAndStay(false)
+	e.resetAndSend(input)
+	assert.Empty(t, e.textarea.Value())
+	assert.NoFileExists(t, att.path)
+
+	// Slash commands are never kept
+	e.SetSendAndStay(true)
+	e.textarea.SetValue("/compact")
+	e.resetAndSend("/compact")
+	assert.Empty(t, e.textarea.Value())
+}
+
+func TestHandlePaste_CustomLimits(t *testing.T) {
+	t.Parallel()
+
+	e := newPasteTestEditor()
+	e.SetLargePaste("inline", 2, 1000)
+	assert.False(t, e.handlePaste("a\nb\nc\nd\ne\nf\ng"), "inline never buffers the paste")
+
+	e.SetLargePaste("collapse", 2, 1000)
+	assert.False(t, e.handlePaste("a\nb"))
+	assert.True(t, e.handlePaste("a\nb\nc"))
+}
+
+func TestHandlePaste_Collapse(t *testing.T) {
+	t.Parallel()
+
+	e := newPasteTestEditor()
+	e.SetLargePaste("collapse", 0, 0)
+	content := strings.Repeat("log line\n", 20)
+
+	require.True(t, e.handlePaste(content))
+	assert.Equal(t, "[pasted 20 lines #1]", e.textarea.Value())
+	require.Len(t, e.attachments, 1)
+	assert.Empty(t, e.attachments[0].path, "collapsed pastes aren't written to disk")
+
+	// The token is expanded in place when sending, not attached.
+	e.textarea.InsertString(" what failed?")
+	msg := e.resetAndSend(e.textarea.Value())().(messages.SendMsg)
+	assert.Equal(t, content+" what failed?", msg.Content)
+	assert.Empty(t, msg.Attachments)
+}
+
+func TestExpandCollapsedPastes(t *testing.T) {
+	t.Parallel()
+
+	e := newPasteTestEditor()
+	e.SetLargePaste("collapse", 0, 0)
+	content := strings.Repeat("x", maxInlinePasteChars+1)
+	require.True(t, e.handlePaste(content))
+	assert.True(t, e.hasCollapsedPastes())
+
+	e.Update(tea.KeyPressMsg{Code: 'e', Mod: tea.ModAlt})
+	assert.Equal(t, content, e.textarea.Value())
+	assert.False(t, e.hasCollapsedPastes())
+}
@@ -0,0 +1,126 @@
+package editor
+
+import (
+	"strings"
+
+	"charm.land/bubbles/v2/textarea"
+	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/docker/cagent/pkg/tui/styles"
+)
+
+// wrapIndicator marks the rows of a soft-wrapped line that continue on the
+// next row.
+const wrapIndicator = "↵"
+
+// wrapRows caches which display rows of a value continue on the next row.
+type wrapRows struct {
+	value string
+	width int
+	wraps []bool
+}
+
+// SetSoftWrap sets whether long lines are soft-wrapped to the editor width.
+// When off, long lines scroll horizontally to follow the cursor.
+func (e *editor) SetSoftWrap(on bool) {
+	e.noWrap = !on
+	e.hScroll = 0
+	e.syncTextareaWidth()
+}
+
+// setTextareaWidth sets the number of columns the textarea is shown in.
+func (e *editor) setTextareaWidth(width int) {
+	e.visibleWidth = width
+	e.syncTextareaWidth()
+}
+
+// syncTextareaWidth sizes the textarea for the wrap mode. With soft wrap, one
+// column is kept for the wrap indicator. Without it, the textarea is made
+// wider than its longest line so nothing wraps, and View crops it to the
+// visible width.
+func (e *editor) syncTextareaWidth() {
+	if !e.noWrap {
+		e.textarea.SetWidth(max(e.visibleWidth-1, 1))
+		return
+	}
+
+	longest := 0
+	for line := range strings.SplitSeq(e.textarea.Value(), "\n") {
+		longest = max(longest, lipgloss.Width(line))
+	}
+	// Leave room for the cursor past the end of the longest line.
+	e.textarea.SetWidth(longest + max(e.visibleWidth, 1))
+}
+
+// renderWrap adds the wrap indicators to the textarea view, or crops it
+// around the cursor when soft wrap is off.
+func (e *editor) renderWrap(view string) string {
+	if e.noWrap {
+		return e.scrollHorizontally(view)
+	}
+
+	wraps := e.wrappedRows()
+	offset := e.textarea.ScrollYOffset()
+	indicatorX := e.textarea.Width()
+
+	var overlays []*lipgloss.Layer
+	for y := range strings.Count(view, "\n") + 1 {
+		if row := offset + y; row < len(wraps) && wraps[row] {
+			overlays = append(overlays, lipgloss.NewLayer(styles.MutedStyle.Render(wrapIndicator)).X(indicatorX).Y(y))
+		}
+	}
+	if len(overlays) == 0 {
+		return view
+	}
+	return lipgloss.NewCompositor(append([]*lipgloss.Layer{lipgloss.NewLayer(view)}, overlays...)...).Render()
+}
+
+// scrollHorizontally crops every row of view to the visible width, scrolling
+// just enough to keep the cursor in sight.
+func (e *editor) scrollHorizontally(view string) string {
+	width := max(e.visibleWidth, 1)
+	cursor := e.textarea.LineInfo().CharOffset
+	if cursor < e.hScroll {
+		e.hScroll = cursor
+	} else if cursor >= e.hScroll+width {
+		e.hScroll = cursor - width + 1
+	}
+
+	lines := strings.Split(view, "\n")
+	for i, line := range lines {
+		lines[i] = ansi.Cut(line, e.hScroll, e.hScroll+width)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// wrappedRows reports, for each display row of the soft-wrapped content,
+// whether the line continues on the next row. It uses a textarea to wrap
+// each line exactly like the editor does.
+func (e *editor) wrappedRows() []bool {
+	value, width := e.textarea.Value(), e.textarea.Width()
+	if e.wrapCache.value == value && e.wrapCache.width == width && e.wrapCache.wraps != nil {
+		return e.wrapCache.wraps
+	}
+
+	probe := textarea.New()
+	probe.Prompt = ""
+	probe.ShowLineNumbers = false
+	probe.CharLimit = -1
+	probe.MaxWidth = 0
+	probe.SetWidth(width)
+
+	var wraps []bool
+	for line := range strings.SplitSeq(value, "\n") {
+		probe.SetValue(line)
+		probe.MoveToEnd()
+		rows := max(probe.LineInfo().Height, 1)
+		for range rows - 1 {
+			wraps = append(wraps, true)
+		}
+		wraps = append(wraps, false)
+	}
+
+	e.wrapCache = wrapRows{value: value, width: width, wraps: wraps}
+	return wraps
+}
@@ -0,0 +1,54 @@
+package editor
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/docker/cagent/pkg/tui/styles"
+)
+
+func TestSoftWrap_ShowsWrapIndicator(t *testing.T) {
+	t.Parallel()
+
+	e := New(nil, nil).(*editor)
+	e.SetSize(20, 5)
+	e.textarea.SetValue("short\n" + strings.Repeat("word ", 8))
+
+	assert.Equal(t, []bool{false, true, true, false}, e.wrappedRows())
+
+	// The first line is the editor's top padding.
+	lines := strings.Split(ansi.Strip(e.View()), "\n")[1:]
+	require.GreaterOrEqual(t, len(lines), 4)
+	assert.NotContains(t, lines[0], wrapIndicator)
+	assert.Contains(t, lines[1], wrapIndicator)
+	assert.Contains(t, lines[2], wrapIndicator)
+	assert.NotContains(t, lines[3], wrapIndicator)
+}
+
+func TestNoWrap_ScrollsHorizontally(t *testing.T) {
+	t.Parallel()
+
+	e := New(nil, nil).(*editor)
+	e.SetSize(20, 5)
+	e.SetSoftWrap(false)
+	e.textarea.SetValue("start " + strings.Repeat("x", 40) + " end")
+	e.textarea.MoveToEnd()
+
+	view := ansi.Strip(e.View())
+	lines := strings.Split(view, "\n")[1:]
+	assert.Equal(t, 1, e.ContentLines(), "long lines don't wrap")
+	assert.Contains(t, lines[0], "end", "the view follows the cursor")
+	assert.NotContains(t, lines[0], "start")
+	assert.NotContains(t, view, wrapIndicator)
+	for _, line := range lines {
+		assert.LessOrEqual(t, ansi.StringWidth(line), 20+2*styles.AppPadding)
+	}
+
+	e.textarea.CursorStart()
+	lines = strings.Split(ansi.Strip(e.View()), "\n")[1:]
+	assert.Contains(t, lines[0], "start")
+}
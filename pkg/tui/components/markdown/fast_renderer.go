@@ -1859,6 +1859,12 @@ func (p *parser) renderCodeBlock(code, lang string) {
 
 // renderCodeBlockWithIndent renders a fenced code block with indentation and width constraints.
 func (p *parser) renderCodeBlockWithIndent(code, lang, indent string, availableWidth int) {
+	if lang == "mermaid" && renderDiagrams.Load() {
+		if diagram, ok := renderMermaid(code, availableWidth-4); ok {
+			code, lang = "mermaid diagram (approximation)\n\n"+diagram, ""
+		}
+	}
+
 	// Get syntax highlighting tokens
 	tokens := p.syntaxHighlight(code, lang)
 
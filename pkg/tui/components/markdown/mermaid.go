@@ -0,0 +1,394 @@
+package markdown
+
+import (
+	"cmp"
+	"regexp"
+	"slices"
+	"strings"
+	"sync/atomic"
+
+	runewidth "github.com/mattn/go-runewidth"
+)
+
+// renderDiagrams enables the ASCII rendering of mermaid code blocks.
+var renderDiagrams atomic.Bool
+
+// SetRenderDiagrams enables or disables the best-effort ASCII rendering of
+// mermaid flowcharts. When disabled, or when a diagram can't be rendered,
+// mermaid blocks are shown as regular code blocks.
+func SetRenderDiagrams(enabled bool) {
+	renderDiagrams.Store(enabled)
+}
+
+const (
+	// mermaidMaxNodes is the maximum number of nodes rendered as a diagram.
+	mermaidMaxNodes = 40
+	// mermaidMaxLabelWidth is the width above which node labels are truncated.
+	mermaidMaxLabelWidth = 30
+	// mermaidBoxGap is the number of columns between two boxes of a row.
+	mermaidBoxGap = 2
+)
+
+var (
+	mermaidHeader = regexp.MustCompile(`^(?:graph|flowchart)(?:\s+(?:TD|TB|BT|LR|RL))?$`)
+	// mermaidTextLink matches links with the text in the middle: A -- text --> B
+	mermaidTextLink = regexp.MustCompile(`^(?:--|==|-\.)\s+([^\s>|-][^|]*?)\s+(?:-{2,}>|={2,}>|\.+->|-{3,}|={3,}|\.+-)`)
+	// mermaidLink matches links with an optional text between pipes: A -->|text| B
+	mermaidLink   = regexp.MustCompile(`^<?(?:-{2,}>|={2,}>|-\.+->|-{3,}|={3,}|-\.+-|~{3,})(?:\|([^|]*)\|)?`)
+	mermaidNodeID = regexp.MustCompile(`^[A-Za-z0-9_]+(?:-[A-Za-z0-9_]+)*`)
+	mermaidClass  = regexp.MustCompile(`^:::[A-Za-z0-9_\-]+`)
+)
+
+// mermaidShapes maps the opening delimiters of node shapes to their closing
+// ones. Longer delimiters come first.
+var mermaidShapes = [][2]string{
+	{"(((", ")))"}, {"((", "))"}, {"([", "])"}, {"[[", "]]"}, {"[(", ")]"},
+	{"{{", "}}"}, {"[/", "/]"}, {`[\`, `\]`}, {"[", "]"}, {"(", ")"}, {"{", "}"}, {">", "]"},
+}
+
+// mermaidIgnored are the statements that don't change the shape of the graph.
+var mermaidIgnored = []string{"subgraph", "end", "classDef", "class", "style", "linkStyle", "click", "direction"}
+
+type mermaidEdge struct {
+	from, to string
+	label    string
+}
+
+type mermaidGraph struct {
+	ids    []string // in order of appearance
+	labels map[string]string
+	edges  []mermaidEdge
+}
+
+// renderMermaid renders a mermaid flowchart as boxes laid out top-down, one
+// row per level. Edges between consecutive rows are drawn, the others and
+// the edge labels are listed below the diagram. It returns false when the
+// diagram isn't a flowchart, can't be parsed or doesn't fit in width.
+func renderMermaid(code string, width int) (string, bool) {
+	g, ok := parseMermaid(code)
+	if !ok || len(g.ids) == 0 || len(g.ids) > mermaidMaxNodes {
+		return "", false
+	}
+	return g.render(width)
+}
+
+func parseMermaid(code string) (*mermaidGraph, bool) {
+	g := &mermaidGraph{labels: map[string]string{}}
+	header := false
+	for line := range strings.SplitSeq(code, "\n") {
+		for stmt := range strings.SplitSeq(line, ";") {
+			stmt = strings.TrimSpace(stmt)
+			if stmt == "" || strings.HasPrefix(stmt, "%%") {
+				continue
+			}
+			if !header {
+				if !mermaidHeader.MatchString(stmt) {
+					return nil, false
+				}
+				header = true
+				continue
+			}
+			if keyword, _, _ := strings.Cut(stmt, " "); slices.Contains(mermaidIgnored, keyword) {
+				continue
+			}
+			if !g.parseStatement(stmt) {
+				return nil, false
+			}
+		}
+	}
+	return g, header
+}
+
+// parseStatement parses a chain of node groups joined by links, such as
+// "A[Start] --> B & C -->|done| D".
+func (g *mermaidGraph) parseStatement(stmt string) bool {
+	var previous []string
+	pendingLabel := ""
+	rest := stmt
+	for {
+		var group []string
+		for {
+			id, after, ok := g.parseNode(rest)
+			if !ok {
+				return false
+			}
+			group = append(group, id)
+			rest = strings.TrimSpace(after)
+			if !strings.HasPrefix(rest, "&") {
+				break
+			}
+			rest = strings.TrimSpace(rest[1:])
+		}
+		for _, from := range previous {
+			for _, to := range group {
+				g.edges = append(g.edges, mermaidEdge{from: from, to: to, label: pendingLabel})
+			}
+		}
+		if rest == "" {
+			return true
+		}
+
+		if m := mermaidTextLink.FindStringSubmatch(rest); m != nil {
+			pendingLabel = strings.TrimSpace(m[1])
+			rest = rest[len(m[0]):]
+		} else if m := mermaidLink.FindStringSubmatch(rest); m != nil {
+			pendingLabel = strings.TrimSpace(m[1])
+			rest = rest[len(m[0]):]
+		} else {
+			return false
+		}
+		rest = strings.TrimSpace(rest)
+		previous = group
+	}
+}
+
+// parseNode parses a node reference, with an optional shape and label, and
+// registers it. It returns what follows the node.
+func (g *mermaidGraph) parseNode(s string) (id, rest string, ok bool) {
+	id = mermaidNodeID.FindString(s)
+	if id == "" {
+		return "", "", false
+	}
+	rest = s[len(id):]
+
+	label := ""
+	for _, shape := range mermaidShapes {
+		if !strings.HasPrefix(rest, shape[0]) {
+			continue
+		}
+		end := strings.Index(rest[len(shape[0]):], shape[1])
+		if end < 0 {
+			return "", "", false
+		}
+		label = cleanMermaidLabel(rest[len(shape[0]) : len(shape[0])+end])
+		rest = rest[len(shape[0])+end+len(shape[1]):]
+		break
+	}
+	rest = strings.TrimPrefix(rest, mermaidClass.FindString(rest))
+
+	if _, known := g.labels[id]; !known {
+		g.ids = append(g.ids, id)
+		g.labels[id] = id
+	}
+	if label != "" {
+		g.labels[id] = label
+	}
+	return id, rest, true
+}
+
+func cleanMermaidLabel(label string) string {
+	label = strings.TrimSpace(label)
+	label = strings.Trim(label, `"`)
+	for _, br := range []string{"<br/>", "<br />", "<br>"} {
+		label = strings.ReplaceAll(label, br, " ")
+	}
+	label = strings.Join(strings.Fields(label), " ")
+	return runewidth.Truncate(label, mermaidMaxLabelWidth, "…")
+}
+
+// levels assigns each node to the row of the longest path leading to it,
+// ignoring the edges that close a cycle. Back reports those edges.
+func (g *mermaidGraph) levels() (level map[string]int, back map[int]bool) {
+	back = map[int]bool{}
+	state := map[string]int{} // 0: unvisited, 1: on the stack, 2: done
+	var order []string
+	var visit func(id string)
+	visit = func(id string) {
+		state[id] = 1
+		for i, e := range g.edges {
+			if e.from != id {
+				continue
+			}
+			switch state[e.to] {
+			case 0:
+				visit(e.to)
+			case 1:
+				back[i] = true
+			}
+		}
+		state[id] = 2
+		order = append(order, id)
+	}
+	for _, id := range g.ids {
+		if state[id] == 0 {
+			visit(id)
+		}
+	}
+
+	// order is a reverse topological order of the graph without back edges.
+	level = map[string]int{}
+	for _, id := range slices.Backward(order) {
+		for i, e := range g.edges {
+			if e.from == id && !back[i] {
+				level[e.to] = max(level[e.to], level[id]+1)
+			}
+		}
+	}
+	return level, back
+}
+
+func (g *mermaidGraph) render(width int) (string, bool) {
+	level, back := g.levels()
+
+	var rows [][]string
+	for _, id := range g.ids {
+		for len(rows) <= level[id] {
+			rows = append(rows, nil)
+		}
+		rows[level[id]] = append(rows[level[id]], id)
+	}
+
+	// Order each row by the average position of the parents, to limit the
+	// crossings, keeping the order of appearance otherwise.
+	for r := 1; r < len(rows); r++ {
+		position := map[string]int{}
+		for i, id := range rows[r-1] {
+			position[id] = i
+		}
+		weight := map[string]float64{}
+		for _, id := range rows[r] {
+			sum, count := 0, 0
+			for _, e := range g.edges {
+				if p, ok := position[e.from]; ok && e.to == id {
+					sum += p
+					count++
+				}
+			}
+			weight[id] = float64(len(rows[r-1]))
+			if count > 0 {
+				weight[id] = float64(sum) / float64(count)
+			}
+		}
+		slices.SortStableFunc(rows[r], func(a, b string) int {
+			return cmp.Compare(weight[a], weight[b])
+		})
+	}
+
+	// Lay out the boxes: each row is centered. Boxes have an odd width so
+	// that their centers line up from a row to the next.
+	boxWidth := func(id string) int { return (runewidth.StringWidth(g.labels[id]) + 4) | 1 }
+	rowWidth := func(row []string) int {
+		w := mermaidBoxGap * (len(row) - 1)
+		for _, id := range row {
+			w += boxWidth(id)
+		}
+		return w
+	}
+	diagramWidth := 0
+	for _, row := range rows {
+		diagramWidth = max(diagramWidth, rowWidth(row))
+	}
+	if diagramWidth > width {
+		return "", false
+	}
+	start := map[string]int{}
+	for _, row := range rows {
+		col := (diagramWidth - rowWidth(row)) / 2
+		for _, id := range row {
+			start[id] = col
+			col += boxWidth(id) + mermaidBoxGap
+		}
+	}
+	center := func(id string) int { return start[id] + boxWidth(id)/2 }
+
+	var out []string
+	for r, row := range rows {
+		if r > 0 {
+			out = append(out, g.connectors(rows[r-1], center, level, back, diagramWidth)...)
+		}
+		var top, middle, bottom strings.Builder
+		col := 0
+		for _, id := range row {
+			pad := strings.Repeat(" ", start[id]-col)
+			dashes := strings.Repeat("─", boxWidth(id)-2)
+			top.WriteString(pad + "┌" + dashes + "┐")
+			middle.WriteString(pad + "│ " + g.labels[id] + strings.Repeat(" ", boxWidth(id)-3-runewidth.StringWidth(g.labels[id])) + "│")
+			bottom.WriteString(pad + "└" + dashes + "┘")
+			col = start[id] + boxWidth(id)
+		}
+		out = append(out, top.String(), middle.String(), bottom.String())
+	}
+
+	// List the edges that aren't drawn, or whose label isn't.
+	var extra []string
+	for i, e := range g.edges {
+		if !back[i] && level[e.to] == level[e.from]+1 && e.label == "" {
+			continue
+		}
+		arrow := " ──▶ "
+		if e.label != "" {
+			arrow = " ── " + e.label + " ──▶ "
+		}
+		extra = append(extra, g.labels[e.from]+arrow+g.labels[e.to])
+	}
+	if len(extra) > 0 {
+		out = append(out, "")
+		out = append(out, extra...)
+	}
+
+	return strings.Join(out, "\n"), true
+}
+
+// connectors draws the edges from a row to the next one: a line down from
+// each source, a horizontal line joining them to the targets, and an arrow
+// down to each target.
+func (g *mermaidGraph) connectors(upper []string, center func(string) int, level map[string]int, back map[int]bool, width int) []string {
+	sources := map[int]bool{}
+	targets := map[int]bool{}
+	for i, e := range g.edges {
+		if back[i] || level[e.to] != level[e.from]+1 || !slices.Contains(upper, e.from) {
+			continue
+		}
+		sources[center(e.from)] = true
+		targets[center(e.to)] = true
+	}
+	if len(sources) == 0 {
+		return []string{""}
+	}
+
+	down := []rune(strings.Repeat(" ", width))
+	bus := []rune(strings.Repeat(" ", width))
+	arrows := []rune(strings.Repeat(" ", width))
+	left, right := width, -1
+	for c := range sources {
+		down[c] = '│'
+		left, right = min(left, c), max(right, c)
+	}
+	for c := range targets {
+		arrows[c] = '▼'
+		left, right = min(left, c), max(right, c)
+	}
+	for c := left; c <= right; c++ {
+		up, dn := sources[c], targets[c]
+		switch {
+		case left == right:
+			bus[c] = '│'
+		case c == left:
+			bus[c] = junction(up, dn, '└', '┌', '├')
+		case c == right:
+			bus[c] = junction(up, dn, '┘', '┐', '┤')
+		default:
+			bus[c] = junction(up, dn, '┴', '┬', '┼')
+		}
+	}
+
+	return []string{
+		strings.TrimRight(string(down), " "),
+		strings.TrimRight(string(bus), " "),
+		strings.TrimRight(string(arrows), " "),
+	}
+}
+
+// junction returns the box drawing character joining the horizontal line to
+// a line going up, down, or both.
+func junction(up, down bool, upRune, downRune, bothRune rune) rune {
+	switch {
+	case up && down:
+		return bothRune
+	case up:
+		return upRune
+	case down:
+		return downRune
+	}
+	return '─'
+}
@@ -0,0 +1,73 @@
+package markdown
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderMermaid(t *testing.T) {
+	t.Parallel()
+
+	out, ok := renderMermaid("graph LR; A-->B; A-->C; B-->D; C-->D", 80)
+	require.True(t, ok)
+	assert.Equal(t, strings.Join([]string{
+		"   ┌───┐",
+		"   │ A │",
+		"   └───┘",
+		"     │",
+		"  ┌──┴───┐",
+		"  ▼      ▼",
+		"┌───┐  ┌───┐",
+		"│ B │  │ C │",
+		"└───┘  └───┘",
+		"  │      │",
+		"  └──┬───┘",
+		"     ▼",
+		"   ┌───┐",
+		"   │ D │",
+		"   └───┘",
+	}, "\n"), out)
+}
+
+func TestRenderMermaid_ListsOtherEdges(t *testing.T) {
+	t.Parallel()
+
+	out, ok := renderMermaid(`flowchart TD
+    %% A comment
+    A[Start] --> B{"Is it working?"}
+    B -->|Yes| C([Ship it])
+    B -- No --> D[Debug]:::red
+    D --> B
+    A --> C
+    classDef red fill:#f00`, 80)
+	require.True(t, ok)
+
+	assert.Contains(t, out, "│ Is it working?  │")
+	assert.Contains(t, out, "│ Ship it │")
+	assert.Contains(t, out, "Is it working? ── Yes ──▶ Ship it")
+	assert.Contains(t, out, "Is it working? ── No ──▶ Debug")
+	assert.Contains(t, out, "Debug ──▶ Is it working?")
+	assert.Contains(t, out, "Start ──▶ Ship it")
+}
+
+func TestRenderMermaid_Fallback(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]string{
+		"not a flowchart": "sequenceDiagram\n    Alice->>Bob: Hi",
+		"unknown syntax":  "graph TD\n    A ==o B ?? C",
+		"unclosed shape":  "graph TD\n    A[Start --> B",
+		"empty":           "graph TD",
+		"too wide":        "graph TD\n    A[" + strings.Repeat("x", 25) + "] & B[" + strings.Repeat("y", 25) + "] & C[" + strings.Repeat("z", 25) + "]",
+	}
+	for name, code := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			_, ok := renderMermaid(code, 60)
+			assert.False(t, ok)
+		})
+	}
+}
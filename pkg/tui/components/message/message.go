@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"regexp"
 	"strings"
+	"sync/atomic"
 
 	tea "charm.land/bubbletea/v2"
 	"github.com/charmbracelet/x/ansi"
@@ -15,12 +16,69 @@ import (
 	"github.com/docker/cagent/pkg/tui/types"
 )
 
+// showThroughput shows the generation speed under the assistant messages.
+var showThroughput atomic.Bool
+
+// SetShowThroughput shows, or hides, the generation speed under the
+// assistant messages.
+func SetShowThroughput(show bool) {
+	showThroughput.Store(show)
+}
+
+// ShowThroughput reports whether the generation speed is shown under the
+// assistant messages.
+func ShowThroughput() bool {
+	return showThroughput.Load()
+}
+
+// Generic roles of the message labels, used when a message has no agent name.
+const (
+	RoleUser      = "user"
+	RoleAssistant = "assistant"
+)
+
+// roleLabels maps agent names, RoleUser and RoleAssistant to the label shown
+// above their messages.
+var roleLabels atomic.Pointer[map[string]string]
+
+// SetRoleLabels sets the labels shown above the messages, by agent name or
+// generic role. The messages of an agent without a label are labeled with the
+// agent's name, and the user's messages are only labeled when RoleUser has a
+// label.
+func SetRoleLabels(labels map[string]string) {
+	roleLabels.Store(&labels)
+}
+
+// roleLabel returns the label of the messages sent by sender, which is an
+// agent name or a generic role.
+func roleLabel(sender string) string {
+	if labels := roleLabels.Load(); labels != nil {
+		if label, ok := (*labels)[sender]; ok && label != "" {
+			return label
+		}
+	}
+	if sender == RoleUser {
+		return ""
+	}
+	return sender
+}
+
+// FormatThroughput formats a generation speed, e.g. "42 tok/s".
+func FormatThroughput(tokensPerSecond float64) string {
+	return fmt.Sprintf("%.0f tok/s", tokensPerSecond)
+}
+
 // Model represents a view that can render a message
 type Model interface {
 	layout.Model
 	layout.Sizeable
 	SetMessage(msg *types.Message)
 	SetSelected(selected bool)
+	SetStreaming(streaming bool)
+	// SetRawAll shows the markdown source of every message instead of the
+	// rendered view. ToggleRaw flips that for this message only.
+	SetRawAll(raw bool)
+	ToggleRaw()
 }
 
 // messageModel implements Model
@@ -28,11 +86,14 @@ type messageModel struct {
 	message  *types.Message
 	previous *types.Message
 
-	width    int
-	height   int
-	focused  bool
-	selected bool
-	spinner  spinner.Spinner
+	width     int
+	height    int
+	focused   bool
+	selected  bool
+	streaming bool
+	raw       bool // show the markdown source, relative to rawAll
+	rawAll    bool
+	spinner   spinner.Spinner
 }
 
 // New creates a new message view
@@ -65,6 +126,24 @@ func (mv *messageModel) SetSelected(selected bool) {
 	mv.selected = selected
 }
 
+// SetStreaming marks the message as still receiving content from the model.
+func (mv *messageModel) SetStreaming(streaming bool) {
+	mv.streaming = streaming
+}
+
+func (mv *messageModel) SetRawAll(raw bool) {
+	mv.rawAll = raw
+}
+
+func (mv *messageModel) ToggleRaw() {
+	mv.raw = !mv.raw
+}
+
+// showRaw reports whether the markdown source is shown instead of the rendered view.
+func (mv *messageModel) showRaw() bool {
+	return mv.raw != mv.rawAll
+}
+
 // Update handles messages and updates the message view state
 func (mv *messageModel) Update(msg tea.Msg) (layout.Model, tea.Cmd) {
 	if mv.message.Type == types.MessageTypeSpinner || mv.message.Type == types.MessageTypeLoading {
@@ -94,7 +173,7 @@ func (mv *messageModel) Render(width int) string {
 		}
 
 		if msg.SessionPosition == nil {
-			return messageStyle.Width(width).Render(msg.Content)
+			return mv.senderPrefix(RoleUser) + messageStyle.Width(width).Render(msg.Content)
 		}
 
 		// For editable messages, place the pencil icon in the top padding row
@@ -118,27 +197,41 @@ func (mv *messageModel) Render(width int) string {
 
 		// Use a modified style with no top padding (our icon row replaces it)
 		noTopPaddingStyle := messageStyle.PaddingTop(0)
-		return noTopPaddingStyle.Width(width).Render(contentWithIcon)
+		return mv.senderPrefix(RoleUser) + noTopPaddingStyle.Width(width).Render(contentWithIcon)
 	case types.MessageTypeAssistant:
 		if msg.Content == "" {
 			return mv.spinner.View()
 		}
 
 		messageStyle := styles.AssistantMessageStyle
-		if mv.selected {
+		switch {
+		case mv.selected:
 			messageStyle = styles.SelectedMessageStyle
+		case mv.streaming:
+			messageStyle = styles.StreamingMessageStyle
 		}
 
-		rendered, err := markdown.NewRenderer(width - messageStyle.GetHorizontalFrameSize()).Render(msg.Content)
-		if err != nil {
-			rendered = msg.Content
+		var rendered string
+		if mv.showRaw() {
+			rendered = messageStyle.Width(width).Render(msg.Content)
+		} else {
+			var err error
+			rendered, err = markdown.NewRenderer(width - messageStyle.GetHorizontalFrameSize()).Render(msg.Content)
+			if err != nil {
+				rendered = msg.Content
+			}
+			rendered = messageStyle.Render(rendered)
+		}
+		if showThroughput.Load() && msg.TokensPerSecond > 0 && !mv.streaming {
+			footer := styles.MutedStyle.PaddingLeft(messageStyle.GetPaddingLeft()).Render(FormatThroughput(msg.TokensPerSecond))
+			rendered = strings.TrimRight(rendered, "\n") + "\n" + footer
 		}
 
 		if mv.sameAgentAsPrevious(msg) {
-			return messageStyle.Render(rendered)
+			return rendered
 		}
 
-		return mv.senderPrefix(msg.Sender) + messageStyle.Render(rendered)
+		return mv.senderPrefix(msg.Sender) + rendered
 	case types.MessageTypeShellOutput:
 		if rendered, err := markdown.NewRenderer(width).Render(fmt.Sprintf("```console\n%s\n```", msg.Content)); err == nil {
 			return rendered
@@ -148,6 +241,9 @@ func (mv *messageModel) Render(width int) string {
 		return styles.WarningStyle.Render("⚠ stream cancelled ⚠")
 	case types.MessageTypeWelcome:
 		messageStyle := styles.WelcomeMessageStyle
+		if mv.showRaw() {
+			return messageStyle.Width(width - 1).Render(msg.Content)
+		}
 		// Convert explicit newlines to markdown hard line breaks (two trailing spaces)
 		// This preserves line breaks from YAML multiline syntax (|) while still
 		// allowing markdown formatting like **bold** and *italic*
@@ -174,11 +270,17 @@ func (mv *messageModel) Render(width int) string {
 	}
 }
 
+// senderPrefix renders the label of the messages sent by an agent, or by a
+// generic role. Messages without an agent name get the assistant's label.
 func (mv *messageModel) senderPrefix(sender string) string {
 	if sender == "" {
+		sender = RoleAssistant
+	}
+	label := roleLabel(sender)
+	if label == "" {
 		return ""
 	}
-	return styles.AgentBadgeStyleFor(sender).MarginLeft(2).Render(sender) + "\n\n"
+	return styles.AgentBadgeStyleFor(sender).MarginLeft(2).Render(label) + "\n\n"
 }
 
 // sameAgentAsPrevious returns true if the previous message was from the same agent
@@ -213,3 +213,58 @@ func TestWelcomeMessagePreservesLineBreaks(t *testing.T) {
 	plainRendered := stripANSI(rendered)
 	assert.Contains(t, plainRendered, "indented")
 }
+
+func TestAssistantMessageRawMarkdown(t *testing.T) {
+	t.Parallel()
+
+	msg := types.Agent(types.MessageTypeAssistant, "root", "Use `go test` and **bold**")
+	mv := New(msg, nil)
+	mv.SetSize(80, 0)
+
+	rendered := stripANSI(mv.View())
+	assert.NotContains(t, rendered, "`go test`")
+	assert.NotContains(t, rendered, "**bold**")
+
+	mv.SetRawAll(true)
+	assert.Contains(t, stripANSI(mv.View()), "Use `go test` and **bold**")
+
+	// Toggling a message flips the global setting for that message only
+	mv.ToggleRaw()
+	assert.NotContains(t, stripANSI(mv.View()), "**bold**")
+	mv.SetRawAll(false)
+	assert.Contains(t, stripANSI(mv.View()), "**bold**")
+}
+
+func TestAssistantMessageThroughputFooter(t *testing.T) {
+	msg := types.Agent(types.MessageTypeAssistant, "root", "Hello there")
+	msg.TokensPerSecond = 41.6
+	mv := New(msg, nil)
+	mv.SetSize(80, 0)
+
+	SetShowThroughput(false)
+	assert.NotContains(t, stripANSI(mv.View()), "tok/s")
+
+	SetShowThroughput(true)
+	t.Cleanup(func() { SetShowThroughput(false) })
+	assert.Contains(t, stripANSI(mv.View()), "42 tok/s")
+}
+
+func TestRoleLabels(t *testing.T) {
+	assistant := New(types.Agent(types.MessageTypeAssistant, "root", "Hello"), nil)
+	assistant.SetSize(80, 0)
+	anonymous := New(types.Agent(types.MessageTypeAssistant, "", "Hello"), nil)
+	anonymous.SetSize(80, 0)
+	user := New(types.User("Hi"), nil)
+	user.SetSize(80, 0)
+
+	SetRoleLabels(nil)
+	assert.True(t, strings.HasPrefix(strings.TrimSpace(stripANSI(assistant.View())), "root"))
+	assert.True(t, strings.HasPrefix(strings.TrimSpace(stripANSI(anonymous.View())), "assistant"))
+	assert.NotContains(t, stripANSI(user.View()), "user")
+
+	SetRoleLabels(map[string]string{"root": "Planner", "assistant": "AI", "user": "Me"})
+	t.Cleanup(func() { SetRoleLabels(nil) })
+	assert.True(t, strings.HasPrefix(strings.TrimSpace(stripANSI(assistant.View())), "Planner"))
+	assert.True(t, strings.HasPrefix(strings.TrimSpace(stripANSI(anonymous.View())), "AI"))
+	assert.True(t, strings.HasPrefix(strings.TrimSpace(stripANSI(user.View())), "Me"))
+}
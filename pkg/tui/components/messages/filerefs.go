@@ -0,0 +1,86 @@
+package messages
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/mattn/go-runewidth"
+)
+
+// FileRef is a path:line(:col) reference found in the transcript, such as
+// the ones of compiler errors, test failures or search results.
+type FileRef struct {
+	Path string
+	Line int
+	// Col is 0 when the reference has no column.
+	Col int
+}
+
+// fileRefPattern matches path:line and path:line:col. The path needs an
+// extension or a directory so that times and ports aren't taken for file
+// references.
+var fileRefPattern = regexp.MustCompile(`((?:[\w.~-]*/)*[\w-][\w.-]*\.[A-Za-z]\w*|(?:[\w.~-]*/)+[\w.-]*\w):(\d+)(?::(\d+))?`)
+
+// fileRefMatch is a reference and the display columns it spans in a line.
+type fileRefMatch struct {
+	ref        FileRef
+	start, end int
+}
+
+// findFileRefs returns the file references of a plain text line.
+func findFileRefs(line string) []fileRefMatch {
+	var matches []fileRefMatch
+	for _, idx := range fileRefPattern.FindAllStringSubmatchIndex(line, -1) {
+		// Skip the host:port of URLs
+		if path := line[idx[2]:idx[3]]; strings.Contains(path, "//") || strings.HasSuffix(line[:idx[0]], ":") {
+			continue
+		}
+		lineNum, err := strconv.Atoi(line[idx[4]:idx[5]])
+		if err != nil || lineNum == 0 {
+			continue
+		}
+		var col int
+		if idx[6] >= 0 {
+			col, _ = strconv.Atoi(line[idx[6]:idx[7]])
+		}
+		matches = append(matches, fileRefMatch{
+			ref:   FileRef{Path: line[idx[2]:idx[3]], Line: lineNum, Col: col},
+			start: runewidth.StringWidth(line[:idx[0]]),
+			end:   runewidth.StringWidth(line[:idx[1]]),
+		})
+	}
+	return matches
+}
+
+// fileRefAt returns the file reference under the given display column of a
+// rendered line.
+func (m *model) fileRefAt(line, col int) (FileRef, bool) {
+	m.ensureAllItemsRendered()
+	if line < 0 || line >= len(m.renderedLines) {
+		return FileRef{}, false
+	}
+
+	plain := ansi.Strip(m.renderedLines[line])
+	for _, match := range findFileRefs(plain) {
+		if col >= match.start && col < match.end {
+			return match.ref, true
+		}
+	}
+	return FileRef{}, false
+}
+
+// LastVisibleFileRef returns the last file reference of the visible part
+// of the transcript, the one the user most likely just read.
+func (m *model) LastVisibleFileRef() (FileRef, bool) {
+	m.ensureAllItemsRendered()
+
+	last := min(len(m.renderedLines), m.scrollOffset+m.height) - 1
+	for line := last; line >= m.scrollOffset && line >= 0; line-- {
+		if matches := findFileRefs(ansi.Strip(m.renderedLines[line])); len(matches) > 0 {
+			return matches[len(matches)-1].ref, true
+		}
+	}
+	return FileRef{}, false
+}
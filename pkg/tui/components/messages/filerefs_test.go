@@ -0,0 +1,38 @@
+package messages
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindFileRefs(t *testing.T) {
+	t.Parallel()
+
+	refs := func(line string) []FileRef {
+		var found []FileRef
+		for _, match := range findFileRefs(line) {
+			found = append(found, match.ref)
+		}
+		return found
+	}
+
+	assert.Equal(t, []FileRef{{Path: "pkg/tui/tui.go", Line: 12, Col: 4}}, refs("pkg/tui/tui.go:12:4: undefined: foo"))
+	assert.Equal(t, []FileRef{{Path: "/src/main.go", Line: 7}}, refs("see /src/main.go:7."))
+	assert.Equal(t, []FileRef{{Path: "docker/Dockerfile", Line: 3}}, refs("docker/Dockerfile:3"))
+	assert.Equal(t, []FileRef{{Path: "a.go", Line: 1}, {Path: "b.go", Line: 2}}, refs("a.go:1 and b.go:2"))
+
+	assert.Empty(t, refs("at 12:30 on localhost:8080"))
+	assert.Empty(t, refs("https://github.com:443/docker/cagent"))
+	assert.Empty(t, refs("main.go:0"))
+}
+
+func TestFindFileRefs_Columns(t *testing.T) {
+	t.Parallel()
+
+	matches := findFileRefs("│ → main.go:3 failed")
+	if assert.Len(t, matches, 1) {
+		assert.Equal(t, 4, matches[0].start)
+		assert.Equal(t, 13, matches[0].end)
+	}
+}
@@ -23,6 +23,7 @@ import (
 	"github.com/docker/cagent/pkg/tui/components/message"
 	"github.com/docker/cagent/pkg/tui/components/reasoningblock"
 	"github.com/docker/cagent/pkg/tui/components/scrollview"
+	"github.com/docker/cagent/pkg/tui/components/subsession"
 	"github.com/docker/cagent/pkg/tui/components/tool"
 	"github.com/docker/cagent/pkg/tui/components/tool/editfile"
 	"github.com/docker/cagent/pkg/tui/core"
@@ -36,6 +37,13 @@ import (
 // ToggleHideToolResultsMsg triggers hiding/showing tool results
 type ToggleHideToolResultsMsg struct{}
 
+// RefreshMsg re-renders all the messages, after a setting that changes how
+// they render.
+type RefreshMsg struct{}
+
+// ToggleRawMarkdownMsg triggers showing the markdown source of all messages
+type ToggleRawMarkdownMsg struct{}
+
 // Model represents a chat message list component
 type Model interface {
 	layout.Model
@@ -68,6 +76,9 @@ type Model interface {
 	// IsMouseOnScrollbar returns true when the given screen coordinates are on the scrollbar.
 	IsMouseOnScrollbar(x, y int) bool
 
+	// ClearStreamingMessage removes the highlight from the message that was receiving streamed content.
+	ClearStreamingMessage()
+
 	// Inline editing methods
 	StartInlineEdit(msgIndex, sessionPosition int, content string) tea.Cmd
 	CancelInlineEdit() tea.Cmd
@@ -76,6 +87,27 @@ type Model interface {
 	// FocusAt gives focus and selects the message at the given screen coordinates.
 	// Falls back to the default Focus behavior if no message is found at that position.
 	FocusAt(x, y int) tea.Cmd
+
+	// SetReplayCursor shows only the first n messages, as if the rest hadn't
+	// been streamed yet. A negative n leaves replay mode and shows everything.
+	SetReplayCursor(n int) tea.Cmd
+	// ReplayCursor returns the number of messages shown in replay mode (-1 when
+	// not replaying) and the total number of messages.
+	ReplayCursor() (cursor, total int)
+
+	// CurrentScrollMark returns the message at the top of the viewport and
+	// the line within it, to come back to it with ScrollToMark.
+	CurrentScrollMark() (msgIndex, lineOffset int)
+	// ScrollToMark scrolls to the given line of the message at msgIndex.
+	ScrollToMark(msgIndex, lineOffset int)
+
+	// SetLastResponseThroughput records the generation speed of the response
+	// that just ended on its assistant message.
+	SetLastResponseThroughput(agentName string, tokensPerSecond float64)
+
+	// LastVisibleFileRef returns the last path:line reference of the visible
+	// part of the transcript.
+	LastVisibleFileRef() (FileRef, bool)
 }
 
 // renderedItem represents a cached rendered message with position information
@@ -121,6 +153,9 @@ type model struct {
 	selectedMessageIndex int  // Index of selected message (-1 = no selection)
 	focused              bool // Whether the messages component is focused
 
+	// Index of the assistant message currently receiving streamed content (-1 = none)
+	streamingMsgIndex int
+
 	// Debug layout mode - highlights truncated lines with red background
 	debugLayout bool
 
@@ -130,6 +165,13 @@ type model struct {
 	inlineEditTextarea      textarea.Model // Textarea for inline editing
 	inlineEditOriginal      string         // Original content (for cancel)
 	inlineEditPrevSelection int            // Previous selection index before entering inline edit (-1 = was not in selection mode)
+
+	// Replay state: messages at or after this index are hidden (-1 = not replaying)
+	replayCursor int
+
+	// Groups of consecutive tool calls collapsed by the user, keyed by the ID
+	// of their first tool call
+	collapsedToolGroups map[string]bool
 }
 
 // New creates a new message list component
@@ -155,7 +197,10 @@ func newModel(width, height int, sessionState *service.SessionState) *model {
 		sessionState:         sessionState,
 		scrollview:           sv,
 		selectedMessageIndex: -1,
+		streamingMsgIndex:    -1,
 		inlineEditMsgIndex:   -1,
+		replayCursor:         -1,
+		collapsedToolGroups:  make(map[string]bool),
 		debugLayout:          os.Getenv("DOCKER_AGENT_EXPERIMENTAL_DEBUG_LAYOUT") == "1" || os.Getenv("CAGENT_EXPERIMENTAL_DEBUG_LAYOUT") == "1",
 		renderDirty:          true,
 	}
@@ -178,6 +223,7 @@ func (m *model) Update(msg tea.Msg) (layout.Model, tea.Cmd) {
 
 	switch msg := msg.(type) {
 	case messages.StreamCancelledMsg:
+		m.ClearStreamingMessage()
 		m.removeSpinner()
 		m.removePendingToolCallMessages()
 		m.stopReasoningBlockAnimations()
@@ -219,6 +265,15 @@ func (m *model) Update(msg tea.Msg) (layout.Model, tea.Cmd) {
 		m.invalidateAllItems()
 		return m, nil
 
+	case ToggleRawMarkdownMsg:
+		m.sessionState.ToggleRawMarkdown()
+		m.invalidateAllItems()
+		return m, nil
+
+	case RefreshMsg:
+		m.invalidateAllItems()
+		return m, nil
+
 	case messages.ThemeChangedMsg:
 		// Theme changed - invalidate all render caches
 		m.invalidateAllItems()
@@ -281,6 +336,13 @@ func (m *model) handleMouseClick(msg tea.MouseClickMsg) (layout.Model, tea.Cmd)
 
 	line, col := m.mouseToLineCol(msg.X, msg.Y)
 
+	// Ctrl+click opens the file:line reference under the mouse
+	if msg.Mod.Contains(tea.ModCtrl) {
+		if ref, ok := m.fileRefAt(line, col); ok {
+			return m, core.CmdHandler(messages.OpenFileAtLineMsg{Path: ref.Path, Line: ref.Line, Col: ref.Col})
+		}
+	}
+
 	// Check for reasoning block header toggle
 	if msgIdx, localLine := m.globalLineToMessageLine(line); msgIdx >= 0 {
 		if block, ok := m.views[msgIdx].(*reasoningblock.Model); ok {
@@ -292,6 +354,20 @@ func (m *model) handleMouseClick(msg tea.MouseClickMsg) (layout.Model, tea.Cmd)
 			}
 		}
 
+		if block, ok := m.views[msgIdx].(*subsession.Model); ok {
+			if block.IsToggleLine(localLine) {
+				block.Toggle()
+				m.bottomSlack = 0
+				m.invalidateItem(msgIdx)
+				return m, nil
+			}
+		}
+
+		if m.isToolGroupHeaderLine(msgIdx, localLine) {
+			m.toggleToolGroup(msgIdx)
+			return m, nil
+		}
+
 		if clicked, msg := m.isEditLabelClick(msgIdx, localLine, col); clicked {
 			return m, core.CmdHandler(messages.EditUserMessageMsg{
 				MsgIndex:        msgIdx,
@@ -452,6 +528,20 @@ func (m *model) handleKeyPress(msg tea.KeyPressMsg) (layout.Model, tea.Cmd) {
 			}
 		}
 		return m, nil
+	case "r":
+		if m.focused && m.selectedMessageIndex >= 0 && m.selectedMessageIndex < len(m.views) {
+			if v, ok := m.views[m.selectedMessageIndex].(message.Model); ok {
+				v.ToggleRaw()
+				m.invalidateItem(m.selectedMessageIndex)
+			}
+		}
+		return m, nil
+	case "+":
+		m.setAllExpanded(true)
+		return m, nil
+	case "-":
+		m.setAllExpanded(false)
+		return m, nil
 	case "pgup":
 		m.scrollPageUp()
 		return m, nil
@@ -464,6 +554,9 @@ func (m *model) handleKeyPress(msg tea.KeyPressMsg) (layout.Model, tea.Cmd) {
 	case "end":
 		m.scrollToBottom()
 		return m, nil
+	case "u":
+		cmd := m.scrollToLastUserMessage()
+		return m, cmd
 	}
 	return m, nil
 }
@@ -639,6 +732,9 @@ func (m *model) Bindings() []key.Binding {
 		key.NewBinding(key.WithKeys("up"), key.WithHelp("↑", "select prev")),
 		key.NewBinding(key.WithKeys("down"), key.WithHelp("↓", "select next")),
 		key.NewBinding(key.WithKeys("c"), key.WithHelp("c", "copy message")),
+		key.NewBinding(key.WithKeys("+"), key.WithHelp("+", "expand all")),
+		key.NewBinding(key.WithKeys("-"), key.WithHelp("-", "collapse all")),
+		key.NewBinding(key.WithKeys("u"), key.WithHelp("u", "last prompt")),
 	}
 
 	// Only show edit binding when a user message with session position is selected
@@ -647,6 +743,9 @@ func (m *model) Bindings() []key.Binding {
 		if msg.Type == types.MessageTypeUser && msg.SessionPosition != nil {
 			bindings = append(bindings, key.NewBinding(key.WithKeys("e"), key.WithHelp("e", "edit message")))
 		}
+		if msg.Type == types.MessageTypeAssistant || msg.Type == types.MessageTypeWelcome {
+			bindings = append(bindings, key.NewBinding(key.WithKeys("r"), key.WithHelp("r", "raw markdown")))
+		}
 	}
 
 	return bindings
@@ -752,7 +851,7 @@ func (m *model) isAtBottom() bool {
 
 // Message selection methods
 func (m *model) isSelectableMessage(index int) bool {
-	if index < 0 || index >= len(m.messages) {
+	if index < 0 || index >= m.visibleCount() {
 		return false
 	}
 	msg := m.messages[index]
@@ -768,7 +867,7 @@ func (m *model) isSelectableMessage(index int) bool {
 }
 
 func (m *model) findLastSelectableMessage() int {
-	for i := len(m.messages) - 1; i >= 0; i-- {
+	for i := m.visibleCount() - 1; i >= 0; i-- {
 		if m.isSelectableMessage(i) {
 			return i
 		}
@@ -779,7 +878,7 @@ func (m *model) findLastSelectableMessage() int {
 // findLastAssistantMessage finds the last assistant or reasoning block message.
 // Used for initial focus selection to start on assistant content.
 func (m *model) findLastAssistantMessage() int {
-	for i := len(m.messages) - 1; i >= 0; i-- {
+	for i := m.visibleCount() - 1; i >= 0; i-- {
 		if i >= len(m.messages) {
 			continue
 		}
@@ -856,20 +955,7 @@ func (m *model) scrollToSelectedMessage() {
 		return
 	}
 
-	// Ensure all items are rendered so totalHeight is accurate
-	m.ensureAllItemsRendered()
-
-	// Calculate the line range for the selected message
-	startLine := 0
-	for i := range m.selectedMessageIndex {
-		if i < len(m.views) {
-			item := m.renderItem(i, m.views[i])
-			startLine += item.height
-			if m.needsSeparator(i) {
-				startLine++
-			}
-		}
-	}
+	startLine := m.messageStartLine(m.selectedMessageIndex)
 
 	var selectedHeight int
 	if m.selectedMessageIndex < len(m.views) {
@@ -886,17 +972,77 @@ func (m *model) scrollToSelectedMessage() {
 	}
 }
 
+// scrollToLastUserMessage scrolls the most recent user message to the top of
+// the viewport, rather than to the very bottom which may be a long tool
+// output. The message is selected when the list is focused and it can be.
+func (m *model) scrollToLastUserMessage() tea.Cmd {
+	index := -1
+	for i := m.visibleCount() - 1; i >= 0; i-- {
+		if m.messages[i].Type == types.MessageTypeUser {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		return nil
+	}
+
+	m.userHasScrolled = true
+	m.bottomSlack = 0
+	m.setScrollOffset(m.messageStartLine(index))
+	if m.isAtBottom() {
+		m.userHasScrolled = false
+	}
+
+	if !m.focused || !m.isSelectableMessage(index) || index == m.selectedMessageIndex {
+		return nil
+	}
+	oldIndex := m.selectedMessageIndex
+	m.selectedMessageIndex = index
+	m.invalidateAllItems()
+	if m.messageTypeChanged(oldIndex, index) {
+		return core.CmdHandler(messages.InvalidateStatusBarMsg{})
+	}
+	return nil
+}
+
+// messageStartLine returns the line at which the message at index starts in
+// the rendered content.
+func (m *model) messageStartLine(index int) int {
+	// Ensure all items are rendered so totalHeight is accurate
+	m.ensureAllItemsRendered()
+
+	startLine := 0
+	for i := range min(index, len(m.views)) {
+		item := m.renderItem(i, m.views[i])
+		if item.view == "" {
+			continue
+		}
+		startLine += item.height
+		if m.needsSeparator(i) {
+			startLine++
+		}
+	}
+	return startLine
+}
+
 // Caching methods
 func (m *model) shouldCacheMessage(index int) bool {
 	if index < 0 || index >= len(m.messages) {
 		return false
 	}
 
+	if index == m.streamingMsgIndex {
+		// The streaming highlight goes away once the stream ends
+		return false
+	}
+
 	msg := m.messages[index]
 	switch msg.Type {
 	case types.MessageTypeToolCall:
 		return msg.ToolStatus == types.ToolStatusCompleted ||
 			msg.ToolStatus == types.ToolStatusError ||
+			msg.ToolStatus == types.ToolStatusTimedOut ||
 			msg.ToolStatus == types.ToolStatusConfirmation
 	case types.MessageTypeToolResult:
 		return true
@@ -905,7 +1051,7 @@ func (m *model) shouldCacheMessage(index int) bool {
 	case types.MessageTypeAssistantReasoningBlock:
 		// Don't cache reasoning blocks - they can have spinners for in-progress tools
 		return false
-	case types.MessageTypeUser:
+	case types.MessageTypeUser, types.MessageTypeSubSession:
 		return true
 	default:
 		return false
@@ -920,11 +1066,26 @@ func (m *model) renderItem(index int, view layout.Model) renderedItem {
 		return renderedItem{view: rendered, height: height}
 	}
 
+	if index >= m.visibleCount() {
+		return renderedItem{}
+	}
+
+	if start, size := m.toolGroupAt(index); size > 0 {
+		return m.renderToolGroupItem(index, start, size)
+	}
+	return m.renderView(index, view)
+}
+
+// renderView renders the view of the message at index, using the cache when
+// possible.
+func (m *model) renderView(index int, view layout.Model) renderedItem {
 	isSelected := m.focused && index == m.selectedMessageIndex
 
 	switch v := view.(type) {
 	case message.Model:
 		v.SetSelected(isSelected)
+		v.SetStreaming(index == m.streamingMsgIndex)
+		v.SetRawAll(m.sessionState.RawMarkdown())
 	case *reasoningblock.Model:
 		v.SetSelected(isSelected)
 	}
@@ -936,7 +1097,7 @@ func (m *model) renderItem(index int, view layout.Model) renderedItem {
 		}
 	}
 
-	rendered := view.View()
+	rendered := hardWrapOverflow(view.View(), m.contentWidth())
 	height := lipgloss.Height(rendered)
 	if rendered == "" {
 		height = 0
@@ -951,6 +1112,30 @@ func (m *model) renderItem(index int, view layout.Model) renderedItem {
 	return item
 }
 
+// hardWrapOverflow breaks the lines of a rendered view that are wider than
+// width. Views wrap their own content, but some, like a table cell holding a
+// base64 blob or minified JSON, can still overflow. Breaking them here keeps
+// the line count, which the scroll and selection math rely on, in sync with
+// what's drawn.
+func hardWrapOverflow(view string, width int) string {
+	if width <= 0 {
+		return view
+	}
+
+	lines := strings.Split(view, "\n")
+	wrapped := false
+	for i, line := range lines {
+		if ansi.StringWidth(line) > width {
+			lines[i] = ansi.Hardwrap(line, width, false)
+			wrapped = true
+		}
+	}
+	if !wrapped {
+		return view
+	}
+	return strings.Join(lines, "\n")
+}
+
 // renderInlineEditTextarea renders the inline editing textarea with user message styling.
 func (m *model) renderInlineEditTextarea() string {
 	// Use the same style as user messages but with a highlight to indicate editing
@@ -995,9 +1180,13 @@ func trimEndOfBufferLines(view string) string {
 }
 
 func (m *model) needsSeparator(index int) bool {
-	if index >= len(m.messages)-1 {
+	if index >= m.visibleCount()-1 {
 		return false
 	}
+	// A collapsed group of tool calls is a single block
+	if start, size := m.toolGroupAt(index); size > 0 && index == start && m.isToolGroupCollapsed(start, size) {
+		return true
+	}
 	currentIsToolCall := m.messages[index].Type == types.MessageTypeToolCall
 	nextIsToolCall := m.messages[index+1].Type == types.MessageTypeToolCall
 
@@ -1044,6 +1233,22 @@ func (m *model) ensureAllItemsRendered() {
 	m.renderDirty = false
 }
 
+// setAllExpanded expands or collapses every reasoning block and group of tool
+// calls in the transcript and shows or hides tool results to match.
+func (m *model) setAllExpanded(expanded bool) {
+	for _, view := range m.views {
+		switch block := view.(type) {
+		case *reasoningblock.Model:
+			block.SetExpanded(expanded)
+		case *subsession.Model:
+			block.SetExpanded(expanded)
+		}
+	}
+	m.setAllToolGroupsCollapsed(!expanded)
+	m.sessionState.SetHideToolResults(!expanded)
+	m.invalidateAllItems()
+}
+
 func (m *model) invalidateItem(index int) {
 	if m.shouldCacheMessage(index) {
 		delete(m.renderedItems, index)
@@ -1211,6 +1416,8 @@ func (m *model) LoadFromSession(sess *session.Session) tea.Cmd {
 	m.totalHeight = 0
 	m.bottomSlack = 0
 	m.selectedMessageIndex = -1
+	m.streamingMsgIndex = -1
+	m.replayCursor = -1
 
 	var cmds []tea.Cmd
 
@@ -1227,6 +1434,17 @@ func (m *model) LoadFromSession(sess *session.Session) tea.Cmd {
 	}
 
 	for pos, item := range sess.Messages {
+		if item.IsSubSession() {
+			block := subsession.New(item.SubSession, m.sessionState)
+			block.SetSize(m.contentWidth(), 0)
+			msg := &types.Message{
+				Type:    types.MessageTypeSubSession,
+				Sender:  block.AgentName(),
+				Content: block.Summary(),
+			}
+			appendSessionMessage(msg, block)
+			continue
+		}
 		if !item.IsMessage() {
 			continue
 		}
@@ -1390,10 +1608,53 @@ func (m *model) AppendToLastMessage(agentName, content string) tea.Cmd {
 		lastMsg.Content += content
 		m.views[lastIdx].(message.Model).SetMessage(lastMsg)
 		m.invalidateItem(lastIdx)
+		m.setStreamingMessage(lastIdx)
 		return nil
 	}
 
-	return m.addMessage(types.Agent(types.MessageTypeAssistant, agentName, content))
+	// The answer starts: collapse the reasoning that led to it, so that the
+	// answer stays prominent. It can still be expanded again.
+	if lastMsg.Type == types.MessageTypeAssistantReasoningBlock && lastMsg.Sender == agentName && reasoningblock.AutoCollapse() {
+		if block, ok := m.views[lastIdx].(*reasoningblock.Model); ok && block.IsExpanded() {
+			block.SetExpanded(false)
+			m.invalidateItem(lastIdx)
+		}
+	}
+
+	cmd := m.addMessage(types.Agent(types.MessageTypeAssistant, agentName, content))
+	m.setStreamingMessage(len(m.messages) - 1)
+	return cmd
+}
+
+func (m *model) SetLastResponseThroughput(agentName string, tokensPerSecond float64) {
+	// The tool calls of the response come after its text, and haven't run
+	// yet. Anything else means the response had no text.
+	for i, msg := range slices.Backward(m.messages) {
+		switch {
+		case msg.Type == types.MessageTypeToolCall && msg.ToolResult == nil:
+			continue
+		case msg.Type == types.MessageTypeAssistant && msg.Sender == agentName:
+			msg.TokensPerSecond = tokensPerSecond
+			m.views[i].(message.Model).SetMessage(msg)
+			m.invalidateItem(i)
+		}
+		return
+	}
+}
+
+// setStreamingMessage moves the streaming highlight to the message at index (-1 = none).
+func (m *model) setStreamingMessage(index int) {
+	if index == m.streamingMsgIndex {
+		return
+	}
+	delete(m.renderedItems, m.streamingMsgIndex)
+	delete(m.renderedItems, index)
+	m.streamingMsgIndex = index
+	m.renderDirty = true
+}
+
+func (m *model) ClearStreamingMessage() {
+	m.setStreamingMessage(-1)
 }
 
 func (m *model) AppendReasoning(agentName, content string) tea.Cmd {
@@ -1473,6 +1734,62 @@ func (m *model) getActiveReasoningBlock(agentName string) (*reasoningblock.Model
 	return nil, -1
 }
 
+// visibleCount returns the number of messages that are rendered: all of them,
+// or the ones before the replay cursor while replaying.
+func (m *model) visibleCount() int {
+	if m.replayCursor >= 0 {
+		return min(m.replayCursor, len(m.messages))
+	}
+	return len(m.messages)
+}
+
+func (m *model) SetReplayCursor(n int) tea.Cmd {
+	if n < 0 {
+		n = -1
+	} else {
+		n = min(n, len(m.messages))
+	}
+	if n == m.replayCursor {
+		return nil
+	}
+
+	m.replayCursor = n
+	if m.selectedMessageIndex >= m.visibleCount() {
+		m.selectedMessageIndex = m.findLastSelectableMessage()
+	}
+	m.invalidateAllItems()
+	m.scrollToBottom()
+	return nil
+}
+
+func (m *model) ReplayCursor() (cursor, total int) {
+	return m.replayCursor, len(m.messages)
+}
+
+func (m *model) CurrentScrollMark() (msgIndex, lineOffset int) {
+	// Skip the separator lines between messages
+	for line := m.scrollOffset; line < m.totalScrollableHeight(); line++ {
+		if msgIndex, lineOffset = m.globalLineToMessageLine(line); msgIndex >= 0 {
+			return msgIndex, lineOffset
+		}
+	}
+	return 0, 0
+}
+
+func (m *model) ScrollToMark(msgIndex, lineOffset int) {
+	msgIndex = max(0, min(msgIndex, m.visibleCount()-1))
+	if msgIndex < len(m.views) {
+		// The message may have been re-rendered shorter
+		lineOffset = min(lineOffset, m.renderItem(msgIndex, m.views[msgIndex]).height-1)
+	}
+	m.userHasScrolled = true
+	m.bottomSlack = 0
+	m.setScrollOffset(m.messageStartLine(msgIndex) + max(0, lineOffset))
+	if m.isAtBottom() {
+		m.userHasScrolled = false
+	}
+}
+
 func (m *model) ScrollToBottom() tea.Cmd {
 	return func() tea.Msg {
 		if !m.userHasScrolled {
@@ -16,6 +16,7 @@ import (
 	"github.com/docker/cagent/pkg/tools"
 	"github.com/docker/cagent/pkg/tui/animation"
 	"github.com/docker/cagent/pkg/tui/components/reasoningblock"
+	"github.com/docker/cagent/pkg/tui/components/subsession"
 	"github.com/docker/cagent/pkg/tui/core/layout"
 	tuimessages "github.com/docker/cagent/pkg/tui/messages"
 	"github.com/docker/cagent/pkg/tui/service"
@@ -39,6 +40,71 @@ func TestViewDoesNotWrapWideLines(t *testing.T) {
 	}
 }
 
+func TestViewHardWrapsPathologicalLongLines(t *testing.T) {
+	t.Parallel()
+
+	sessionState := &service.SessionState{}
+	m := NewScrollableView(40, 10, sessionState).(*model)
+	m.SetSize(40, 10)
+
+	blob := strings.Repeat("QUJDREVGR0hJSktMTU5PUFFSU1RVVldYWVo=", 140) // ~5000 chars, no spaces
+	m.AddUserMessage("decode this")
+	// The renderer breaks long words in paragraphs but not in table cells.
+	m.AppendToLastMessage("root", "Here it is:\n\n"+blob+"\n\n| field | value |\n|---|---|\n| data | "+blob+" |\n\nDone.")
+	m.ClearStreamingMessage()
+
+	out := m.View()
+	lines := strings.Split(out, "\n")
+	assert.Len(t, lines, 10, "viewport height must stay intact")
+	for _, line := range lines {
+		assert.LessOrEqual(t, ansi.StringWidth(line), 40)
+	}
+
+	// Every line counted by the scroll math fits in the viewport.
+	for _, line := range m.renderedLines {
+		assert.LessOrEqual(t, ansi.StringWidth(line), m.contentWidth())
+	}
+	assert.Greater(t, m.totalHeight, len(blob)/m.contentWidth(), "the blob must be broken, not truncated")
+
+	// The end of the message is reachable by scrolling.
+	m.scrollToBottom()
+	assert.Contains(t, ansi.Strip(m.View()), "Done.")
+}
+
+func TestStreamingMessageHighlight(t *testing.T) {
+	t.Parallel()
+
+	sessionState := &service.SessionState{}
+	m := NewScrollableView(80, 24, sessionState).(*model)
+	m.SetSize(80, 24)
+
+	m.AddUserMessage("Hi")
+	m.AppendToLastMessage("root", "Hello")
+	m.AppendToLastMessage("root", " world")
+	require.Len(t, m.messages, 2)
+	assert.Equal(t, 1, m.streamingMsgIndex)
+	assert.False(t, m.shouldCacheMessage(1), "streaming message must not be cached")
+	m.View()
+
+	m.ClearStreamingMessage()
+	assert.Equal(t, -1, m.streamingMsgIndex)
+	assert.True(t, m.shouldCacheMessage(1))
+	assert.True(t, m.renderDirty)
+}
+
+func TestStreamCancelledClearsStreamingMessage(t *testing.T) {
+	t.Parallel()
+
+	sessionState := &service.SessionState{}
+	m := NewScrollableView(80, 24, sessionState).(*model)
+
+	m.AddUserMessage("Hi")
+	m.AppendToLastMessage("root", "partial")
+	m.Update(tuimessages.StreamCancelledMsg{})
+
+	assert.Equal(t, -1, m.streamingMsgIndex)
+}
+
 func TestLoadFromSessionIncludesReasoningContent(t *testing.T) {
 	t.Parallel()
 
@@ -87,6 +153,58 @@ func TestLoadFromSessionIncludesReasoningContent(t *testing.T) {
 	assert.Equal(t, "root", m.messages[2].Sender)
 }
 
+func TestLoadFromSessionCollapsesSubSessions(t *testing.T) {
+	t.Parallel()
+
+	sessionState := &service.SessionState{}
+	m := NewScrollableView(80, 24, sessionState).(*model)
+	m.SetSize(80, 24)
+
+	sub := &session.Session{
+		Messages: []session.Item{
+			session.NewMessageItem(&session.Message{
+				AgentName: "worker",
+				Implicit:  true,
+				Message:   chat.Message{Role: chat.MessageRoleUser, Content: "Please proceed."},
+			}),
+			session.NewMessageItem(&session.Message{
+				AgentName: "worker",
+				Message:   chat.Message{Role: chat.MessageRoleAssistant, Content: "The tests pass now.\n\nDetails follow."},
+			}),
+		},
+	}
+	sess := &session.Session{
+		Messages: []session.Item{
+			session.NewMessageItem(&session.Message{
+				Message: chat.Message{Role: chat.MessageRoleUser, Content: "Fix the tests"},
+			}),
+			session.NewSubSessionItem(sub),
+			session.NewMessageItem(&session.Message{
+				AgentName: "root",
+				Message:   chat.Message{Role: chat.MessageRoleAssistant, Content: "Done"},
+			}),
+		},
+	}
+
+	m.LoadFromSession(sess)
+
+	require.Len(t, m.messages, 3)
+	assert.Equal(t, types.MessageTypeSubSession, m.messages[1].Type)
+	assert.Equal(t, "worker", m.messages[1].Sender)
+	block, ok := m.views[1].(*subsession.Model)
+	require.True(t, ok, "view should be a sub-session block")
+	assert.False(t, block.IsExpanded(), "sub-sessions start collapsed")
+
+	out := ansi.Strip(m.View())
+	assert.Contains(t, out, "transferred to worker")
+	assert.Contains(t, out, "The tests pass now.")
+	assert.NotContains(t, out, "Details follow.")
+
+	m.setAllExpanded(true)
+	assert.True(t, block.IsExpanded())
+	assert.Contains(t, ansi.Strip(m.View()), "Details follow.")
+}
+
 func TestLoadFromSessionReasoningOrderWithToolCalls(t *testing.T) {
 	t.Parallel()
 
@@ -1140,3 +1258,175 @@ func TestBindingsExcludesEditKeyWhenAssistantMessageSelected(t *testing.T) {
 	}
 	assert.False(t, foundE, "Bindings should NOT include 'e' key when assistant message is selected")
 }
+
+func TestExpandCollapseAll(t *testing.T) {
+	t.Parallel()
+
+	sessionState := &service.SessionState{}
+	m := NewScrollableView(80, 24, sessionState).(*model)
+	m.SetSize(80, 24)
+
+	sess := &session.Session{
+		ID: "test-session",
+		Messages: []session.Item{
+			session.NewMessageItem(&session.Message{
+				AgentName: "root",
+				Message: chat.Message{
+					Role:             chat.MessageRoleAssistant,
+					ReasoningContent: "First thought",
+				},
+			}),
+			session.NewMessageItem(&session.Message{
+				AgentName: "other",
+				Message: chat.Message{
+					Role:             chat.MessageRoleAssistant,
+					ReasoningContent: "Second thought",
+				},
+			}),
+		},
+	}
+	m.LoadFromSession(sess)
+
+	var blocks []*reasoningblock.Model
+	for _, view := range m.views {
+		if block, ok := view.(*reasoningblock.Model); ok {
+			blocks = append(blocks, block)
+		}
+	}
+	require.Len(t, blocks, 2)
+
+	m.Update(tea.KeyPressMsg{Code: '+', Text: "+"})
+	for _, block := range blocks {
+		assert.True(t, block.IsExpanded())
+	}
+	assert.False(t, sessionState.HideToolResults())
+
+	m.Update(tea.KeyPressMsg{Code: '-', Text: "-"})
+	for _, block := range blocks {
+		assert.False(t, block.IsExpanded())
+	}
+	assert.True(t, sessionState.HideToolResults())
+}
+
+func TestReplayCursorHidesLaterMessages(t *testing.T) {
+	t.Parallel()
+
+	sessionState := &service.SessionState{}
+	m := NewScrollableView(80, 24, sessionState).(*model)
+	m.SetSize(80, 24)
+
+	for _, content := range []string{"first", "second", "third"} {
+		msg := types.Agent(types.MessageTypeAssistant, "root", content)
+		m.messages = append(m.messages, msg)
+		m.views = append(m.views, m.createMessageView(msg))
+	}
+
+	cursor, total := m.ReplayCursor()
+	assert.Equal(t, -1, cursor)
+	assert.Equal(t, 3, total)
+
+	m.SetReplayCursor(1)
+	out := ansi.Strip(m.View())
+	assert.Contains(t, out, "first")
+	assert.NotContains(t, out, "second")
+	assert.NotContains(t, out, "third")
+	assert.Equal(t, 0, m.findLastSelectableMessage())
+
+	m.SetReplayCursor(10)
+	cursor, _ = m.ReplayCursor()
+	assert.Equal(t, 3, cursor, "cursor is clamped to the number of messages")
+	assert.Contains(t, ansi.Strip(m.View()), "third")
+
+	m.SetReplayCursor(0)
+	assert.NotContains(t, ansi.Strip(m.View()), "first")
+
+	m.SetReplayCursor(-1)
+	out = ansi.Strip(m.View())
+	assert.Contains(t, out, "first")
+	assert.Contains(t, out, "third")
+}
+
+func TestKeyUScrollsToLastUserMessage(t *testing.T) {
+	t.Parallel()
+
+	sessionPos := 2
+	sessionState := &service.SessionState{}
+	m := NewScrollableView(80, 10, sessionState).(*model)
+	m.SetSize(80, 10)
+
+	msgs := []*types.Message{
+		{Type: types.MessageTypeUser, Content: "first prompt"},
+		types.Agent(types.MessageTypeAssistant, "root", "answer"),
+		{Type: types.MessageTypeUser, Content: "latest prompt", SessionPosition: &sessionPos},
+		types.Agent(types.MessageTypeAssistant, "root", strings.Repeat("long tool dump\n", 50)),
+	}
+	for _, msg := range msgs {
+		m.messages = append(m.messages, msg)
+		m.views = append(m.views, m.createMessageView(msg))
+	}
+	m.scrollToBottom()
+	require.NotContains(t, ansi.Strip(m.View()), "latest prompt")
+
+	m.Update(tea.KeyPressMsg{Code: 'u', Text: "u"})
+
+	assert.Equal(t, m.messageStartLine(2), m.scrollOffset)
+	lines := strings.Split(ansi.Strip(m.View()), "\n")
+	assert.Contains(t, strings.Join(lines[:3], "\n"), "latest prompt", "the prompt is at the top of the viewport")
+	assert.Equal(t, -1, m.selectedMessageIndex, "nothing is selected when the list isn't focused")
+
+	m.Focus()
+	m.Update(tea.KeyPressMsg{Code: 'u', Text: "u"})
+	assert.Equal(t, 2, m.selectedMessageIndex)
+}
+
+func TestScrollMarkRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	sessionState := &service.SessionState{}
+	m := NewScrollableView(80, 10, sessionState).(*model)
+	m.SetSize(80, 10)
+
+	msgs := []*types.Message{
+		{Type: types.MessageTypeUser, Content: "first prompt"},
+		types.Agent(types.MessageTypeAssistant, "root", strings.Repeat("first answer\n", 30)),
+		{Type: types.MessageTypeUser, Content: "second prompt"},
+		types.Agent(types.MessageTypeAssistant, "root", strings.Repeat("second answer\n", 30)),
+	}
+	for _, msg := range msgs {
+		m.messages = append(m.messages, msg)
+		m.views = append(m.views, m.createMessageView(msg))
+	}
+
+	m.setScrollOffset(m.messageStartLine(1) + 5)
+	msgIndex, lineOffset := m.CurrentScrollMark()
+	assert.Equal(t, 1, msgIndex)
+	assert.Equal(t, 5, lineOffset)
+
+	m.scrollToBottom()
+	m.ScrollToMark(msgIndex, lineOffset)
+	assert.Equal(t, m.messageStartLine(1)+5, m.scrollOffset)
+	assert.True(t, m.userHasScrolled)
+
+	// Marks past the end are clamped
+	m.ScrollToMark(10, 1000)
+	assert.Positive(t, m.scrollOffset)
+}
+
+func TestAnswerCollapsesExpandedReasoning(t *testing.T) {
+	t.Parallel()
+
+	m := NewScrollableView(80, 24, &service.SessionState{}).(*model)
+	m.SetSize(80, 24)
+
+	m.AddUserMessage("question")
+	m.AppendReasoning("root", "Thinking about it")
+	block, ok := m.views[len(m.views)-1].(*reasoningblock.Model)
+	require.True(t, ok)
+	block.SetExpanded(true)
+
+	m.AppendToLastMessage("root", "The answer")
+
+	assert.False(t, block.IsExpanded())
+	require.Len(t, m.messages, 3)
+	assert.Equal(t, types.MessageTypeAssistant, m.messages[2].Type)
+}
@@ -0,0 +1,149 @@
+package messages
+
+import (
+	"strconv"
+
+	"github.com/docker/cagent/pkg/tools/builtin"
+	"github.com/docker/cagent/pkg/tui/styles"
+	"github.com/docker/cagent/pkg/tui/types"
+)
+
+// minToolGroupSize is the number of consecutive tool calls from which they
+// are shown as a single collapsible group.
+const minToolGroupSize = 3
+
+// isGroupableToolCall reports whether the message at index can be part of a
+// group of tool calls. transfer_task always stands on its own.
+func (m *model) isGroupableToolCall(index int) bool {
+	if index < 0 || index >= m.visibleCount() {
+		return false
+	}
+	msg := m.messages[index]
+	return msg.Type == types.MessageTypeToolCall && msg.ToolCall.Function.Name != builtin.ToolNameTransferTask
+}
+
+// sameToolGroup reports whether the messages at index and index+1 are
+// consecutive tool calls from the same agent.
+func (m *model) sameToolGroup(index int) bool {
+	return m.isGroupableToolCall(index) && m.isGroupableToolCall(index+1) &&
+		m.messages[index].Sender == m.messages[index+1].Sender
+}
+
+// toolGroupAt returns the index of the first message and the size of the
+// group of tool calls the message at index belongs to, or a size of 0 if it
+// isn't part of a group.
+func (m *model) toolGroupAt(index int) (start, size int) {
+	if !m.isGroupableToolCall(index) {
+		return 0, 0
+	}
+	start, end := index, index
+	for start > 0 && m.sameToolGroup(start-1) {
+		start--
+	}
+	for m.sameToolGroup(end) {
+		end++
+	}
+	if size = end - start + 1; size < minToolGroupSize {
+		return 0, 0
+	}
+	return start, size
+}
+
+// toolGroupInProgress reports whether any tool call of the group is still
+// waiting or running. Such groups stay expanded.
+func (m *model) toolGroupInProgress(start, size int) bool {
+	for _, msg := range m.messages[start : start+size] {
+		switch msg.ToolStatus {
+		case types.ToolStatusPending, types.ToolStatusConfirmation, types.ToolStatusRunning:
+			return true
+		}
+	}
+	return false
+}
+
+// isToolGroupCollapsed reports whether the group starting at start is shown
+// as its header only.
+func (m *model) isToolGroupCollapsed(start, size int) bool {
+	return m.collapsedToolGroups[m.messages[start].ToolCall.ID] && !m.toolGroupInProgress(start, size)
+}
+
+// isToolGroupHeaderLine reports whether the given line of the message at
+// index is the header of a group of tool calls that can be toggled.
+func (m *model) isToolGroupHeaderLine(index, line int) bool {
+	start, size := m.toolGroupAt(index)
+	return size > 0 && index == start && line == 0 && !m.toolGroupInProgress(start, size)
+}
+
+// toggleToolGroup collapses or expands the group starting at start.
+func (m *model) toggleToolGroup(start int) {
+	id := m.messages[start].ToolCall.ID
+	if m.collapsedToolGroups[id] {
+		delete(m.collapsedToolGroups, id)
+	} else {
+		m.collapsedToolGroups[id] = true
+	}
+	m.bottomSlack = 0
+	m.renderDirty = true
+}
+
+// setAllToolGroupsCollapsed collapses or expands every group of tool calls.
+func (m *model) setAllToolGroupsCollapsed(collapsed bool) {
+	clear(m.collapsedToolGroups)
+	if !collapsed {
+		return
+	}
+	for i := 0; i < m.visibleCount(); i++ {
+		if start, size := m.toolGroupAt(i); size > 0 {
+			m.collapsedToolGroups[m.messages[start].ToolCall.ID] = true
+			i = start + size - 1
+		}
+	}
+}
+
+// renderToolGroupHeader renders the line shown above a group of tool calls,
+// or instead of it when collapsed.
+func (m *model) renderToolGroupHeader(start, size int) string {
+	header := styles.ToolMessageStyle.Bold(true).Render(strconv.Itoa(size) + " tool calls")
+
+	failed := 0
+	for _, msg := range m.messages[start : start+size] {
+		if msg.ToolStatus == types.ToolStatusError || msg.ToolStatus == types.ToolStatusTimedOut {
+			failed++
+		}
+	}
+	if failed > 0 {
+		header += styles.ToolErrorMessageStyle.Render(" (" + strconv.Itoa(failed) + " failed)")
+	}
+
+	switch {
+	case m.toolGroupInProgress(start, size):
+	case m.isToolGroupCollapsed(start, size):
+		header += styles.MutedStyle.Bold(true).Render(" [+]")
+	default:
+		header += styles.MutedStyle.Bold(true).Render(" [-]")
+	}
+	return header
+}
+
+// renderToolGroupItem renders a tool call that is part of a group: the first
+// one gets the group header, and the others are hidden when the group is
+// collapsed.
+func (m *model) renderToolGroupItem(index, start, size int) renderedItem {
+	collapsed := m.isToolGroupCollapsed(start, size)
+	if index != start {
+		if collapsed {
+			return renderedItem{}
+		}
+		return m.renderView(index, m.views[index])
+	}
+
+	header := m.renderToolGroupHeader(start, size)
+	if collapsed {
+		return renderedItem{view: header, height: 1}
+	}
+	item := m.renderView(index, m.views[index])
+	if item.view == "" {
+		return renderedItem{view: header, height: 1}
+	}
+	return renderedItem{view: header + "\n" + item.view, height: item.height + 1}
+}
@@ -0,0 +1,81 @@
+package messages
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/docker/cagent/pkg/tools"
+	"github.com/docker/cagent/pkg/tui/service"
+	"github.com/docker/cagent/pkg/tui/types"
+)
+
+func TestToolCallsAreGrouped(t *testing.T) {
+	t.Parallel()
+
+	m := NewScrollableView(80, 40, &service.SessionState{}).(*model)
+	m.SetSize(80, 40)
+
+	m.AddUserMessage("list the files")
+	for i := range 3 {
+		id := "call-" + strconv.Itoa(i)
+		m.AddOrUpdateToolCall("root", tools.ToolCall{ID: id, Function: tools.FunctionCall{Name: "read_file", Arguments: `{"path":"file` + strconv.Itoa(i) + `"}`}}, tools.Tool{Name: "read_file"}, types.ToolStatusRunning)
+	}
+
+	start, size := m.toolGroupAt(2)
+	assert.Equal(t, 1, start)
+	assert.Equal(t, 3, size)
+	_, size = m.toolGroupAt(0)
+	assert.Zero(t, size)
+
+	view := ansi.Strip(m.View())
+	assert.Contains(t, view, "3 tool calls")
+	assert.NotContains(t, view, "[-]", "a running group can't be collapsed")
+
+	// Can't be collapsed while running
+	headerLine := m.messageStartLine(1)
+	msgIdx, localLine := m.globalLineToMessageLine(headerLine)
+	require.Equal(t, 1, msgIdx)
+	assert.False(t, m.isToolGroupHeaderLine(msgIdx, localLine))
+
+	for i := range 3 {
+		m.AddOrUpdateToolCall("root", tools.ToolCall{ID: "call-" + strconv.Itoa(i)}, tools.Tool{Name: "read_file"}, types.ToolStatusCompleted)
+	}
+	require.True(t, m.isToolGroupHeaderLine(msgIdx, localLine))
+	expanded := ansi.Strip(m.View())
+	expandedHeight := m.totalHeight
+	assert.Contains(t, expanded, "3 tool calls [-]")
+	assert.Contains(t, expanded, "file2")
+
+	m.toggleToolGroup(1)
+	collapsed := ansi.Strip(m.View())
+	assert.Contains(t, collapsed, "3 tool calls [+]")
+	assert.NotContains(t, collapsed, "file2")
+	assert.Less(t, m.totalHeight, expandedHeight)
+
+	// Expand all
+	m.setAllExpanded(true)
+	assert.Contains(t, ansi.Strip(m.View()), "file2")
+	m.setAllExpanded(false)
+	assert.True(t, m.isToolGroupCollapsed(1, 3))
+}
+
+func TestToolGroupNeedsEnoughCalls(t *testing.T) {
+	t.Parallel()
+
+	m := NewScrollableView(80, 40, &service.SessionState{}).(*model)
+	m.SetSize(80, 40)
+
+	m.AddOrUpdateToolCall("root", tools.ToolCall{ID: "a", Function: tools.FunctionCall{Name: "read_file"}}, tools.Tool{Name: "read_file"}, types.ToolStatusCompleted)
+	m.AddOrUpdateToolCall("root", tools.ToolCall{ID: "b", Function: tools.FunctionCall{Name: "read_file"}}, tools.Tool{Name: "read_file"}, types.ToolStatusCompleted)
+	m.AddOrUpdateToolCall("other", tools.ToolCall{ID: "c", Function: tools.FunctionCall{Name: "read_file"}}, tools.Tool{Name: "read_file"}, types.ToolStatusCompleted)
+
+	for i := range m.messages {
+		_, size := m.toolGroupAt(i)
+		assert.Zero(t, size, "calls from different agents aren't grouped")
+	}
+	assert.NotContains(t, ansi.Strip(m.View()), "tool calls")
+}
@@ -1,6 +1,7 @@
 package notification
 
 import (
+	"slices"
 	"strings"
 	"sync/atomic"
 	"time"
@@ -30,9 +31,24 @@ const (
 	TypeError
 )
 
+// String returns the name of the type, as used in the user settings.
+func (t Type) String() string {
+	switch t {
+	case TypeWarning:
+		return "warning"
+	case TypeInfo:
+		return "info"
+	case TypeError:
+		return "error"
+	default:
+		return "success"
+	}
+}
+
 type ShowMsg struct {
-	Text string
-	Type Type // Defaults to TypeSuccess for backward compatibility
+	Text     string
+	Type     Type          // Defaults to TypeSuccess for backward compatibility
+	Duration time.Duration // Defaults to the duration of the type when zero
 }
 
 type HideMsg struct {
@@ -76,18 +92,73 @@ type notificationItem struct {
 }
 
 // Manager represents a notification manager that displays
-// multiple stacked messages in the bottom right corner of the screen
+// multiple stacked messages, in the bottom right corner of the screen
+// by default
 type Manager struct {
 	width, height int
 	items         []notificationItem
+
+	vertical   lipgloss.Position // lipgloss.Top or lipgloss.Bottom
+	horizontal lipgloss.Position // lipgloss.Left, lipgloss.Center or lipgloss.Right
+	durations  map[Type]time.Duration
+
+	// quiet drops all the notifications but errors, during quiet hours.
+	quiet bool
 }
 
 func New() Manager {
 	return Manager{
-		items: make([]notificationItem, 0),
+		items:      make([]notificationItem, 0),
+		vertical:   lipgloss.Bottom,
+		horizontal: lipgloss.Right,
+		durations:  make(map[Type]time.Duration),
+	}
+}
+
+// SetPosition sets where the notifications are shown: "top" or "bottom"
+// followed by "-left", "-center" or "-right", e.g. "top-right". Invalid
+// positions are ignored.
+func (n *Manager) SetPosition(position string) {
+	vertical, horizontal, ok := strings.Cut(position, "-")
+	if !ok {
+		return
+	}
+
+	var v, h lipgloss.Position
+	switch vertical {
+	case "top":
+		v = lipgloss.Top
+	case "bottom":
+		v = lipgloss.Bottom
+	default:
+		return
+	}
+	switch horizontal {
+	case "left":
+		h = lipgloss.Left
+	case "center":
+		h = lipgloss.Center
+	case "right":
+		h = lipgloss.Right
+	default:
+		return
+	}
+	n.vertical, n.horizontal = v, h
+}
+
+// SetDuration sets how long the notifications of the given type stay on
+// screen when ShowMsg doesn't set a duration.
+func (n *Manager) SetDuration(t Type, d time.Duration) {
+	if d > 0 {
+		n.durations[t] = d
 	}
 }
 
+// SetQuiet sets whether the notifications other than errors are dropped.
+func (n *Manager) SetQuiet(quiet bool) {
+	n.quiet = quiet
+}
+
 func (n *Manager) SetSize(width, height int) {
 	n.width = width
 	n.height = height
@@ -110,13 +181,23 @@ func (n *Manager) Update(msg tea.Msg) (Manager, tea.Cmd) {
 				notifType = TypeError
 			}
 		}
+		if n.quiet && notifType != TypeError {
+			return *n, nil
+		}
 		item := notificationItem{
 			ID:   id,
 			Text: msg.Text,
 			Type: notifType,
 		}
 
-		item.TimerCmd = tea.Tick(defaultDuration, func(t time.Time) tea.Msg {
+		duration := msg.Duration
+		if duration <= 0 {
+			duration = n.durations[notifType]
+		}
+		if duration <= 0 {
+			duration = defaultDuration
+		}
+		item.TimerCmd = tea.Tick(duration, func(t time.Time) tea.Msg {
 			return HideMsg{ID: id}
 		})
 
@@ -148,9 +229,15 @@ func (n *Manager) View() string {
 		return ""
 	}
 
+	// Items are stored newest first. Stack them so that the newest one is
+	// the closest to the edge of the screen.
+	items := slices.Clone(n.items)
+	if n.vertical == lipgloss.Bottom {
+		slices.Reverse(items)
+	}
+
 	var views []string
-	for i := len(n.items) - 1; i >= 0; i-- {
-		item := n.items[i]
+	for _, item := range items {
 
 		// Select style based on notification type
 		var style lipgloss.Style
@@ -186,7 +273,7 @@ func (n *Manager) View() string {
 		views = append(views, view)
 	}
 
-	return lipgloss.JoinVertical(lipgloss.Right, views...)
+	return lipgloss.JoinVertical(n.horizontal, views...)
 }
 
 func (n *Manager) GetLayer() *lipgloss.Layer {
@@ -205,9 +292,19 @@ func (n *Manager) position() (row, col int) {
 	viewHeight := lipgloss.Height(notificationView)
 	viewWidth := lipgloss.Width(notificationView)
 
-	// Position in bottom right corner with padding
-	row = max(0, n.height-viewHeight-notificationPadding)
-	col = max(0, n.width-viewWidth-notificationPadding)
+	row = notificationPadding
+	if n.vertical == lipgloss.Bottom {
+		row = max(0, n.height-viewHeight-notificationPadding)
+	}
+
+	switch n.horizontal {
+	case lipgloss.Left:
+		col = notificationPadding
+	case lipgloss.Center:
+		col = max(0, (n.width-viewWidth)/2)
+	default:
+		col = max(0, n.width-viewWidth-notificationPadding)
+	}
 
 	return row, col
 }
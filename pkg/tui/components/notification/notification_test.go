@@ -1,7 +1,9 @@
 package notification
 
 import (
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
@@ -55,3 +57,69 @@ func TestNotification_GetLayer(t *testing.T) {
 	updated, _ := n.Update(ShowMsg{Text: "Test"})
 	require.NotNil(t, updated.GetLayer())
 }
+
+func TestNotification_ConfiguredPosition(t *testing.T) {
+	n := New()
+	n.SetSize(100, 50)
+	n.SetPosition("top-left")
+	updated, _ := n.Update(ShowMsg{Text: "Test"})
+	row, col := updated.position()
+	require.Equal(t, 2, row)
+	require.Equal(t, 2, col)
+
+	// Invalid positions are ignored.
+	updated.SetPosition("middle")
+	row, col = updated.position()
+	require.Equal(t, 2, row)
+	require.Equal(t, 2, col)
+
+	updated.SetPosition("bottom-center")
+	row, col = updated.position()
+	require.Equal(t, 45, row)
+	require.Equal(t, 46, col)
+}
+
+func TestNotification_Stacks(t *testing.T) {
+	n := New()
+	n.SetSize(100, 50)
+
+	updated, _ := n.Update(ShowMsg{Text: "first"})
+	updated, _ = updated.Update(ShowMsg{Text: "second"})
+	require.Len(t, updated.items, 2)
+
+	// At the bottom, the newest notification is the lowest one.
+	view := updated.View()
+	require.Less(t, strings.Index(view, "first"), strings.Index(view, "second"))
+
+	// At the top, the newest notification is the highest one.
+	updated.SetPosition("top-right")
+	view = updated.View()
+	require.Less(t, strings.Index(view, "second"), strings.Index(view, "first"))
+}
+
+func TestNotification_Duration(t *testing.T) {
+	n := New()
+	n.SetDuration(TypeError, 0)
+	n.SetDuration(TypeError, 10*time.Second)
+
+	require.Equal(t, 10*time.Second, n.durations[TypeError])
+	require.Equal(t, "error", TypeError.String())
+	require.Equal(t, "success", TypeSuccess.String())
+}
+
+func TestNotification_Quiet(t *testing.T) {
+	n := New()
+	n.SetQuiet(true)
+
+	updated, cmd := n.Update(ShowMsg{Text: "Saved", Type: TypeInfo})
+	require.Empty(t, updated.items)
+	require.Nil(t, cmd)
+
+	updated, _ = updated.Update(ShowMsg{Text: "Failed to save"})
+	require.Len(t, updated.items, 1)
+	require.Equal(t, TypeError, updated.items[0].Type)
+
+	updated.SetQuiet(false)
+	updated, _ = updated.Update(ShowMsg{Text: "Saved", Type: TypeInfo})
+	require.Len(t, updated.items, 2)
+}
@@ -6,6 +6,7 @@ import (
 	"math"
 	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	tea "charm.land/bubbletea/v2"
@@ -32,6 +33,22 @@ const (
 	completedToolFadeDuration = 1000 * time.Millisecond
 )
 
+// keepExpanded disables the auto-collapse of the expanded reasoning blocks
+// once the answer starts.
+var keepExpanded atomic.Bool
+
+// SetAutoCollapse sets whether expanded reasoning blocks collapse once the
+// agent starts answering.
+func SetAutoCollapse(autoCollapse bool) {
+	keepExpanded.Store(!autoCollapse)
+}
+
+// AutoCollapse reports whether expanded reasoning blocks collapse once the
+// agent starts answering.
+func AutoCollapse() bool {
+	return !keepExpanded.Load()
+}
+
 // fadeColor returns an interpolated color for the given fade progress (0.0 to 1.0).
 // Progress 0.0 is normal color, 1.0 is very faded (close to background).
 func fadeColor(progress float64) color.Color {
@@ -222,7 +239,7 @@ func (m *Model) UpdateToolResult(toolCallID, content string, status types.ToolSt
 		// Check if this is a transition from in-progress to completed/error
 		wasInProgress := entry.msg.ToolStatus == types.ToolStatusPending ||
 			entry.msg.ToolStatus == types.ToolStatusRunning
-		isCompleted := status == types.ToolStatusCompleted || status == types.ToolStatusError
+		isCompleted := status == types.ToolStatusCompleted || status == types.ToolStatusError || status == types.ToolStatusTimedOut
 
 		entry.msg.Content = strings.ReplaceAll(content, "\t", "    ")
 		entry.msg.ToolStatus = status
@@ -0,0 +1,137 @@
+// Package scratchpad provides a free-form notes editor shown in its own tab.
+// It isn't tied to any agent: its content is only persisted in the TUI state
+// store so it survives restarts.
+package scratchpad
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"charm.land/bubbles/v2/textarea"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+
+	"github.com/docker/cagent/pkg/tui/core/layout"
+	"github.com/docker/cagent/pkg/tui/service/tuistate"
+	"github.com/docker/cagent/pkg/tui/styles"
+)
+
+// saveDelay is how long the content must stay unchanged before it is saved.
+const saveDelay = time.Second
+
+// saveMsg triggers a debounced save. It is ignored if the content changed
+// again since it was scheduled.
+type saveMsg struct{ gen int }
+
+// Model is the scratchpad editor.
+type Model struct {
+	textarea textarea.Model
+	store    *tuistate.Store
+	gen      int
+	saved    string
+	width    int
+	height   int
+}
+
+var _ layout.Model = (*Model)(nil)
+
+// New creates a scratchpad loaded with the content saved in store.
+// store may be nil, in which case nothing is persisted.
+func New(store *tuistate.Store) *Model {
+	ta := textarea.New()
+	ta.SetStyles(styles.InputStyle)
+	ta.Placeholder = "Notes, drafts, anything… saved automatically."
+	ta.Prompt = ""
+	ta.CharLimit = -1
+	ta.ShowLineNumbers = false
+	ta.KeyMap.InsertNewline.SetKeys("enter", "shift+enter", "ctrl+j")
+
+	m := &Model{textarea: ta, store: store}
+	if store != nil {
+		content, err := store.GetScratchpad(context.Background())
+		if err != nil {
+			slog.Warn("Failed to load scratchpad", "error", err)
+		}
+		m.textarea.SetValue(content)
+		m.saved = content
+	}
+	return m
+}
+
+func (m *Model) Init() tea.Cmd {
+	return textarea.Blink
+}
+
+func (m *Model) Update(msg tea.Msg) (layout.Model, tea.Cmd) {
+	if msg, ok := msg.(saveMsg); ok {
+		if msg.gen == m.gen {
+			m.Save()
+		}
+		return m, nil
+	}
+
+	before := m.textarea.Value()
+	var cmd tea.Cmd
+	m.textarea, cmd = m.textarea.Update(msg)
+	if m.textarea.Value() == before {
+		return m, cmd
+	}
+
+	m.gen++
+	gen := m.gen
+	return m, tea.Batch(cmd, tea.Tick(saveDelay, func(time.Time) tea.Msg {
+		return saveMsg{gen: gen}
+	}))
+}
+
+func (m *Model) View() string {
+	title := styles.HighlightWhiteStyle.Render("Scratchpad") +
+		styles.MutedStyle.Render("  not sent to any agent")
+	return lipgloss.NewStyle().
+		Padding(0, styles.AppPadding).
+		Render(lipgloss.JoinVertical(lipgloss.Left, title, "", m.textarea.View()))
+}
+
+// SetSize sets the dimensions of the scratchpad, title included.
+func (m *Model) SetSize(width, height int) tea.Cmd {
+	m.width = width
+	m.height = height
+	m.textarea.SetWidth(max(width-2*styles.AppPadding, 10))
+	m.textarea.SetHeight(max(height-2, 1))
+	return nil
+}
+
+func (m *Model) Focus() tea.Cmd {
+	return m.textarea.Focus()
+}
+
+func (m *Model) Blur() tea.Cmd {
+	m.textarea.Blur()
+	return nil
+}
+
+// Value returns the scratchpad content.
+func (m *Model) Value() string {
+	return m.textarea.Value()
+}
+
+// Save persists the content if it changed since the last save.
+func (m *Model) Save() {
+	content := m.textarea.Value()
+	if m.store == nil || content == m.saved {
+		return
+	}
+	if err := m.store.SaveScratchpad(context.Background(), content); err != nil {
+		slog.Warn("Failed to save scratchpad", "error", err)
+		return
+	}
+	m.saved = content
+}
+
+// IsSaveMsg reports whether msg is a scratchpad save tick, so callers can
+// route it to the scratchpad even when its tab isn't active.
+func IsSaveMsg(msg tea.Msg) bool {
+	_, ok := msg.(saveMsg)
+	return ok
+}
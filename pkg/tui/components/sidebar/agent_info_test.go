@@ -169,3 +169,68 @@ func TestSetAgentInfo_NonexistentAgent(t *testing.T) {
 	assert.Equal(t, "openai", m.availableAgents[0].Provider)
 	assert.Equal(t, "gpt-4o", m.availableAgents[0].Model)
 }
+
+func TestToggleAgentDescription_ExpandsLongDescription(t *testing.T) {
+	t.Parallel()
+
+	sessionState := service.NewSessionState(session.New())
+	sessionState.SetCurrentAgentName("root")
+	m := New(sessionState).(*model)
+	m.SetSize(30, 200)
+	m.SetMode(ModeVertical)
+
+	longDesc := "An agent with a description that is much too long to fit on a single sidebar line"
+	m.SetTeamInfo([]runtime.AgentDetails{
+		{Name: "root", Provider: "openai", Model: "gpt-4o", Description: longDesc},
+		{Name: "short", Provider: "openai", Model: "gpt-4o", Description: "Short"},
+	})
+
+	collapsed := m.verticalView()
+	collapsedLines := len(m.cachedLines)
+	require.Len(t, m.agentDescLines, 1, "only the truncated description is expandable")
+
+	var descY int
+	for line, name := range m.agentDescLines {
+		assert.Equal(t, "root", name)
+		descY = line
+	}
+	assert.Equal(t, ClickAgentDescription, m.HandleClickType(m.layoutCfg.PaddingLeft+3, descY))
+
+	require.True(t, m.ToggleAgentDescription(descY))
+	expanded := m.verticalView()
+	assert.Greater(t, len(m.agentDescLines), 1, "every line of the expanded description is clickable")
+	assert.Greater(t, len(m.cachedLines), collapsedLines, "the expanded description takes more lines")
+	assert.Contains(t, expanded, "fit on a single sidebar")
+
+	require.True(t, m.ToggleAgentDescription(descY))
+	assert.Equal(t, collapsed, m.verticalView())
+
+	assert.False(t, m.ToggleAgentDescription(0), "nothing to toggle outside descriptions")
+}
+
+func TestAgentInfo_HiddenAgents(t *testing.T) {
+	t.Parallel()
+
+	sessionState := service.NewSessionState(session.New())
+	sessionState.SetCurrentAgentName("root")
+	m := New(sessionState).(*model)
+	m.SetSize(40, 200)
+	m.SetMode(ModeVertical)
+	m.SetTeamInfo([]runtime.AgentDetails{
+		{Name: "root", Provider: "openai", Model: "gpt-4o"},
+		{Name: "helper", Provider: "openai", Model: "gpt-4o", Hidden: true},
+		{Name: "coder", Provider: "openai", Model: "gpt-4o"},
+	})
+
+	view := m.verticalView()
+	assert.NotContains(t, view, "helper")
+	assert.Contains(t, view, "^2", "shortcuts skip the hidden agents")
+	assert.NotContains(t, view, "^3")
+
+	// The hidden agent is listed while it is the current agent, without a shortcut
+	sessionState.SetCurrentAgentName("helper")
+	m.invalidateCache()
+	view = m.verticalView()
+	assert.Contains(t, view, "helper")
+	assert.NotContains(t, view, "^3")
+}
@@ -15,7 +15,6 @@ import (
 	"charm.land/lipgloss/v2"
 
 	"github.com/docker/cagent/pkg/modelsdev"
-	"github.com/docker/cagent/pkg/paths"
 	"github.com/docker/cagent/pkg/runtime"
 	"github.com/docker/cagent/pkg/session"
 	"github.com/docker/cagent/pkg/tools"
@@ -58,8 +57,11 @@ type Model interface {
 	LoadFromSession(sess *session.Session)
 	// HandleClick checks if click is on the star or title and returns true if handled
 	HandleClick(x, y int) bool
-	// HandleClickType returns the type of click (star, title, or none)
+	// HandleClickType returns the type of click (star, title, agent description, or none)
 	HandleClickType(x, y int) ClickResult
+	// ToggleAgentDescription expands or collapses the agent description at
+	// viewport line y and returns true if there was one
+	ToggleAgentDescription(y int) bool
 	// IsCollapsed returns whether the sidebar is collapsed
 	IsCollapsed() bool
 	// ToggleCollapsed toggles the collapsed state
@@ -142,7 +144,9 @@ type model struct {
 	preferredWidth     int      // user's preferred width (persisted across collapse/expand)
 	editingTitle       bool     // true when inline title editing is active
 	titleInput         textinput.Model
-	lastTitleClickTime time.Time // for double-click detection on title
+	lastTitleClickTime time.Time       // for double-click detection on title
+	expandedDescs      map[string]bool // agents whose full description is shown
+	agentDescLines     map[int]string  // content line -> agent, for expandable descriptions
 
 	cancelReasoningCheck context.CancelFunc // cancels the in-flight ModelSupportsReasoning call
 
@@ -168,16 +172,17 @@ func New(sessionState *service.SessionState, opts ...Option) Model {
 	ti.Prompt = "" // No prompt to maximize usable width in collapsed sidebar
 
 	m := &model{
-		width:        20,
-		layoutCfg:    DefaultLayoutConfig(),
-		height:       24,
-		sessionUsage: make(map[string]*runtime.Usage),
-		sessionAgent: make(map[string]string),
-		todoComp:     todotool.NewSidebarComponent(),
-		spinner:      spinner.New(spinner.ModeSpinnerOnly, styles.SpinnerDotsHighlightStyle),
-		sessionTitle: "New session",
-		ragIndexing:  make(map[string]*ragIndexingState),
-		sessionState: sessionState,
+		width:         20,
+		layoutCfg:     DefaultLayoutConfig(),
+		height:        24,
+		sessionUsage:  make(map[string]*runtime.Usage),
+		sessionAgent:  make(map[string]string),
+		expandedDescs: make(map[string]bool),
+		todoComp:      todotool.NewSidebarComponent(),
+		spinner:       spinner.New(spinner.ModeSpinnerOnly, styles.SpinnerDotsHighlightStyle),
+		sessionTitle:  "New session",
+		ragIndexing:   make(map[string]*ragIndexingState),
+		sessionState:  sessionState,
 		scrollview: scrollview.New(
 			scrollview.WithWheelStep(1),
 			scrollview.WithKeyMap(nil), // Sidebar has no keyboard scroll — only mouse
@@ -356,7 +361,8 @@ type ClickResult int
 const (
 	ClickNone ClickResult = iota
 	ClickStar
-	ClickTitle // Click on the title area (use double-click to edit)
+	ClickTitle            // Click on the title area (use double-click to edit)
+	ClickAgentDescription // Click on a long agent description (expands or collapses it)
 )
 
 // HandleClick checks if click is on the star or title and returns true if it was
@@ -408,9 +414,28 @@ func (m *model) HandleClickType(x, y int) ClickResult {
 			return ClickTitle
 		}
 	}
+	if _, ok := m.agentDescLines[contentY]; ok {
+		return ClickAgentDescription
+	}
 	return ClickNone
 }
 
+// ToggleAgentDescription expands or collapses the description of the agent
+// rendered at viewport line y. Only descriptions too long to fit on one line
+// can be toggled.
+func (m *model) ToggleAgentDescription(y int) bool {
+	if m.mode == ModeCollapsed {
+		return false
+	}
+	name, ok := m.agentDescLines[y+m.scrollview.ScrollOffset()]
+	if !ok {
+		return false
+	}
+	m.expandedDescs[name] = !m.expandedDescs[name]
+	m.invalidateCache()
+	return true
+}
+
 // titleLineCount returns the number of lines the title occupies when rendered.
 func (m *model) titleLineCount() int {
 	if !m.titleGenerated || m.sessionTitle == "" {
@@ -458,11 +483,7 @@ func (m *model) LoadFromSession(sess *session.Session) {
 
 	// Load working directory from session
 	if sess.WorkingDir != "" {
-		wd := sess.WorkingDir
-		if homeDir := paths.GetHomeDir(); homeDir != "" && strings.HasPrefix(wd, homeDir) {
-			wd = "~" + wd[len(homeDir):]
-		}
-		m.workingDirectory = wd
+		m.workingDirectory = sess.WorkingDir
 	}
 
 	// Session has content if it has messages or token usage
@@ -532,18 +553,12 @@ func (m *model) contextPercent() string {
 	return ""
 }
 
-// getCurrentWorkingDirectory returns the current working directory with home directory replaced by ~/
+// getCurrentWorkingDirectory returns the current working directory.
 func getCurrentWorkingDirectory() string {
 	pwd, err := os.Getwd()
 	if err != nil {
 		return ""
 	}
-
-	// Replace home directory with ~/
-	if homeDir := paths.GetHomeDir(); homeDir != "" && strings.HasPrefix(pwd, homeDir) {
-		pwd = "~" + pwd[len(homeDir):]
-	}
-
 	return pwd
 }
 
@@ -705,7 +720,11 @@ func (m *model) Update(msg tea.Msg) (layout.Model, tea.Cmd) {
 		}
 		m.invalidateCache()
 		return m, nil
-	case messages.SessionToggleChangedMsg:
+	case messages.SessionToggleChangedMsg, messages.ToggleHomeRelativePathsMsg:
+		m.invalidateCache()
+		return m, nil
+	case messages.CostResetMsg:
+		clear(m.sessionUsage)
 		m.invalidateCache()
 		return m, nil
 	case messages.ThemeChangedMsg:
@@ -813,7 +832,7 @@ func (m *model) computeCollapsedViewModel(contentWidth int) CollapsedViewModel {
 	vm := CollapsedViewModel{
 		TitleWithStar:    titleWithStar,
 		WorkingIndicator: m.workingIndicatorCollapsed(),
-		WorkingDir:       m.workingDirectory,
+		WorkingDir:       styles.DisplayPath(m.workingDirectory),
 		UsageSummary:     m.tokenUsageSummary(),
 		ContentWidth:     contentWidth,
 	}
@@ -902,7 +921,16 @@ func (m *model) renderSections(contentWidth int) []string {
 	appendSection(m.sessionInfo(contentWidth))
 	appendSection(m.tokenUsage(contentWidth))
 	appendSection(m.queueSection(contentWidth))
+
+	// Description lines are recorded relative to the agent section; shift
+	// them so clicks can be matched against content lines.
+	agentStart := len(lines)
 	appendSection(m.agentInfo(contentWidth))
+	descLines := make(map[int]string, len(m.agentDescLines))
+	for line, name := range m.agentDescLines {
+		descLines[agentStart+line] = name
+	}
+	m.agentDescLines = descLines
 	appendSection(m.toolsetInfo(contentWidth))
 
 	m.todoComp.SetSize(contentWidth)
@@ -1089,7 +1117,7 @@ func (m *model) sessionInfo(contentWidth int) string {
 	}
 
 	if m.workingDirectory != "" {
-		lines = append(lines, styles.TabAccentStyle.Render("█")+styles.TabPrimaryStyle.Render(" "+m.workingDirectory))
+		lines = append(lines, styles.TabAccentStyle.Render("█")+styles.TabPrimaryStyle.Render(" "+styles.DisplayPath(m.workingDirectory)))
 	}
 
 	return m.renderTab("Session", strings.Join(lines, "\n"), contentWidth)
@@ -1127,6 +1155,8 @@ func (m *model) queueSection(contentWidth int) string {
 
 // agentInfo renders the current agent information
 func (m *model) agentInfo(contentWidth int) string {
+	m.agentDescLines = make(map[int]string)
+
 	// Read current agent from session state so sidebar updates when agent is switched
 	currentAgent := m.sessionState.CurrentAgentName()
 	if currentAgent == "" {
@@ -1142,12 +1172,23 @@ func (m *model) agentInfo(contentWidth int) string {
 	}
 
 	var content strings.Builder
-	for i, agent := range m.availableAgents {
+	// Hidden agents are only listed while they are the current agent, and
+	// never get a shortcut so that ^N matches the Ctrl+number switching.
+	index := 0
+	for _, agent := range m.availableAgents {
+		isCurrent := agent.Name == currentAgent
+		if agent.Hidden && !isCurrent {
+			continue
+		}
 		if content.Len() > 0 {
 			content.WriteString("\n\n")
 		}
-		isCurrent := agent.Name == currentAgent
-		m.renderAgentEntry(&content, agent, isCurrent, i, contentWidth)
+		shortcut := -1
+		if !agent.Hidden {
+			shortcut = index
+			index++
+		}
+		m.renderAgentEntry(&content, agent, isCurrent, shortcut, contentWidth)
 	}
 
 	return m.renderTab(agentTitle, content.String(), contentWidth)
@@ -1165,6 +1206,9 @@ func (m *model) renderAgentEntry(content *strings.Builder, agent runtime.AgentDe
 	}
 	// Agent name
 	agentNameText := prefix + agentStyle.Render(agent.Name)
+	if agent.IsDefault {
+		agentNameText += styles.MutedStyle.Render(" (default)")
+	}
 	// Shortcut hint (^1, ^2, etc.) - show for agents 1-9
 	var shortcutHint string
 	if index >= 0 && index < 9 {
@@ -1184,8 +1228,28 @@ func (m *model) renderAgentEntry(content *strings.Builder, agent runtime.AgentDe
 
 	if desc := agent.Description; desc != "" {
 		content.WriteString("\n")
-		content.WriteString(styles.MutedStyle.Render("├ "))
-		content.WriteString(toolcommon.TruncateText(desc, maxWidth))
+		// Long descriptions are truncated to one line and can be expanded by
+		// clicking on them.
+		expanded := m.expandedDescs[agent.Name]
+		if expanded || lipgloss.Width(desc) > maxWidth {
+			line := tab.ContentOffset() + strings.Count(content.String(), "\n")
+			m.agentDescLines[line] = agent.Name
+		}
+		if !expanded {
+			content.WriteString(styles.MutedStyle.Render("├ "))
+			content.WriteString(toolcommon.TruncateText(desc, maxWidth))
+		} else {
+			for i, line := range toolcommon.WrapLinesWords(desc, maxWidth) {
+				if i == 0 {
+					content.WriteString(styles.MutedStyle.Render("├ "))
+				} else {
+					content.WriteString("\n")
+					content.WriteString(styles.MutedStyle.Render("│ "))
+					m.agentDescLines[tab.ContentOffset()+strings.Count(content.String(), "\n")] = agent.Name
+				}
+				content.WriteString(line)
+			}
+		}
 	}
 
 	content.WriteString("\n")
@@ -17,6 +17,8 @@ type StatusBar struct {
 	width int
 	help  core.KeyMapHelp
 
+	indicator    string
+	quiet        bool
 	showNewTab   bool
 	newTabStartX int
 	newTabEndX   int
@@ -55,6 +57,23 @@ func (s *StatusBar) SetShowNewTab(show bool) {
 	}
 }
 
+// SetIndicator sets a short mode label (e.g. "send & stay") shown before the
+// version. An empty string hides it.
+func (s *StatusBar) SetIndicator(indicator string) {
+	if s.indicator != indicator {
+		s.indicator = indicator
+		s.cacheDirty = true
+	}
+}
+
+// SetQuiet shows or hides the quiet hours indicator.
+func (s *StatusBar) SetQuiet(quiet bool) {
+	if s.quiet != quiet {
+		s.quiet = quiet
+		s.cacheDirty = true
+	}
+}
+
 // ClickedNewTab returns true if the given X coordinate hits the "+" button.
 func (s *StatusBar) ClickedNewTab(x int) bool {
 	return s.showNewTab && x >= s.newTabStartX && x < s.newTabEndX
@@ -76,19 +95,27 @@ func (s *StatusBar) rebuild() {
 	s.newTabStartX = 0
 	s.newTabEndX = 0
 
-	// Build the styled right side: optional new-tab button + version.
-	var right string
-	var rightW, newTabW int
+	// Build the styled right side: optional mode indicator, optional new-tab
+	// button, then version.
+	var right, indicator string
+	var rightW, indicatorW, newTabW int
+	if s.quiet {
+		indicator = styles.MutedStyle.Render("quiet hours") + "  "
+	}
+	if s.indicator != "" {
+		indicator += styles.HighlightWhiteStyle.Render(s.indicator) + "  "
+	}
+	indicatorW = lipgloss.Width(indicator)
 	ver := styles.MutedStyle.Render("cagent " + version.Version)
 	if s.showNewTab {
 		newTab := styles.MutedStyle.Render(" \u2502 ") +
 			styles.HighlightWhiteStyle.Render("+") +
 			styles.SecondaryStyle.Render(" new tab")
 		newTabW = lipgloss.Width(newTab)
-		right = newTab + "  " + ver
+		right = indicator + newTab + "  " + ver
 		rightW = lipgloss.Width(right)
 	} else {
-		right = ver
+		right = indicator + ver
 		rightW = lipgloss.Width(right)
 	}
 
@@ -125,7 +152,7 @@ func (s *StatusBar) rebuild() {
 	gap := max(1, s.width-leftW-rightW-pad)
 
 	if s.showNewTab {
-		s.newTabStartX = leftW + gap
+		s.newTabStartX = leftW + gap + indicatorW
 		s.newTabEndX = s.newTabStartX + newTabW
 	}
 
@@ -134,7 +161,7 @@ func (s *StatusBar) rebuild() {
 
 // View renders the status bar.
 //
-// Layout: [ help text ...           (+ new tab)  cagent VERSION ]
+// Layout: [ help text ...   (quiet hours)  (indicator)  (+ new tab)  cagent VERSION ]
 func (s *StatusBar) View() string {
 	if s.cacheDirty {
 		s.rebuild()
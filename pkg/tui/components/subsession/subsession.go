@@ -0,0 +1,236 @@
+package subsession
+
+import (
+	"fmt"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+
+	"github.com/docker/cagent/pkg/chat"
+	"github.com/docker/cagent/pkg/session"
+	"github.com/docker/cagent/pkg/tools"
+	"github.com/docker/cagent/pkg/tui/components/message"
+	"github.com/docker/cagent/pkg/tui/components/tool"
+	"github.com/docker/cagent/pkg/tui/core/layout"
+	"github.com/docker/cagent/pkg/tui/service"
+	"github.com/docker/cagent/pkg/tui/styles"
+	"github.com/docker/cagent/pkg/tui/types"
+)
+
+// indent is the number of columns the expanded transcript is shifted right by.
+const indent = 2
+
+// Model is a collapsible block showing the transcript of a sub-session
+// created by a task transfer. It is collapsed by default, showing only who
+// the task was transferred to and a one-line summary of the outcome.
+type Model struct {
+	agentName    string
+	summary      string
+	messageCount int
+	toolCount    int
+	views        []layout.Model
+	expanded     bool
+	width        int
+	height       int
+}
+
+// New creates a collapsed block for the given sub-session.
+func New(sub *session.Session, sessionState service.SessionStateReader) *Model {
+	m := &Model{
+		summary: firstLine(sub.GetLastAssistantMessageContent()),
+		width:   80,
+	}
+
+	toolResults := make(map[string]string)
+	for _, item := range sub.Messages {
+		if item.IsMessage() && item.Message.Message.Role == chat.MessageRoleTool {
+			toolResults[item.Message.Message.ToolCallID] = item.Message.Message.Content
+		}
+	}
+
+	var previous *types.Message
+	for _, item := range sub.Messages {
+		if item.IsSubSession() {
+			m.views = append(m.views, New(item.SubSession, sessionState))
+			continue
+		}
+		if !item.IsMessage() || item.Message.Implicit {
+			continue
+		}
+
+		smsg := item.Message
+		if m.agentName == "" {
+			m.agentName = smsg.AgentName
+		}
+		if smsg.Message.Role != chat.MessageRoleAssistant {
+			continue
+		}
+
+		m.messageCount++
+		if smsg.Message.Content != "" {
+			msg := types.Agent(types.MessageTypeAssistant, smsg.AgentName, smsg.Message.Content)
+			m.views = append(m.views, message.New(msg, previous))
+			previous = msg
+		}
+		for i, tc := range smsg.Message.ToolCalls {
+			var toolDef tools.Tool
+			if i < len(smsg.Message.ToolDefinitions) {
+				toolDef = smsg.Message.ToolDefinitions[i]
+			}
+			toolMsg := types.ToolCallMessage(smsg.AgentName, tc, toolDef, types.ToolStatusCompleted)
+			if result, ok := toolResults[tc.ID]; ok {
+				toolMsg.Content = strings.ReplaceAll(result, "\t", "    ")
+			}
+			m.views = append(m.views, tool.New(toolMsg, sessionState))
+			m.toolCount++
+		}
+	}
+
+	if m.agentName == "" {
+		m.agentName = sub.AgentName
+	}
+	m.SetSize(m.width, 0)
+	return m
+}
+
+// AgentName returns the name of the agent the task was transferred to.
+func (m *Model) AgentName() string {
+	return m.agentName
+}
+
+// Summary returns the first line of the sub-session's final answer.
+func (m *Model) Summary() string {
+	return m.summary
+}
+
+// IsExpanded returns the current expanded state.
+func (m *Model) IsExpanded() bool {
+	return m.expanded
+}
+
+// Toggle switches between expanded and collapsed state.
+func (m *Model) Toggle() {
+	m.expanded = !m.expanded
+}
+
+// SetExpanded sets the expanded state directly.
+func (m *Model) SetExpanded(expanded bool) {
+	m.expanded = expanded
+}
+
+// IsToggleLine returns true if clicking this line should toggle the block.
+// Only the header is toggleable.
+func (m *Model) IsToggleLine(lineIdx int) bool {
+	return lineIdx == 0 && len(m.views) > 0
+}
+
+// Init initializes the component.
+func (m *Model) Init() tea.Cmd {
+	var cmds []tea.Cmd
+	for _, view := range m.views {
+		if cmd := view.Init(); cmd != nil {
+			cmds = append(cmds, cmd)
+		}
+	}
+	return tea.Batch(cmds...)
+}
+
+// Update forwards messages to the views of the transcript.
+func (m *Model) Update(msg tea.Msg) (layout.Model, tea.Cmd) {
+	var cmds []tea.Cmd
+	for i, view := range m.views {
+		updated, cmd := view.Update(msg)
+		m.views[i] = updated
+		if cmd != nil {
+			cmds = append(cmds, cmd)
+		}
+	}
+	return m, tea.Batch(cmds...)
+}
+
+// View renders the block.
+func (m *Model) View() string {
+	parts := []string{m.renderHeader()}
+
+	if !m.expanded {
+		if m.summary != "" {
+			summary := ansi.Truncate(m.summary, max(1, m.contentWidth()), "…")
+			parts = append(parts, styles.AssistantMessageStyle.Render(styles.MutedStyle.Render(summary)))
+		}
+		return strings.Join(parts, "\n")
+	}
+
+	pad := lipgloss.NewStyle().PaddingLeft(indent)
+	for _, view := range m.views {
+		if rendered := view.View(); rendered != "" {
+			parts = append(parts, "", pad.Render(rendered))
+		}
+	}
+	return strings.Join(parts, "\n")
+}
+
+// renderHeader renders the header line with toggle affordance.
+func (m *Model) renderHeader() string {
+	header := styles.ThinkingBadgeStyle.Render("↳ transferred to " + m.agentName)
+
+	if len(m.views) > 0 {
+		if m.expanded {
+			header += styles.MutedStyle.Bold(true).Render(" [-]")
+		} else {
+			header += styles.MutedStyle.Bold(true).Render(" [+]")
+		}
+	}
+
+	if !m.expanded {
+		header += styles.MutedStyle.Render(" (" + plural(m.messageCount, "message") + ", " + plural(m.toolCount, "tool call") + ")")
+	}
+
+	return styles.AssistantMessageStyle.Render(header)
+}
+
+// SetSize sets the component dimensions.
+func (m *Model) SetSize(width, height int) tea.Cmd {
+	m.width = width
+	m.height = height
+	for _, view := range m.views {
+		view.SetSize(max(1, width-indent), 0)
+	}
+	return nil
+}
+
+// GetSize returns the current dimensions.
+func (m *Model) GetSize() (int, int) {
+	return m.width, m.height
+}
+
+// contentWidth returns width available for content.
+func (m *Model) contentWidth() int {
+	return m.width - styles.AssistantMessageStyle.GetHorizontalFrameSize()
+}
+
+// StopAnimation stops the animations of the views of the transcript.
+func (m *Model) StopAnimation() {
+	for _, view := range m.views {
+		if stopper, ok := view.(interface{ StopAnimation() }); ok {
+			stopper.StopAnimation()
+		}
+	}
+}
+
+func firstLine(s string) string {
+	for line := range strings.Lines(s) {
+		if line = strings.TrimSpace(line); line != "" {
+			return line
+		}
+	}
+	return ""
+}
+
+func plural(n int, noun string) string {
+	if n == 1 {
+		return fmt.Sprintf("1 %s", noun)
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
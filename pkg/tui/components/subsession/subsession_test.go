@@ -0,0 +1,67 @@
+package subsession
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/docker/cagent/pkg/chat"
+	"github.com/docker/cagent/pkg/session"
+	"github.com/docker/cagent/pkg/tools"
+	"github.com/docker/cagent/pkg/tui/service"
+)
+
+func TestSubSessionBlock(t *testing.T) {
+	t.Parallel()
+
+	sub := &session.Session{
+		Messages: []session.Item{
+			session.NewMessageItem(&session.Message{
+				AgentName: "researcher",
+				Message: chat.Message{
+					Role: chat.MessageRoleAssistant,
+					ToolCalls: []tools.ToolCall{{
+						ID:       "call_1",
+						Function: tools.FunctionCall{Name: "read_file", Arguments: `{"path":"go.mod"}`},
+					}},
+				},
+			}),
+			session.NewMessageItem(&session.Message{
+				Message: chat.Message{Role: chat.MessageRoleTool, ToolCallID: "call_1", Content: "module example"},
+			}),
+			session.NewMessageItem(&session.Message{
+				AgentName: "researcher",
+				Message:   chat.Message{Role: chat.MessageRoleAssistant, Content: "\nThe module is named example.\nMore details."},
+			}),
+		},
+	}
+
+	block := New(sub, &service.SessionState{})
+	block.SetSize(80, 0)
+
+	assert.Equal(t, "researcher", block.AgentName())
+	assert.Equal(t, "The module is named example.", block.Summary())
+	assert.True(t, block.IsToggleLine(0))
+	assert.False(t, block.IsToggleLine(1))
+
+	collapsed := ansi.Strip(block.View())
+	assert.Contains(t, collapsed, "↳ transferred to researcher [+] (2 messages, 1 tool call)")
+	assert.Contains(t, collapsed, "The module is named example.")
+	assert.NotContains(t, collapsed, "More details.")
+
+	block.Toggle()
+	expanded := ansi.Strip(block.View())
+	assert.Contains(t, expanded, "[-]")
+	assert.Contains(t, expanded, "More details.")
+}
+
+func TestSubSessionBlockEmpty(t *testing.T) {
+	t.Parallel()
+
+	block := New(&session.Session{AgentName: "worker"}, &service.SessionState{})
+
+	assert.Equal(t, "worker", block.AgentName())
+	assert.False(t, block.IsToggleLine(0), "nothing to expand")
+	assert.NotContains(t, ansi.Strip(block.View()), "[+]")
+}
@@ -21,3 +21,9 @@ func Render(title, content string, width int) string {
 		),
 	)
 }
+
+// ContentOffset returns the line at which the content starts within a
+// rendered tab, below the title and the body's top padding.
+func ContentOffset() int {
+	return 1 + styles.TabStyle.GetPaddingTop()
+}
@@ -24,6 +24,9 @@ const (
 	// attentionIndicator is shown before the title when the tab needs attention,
 	// replacing the running indicator to signal that user action is required.
 	attentionIndicator = "! "
+	// queuedIndicator is shown before the title while the tab's session waits
+	// for a provider's concurrency limit.
+	queuedIndicator = "queued "
 
 	// dragSourceColorBoost controls how much the drag source tab is blended toward
 	// the active tab colors when it is not the active tab.
@@ -142,6 +145,12 @@ func renderTab(info messages.TabInfo, maxTitleLen, animFrame int, role dragRole)
 			attnFg = blendColors(attnFg, bgColor, dragBystanderDimAmount)
 		}
 		content += lipgloss.NewStyle().Foreground(attnFg).Background(bgColor).Bold(true).Render(attentionIndicator)
+	case info.IsQueued:
+		queuedFg := styles.EnsureContrast(styles.TextMuted, bgColor)
+		if role == dragRoleBystander {
+			queuedFg = blendColors(queuedFg, bgColor, dragBystanderDimAmount)
+		}
+		content += lipgloss.NewStyle().Foreground(queuedFg).Background(bgColor).Italic(true).Render(queuedIndicator)
 	case info.IsRunning && !info.IsActive:
 		runFg := styles.EnsureContrast(styles.TabAccentFg, bgColor)
 		if role == dragRoleBystander {
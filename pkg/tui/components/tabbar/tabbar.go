@@ -105,6 +105,7 @@ type KeyMap struct {
 	NewTab   key.Binding
 	NextTab  key.Binding
 	PrevTab  key.Binding
+	LastTab  key.Binding
 	CloseTab key.Binding
 }
 
@@ -123,6 +124,10 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("ctrl+p"),
 			key.WithHelp("Ctrl+p", "prev tab"),
 		),
+		LastTab: key.NewBinding(
+			key.WithKeys("ctrl+^", "ctrl+6"),
+			key.WithHelp("Ctrl+^", "last tab"),
+		),
 		CloseTab: key.NewBinding(
 			key.WithKeys("ctrl+w"),
 			key.WithHelp("Ctrl+W", "close tab"),
@@ -190,6 +195,10 @@ func (t *TabBar) Bindings() []key.Binding {
 			key.WithKeys("ctrl+p", "ctrl+n"),
 			key.WithHelp("Ctrl+p/n", "prev/next tab"),
 		),
+		key.NewBinding(
+			key.WithKeys("ctrl+^", "ctrl+6"),
+			key.WithHelp("Ctrl+^", "last tab"),
+		),
 	}
 }
 
@@ -218,6 +227,12 @@ func (t *TabBar) Update(msg tea.Msg) tea.Cmd {
 			}
 			return core.CmdHandler(messages.SwitchTabMsg{SessionID: t.tabs[prevIdx].SessionID})
 
+		case key.Matches(msg, t.keyMap.LastTab):
+			if len(t.tabs) <= 1 {
+				return nil
+			}
+			return core.CmdHandler(messages.SwitchToPreviousTabMsg{})
+
 		case key.Matches(msg, t.keyMap.CloseTab):
 			if len(t.tabs) <= 1 {
 				return nil
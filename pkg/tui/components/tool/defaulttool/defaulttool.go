@@ -25,8 +25,8 @@ func render(msg *types.Message, s spinner.Spinner, sessionState service.SessionS
 	}
 
 	var resultContent string
-	if (msg.ToolStatus == types.ToolStatusCompleted || msg.ToolStatus == types.ToolStatusError) && msg.Content != "" {
-		resultContent = toolcommon.FormatToolResult(msg.Content, width)
+	if (msg.ToolStatus == types.ToolStatusCompleted || msg.ToolStatus == types.ToolStatusError || msg.ToolStatus == types.ToolStatusTimedOut) && msg.Content != "" {
+		resultContent = toolcommon.RenderToolResult(msg.ToolCall.Function.Name, msg.Content, width)
 	}
 
 	return toolcommon.RenderTool(msg, s, argsContent, resultContent, width, sessionState.HideToolResults())
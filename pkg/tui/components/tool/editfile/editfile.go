@@ -40,7 +40,7 @@ func render(
 
 	// When the tool failed, render a single-line error header
 	// consistent with other tool error renderings.
-	if msg.ToolStatus == types.ToolStatusError {
+	if msg.ToolStatus == types.ToolStatusError || msg.ToolStatus == types.ToolStatusTimedOut {
 		if msg.Content == "" {
 			return ""
 		}
@@ -92,6 +92,7 @@ func render(
 		content += "\n" + styles.ToolCallResult.Render(
 			renderEditFile(
 				msg.ToolCall,
+				msg.ToolDefinition.WorkingDir,
 				contentWidth,
 				sessionState.SplitDiffView(),
 				msg.ToolStatus,
@@ -117,7 +118,7 @@ func renderCollapsed(
 	}
 
 	// Error state
-	if msg.ToolStatus == types.ToolStatusError {
+	if msg.ToolStatus == types.ToolStatusError || msg.ToolStatus == types.ToolStatusTimedOut {
 		if msg.Content == "" {
 			return ""
 		}
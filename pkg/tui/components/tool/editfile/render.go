@@ -59,6 +59,14 @@ func InvalidateCaches() {
 	cacheMu.Unlock()
 }
 
+// InvalidateCache forgets the cached rendering and line counts of a tool
+// call. Call this when its arguments change.
+func InvalidateCache(toolCallID string) {
+	cacheMu.Lock()
+	delete(cache, toolCallID)
+	cacheMu.Unlock()
+}
+
 type chromaToken struct {
 	Text  string
 	Style lipgloss.Style
@@ -90,7 +98,7 @@ func getOrCreateCache(toolCallID string) *toolRenderCache {
 	return c
 }
 
-func renderEditFile(toolCall tools.ToolCall, width int, splitView bool, toolStatus types.ToolStatus) string {
+func renderEditFile(toolCall tools.ToolCall, workingDir string, width int, splitView bool, toolStatus types.ToolStatus) string {
 	c := getOrCreateCache(toolCall.ID)
 
 	cacheMu.RLock()
@@ -104,7 +112,7 @@ func renderEditFile(toolCall tools.ToolCall, width int, splitView bool, toolStat
 	}
 	cacheMu.RUnlock()
 
-	result := renderEditFileUncached(toolCall, width, splitView, toolStatus)
+	result := renderEditFileUncached(toolCall, workingDir, width, splitView, toolStatus)
 
 	cacheMu.Lock()
 	c.rendered = result
@@ -117,7 +125,7 @@ func renderEditFile(toolCall tools.ToolCall, width int, splitView bool, toolStat
 	return result
 }
 
-func renderEditFileUncached(toolCall tools.ToolCall, width int, splitView bool, toolStatus types.ToolStatus) string {
+func renderEditFileUncached(toolCall tools.ToolCall, workingDir string, width int, splitView bool, toolStatus types.ToolStatus) string {
 	var args builtin.EditFileArgs
 	if err := json.Unmarshal([]byte(toolCall.Function.Arguments), &args); err != nil {
 		return ""
@@ -133,7 +141,7 @@ func renderEditFileUncached(toolCall tools.ToolCall, width int, splitView bool,
 			fmt.Fprintf(&output, "Edit #%d:\n", i+1)
 		}
 
-		diff := computeDiff(args.Path, edit.OldText, edit.NewText, toolStatus)
+		diff := computeDiff(builtin.ResolvePath(workingDir, args.Path), edit.OldText, edit.NewText, toolStatus)
 		if splitView {
 			output.WriteString(renderSplitDiffWithSyntaxHighlight(diff, args.Path, width))
 		} else {
@@ -0,0 +1,363 @@
+package shell
+
+import (
+	"strings"
+
+	"charm.land/lipgloss/v2"
+
+	"github.com/docker/cagent/pkg/tui/styles"
+)
+
+type tokenKind int
+
+const (
+	tokenSpace tokenKind = iota
+	tokenWord
+	tokenCommand
+	tokenFlag
+	tokenString
+	tokenVariable
+	tokenAssignment
+	tokenOperator
+	tokenRedirect
+	tokenComment
+	tokenDanger
+)
+
+type token struct {
+	kind tokenKind
+	text string
+}
+
+// dangerousCommands are highlighted so they stand out when confirming a call.
+var dangerousCommands = map[string]bool{
+	"rm":       true,
+	"sudo":     true,
+	"su":       true,
+	"doas":     true,
+	"dd":       true,
+	"mkfs":     true,
+	"shred":    true,
+	"chmod":    true,
+	"chown":    true,
+	"kill":     true,
+	"pkill":    true,
+	"killall":  true,
+	"shutdown": true,
+	"reboot":   true,
+}
+
+// prefixCommands run the command that follows them, which is highlighted as a
+// command as well.
+var prefixCommands = map[string]bool{
+	"sudo":    true,
+	"doas":    true,
+	"env":     true,
+	"exec":    true,
+	"nohup":   true,
+	"time":    true,
+	"xargs":   true,
+	"command": true,
+}
+
+// Highlight returns the shell command with ANSI styling for commands, flags,
+// strings, variables, operators and redirects. Dangerous commands such as
+// rm or sudo, and their flags, are rendered in the error color.
+//
+// The tokenizer is deliberately simple: it never drops input, so constructs
+// it doesn't understand (heredocs, nested quoting, ...) are rendered as plain
+// words rather than mangled.
+func Highlight(cmd string) string {
+	var b strings.Builder
+	for _, tok := range tokenize(cmd) {
+		style, ok := tokenStyle(tok.kind)
+		if !ok || strings.TrimSpace(tok.text) == "" {
+			b.WriteString(tok.text)
+			continue
+		}
+		// Style each line separately so wrapping and indentation keep working
+		// for multi-line strings.
+		for i, line := range strings.Split(tok.text, "\n") {
+			if i > 0 {
+				b.WriteByte('\n')
+			}
+			if line != "" {
+				b.WriteString(style.Render(line))
+			}
+		}
+	}
+	return b.String()
+}
+
+func tokenStyle(kind tokenKind) (lipgloss.Style, bool) {
+	switch kind {
+	case tokenCommand:
+		return lipgloss.NewStyle().Foreground(styles.Accent).Bold(true), true
+	case tokenFlag:
+		return lipgloss.NewStyle().Foreground(styles.Info), true
+	case tokenString:
+		return lipgloss.NewStyle().Foreground(styles.Success), true
+	case tokenVariable, tokenAssignment:
+		return lipgloss.NewStyle().Foreground(styles.Highlight), true
+	case tokenOperator:
+		return lipgloss.NewStyle().Foreground(styles.Highlight).Bold(true), true
+	case tokenRedirect:
+		return lipgloss.NewStyle().Foreground(styles.Warning).Bold(true), true
+	case tokenComment:
+		return lipgloss.NewStyle().Foreground(styles.TextMuted).Italic(true), true
+	case tokenDanger:
+		return lipgloss.NewStyle().Foreground(styles.Error).Bold(true), true
+	default:
+		return lipgloss.Style{}, false
+	}
+}
+
+// tokenize splits a shell command into tokens. Concatenating the text of the
+// returned tokens always gives back the input.
+func tokenize(cmd string) []token {
+	var (
+		tokens       []token
+		expectCmd    = true
+		dangerousCmd bool
+	)
+
+	emit := func(kind tokenKind, text string) {
+		if text != "" {
+			tokens = append(tokens, token{kind: kind, text: text})
+		}
+	}
+
+	i := 0
+	for i < len(cmd) {
+		c := cmd[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			j := i
+			for j < len(cmd) && strings.IndexByte(" \t\n\r", cmd[j]) >= 0 {
+				j++
+			}
+			// An unescaped newline ends the command, like ';'.
+			if strings.Contains(cmd[i:j], "\n") && !strings.HasSuffix(cmd[:i], "\\") {
+				expectCmd, dangerousCmd = true, false
+			}
+			emit(tokenSpace, cmd[i:j])
+			i = j
+
+		case c == '#' && (i == 0 || strings.IndexByte(" \t\n;|&(", cmd[i-1]) >= 0):
+			j := strings.IndexByte(cmd[i:], '\n')
+			if j < 0 {
+				j = len(cmd) - i
+			}
+			emit(tokenComment, cmd[i:i+j])
+			i += j
+
+		case c == '|' || c == '&' || c == ';' || c == '(' || c == ')' || c == '`':
+			if n := redirectLen(cmd[i:]); n > 0 {
+				emit(tokenRedirect, cmd[i:i+n])
+				i += n
+				continue
+			}
+			j := i + 1
+			if j < len(cmd) && (cmd[j] == c && (c == '|' || c == '&' || c == ';')) {
+				j++
+			}
+			emit(tokenOperator, cmd[i:j])
+			expectCmd, dangerousCmd = c != ')', false
+			i = j
+
+		case c == '<' || c == '>' || (c >= '0' && c <= '9' && redirectLen(cmd[i:]) > 0):
+			n := max(redirectLen(cmd[i:]), 1)
+			emit(tokenRedirect, cmd[i:i+n])
+			i += n
+
+		case c == '$' && i+1 < len(cmd) && cmd[i+1] == '(':
+			emit(tokenOperator, cmd[i:i+2])
+			expectCmd, dangerousCmd = true, false
+			i += 2
+
+		default:
+			j := scanWord(cmd, i)
+			word := cmd[i:j]
+			kind := classifyWord(word, expectCmd, dangerousCmd)
+			switch {
+			case kind == tokenAssignment || kind == tokenFlag || strings.HasPrefix(word, "-"):
+				// VAR=value before a command, and the flags of prefix
+				// commands such as sudo, keep the command position.
+			case kind == tokenCommand || kind == tokenDanger:
+				name := commandName(word)
+				dangerousCmd = dangerousCommands[name]
+				expectCmd = prefixCommands[name]
+			default:
+				expectCmd = false
+			}
+			emitWord(&tokens, word, kind)
+			i = j
+		}
+	}
+	return tokens
+}
+
+// emitWord splits a word into string, variable and plain parts, so that
+// quoted sections and variables are highlighted inside larger words.
+func emitWord(tokens *[]token, word string, kind tokenKind) {
+	if kind != tokenWord {
+		*tokens = append(*tokens, token{kind: kind, text: word})
+		return
+	}
+
+	start := 0
+	flush := func(end int) {
+		if end > start {
+			*tokens = append(*tokens, token{kind: tokenWord, text: word[start:end]})
+		}
+	}
+
+	for i := 0; i < len(word); {
+		switch word[i] {
+		case '\\':
+			i = min(i+2, len(word))
+		case '\'', '"':
+			flush(i)
+			end := quoteEnd(word, i)
+			*tokens = append(*tokens, token{kind: tokenString, text: word[i:end]})
+			i, start = end, end
+		case '$':
+			flush(i)
+			end := variableEnd(word, i)
+			*tokens = append(*tokens, token{kind: tokenVariable, text: word[i:end]})
+			i, start = end, end
+		default:
+			i++
+		}
+	}
+	flush(len(word))
+}
+
+func classifyWord(word string, expectCmd, dangerousCmd bool) tokenKind {
+	switch {
+	case expectCmd && isAssignment(word):
+		return tokenAssignment
+	case strings.HasPrefix(word, "-") && len(word) > 1:
+		if dangerousCmd {
+			return tokenDanger
+		}
+		return tokenFlag
+	case expectCmd && strings.IndexByte("'\"$-", word[0]) < 0:
+		if dangerousCommands[commandName(word)] {
+			return tokenDanger
+		}
+		return tokenCommand
+	default:
+		return tokenWord
+	}
+}
+
+// commandName returns the base name of a command word, so that /bin/rm and
+// mkfs.ext4 are recognized as rm and mkfs.
+func commandName(word string) string {
+	if i := strings.LastIndexByte(word, '/'); i >= 0 {
+		word = word[i+1:]
+	}
+	if i := strings.IndexByte(word, '.'); i > 0 {
+		word = word[:i]
+	}
+	return word
+}
+
+func isAssignment(word string) bool {
+	name, _, ok := strings.Cut(word, "=")
+	if !ok || name == "" {
+		return false
+	}
+	for i, r := range name {
+		if r != '_' && (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') && (i == 0 || r < '0' || r > '9') {
+			return false
+		}
+	}
+	return true
+}
+
+// scanWord returns the end of the word starting at i. Quoted sections are
+// part of the word; an unterminated quote extends to the end of the input.
+func scanWord(cmd string, i int) int {
+	for i < len(cmd) {
+		switch c := cmd[i]; {
+		case c == '\\':
+			i = min(i+2, len(cmd))
+		case c == '\'' || c == '"':
+			i = quoteEnd(cmd, i)
+		case c == '$' && i+1 < len(cmd) && cmd[i+1] == '(':
+			return i
+		case strings.IndexByte(" \t\n\r|&;()<>`", c) >= 0:
+			return i
+		default:
+			i++
+		}
+	}
+	return i
+}
+
+// quoteEnd returns the index just past the quote closing the one at i.
+func quoteEnd(s string, i int) int {
+	q := s[i]
+	for j := i + 1; j < len(s); j++ {
+		switch s[j] {
+		case '\\':
+			if q == '"' {
+				j++
+			}
+		case q:
+			return j + 1
+		}
+	}
+	return len(s)
+}
+
+// variableEnd returns the end of the $NAME, ${...} or $? reference at i.
+func variableEnd(s string, i int) int {
+	j := i + 1
+	if j >= len(s) {
+		return j
+	}
+	if s[j] == '{' {
+		if k := strings.IndexByte(s[j:], '}'); k >= 0 {
+			return j + k + 1
+		}
+		return len(s)
+	}
+	if strings.IndexByte("?!#$@*-0123456789", s[j]) >= 0 {
+		return j + 1
+	}
+	for j < len(s) && (s[j] == '_' || (s[j] >= 'a' && s[j] <= 'z') || (s[j] >= 'A' && s[j] <= 'Z') || (s[j] >= '0' && s[j] <= '9')) {
+		j++
+	}
+	return j
+}
+
+// redirectLen returns the length of the redirection operator at the start of
+// s (>, >>, <, 2>, 2>&1, &>, ...) or 0 if there is none.
+func redirectLen(s string) int {
+	i := 0
+	switch {
+	case strings.HasPrefix(s, "&>"):
+		i = 1
+	default:
+		for i < len(s) && s[i] >= '0' && s[i] <= '9' {
+			i++
+		}
+	}
+	if i >= len(s) || (s[i] != '>' && s[i] != '<') {
+		return 0
+	}
+	i++
+	if i < len(s) && (s[i] == '>' || s[i] == '<' || s[i] == '|') {
+		i++
+	}
+	if i < len(s) && s[i] == '&' {
+		i++
+		for i < len(s) && (s[i] >= '0' && s[i] <= '9' || s[i] == '-') {
+			i++
+		}
+	}
+	return i
+}
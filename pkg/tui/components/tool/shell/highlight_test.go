@@ -0,0 +1,88 @@
+package shell
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/stretchr/testify/assert"
+)
+
+func kinds(cmd string) map[string]tokenKind {
+	result := map[string]tokenKind{}
+	for _, tok := range tokenize(cmd) {
+		if tok.kind != tokenSpace {
+			result[tok.text] = tok.kind
+		}
+	}
+	return result
+}
+
+func TestTokenize_RoundTrips(t *testing.T) {
+	t.Parallel()
+
+	for _, cmd := range []string{
+		"ls -la",
+		`echo "hello $USER" | grep -i 'hello' > out.txt 2>&1`,
+		"FOO=bar go test ./... && echo ok || echo ko; exit 1",
+		`echo "unterminated`,
+		"cat <<EOF\nmulti\nline\nEOF",
+		"echo $(date +%s) `whoami` ${HOME}/x # comment",
+		"for f in *.go; do gofmt -l \"$f\"; done",
+		`a\ b "c\"d" 'e`,
+	} {
+		var b strings.Builder
+		for _, tok := range tokenize(cmd) {
+			b.WriteString(tok.text)
+		}
+		assert.Equal(t, cmd, b.String())
+		assert.Equal(t, cmd, ansi.Strip(Highlight(cmd)))
+	}
+}
+
+func TestTokenize_Kinds(t *testing.T) {
+	t.Parallel()
+
+	got := kinds(`FOO=1 grep -rn "needle" . | sort > out.txt 2>&1 # done`)
+	assert.Equal(t, tokenAssignment, got["FOO=1"])
+	assert.Equal(t, tokenCommand, got["grep"])
+	assert.Equal(t, tokenFlag, got["-rn"])
+	assert.Equal(t, tokenString, got[`"needle"`])
+	assert.Equal(t, tokenWord, got["."])
+	assert.Equal(t, tokenOperator, got["|"])
+	assert.Equal(t, tokenCommand, got["sort"])
+	assert.Equal(t, tokenRedirect, got[">"])
+	assert.Equal(t, tokenRedirect, got["2>&1"])
+	assert.Equal(t, tokenComment, got["# done"])
+}
+
+func TestTokenize_Dangerous(t *testing.T) {
+	t.Parallel()
+
+	got := kinds("sudo -E /bin/rm -rf /tmp/x && ls -l")
+	assert.Equal(t, tokenDanger, got["sudo"])
+	assert.Equal(t, tokenDanger, got["-E"])
+	assert.Equal(t, tokenDanger, got["/bin/rm"])
+	assert.Equal(t, tokenDanger, got["-rf"])
+	assert.Equal(t, tokenWord, got["/tmp/x"])
+	assert.Equal(t, tokenCommand, got["ls"])
+	assert.Equal(t, tokenFlag, got["-l"])
+}
+
+func TestTokenize_Variables(t *testing.T) {
+	t.Parallel()
+
+	got := kinds("echo $HOME/bin ${PATH} $?")
+	assert.Equal(t, tokenVariable, got["$HOME"])
+	assert.Equal(t, tokenWord, got["/bin"])
+	assert.Equal(t, tokenVariable, got["${PATH}"])
+	assert.Equal(t, tokenVariable, got["$?"])
+}
+
+func TestTokenize_UnterminatedQuote(t *testing.T) {
+	t.Parallel()
+
+	got := kinds(`echo 'it never ends | rm -rf /`)
+	assert.Equal(t, tokenString, got[`'it never ends | rm -rf /`])
+	assert.NotContains(t, got, "rm")
+}
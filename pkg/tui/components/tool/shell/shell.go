@@ -1,15 +1,48 @@
 package shell
 
 import (
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+
 	"github.com/docker/cagent/pkg/tools/builtin"
+	"github.com/docker/cagent/pkg/tui/components/spinner"
 	"github.com/docker/cagent/pkg/tui/components/toolcommon"
 	"github.com/docker/cagent/pkg/tui/core/layout"
 	"github.com/docker/cagent/pkg/tui/service"
+	"github.com/docker/cagent/pkg/tui/styles"
 	"github.com/docker/cagent/pkg/tui/types"
 )
 
+var extractCmd = toolcommon.ExtractField(func(a builtin.RunShellArgs) string { return a.Cmd })
+
 func New(msg *types.Message, sessionState service.SessionStateReader) layout.Model {
-	return toolcommon.NewBase(msg, sessionState, toolcommon.SimpleRenderer(
-		toolcommon.ExtractField(func(a builtin.RunShellArgs) string { return a.Cmd }),
-	))
+	return toolcommon.NewBase(msg, sessionState, render)
+}
+
+// render shows the command on the tool line, or syntax highlighted below it
+// while the call is waiting for confirmation.
+func render(msg *types.Message, s spinner.Spinner, sessionState service.SessionStateReader, width, height int) string {
+	if msg.ToolStatus != types.ToolStatusConfirmation {
+		return toolcommon.SimpleRenderer(extractCmd)(msg, s, sessionState, width, height)
+	}
+
+	cmd := extractCmd(msg.ToolCall.Function.Arguments)
+	if strings.TrimSpace(cmd) == "" {
+		return toolcommon.SimpleRenderer(extractCmd)(msg, s, sessionState, width, height)
+	}
+
+	header, ok := toolcommon.RenderFriendlyHeader(msg, s)
+	if !ok {
+		header = toolcommon.Icon(msg, s) + styles.ToolName.Render(msg.ToolDefinition.DisplayName())
+	}
+
+	indentWidth := styles.ToolCompletedIcon.GetMarginLeft()
+	indent := strings.Repeat(" ", indentWidth)
+	lines := strings.Split(ansi.Wrap(Highlight(cmd), max(width-indentWidth, 1), " "), "\n")
+	for i, line := range lines {
+		lines[i] = indent + line
+	}
+
+	return styles.RenderComposite(styles.ToolMessageStyle.Width(width), header+"\n"+strings.Join(lines, "\n"))
 }
@@ -176,7 +176,7 @@ func SimpleRendererWithResult(
 		}
 
 		result := ""
-		if msg.ToolStatus == types.ToolStatusCompleted || msg.ToolStatus == types.ToolStatusError {
+		if msg.ToolStatus == types.ToolStatusCompleted || msg.ToolStatus == types.ToolStatusError || msg.ToolStatus == types.ToolStatusTimedOut {
 			result = extractResult(msg)
 		}
 
@@ -7,7 +7,6 @@ import (
 
 	"charm.land/lipgloss/v2"
 
-	"github.com/docker/cagent/pkg/paths"
 	"github.com/docker/cagent/pkg/tools"
 	"github.com/docker/cagent/pkg/tui/components/spinner"
 	"github.com/docker/cagent/pkg/tui/styles"
@@ -112,6 +111,8 @@ func Icon(msg *types.Message, inProgress spinner.Spinner) string {
 		return styles.ToolCompletedIcon.Render("✓")
 	case types.ToolStatusError:
 		return styles.ToolErrorIcon.Render("✗")
+	case types.ToolStatusTimedOut:
+		return styles.ToolErrorIcon.Render("⏱")
 	case types.ToolStatusConfirmation:
 		return styles.ToolPendingIcon.Render("?")
 	default:
@@ -145,7 +146,7 @@ func FormatToolResult(content string, width int) string {
 func RenderTool(msg *types.Message, inProgress spinner.Spinner, args, result string, width int, hideToolResults bool) string {
 	nameStyle := styles.ToolName
 	resultStyle := styles.ToolMessageStyle
-	if msg.ToolStatus == types.ToolStatusError {
+	if msg.ToolStatus == types.ToolStatusError || msg.ToolStatus == types.ToolStatusTimedOut {
 		nameStyle = styles.ToolNameError
 		resultStyle = styles.ToolErrorMessageStyle
 	}
@@ -203,16 +204,10 @@ func RenderTool(msg *types.Message, inProgress spinner.Spinner, args, result str
 	return styles.RenderComposite(styles.ToolMessageStyle.Width(width), content)
 }
 
-// ShortenPath replaces home directory with ~ for cleaner display.
+// ShortenPath replaces home directory with ~ for cleaner display, unless
+// home-relative paths are disabled.
 func ShortenPath(path string) string {
-	if path == "" {
-		return path
-	}
-	homeDir := paths.GetHomeDir()
-	if homeDir != "" && strings.HasPrefix(path, homeDir) {
-		return "~" + strings.TrimPrefix(path, homeDir)
-	}
-	return path
+	return styles.DisplayPath(path)
 }
 
 // RenderFriendlyHeader renders a friendly description header if present in the tool call arguments.
@@ -0,0 +1,188 @@
+package toolcommon
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+
+	"charm.land/lipgloss/v2"
+	"charm.land/lipgloss/v2/table"
+
+	"github.com/docker/cagent/pkg/tui/styles"
+)
+
+// ResultRenderer renders the result of a tool call in the given width. It
+// returns false when it can't render the content, for the default rendering
+// to be used instead.
+type ResultRenderer func(content string, width int) (string, bool)
+
+// maxTableRows is the number of rows the table renderer shows before
+// summarizing the rest.
+const maxTableRows = 10
+
+// builtinResultRenderers are the renderers tools can opt in to by name.
+var builtinResultRenderers = map[string]ResultRenderer{
+	"json":  RenderJSONResult,
+	"table": RenderTableResult,
+}
+
+var (
+	resultRenderersMu sync.RWMutex
+	resultRenderers   = map[string]ResultRenderer{}
+)
+
+// ResultRendererNames returns the names of the built-in result renderers.
+func ResultRendererNames() []string {
+	return slices.Sorted(maps.Keys(builtinResultRenderers))
+}
+
+// RegisterResultRenderer renders the results of the tool with the given
+// name with the given renderer.
+func RegisterResultRenderer(toolName string, renderer ResultRenderer) {
+	resultRenderersMu.Lock()
+	defer resultRenderersMu.Unlock()
+	resultRenderers[toolName] = renderer
+}
+
+// RegisterResultRendererByName renders the results of the tool with the
+// built-in renderer with the given name, see ResultRendererNames.
+func RegisterResultRendererByName(toolName, rendererName string) error {
+	renderer, ok := builtinResultRenderers[rendererName]
+	if !ok {
+		return fmt.Errorf("unknown tool result renderer %q, expected one of %s", rendererName, strings.Join(ResultRendererNames(), ", "))
+	}
+	RegisterResultRenderer(toolName, renderer)
+	return nil
+}
+
+// RenderToolResult renders the result of a call to the given tool, with the
+// renderer registered for it if any, or FormatToolResult.
+func RenderToolResult(toolName, content string, width int) string {
+	resultRenderersMu.RLock()
+	renderer, ok := resultRenderers[toolName]
+	resultRenderersMu.RUnlock()
+
+	if ok {
+		if rendered, ok := renderer(content, width); ok {
+			return rendered
+		}
+	}
+	return FormatToolResult(content, width)
+}
+
+// RenderJSONResult pretty-prints a JSON result, keeping the order of the keys.
+func RenderJSONResult(content string, width int) (string, bool) {
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, []byte(strings.TrimSpace(content)), "", "  "); err != nil {
+		return "", false
+	}
+
+	availableWidth := max(width-styles.ToolCallResult.GetHorizontalFrameSize(), 10)
+	return strings.Join(WrapLines(buf.String(), availableWidth), "\n"), true
+}
+
+// RenderTableResult renders a JSON array of objects as a table, with one
+// column per key, in order of first appearance.
+func RenderTableResult(content string, width int) (string, bool) {
+	var items []json.RawMessage
+	if err := json.Unmarshal([]byte(content), &items); err != nil || len(items) == 0 {
+		return "", false
+	}
+
+	var columns []string
+	var objects []map[string]json.RawMessage
+	for _, item := range items {
+		keys, err := objectKeys(item)
+		if err != nil {
+			return "", false
+		}
+		var object map[string]json.RawMessage
+		if err := json.Unmarshal(item, &object); err != nil {
+			return "", false
+		}
+		for _, key := range keys {
+			if !slices.Contains(columns, key) {
+				columns = append(columns, key)
+			}
+		}
+		objects = append(objects, object)
+	}
+	if len(columns) == 0 {
+		return "", false
+	}
+
+	var rows [][]string
+	for _, object := range objects[:min(len(objects), maxTableRows)] {
+		row := make([]string, len(columns))
+		for i, column := range columns {
+			row[i] = tableCell(object[column])
+		}
+		rows = append(rows, row)
+	}
+
+	availableWidth := max(width-styles.ToolCallResult.GetHorizontalFrameSize(), 10)
+	t := table.New().
+		Border(lipgloss.NormalBorder()).
+		BorderStyle(styles.MutedStyle).
+		StyleFunc(func(row, _ int) lipgloss.Style {
+			if row == table.HeaderRow {
+				return lipgloss.NewStyle().Bold(true).Padding(0, 1)
+			}
+			return lipgloss.NewStyle().Padding(0, 1)
+		}).
+		Headers(columns...).
+		Rows(rows...)
+	rendered := t.String()
+	if lipgloss.Width(rendered) > availableWidth {
+		rendered = t.Width(availableWidth).Wrap(false).String()
+	}
+
+	if len(objects) > maxTableRows {
+		rendered += "\n" + fmt.Sprintf("… %d more rows", len(objects)-maxTableRows)
+	}
+	return rendered, true
+}
+
+// objectKeys returns the keys of a JSON object, in order.
+func objectKeys(raw json.RawMessage) ([]string, error) {
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	if tok, err := decoder.Token(); err != nil || tok != json.Delim('{') {
+		return nil, fmt.Errorf("not a JSON object")
+	}
+
+	var keys []string
+	for decoder.More() {
+		tok, err := decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, tok.(string))
+
+		var value json.RawMessage
+		if err := decoder.Decode(&value); err != nil {
+			return nil, err
+		}
+	}
+	return keys, nil
+}
+
+// tableCell formats a JSON value for a table cell: strings without their
+// quotes, the other values as compact JSON, on a single line.
+func tableCell(raw json.RawMessage) string {
+	if raw == nil {
+		return ""
+	}
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return strings.Join(strings.Fields(s), " ")
+	}
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, raw); err != nil {
+		return string(raw)
+	}
+	return buf.String()
+}
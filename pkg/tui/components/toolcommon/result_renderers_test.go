@@ -0,0 +1,75 @@
+package toolcommon
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderTableResult(t *testing.T) {
+	t.Parallel()
+
+	content := `[{"name":"TestParse","status":"pass","ms":12},{"name":"TestRender","status":"fail","error":{"line":42}}]`
+	rendered, ok := RenderTableResult(content, 80)
+	require.True(t, ok)
+
+	lines := strings.Split(ansi.Strip(rendered), "\n")
+	// top border, header, header separator, two rows, bottom border
+	require.Len(t, lines, 6)
+	assert.Regexp(t, `name\s+│ status │ ms │ error`, lines[1])
+	assert.Regexp(t, `TestParse\s+│ pass\s+│ 12 │`, lines[3])
+	assert.Contains(t, lines[4], `{"line":42}`)
+}
+
+func TestRenderTableResult_FitsWidthAndSummarizesRows(t *testing.T) {
+	t.Parallel()
+
+	var items []string
+	for range 15 {
+		items = append(items, `{"path":"`+strings.Repeat("a", 100)+`","size":1}`)
+	}
+	rendered, ok := RenderTableResult("["+strings.Join(items, ",")+"]", 60)
+	require.True(t, ok)
+
+	for line := range strings.SplitSeq(rendered, "\n") {
+		assert.LessOrEqual(t, ansi.StringWidth(line), 60)
+	}
+	assert.Contains(t, rendered, "… 5 more rows")
+}
+
+func TestRenderTableResult_RejectsOtherContent(t *testing.T) {
+	t.Parallel()
+
+	for _, content := range []string{"plain text", `{"a":1}`, `[]`, `[1,2]`, `[{"a":1},"b"]`} {
+		_, ok := RenderTableResult(content, 80)
+		assert.False(t, ok, content)
+	}
+}
+
+func TestRenderJSONResult(t *testing.T) {
+	t.Parallel()
+
+	rendered, ok := RenderJSONResult(`{"z":1,"a":[true,null]}`, 80)
+	require.True(t, ok)
+	assert.Equal(t, "{\n  \"z\": 1,\n  \"a\": [\n    true,\n    null\n  ]\n}", rendered)
+
+	_, ok = RenderJSONResult("not json", 80)
+	assert.False(t, ok)
+}
+
+func TestRenderToolResult(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, RegisterResultRendererByName("test_results_tool", "table"))
+	require.Error(t, RegisterResultRendererByName("other_tool", "chart"))
+
+	table := RenderToolResult("test_results_tool", `[{"name":"TestA"}]`, 80)
+	assert.Contains(t, table, "│")
+
+	// Falls back to the default rendering
+	assert.Equal(t, "not a table", RenderToolResult("test_results_tool", "not a table", 80))
+	assert.Equal(t, `[{"name":"TestA"}]`, RenderToolResult("unregistered_tool", `[{"name":"TestA"}]`, 80))
+}
@@ -0,0 +1,69 @@
+package core
+
+import (
+	"cmp"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// ExternalEditorCommand returns the command opening path in the user's
+// editor: $VISUAL, $EDITOR, or the platform default.
+func ExternalEditorCommand(path string) *exec.Cmd {
+	return ExternalEditorCommandAt(path, 0, 0)
+}
+
+// ExternalEditorCommandAt is like ExternalEditorCommand but also moves the
+// cursor to line and col (1-based, 0 when unknown) in the editors whose goto
+// arguments are known. Other editors just open the file.
+func ExternalEditorCommandAt(path string, line, col int) *exec.Cmd {
+	editorCmd := cmp.Or(os.Getenv("VISUAL"), os.Getenv("EDITOR"))
+	if editorCmd == "" {
+		if runtime.GOOS == "windows" {
+			editorCmd = "notepad"
+		} else {
+			editorCmd = "vi"
+		}
+	}
+
+	// The editor command may include arguments like "code --wait"
+	parts := strings.Fields(editorCmd)
+	args := append(parts[1:], editorGotoArgs(parts[0], path, line, col)...)
+	return exec.Command(parts[0], args...)
+}
+
+// editorGotoArgs returns the arguments opening path at line and col with
+// editor, falling back to path alone when line is unknown or the editor
+// isn't.
+func editorGotoArgs(editor, path string, line, col int) []string {
+	if line <= 0 {
+		return []string{path}
+	}
+	position := strconv.Itoa(line)
+	if col > 0 {
+		position += ":" + strconv.Itoa(col)
+	}
+
+	switch strings.TrimSuffix(strings.ToLower(filepath.Base(editor)), ".exe") {
+	case "code", "code-insiders", "codium", "vscodium", "cursor", "windsurf":
+		return []string{"--goto", path + ":" + position}
+	case "subl", "sublime_text", "zed", "hx", "helix":
+		return []string{path + ":" + position}
+	case "emacs", "emacsclient", "micro":
+		return []string{"+" + position, path}
+	case "nano":
+		return []string{"+" + strings.Replace(position, ":", ",", 1), path}
+	case "vi", "vim", "nvim", "gvim", "mvim", "kak", "joe", "mg":
+		return []string{"+" + strconv.Itoa(line), path}
+	case "idea", "goland", "pycharm", "webstorm", "clion", "rider", "phpstorm", "rubymine":
+		args := []string{"--line", strconv.Itoa(line)}
+		if col > 0 {
+			args = append(args, "--column", strconv.Itoa(col))
+		}
+		return append(args, path)
+	}
+	return []string{path}
+}
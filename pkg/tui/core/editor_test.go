@@ -0,0 +1,43 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEditorGotoArgs(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		editor string
+		line   int
+		col    int
+		want   []string
+	}{
+		{editor: "code", line: 12, col: 3, want: []string{"--goto", "main.go:12:3"}},
+		{editor: "/usr/local/bin/cursor", line: 12, want: []string{"--goto", "main.go:12"}},
+		{editor: "zed", line: 12, col: 3, want: []string{"main.go:12:3"}},
+		{editor: "nvim", line: 12, col: 3, want: []string{"+12", "main.go"}},
+		{editor: "emacs", line: 12, col: 3, want: []string{"+12:3", "main.go"}},
+		{editor: "nano", line: 12, col: 3, want: []string{"+12,3", "main.go"}},
+		{editor: "goland", line: 12, col: 3, want: []string{"--line", "12", "--column", "3", "main.go"}},
+		{editor: "Code.exe", line: 12, want: []string{"--goto", "main.go:12"}},
+		{editor: "ed", line: 12, want: []string{"main.go"}},
+		{editor: "code", want: []string{"main.go"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.editor, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.want, editorGotoArgs(tt.editor, "main.go", tt.line, tt.col))
+		})
+	}
+}
+
+func TestExternalEditorCommandAt(t *testing.T) {
+	t.Setenv("VISUAL", "")
+	t.Setenv("EDITOR", "code --wait")
+
+	cmd := ExternalEditorCommandAt("main.go", 7, 0)
+	assert.Equal(t, []string{"code", "--wait", "--goto", "main.go:7"}, cmd.Args)
+}
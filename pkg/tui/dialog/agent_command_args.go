@@ -0,0 +1,189 @@
+package dialog
+
+import (
+	"strings"
+
+	"charm.land/bubbles/v2/key"
+	"charm.land/bubbles/v2/textinput"
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/docker/cagent/pkg/config/types"
+	"github.com/docker/cagent/pkg/tui/core"
+	"github.com/docker/cagent/pkg/tui/core/layout"
+	"github.com/docker/cagent/pkg/tui/messages"
+	"github.com/docker/cagent/pkg/tui/styles"
+)
+
+// agentCommandArgsDialog asks for the arguments of an agent command whose
+// instruction has placeholders, then sends the command with them.
+type agentCommandArgsDialog struct {
+	BaseDialog
+	name    string
+	command types.Command
+	args    []string
+	inputs  []textinput.Model
+	current int
+	keyMap  promptPlaceholdersKeyMap
+}
+
+// NewAgentCommandArgsDialog creates a dialog with one input per argument of
+// the agent command, see runtime.CommandArgs.
+func NewAgentCommandArgsDialog(name string, command types.Command, args []string) Dialog {
+	inputs := make([]textinput.Model, len(args))
+	for i, arg := range args {
+		inputs[i] = textinput.New()
+		inputs[i].SetStyles(styles.DialogInputStyle)
+		inputs[i].Placeholder = arg
+		inputs[i].CharLimit = 2000
+	}
+	inputs[0].Focus()
+
+	return &agentCommandArgsDialog{
+		name:    name,
+		command: command,
+		args:    args,
+		inputs:  inputs,
+		keyMap: promptPlaceholdersKeyMap{
+			Previous: key.NewBinding(key.WithKeys("up", "shift+tab")),
+			Next:     key.NewBinding(key.WithKeys("down", "tab")),
+			Insert:   key.NewBinding(key.WithKeys("enter")),
+			Close:    key.NewBinding(key.WithKeys("esc")),
+		},
+	}
+}
+
+func (d *agentCommandArgsDialog) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+func (d *agentCommandArgsDialog) Update(msg tea.Msg) (layout.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		cmd := d.SetSize(msg.Width, msg.Height)
+		return d, cmd
+
+	case tea.PasteMsg:
+		var cmd tea.Cmd
+		d.inputs[d.current], cmd = d.inputs[d.current].Update(msg)
+		return d, cmd
+
+	case tea.KeyPressMsg:
+		if cmd := HandleQuit(msg); cmd != nil {
+			return d, cmd
+		}
+
+		switch {
+		case key.Matches(msg, d.keyMap.Close):
+			return d, core.CmdHandler(CloseDialogMsg{})
+		case key.Matches(msg, d.keyMap.Previous):
+			d.focus(d.current - 1)
+			return d, nil
+		case key.Matches(msg, d.keyMap.Next):
+			d.focus(d.current + 1)
+			return d, nil
+		case key.Matches(msg, d.keyMap.Insert):
+			// Enter moves to the next argument, and sends the command from
+			// the last one.
+			if d.current < len(d.inputs)-1 {
+				d.focus(d.current + 1)
+				return d, nil
+			}
+			return d, tea.Sequence(
+				core.CmdHandler(CloseDialogMsg{}),
+				core.CmdHandler(messages.AgentCommandMsg{Command: d.commandLine()}),
+			)
+		}
+
+		var cmd tea.Cmd
+		d.inputs[d.current], cmd = d.inputs[d.current].Update(msg)
+		return d, cmd
+	}
+	return d, nil
+}
+
+// focus moves the focus to the input at index i, if there is one.
+func (d *agentCommandArgsDialog) focus(i int) {
+	if i < 0 || i >= len(d.inputs) {
+		return
+	}
+	d.inputs[d.current].Blur()
+	d.current = i
+	d.inputs[d.current].Focus()
+}
+
+// commandLine returns the command with the arguments entered so far. A
+// single "arguments" input is passed as is, positional arguments are quoted
+// when they have spaces so that each stays one argument.
+func (d *agentCommandArgsDialog) commandLine() string {
+	line := "/" + d.name
+	if len(d.inputs) == 1 && d.args[0] == "arguments" {
+		if value := strings.TrimSpace(d.inputs[0].Value()); value != "" {
+			line += " " + value
+		}
+		return line
+	}
+
+	values := make([]string, len(d.inputs))
+	last := -1
+	for i, input := range d.inputs {
+		values[i] = strings.TrimSpace(input.Value())
+		if values[i] != "" {
+			last = i
+		}
+	}
+	for _, value := range values[:last+1] {
+		line += " " + quoteArg(value)
+	}
+	return line
+}
+
+// quoteArg quotes an argument with spaces, or an empty one, so that it's
+// tokenized as a single argument.
+func quoteArg(value string) string {
+	if value != "" && !strings.ContainsAny(value, " \t") {
+		return value
+	}
+	if strings.Contains(value, `"`) {
+		return "'" + value + "'"
+	}
+	return `"` + value + `"`
+}
+
+func (d *agentCommandArgsDialog) Position() (row, col int) {
+	return d.CenterDialog(d.View())
+}
+
+func (d *agentCommandArgsDialog) View() string {
+	dialogWidth := d.ComputeDialogWidth(60, 40, 80)
+	contentWidth := d.ContentWidth(dialogWidth, 2)
+
+	content := NewContent(contentWidth).
+		AddTitle("/" + d.name).
+		AddSeparator().
+		AddSpace()
+	if d.command.Description != "" {
+		content.AddContent(styles.MutedStyle.Render(d.command.Description))
+		content.AddSpace()
+	}
+
+	for i, arg := range d.args {
+		label := styles.DialogContentStyle
+		if i == d.current {
+			label = label.Bold(true)
+		}
+		d.inputs[i].SetWidth(contentWidth)
+		content.AddContent(label.Render(arg))
+		content.AddContent(d.inputs[i].View())
+		if i < len(d.args)-1 {
+			content.AddSpace()
+		}
+	}
+
+	content.AddSpace()
+	content.AddHelpKeys("↑↓", "navigate", "enter", "next/send", "Esc", "cancel")
+
+	return styles.DialogStyle.
+		Padding(1, 2).
+		Width(dialogWidth).
+		Render(content.Build())
+}
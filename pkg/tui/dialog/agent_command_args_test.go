@@ -0,0 +1,57 @@
+package dialog
+
+import (
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/docker/cagent/pkg/config/types"
+	"github.com/docker/cagent/pkg/tui/messages"
+)
+
+func TestAgentCommandArgsDialogSendsCommand(t *testing.T) {
+	t.Parallel()
+
+	cmd := types.Command{Description: "Fix a file", Instruction: `Fix ${args[0]} on ${args[1] || "main"}`}
+	d := NewAgentCommandArgsDialog("fix", cmd, []string{"argument 1", "argument 2"}).(*agentCommandArgsDialog)
+	d.SetSize(100, 40)
+	assert.Contains(t, d.View(), "Fix a file")
+
+	d.Update(tea.PasteMsg{Content: "my file.go"})
+	_, next := d.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	assert.Nil(t, next, "enter moves to the next argument")
+	assert.Equal(t, 1, d.current)
+
+	_, send := d.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	require.NotNil(t, send)
+
+	var sent []messages.AgentCommandMsg
+	for _, msg := range collectMsgs(send) {
+		if m, ok := msg.(messages.AgentCommandMsg); ok {
+			sent = append(sent, m)
+		}
+	}
+	require.Len(t, sent, 1)
+	assert.Equal(t, `/fix "my file.go"`, sent[0].Command, "the trailing empty argument is left out")
+}
+
+func TestAgentCommandArgsDialogAllArguments(t *testing.T) {
+	t.Parallel()
+
+	cmd := types.Command{Instruction: "Review ${args}"}
+	d := NewAgentCommandArgsDialog("review", cmd, []string{"arguments"}).(*agentCommandArgsDialog)
+	d.Update(tea.PasteMsg{Content: "the parser changes"})
+
+	assert.Equal(t, "/review the parser changes", d.commandLine())
+}
+
+func TestQuoteArg(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "file.go", quoteArg("file.go"))
+	assert.Equal(t, `"two words"`, quoteArg("two words"))
+	assert.Equal(t, `'say "hi"'`, quoteArg(`say "hi"`))
+	assert.Equal(t, `""`, quoteArg(""))
+}
@@ -0,0 +1,278 @@
+package dialog
+
+import (
+	"fmt"
+	"strings"
+
+	"charm.land/bubbles/v2/key"
+	"charm.land/bubbles/v2/textinput"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+
+	"github.com/docker/cagent/pkg/runtime"
+	"github.com/docker/cagent/pkg/tui/components/toolcommon"
+	"github.com/docker/cagent/pkg/tui/core"
+	"github.com/docker/cagent/pkg/tui/core/layout"
+	"github.com/docker/cagent/pkg/tui/messages"
+	"github.com/docker/cagent/pkg/tui/styles"
+)
+
+// agentPickerMaxVisible is the number of agents shown at once.
+const agentPickerMaxVisible = 10
+
+// agentMatch is an agent of the picker that matches the search query.
+type agentMatch struct {
+	agent runtime.AgentDetails
+	// tool is the first tool whose name matches the query, when the query
+	// matches neither the name nor the description of the agent.
+	tool string
+}
+
+// agentPickerToggleHiddenKey shows or hides the hidden agents in the picker.
+var agentPickerToggleHiddenKey = key.NewBinding(key.WithKeys("tab"))
+
+// agentPickerDialog lets the user search the agents of the team by name,
+// description or tool name, and switch to one of them.
+type agentPickerDialog struct {
+	BaseDialog
+	textInput  textinput.Model
+	agents     []runtime.AgentDetails
+	current    string
+	filtered   []agentMatch
+	selected   int
+	offset     int
+	showHidden bool
+	keyMap     commandPaletteKeyMap
+}
+
+// NewAgentPickerDialog creates a dialog to search and switch between agents.
+func NewAgentPickerDialog(agents []runtime.AgentDetails, current string) Dialog {
+	ti := textinput.New()
+	ti.SetStyles(styles.DialogInputStyle)
+	ti.Placeholder = "Search by name, description or tool…"
+	ti.Focus()
+	ti.CharLimit = 100
+	ti.SetWidth(50)
+
+	d := &agentPickerDialog{
+		textInput: ti,
+		agents:    agents,
+		current:   current,
+		keyMap:    defaultCommandPaletteKeyMap(),
+	}
+	d.filterAgents()
+	d.selectAgent(current)
+	return d
+}
+
+func (d *agentPickerDialog) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+func (d *agentPickerDialog) Update(msg tea.Msg) (layout.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		cmd := d.SetSize(msg.Width, msg.Height)
+		return d, cmd
+
+	case tea.PasteMsg:
+		var cmd tea.Cmd
+		d.textInput, cmd = d.textInput.Update(msg)
+		d.refilter()
+		return d, cmd
+
+	case tea.KeyPressMsg:
+		if cmd := HandleQuit(msg); cmd != nil {
+			return d, cmd
+		}
+
+		switch {
+		case key.Matches(msg, d.keyMap.Escape):
+			return d, core.CmdHandler(CloseDialogMsg{})
+		case key.Matches(msg, d.keyMap.Up):
+			d.selected = max(0, d.selected-1)
+			return d, nil
+		case key.Matches(msg, d.keyMap.Down):
+			d.selected = max(0, min(len(d.filtered)-1, d.selected+1))
+			return d, nil
+		case key.Matches(msg, d.keyMap.Enter):
+			return d, d.switchToSelected()
+		case key.Matches(msg, agentPickerToggleHiddenKey):
+			d.showHidden = !d.showHidden
+			d.refilter()
+			return d, nil
+		default:
+			var cmd tea.Cmd
+			d.textInput, cmd = d.textInput.Update(msg)
+			d.refilter()
+			return d, cmd
+		}
+	}
+	return d, nil
+}
+
+// switchToSelected closes the dialog and switches to the selected agent.
+func (d *agentPickerDialog) switchToSelected() tea.Cmd {
+	if d.selected < 0 || d.selected >= len(d.filtered) {
+		return nil
+	}
+	name := d.filtered[d.selected].agent.Name
+	if name == d.current {
+		return core.CmdHandler(CloseDialogMsg{})
+	}
+	return tea.Sequence(
+		core.CmdHandler(CloseDialogMsg{}),
+		core.CmdHandler(messages.SwitchAgentMsg{AgentName: name}),
+	)
+}
+
+// refilter filters the agents again, keeping the selected agent selected
+// when it still matches.
+func (d *agentPickerDialog) refilter() {
+	var selectedName string
+	if d.selected >= 0 && d.selected < len(d.filtered) {
+		selectedName = d.filtered[d.selected].agent.Name
+	}
+	d.filterAgents()
+	d.selectAgent(selectedName)
+}
+
+// filterAgents keeps the agents whose name, description or tool names
+// contain the search query. Hidden agents are left out, unless they are
+// the current agent or the user asked to show them.
+func (d *agentPickerDialog) filterAgents() {
+	query := strings.ToLower(strings.TrimSpace(d.textInput.Value()))
+
+	d.filtered = d.filtered[:0]
+	for _, a := range d.agents {
+		if a.Hidden && !d.showHidden && a.Name != d.current {
+			continue
+		}
+		if query == "" ||
+			strings.Contains(strings.ToLower(a.Name), query) ||
+			strings.Contains(strings.ToLower(a.Description), query) {
+			d.filtered = append(d.filtered, agentMatch{agent: a})
+			continue
+		}
+		for _, tool := range a.ToolNames {
+			if strings.Contains(strings.ToLower(tool), query) {
+				d.filtered = append(d.filtered, agentMatch{agent: a, tool: tool})
+				break
+			}
+		}
+	}
+}
+
+// selectAgent selects the agent with the given name, or the first one.
+func (d *agentPickerDialog) selectAgent(name string) {
+	d.selected = 0
+	d.offset = 0
+	for i, m := range d.filtered {
+		if m.agent.Name == name {
+			d.selected = i
+			return
+		}
+	}
+}
+
+func (d *agentPickerDialog) Position() (row, col int) {
+	return d.CenterDialog(d.View())
+}
+
+func (d *agentPickerDialog) View() string {
+	dialogWidth := d.ComputeDialogWidth(70, 50, 90)
+	contentWidth := d.ContentWidth(dialogWidth, 2)
+	d.textInput.SetWidth(contentWidth)
+
+	// Keep the selection in the visible window.
+	if d.selected < d.offset {
+		d.offset = d.selected
+	}
+	if d.selected >= d.offset+agentPickerMaxVisible {
+		d.offset = d.selected - agentPickerMaxVisible + 1
+	}
+	d.offset = max(0, min(d.offset, len(d.filtered)-agentPickerMaxVisible))
+
+	content := NewContent(contentWidth).
+		AddTitle("Agents").
+		AddSpace().
+		AddContent(d.textInput.View()).
+		AddSeparator()
+
+	if len(d.filtered) == 0 {
+		content.AddContent(styles.MutedStyle.Render("No agents found"))
+	}
+	end := min(len(d.filtered), d.offset+agentPickerMaxVisible)
+	for i := d.offset; i < end; i++ {
+		content.AddContent(d.renderAgent(d.filtered[i], i == d.selected, contentWidth))
+	}
+	if len(d.filtered) > agentPickerMaxVisible {
+		content.AddContent(styles.MutedStyle.Render(fmt.Sprintf("%d-%d of %d", d.offset+1, end, len(d.filtered))))
+	}
+
+	content.AddSpace()
+	if d.hasHidden() {
+		hiddenHelp := "show hidden"
+		if d.showHidden {
+			hiddenHelp = "hide hidden"
+		}
+		content.AddHelpKeys("↑/↓", "navigate", "enter", "switch", "tab", hiddenHelp, "esc", "close")
+	} else {
+		content.AddHelpKeys("↑/↓", "navigate", "enter", "switch", "esc", "close")
+	}
+
+	return styles.DialogStyle.
+		Padding(1, 2).
+		Width(dialogWidth).
+		Render(content.Build())
+}
+
+// hasHidden returns true if some of the agents are hidden.
+func (d *agentPickerDialog) hasHidden() bool {
+	for _, a := range d.agents {
+		if a.Hidden {
+			return true
+		}
+	}
+	return false
+}
+
+func (d *agentPickerDialog) renderAgent(m agentMatch, selected bool, contentWidth int) string {
+	actionStyle := styles.PaletteUnselectedActionStyle
+	descStyle := styles.PaletteUnselectedDescStyle
+	if selected {
+		actionStyle = styles.PaletteSelectedActionStyle
+		descStyle = styles.PaletteSelectedDescStyle
+	}
+
+	label := " " + m.agent.Name
+	if m.agent.Name == d.current {
+		label += " (current)"
+	}
+	if m.agent.Hidden {
+		label += " (hidden)"
+	}
+	line := actionStyle.Render(label)
+
+	desc := strings.Join(strings.Fields(m.agent.Description), " ")
+	if m.tool != "" {
+		desc = "tool: " + m.tool
+	}
+	if desc != "" {
+		separator := " • "
+		if available := contentWidth - lipgloss.Width(line) - lipgloss.Width(separator); available > 0 {
+			line += descStyle.Render(separator + toolcommon.TruncateText(desc, available))
+		}
+	}
+
+	// Toolsets that failed to start are listed under the agent, so that
+	// missing tools are explained at a glance.
+	for _, ts := range m.agent.Toolsets {
+		if ts.Status != runtime.ToolsetFailed {
+			continue
+		}
+		failure := "   ✗ " + ts.Name + ": " + strings.Join(strings.Fields(ts.Error), " ")
+		line += "\n" + styles.ErrorStyle.Render(toolcommon.TruncateText(failure, contentWidth))
+	}
+	return line
+}
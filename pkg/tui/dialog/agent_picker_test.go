@@ -0,0 +1,132 @@
+package dialog
+
+import (
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/docker/cagent/pkg/runtime"
+)
+
+func TestAgentPickerDialog_Filter(t *testing.T) {
+	t.Parallel()
+
+	agents := []runtime.AgentDetails{
+		{Name: "root", Description: "Coordinates the team"},
+		{Name: "coder", Description: "Writes code", ToolNames: []string{"read_file", "shell"}},
+		{Name: "researcher", Description: "Searches the web", ToolNames: []string{"fetch"}},
+	}
+	d := NewAgentPickerDialog(agents, "coder").(*agentPickerDialog)
+	d.SetSize(100, 40)
+
+	// The current agent is selected
+	assert.Len(t, d.filtered, 3)
+	assert.Equal(t, "coder", d.filtered[d.selected].agent.Name)
+
+	tests := []struct {
+		query    string
+		expected []string
+		tool     string
+	}{
+		{"web", []string{"researcher"}, ""},
+		{"CODE", []string{"coder"}, ""},
+		{"shell", []string{"coder"}, "shell"},
+		{"nothing", nil, ""},
+	}
+	for _, tt := range tests {
+		d.textInput.SetValue(tt.query)
+		d.refilter()
+
+		var names []string
+		for _, m := range d.filtered {
+			names = append(names, m.agent.Name)
+		}
+		assert.Equal(t, tt.expected, names, tt.query)
+		if tt.tool != "" {
+			assert.Equal(t, tt.tool, d.filtered[0].tool)
+			assert.Contains(t, d.View(), "tool: shell")
+		}
+	}
+
+	// The selection follows the agent when the list changes
+	d.textInput.SetValue("e")
+	d.refilter()
+	d.selectAgent("researcher")
+	d.textInput.SetValue("es")
+	d.refilter()
+	assert.Equal(t, "researcher", d.filtered[d.selected].agent.Name)
+}
+
+func TestAgentPickerDialog_Switch(t *testing.T) {
+	t.Parallel()
+
+	agents := []runtime.AgentDetails{{Name: "root"}, {Name: "coder"}}
+	d := NewAgentPickerDialog(agents, "root")
+	d.SetSize(100, 40)
+
+	// Picking the current agent only closes the dialog
+	_, cmd := d.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	require.NotNil(t, cmd)
+	assert.Equal(t, CloseDialogMsg{}, cmd())
+
+	_, _ = d.Update(tea.KeyPressMsg{Code: tea.KeyDown})
+	_, cmd = d.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	require.NotNil(t, cmd)
+	assert.NotEqual(t, CloseDialogMsg{}, cmd())
+}
+
+func TestAgentPickerDialog_HiddenAgents(t *testing.T) {
+	t.Parallel()
+
+	agents := []runtime.AgentDetails{
+		{Name: "root"},
+		{Name: "helper", Hidden: true},
+		{Name: "coder"},
+	}
+	names := func(d *agentPickerDialog) []string {
+		var names []string
+		for _, m := range d.filtered {
+			names = append(names, m.agent.Name)
+		}
+		return names
+	}
+
+	d := NewAgentPickerDialog(agents, "root").(*agentPickerDialog)
+	d.SetSize(100, 40)
+	assert.Equal(t, []string{"root", "coder"}, names(d))
+	assert.Contains(t, d.View(), "show hidden")
+
+	// Tab reveals the hidden agents, and hides them again
+	_, _ = d.Update(tea.KeyPressMsg{Code: tea.KeyTab})
+	assert.Equal(t, []string{"root", "helper", "coder"}, names(d))
+	assert.Contains(t, d.View(), "helper (hidden)")
+	_, _ = d.Update(tea.KeyPressMsg{Code: tea.KeyTab})
+	assert.Equal(t, []string{"root", "coder"}, names(d))
+
+	// A hidden agent stays listed while it is the current agent
+	d = NewAgentPickerDialog(agents, "helper").(*agentPickerDialog)
+	assert.Equal(t, []string{"root", "helper", "coder"}, names(d))
+	assert.Equal(t, "helper", d.filtered[d.selected].agent.Name)
+}
+
+func TestAgentPickerDialog_FailedToolsets(t *testing.T) {
+	t.Parallel()
+
+	agents := []runtime.AgentDetails{{
+		Name: "root",
+		Toolsets: []runtime.ToolsetStatus{
+			{Name: "filesystem", Status: runtime.ToolsetLoaded},
+			{Name: "mcp(github)", Status: runtime.ToolsetFailed, Error: "exec: \"github-mcp\": not found"},
+			{Name: "mcp(slack)", Status: runtime.ToolsetLoading},
+		},
+	}}
+	d := NewAgentPickerDialog(agents, "root")
+	d.SetSize(100, 40)
+
+	view := d.View()
+	assert.Contains(t, view, `✗ mcp(github): exec: "github-mcp": not found`)
+	assert.NotContains(t, view, "filesystem")
+	assert.NotContains(t, view, "mcp(slack)")
+}
@@ -0,0 +1,144 @@
+package dialog
+
+import (
+	"fmt"
+
+	"charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/docker/cagent/pkg/tui/components/toolcommon"
+	"github.com/docker/cagent/pkg/tui/core"
+	"github.com/docker/cagent/pkg/tui/core/layout"
+	"github.com/docker/cagent/pkg/tui/messages"
+	"github.com/docker/cagent/pkg/tui/service/tuistate"
+	"github.com/docker/cagent/pkg/tui/styles"
+)
+
+// bookmarksDialogMaxVisible is the number of bookmarks shown at once.
+const bookmarksDialogMaxVisible = 12
+
+type bookmarksKeyMap struct {
+	Up     key.Binding
+	Down   key.Binding
+	Jump   key.Binding
+	Delete key.Binding
+	Close  key.Binding
+}
+
+// bookmarksDialog lists the bookmarks of the current session and jumps to
+// the selected one.
+type bookmarksDialog struct {
+	BaseDialog
+	// entries returns the current bookmarks. It is called on every render so
+	// the dialog follows deletions while it is open.
+	entries  func() []tuistate.Bookmark
+	selected int
+	offset   int
+	keyMap   bookmarksKeyMap
+}
+
+// NewBookmarksDialog creates a dialog listing the bookmarks returned by
+// entries. Jumps are sent as JumpToBookmarkMsg and deletions as
+// DeleteBookmarkMsg.
+func NewBookmarksDialog(entries func() []tuistate.Bookmark) Dialog {
+	return &bookmarksDialog{
+		entries: entries,
+		keyMap: bookmarksKeyMap{
+			Up:     key.NewBinding(key.WithKeys("up", "k")),
+			Down:   key.NewBinding(key.WithKeys("down", "j")),
+			Jump:   key.NewBinding(key.WithKeys("enter")),
+			Delete: key.NewBinding(key.WithKeys("d", "delete", "backspace")),
+			Close:  key.NewBinding(key.WithKeys("esc", "q")),
+		},
+	}
+}
+
+func (d *bookmarksDialog) Init() tea.Cmd {
+	return nil
+}
+
+func (d *bookmarksDialog) Update(msg tea.Msg) (layout.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		cmd := d.SetSize(msg.Width, msg.Height)
+		return d, cmd
+
+	case tea.KeyPressMsg:
+		return d, d.handleKey(msg)
+	}
+	return d, nil
+}
+
+func (d *bookmarksDialog) handleKey(msg tea.KeyPressMsg) tea.Cmd {
+	entries := d.entries()
+	d.selected = max(0, min(d.selected, len(entries)-1))
+
+	switch {
+	case key.Matches(msg, d.keyMap.Close):
+		return core.CmdHandler(CloseDialogMsg{})
+	case len(entries) == 0:
+		return nil
+	case key.Matches(msg, d.keyMap.Up):
+		d.selected = max(0, d.selected-1)
+	case key.Matches(msg, d.keyMap.Down):
+		d.selected = min(len(entries)-1, d.selected+1)
+	case key.Matches(msg, d.keyMap.Jump):
+		b := entries[d.selected]
+		return tea.Sequence(
+			core.CmdHandler(CloseDialogMsg{}),
+			core.CmdHandler(messages.JumpToBookmarkMsg{MessageIndex: b.MessageIndex, LineOffset: b.LineOffset}),
+		)
+	case key.Matches(msg, d.keyMap.Delete):
+		return core.CmdHandler(messages.DeleteBookmarkMsg{Label: entries[d.selected].Label})
+	}
+	return nil
+}
+
+func (d *bookmarksDialog) Position() (row, col int) {
+	return d.CenterDialog(d.View())
+}
+
+func (d *bookmarksDialog) View() string {
+	dialogWidth := d.ComputeDialogWidth(60, 40, 80)
+	contentWidth := d.ContentWidth(dialogWidth, 2)
+	entries := d.entries()
+	d.selected = max(0, min(d.selected, len(entries)-1))
+
+	// Keep the selection in the visible window.
+	if d.selected < d.offset {
+		d.offset = d.selected
+	}
+	if d.selected >= d.offset+bookmarksDialogMaxVisible {
+		d.offset = d.selected - bookmarksDialogMaxVisible + 1
+	}
+	d.offset = max(0, min(d.offset, len(entries)-bookmarksDialogMaxVisible))
+
+	content := NewContent(contentWidth).
+		AddTitle(fmt.Sprintf("Bookmarks (%d)", len(entries))).
+		AddSeparator().
+		AddSpace()
+
+	if len(entries) == 0 {
+		content.AddContent(styles.MutedStyle.Render("No bookmarks"))
+	}
+	end := min(len(entries), d.offset+bookmarksDialogMaxVisible)
+	for i := d.offset; i < end; i++ {
+		line := toolcommon.TruncateText(entries[i].Label, contentWidth)
+		if i == d.selected {
+			content.AddContent(styles.PaletteSelectedActionStyle.Render(line))
+		} else {
+			content.AddContent(styles.PaletteUnselectedActionStyle.Render(line))
+		}
+	}
+	if len(entries) > bookmarksDialogMaxVisible {
+		content.AddContent(styles.MutedStyle.Render(fmt.Sprintf("%d-%d of %d", d.offset+1, end, len(entries))))
+	}
+
+	content.AddSpace()
+	content.AddHelpKeys("↑↓", "navigate", "enter", "jump", "d", "delete", "Esc", "close")
+
+	return styles.DialogStyle.
+		Padding(1, 2).
+		Width(dialogWidth).
+		Render(content.Build())
+}
@@ -0,0 +1,46 @@
+package dialog
+
+import (
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/docker/cagent/pkg/tui/messages"
+	"github.com/docker/cagent/pkg/tui/service/tuistate"
+)
+
+func TestBookmarksDialog(t *testing.T) {
+	t.Parallel()
+
+	bookmarks := []tuistate.Bookmark{
+		{Label: "setup", MessageIndex: 1},
+		{Label: "the fix", MessageIndex: 7, LineOffset: 12},
+	}
+	d := NewBookmarksDialog(func() []tuistate.Bookmark { return bookmarks })
+	d.SetSize(100, 40)
+
+	view := d.View()
+	assert.Contains(t, view, "Bookmarks (2)")
+	assert.Contains(t, view, "setup")
+	assert.Contains(t, view, "the fix")
+
+	_, _ = d.Update(tea.KeyPressMsg{Code: tea.KeyDown})
+	_, cmd := d.Update(tea.KeyPressMsg{Code: 'd', Text: "d"})
+	require.NotNil(t, cmd)
+	assert.Equal(t, messages.DeleteBookmarkMsg{Label: "the fix"}, cmd())
+
+	_, cmd = d.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	require.NotNil(t, cmd)
+
+	// The list follows the bookmarks
+	bookmarks = nil
+	assert.Contains(t, d.View(), "No bookmarks")
+	_, cmd = d.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	assert.Nil(t, cmd)
+
+	_, cmd = d.Update(tea.KeyPressMsg{Code: tea.KeyEscape})
+	require.NotNil(t, cmd)
+	assert.Equal(t, CloseDialogMsg{}, cmd())
+}
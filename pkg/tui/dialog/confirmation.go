@@ -0,0 +1,104 @@
+package dialog
+
+import (
+	"charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/docker/cagent/pkg/tui/core"
+	"github.com/docker/cagent/pkg/tui/core/layout"
+	"github.com/docker/cagent/pkg/tui/styles"
+)
+
+type confirmationKeyMap struct {
+	Yes key.Binding
+	No  key.Binding
+	Esc key.Binding
+}
+
+func defaultConfirmationKeyMap() confirmationKeyMap {
+	return confirmationKeyMap{
+		Yes: key.NewBinding(
+			key.WithKeys("y", "Y"),
+			key.WithHelp("Y", "yes"),
+		),
+		No: key.NewBinding(
+			key.WithKeys("n", "N"),
+			key.WithHelp("N", "no"),
+		),
+		Esc: key.NewBinding(
+			key.WithKeys("esc"),
+			key.WithHelp("Esc", "cancel"),
+		),
+	}
+}
+
+// confirmationDialog asks the user to confirm a destructive action.
+type confirmationDialog struct {
+	BaseDialog
+	keyMap    confirmationKeyMap
+	title     string
+	question  string
+	onConfirm tea.Msg
+}
+
+// NewConfirmationDialog creates a yes/no dialog that sends onConfirm once the user accepts.
+func NewConfirmationDialog(title, question string, onConfirm tea.Msg) Dialog {
+	return &confirmationDialog{
+		keyMap:    defaultConfirmationKeyMap(),
+		title:     title,
+		question:  question,
+		onConfirm: onConfirm,
+	}
+}
+
+// Init initializes the confirmation dialog.
+func (d *confirmationDialog) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles messages for the confirmation dialog.
+func (d *confirmationDialog) Update(msg tea.Msg) (layout.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		cmd := d.SetSize(msg.Width, msg.Height)
+		return d, cmd
+
+	case tea.KeyPressMsg:
+		switch {
+		case key.Matches(msg, d.keyMap.Yes):
+			return d, tea.Sequence(
+				core.CmdHandler(CloseDialogMsg{}),
+				core.CmdHandler(d.onConfirm),
+			)
+		case key.Matches(msg, d.keyMap.No), key.Matches(msg, d.keyMap.Esc):
+			return d, core.CmdHandler(CloseDialogMsg{})
+		}
+	}
+
+	return d, nil
+}
+
+// Position returns the dialog position (centered).
+func (d *confirmationDialog) Position() (row, col int) {
+	return d.CenterDialog(d.View())
+}
+
+// View renders the confirmation dialog.
+func (d *confirmationDialog) View() string {
+	dialogWidth := d.ComputeDialogWidth(50, 30, 60)
+	contentWidth := d.ContentWidth(dialogWidth, 2)
+
+	content := NewContent(contentWidth).
+		AddTitle(d.title).
+		AddSeparator().
+		AddSpace().
+		AddQuestion(d.question).
+		AddSpace().
+		AddHelpKeys("Y", "yes", "N", "no").
+		Build()
+
+	return styles.DialogStyle.
+		Padding(1, 2).
+		Width(dialogWidth).
+		Render(content)
+}
@@ -2,9 +2,14 @@ package dialog
 
 import (
 	"cmp"
+	"encoding/csv"
 	"fmt"
+	"os"
+	"path/filepath"
 	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	"charm.land/bubbles/v2/key"
 	tea "charm.land/bubbletea/v2"
@@ -15,8 +20,10 @@ import (
 	"github.com/docker/cagent/pkg/session"
 	"github.com/docker/cagent/pkg/tui/components/notification"
 	"github.com/docker/cagent/pkg/tui/components/scrollview"
+	"github.com/docker/cagent/pkg/tui/components/toolcommon"
 	"github.com/docker/cagent/pkg/tui/core"
 	"github.com/docker/cagent/pkg/tui/core/layout"
+	"github.com/docker/cagent/pkg/tui/messages"
 	"github.com/docker/cagent/pkg/tui/styles"
 )
 
@@ -26,22 +33,40 @@ type costDialog struct {
 	session    *session.Session
 	keyMap     costDialogKeyMap
 	scrollview *scrollview.Model
+	// separateSubSessions breaks the sub-session usage out of the parent's
+	// messages, as its own section.
+	separateSubSessions bool
+	// heatmap shows the messages as bars proportional to their tokens
+	// instead of their cost and token counts.
+	heatmap bool
+	// history is the saved sessions the cost of the session is compared with.
+	history []session.Summary
 }
 
 type costDialogKeyMap struct {
-	Close, Copy key.Binding
+	Close, Copy, CopyCSV, SaveCSV, SubSessions, Heatmap key.Binding
 }
 
-func NewCostDialog(sess *session.Session) Dialog {
+// NewCostDialog creates a dialog with the cost breakdown of the session. The
+// usage of the sub-sessions is shown within the parent's messages, where the
+// task was transferred, or as its own section when separateSubSessions is set.
+// The cost of the session is compared with the average of the history.
+func NewCostDialog(sess *session.Session, separateSubSessions bool, history []session.Summary) Dialog {
 	return &costDialog{
-		session: sess,
+		session:             sess,
+		separateSubSessions: separateSubSessions,
+		history:             history,
 		scrollview: scrollview.New(
 			scrollview.WithKeyMap(scrollview.ReadOnlyScrollKeyMap()),
 			scrollview.WithReserveScrollbarSpace(true),
 		),
 		keyMap: costDialogKeyMap{
-			Close: key.NewBinding(key.WithKeys("esc", "enter", "q"), key.WithHelp("Esc", "close")),
-			Copy:  key.NewBinding(key.WithKeys("c"), key.WithHelp("c", "copy")),
+			Close:       key.NewBinding(key.WithKeys("esc", "enter", "q"), key.WithHelp("Esc", "close")),
+			Copy:        key.NewBinding(key.WithKeys("c"), key.WithHelp("c", "copy")),
+			CopyCSV:     key.NewBinding(key.WithKeys("v"), key.WithHelp("v", "copy csv")),
+			SaveCSV:     key.NewBinding(key.WithKeys("s"), key.WithHelp("s", "save csv")),
+			SubSessions: key.NewBinding(key.WithKeys("b"), key.WithHelp("b", "sub-sessions")),
+			Heatmap:     key.NewBinding(key.WithKeys("h"), key.WithHelp("h", "heatmap")),
 		},
 	}
 }
@@ -67,6 +92,17 @@ func (d *costDialog) Update(msg tea.Msg) (layout.Model, tea.Cmd) {
 		case key.Matches(msg, d.keyMap.Copy):
 			_ = clipboard.WriteAll(d.renderPlainText())
 			return d, notification.SuccessCmd("Cost details copied to clipboard.")
+		case key.Matches(msg, d.keyMap.CopyCSV):
+			_ = clipboard.WriteAll(d.renderCSV())
+			return d, notification.SuccessCmd("Cost details copied to clipboard as CSV.")
+		case key.Matches(msg, d.keyMap.SaveCSV):
+			return d, d.saveCSV()
+		case key.Matches(msg, d.keyMap.SubSessions):
+			d.separateSubSessions = !d.separateSubSessions
+			return d, core.CmdHandler(messages.ToggleSeparateSubSessionCostsMsg{})
+		case key.Matches(msg, d.keyMap.Heatmap):
+			d.heatmap = !d.heatmap
+			return d, nil
 		}
 	}
 	return d, nil
@@ -115,18 +151,34 @@ func (u *totalUsage) isSubSessionMarker() bool {
 	return u.marker
 }
 
+// readingWordsPerMinute is the reading speed used to estimate how long the
+// generated words take to read.
+const readingWordsPerMinute = 200
+
 // costData holds aggregated cost data for display.
 type costData struct {
-	total             totalUsage
-	models            []totalUsage
-	messages          []totalUsage
-	hasPerMessageData bool
+	total    totalUsage
+	models   []totalUsage
+	messages []totalUsage
+	// tasks holds the usage of each sub-session, nested ones included.
+	tasks []totalUsage
+	// subSessionMessages holds the usage records of the sub-sessions when
+	// they are separated from the parent's messages, and subSessions their
+	// total.
+	subSessionMessages []totalUsage
+	subSessions        totalUsage
+	hasPerMessageData  bool
+	// words is the number of words in the assistant responses.
+	words int64
 }
 
 func (d *costDialog) gatherCostData() costData {
 	var data costData
 	modelMap := make(map[string]*totalUsage)
 	msgCounter := 0 // sequential counter across parent and sub-sessions
+	// records is where the usage records go: the parent's messages, or the
+	// sub-session section while walking a separated sub-session.
+	records := &data.messages
 
 	// Helper to add a usage record to the aggregated data
 	addRecord := func(agentName, model string, cost float64, usage *chat.Usage) {
@@ -146,7 +198,7 @@ func (d *costDialog) gatherCostData() costData {
 		if agentName != "" {
 			msgLabel = fmt.Sprintf("#%d [%s]", msgCounter, agentName)
 		}
-		data.messages = append(data.messages, totalUsage{
+		*records = append(*records, totalUsage{
 			label: msgLabel,
 			cost:  cost,
 			Usage: *usage,
@@ -158,7 +210,7 @@ func (d *costDialog) gatherCostData() costData {
 		data.hasPerMessageData = true
 		data.total.cost += cost
 
-		data.messages = append(data.messages, totalUsage{
+		*records = append(*records, totalUsage{
 			label: "compaction",
 			cost:  cost,
 		})
@@ -166,29 +218,50 @@ func (d *costDialog) gatherCostData() costData {
 
 	// addSubSessionMarker adds a visual separator for a sub-session boundary.
 	addSubSessionMarker := func(label string) {
-		data.messages = append(data.messages, totalUsage{label: label, marker: true})
+		*records = append(*records, totalUsage{label: label, marker: true})
 	}
 
 	// walkSession recursively walks session items, inserting sub-session
 	// boundary markers so the "By Message" section shows clear grouping.
-	var walkSession func(sess *session.Session)
-	walkSession = func(sess *session.Session) {
-		for _, item := range sess.Messages {
+	var walkSession func(sess *session.Session, depth int)
+	walkSession = func(sess *session.Session, depth int) {
+		for _, item := range sess.CostItems() {
 			switch {
 			case item.IsMessage():
 				msg := item.Message
 				if msg.Message.Role != chat.MessageRoleSystem && msg.Message.Usage != nil {
 					addRecord(msg.AgentName, msg.Message.Model, msg.Message.Cost, msg.Message.Usage)
 				}
+				if msg.Message.Role == chat.MessageRoleAssistant {
+					data.words += int64(len(strings.Fields(msg.Message.Content)))
+				}
 			case item.IsSubSession():
+				separated := d.separateSubSessions && depth == 0
+				if separated {
+					records = &data.subSessionMessages
+				}
 				addSubSessionMarker("── sub-session start ──")
-				walkSession(item.SubSession)
+				taskIndex, start := len(data.tasks), len(*records)
+				data.tasks = append(data.tasks, totalUsage{label: fmt.Sprintf("task #%d", taskIndex+1)})
+				walkSession(item.SubSession, depth+1)
+				for _, m := range (*records)[start:] {
+					if !m.isSubSessionMarker() {
+						data.tasks[taskIndex].add(m.cost, &m.Usage)
+					}
+				}
+				if separated {
+					task := data.tasks[taskIndex]
+					data.subSessions.add(task.cost, &task.Usage)
+				}
 				subCost := item.SubSession.TotalCost()
 				if subCost > 0 {
 					addSubSessionMarker(fmt.Sprintf("── sub-session end (%s) ──", formatCost(subCost)))
 				} else {
 					addSubSessionMarker("── sub-session end ──")
 				}
+				if separated {
+					records = &data.messages
+				}
 			}
 			if item.Summary != "" && item.Cost > 0 {
 				addCompactionCost(item.Cost)
@@ -197,7 +270,7 @@ func (d *costDialog) gatherCostData() costData {
 	}
 
 	// Walk session items (local mode) to preserve sub-session structure.
-	walkSession(d.session)
+	walkSession(d.session, 0)
 
 	// Fall back to remote mode if no per-message data was found.
 	if !data.hasPerMessageData {
@@ -241,8 +314,23 @@ func (d *costDialog) renderContent(contentWidth, maxHeight int) string {
 		accentStyle().Render(formatCost(data.total.cost)),
 		d.renderInputLine(data.total, true),
 		fmt.Sprintf("%s %s", labelStyle().Render("output:"), valueStyle().Render(formatTokenCount(data.total.OutputTokens))),
-		"",
 	}
+	if data.words > 0 {
+		lines = append(lines, fmt.Sprintf("%s %s", labelStyle().Render("words:"), valueStyle().Render(formatWords(data.words))))
+	}
+	if len(data.subSessionMessages) > 0 {
+		lines = append(lines, fmt.Sprintf("%s %s", labelStyle().Render("sub-sessions:"), valueStyle().Render(formatCost(data.subSessions.cost))))
+	}
+	lines = append(lines, "")
+
+	// History Section
+	lines = append(lines, sectionStyle().Render("History"), "")
+	if comparison, average := d.historyComparison(); comparison != "" {
+		lines = append(lines, valueStyle().Render(comparison), styles.MutedStyle.Render(average))
+	} else {
+		lines = append(lines, styles.MutedStyle.Render(noHistoryText))
+	}
+	lines = append(lines, "")
 
 	// By Model Section
 	if len(data.models) > 0 {
@@ -253,25 +341,75 @@ func (d *costDialog) renderContent(contentWidth, maxHeight int) string {
 		lines = append(lines, "")
 	}
 
-	// By Message Section
-	if len(data.messages) > 0 {
-		lines = append(lines, sectionStyle().Render("By Message"), "")
-		for _, m := range data.messages {
-			if m.isSubSessionMarker() {
+	// The bars of both sections share the same scale.
+	heatmap := newTokenHeatmap(contentWidth, data.messages, data.subSessionMessages)
+	addRecords := func(title string, records []totalUsage) {
+		lines = append(lines, sectionStyle().Render(title), "")
+		if d.heatmap {
+			lines = append(lines, heatmap.legend(), "")
+		}
+		for _, m := range records {
+			switch {
+			case m.isSubSessionMarker():
 				lines = append(lines, styles.MutedStyle.Render(m.label))
-			} else {
+			case d.heatmap:
+				lines = append(lines, heatmap.renderLine(m))
+			default:
 				lines = append(lines, d.renderUsageLine(m))
 			}
 		}
 		lines = append(lines, "")
+	}
+
+	// By Message Section
+	if len(data.messages) > 0 {
+		addRecords("By Message", data.messages)
 	} else if !data.hasPerMessageData && data.total.cost > 0 {
 		lines = append(lines, styles.MutedStyle.Render("Per-message breakdown not available for this session."), "")
 	}
 
+	// By Sub-session Section
+	if len(data.subSessionMessages) > 0 {
+		addRecords("By Sub-session", data.subSessionMessages)
+	}
+
 	// Apply scrolling
 	return d.applyScrolling(lines, contentWidth, maxHeight)
 }
 
+const noHistoryText = "No saved sessions to compare with yet."
+
+// historyComparison describes how the cost of the session compares with the
+// average cost of the saved sessions, of the ones started in the same working
+// directory when there are any. Only the sessions' own costs are compared,
+// since the saved ones don't include their sub-sessions'. It returns empty
+// strings when there's no saved session to compare with.
+func (d *costDialog) historyComparison() (comparison, average string) {
+	var avg float64
+	var count int
+	scope := "your average"
+	if dir := d.session.WorkingDir; dir != "" {
+		avg, count = session.AverageCost(d.history, d.session.ID, dir)
+		scope = "your average in this directory"
+	}
+	if count == 0 {
+		avg, count = session.AverageCost(d.history, d.session.ID, "")
+		scope = "your average"
+	}
+	if count == 0 {
+		return "", ""
+	}
+
+	cost := d.session.OwnCost()
+	sessions := "sessions"
+	if count == 1 {
+		sessions = "session"
+	}
+	comparison = fmt.Sprintf("this session: %s (%.1f× %s)", formatCost(cost), cost/avg, scope)
+	average = fmt.Sprintf("average of %s over %d saved %s", formatCost(avg), count, sessions)
+	return comparison, average
+}
+
 func (d *costDialog) renderInputLine(u totalUsage, showBreakdown bool) string {
 	line := fmt.Sprintf("%s %s", labelStyle().Render("input:"), valueStyle().Render(formatTokenCount(u.totalInput())))
 	if showBreakdown && (u.CachedInputTokens > 0 || u.CacheWriteTokens > 0) {
@@ -293,6 +431,62 @@ func (d *costDialog) renderUsageLine(u totalUsage) string {
 		accentStyle().Render(u.label))
 }
 
+// tokenHeatmap renders the usage records as bars proportional to their
+// tokens, the input in one color and the output in another, so that the
+// messages eating the context stand out.
+type tokenHeatmap struct {
+	labelWidth int
+	barWidth   int
+	maxTokens  int64
+}
+
+// heatmapCountWidth is the width of the token count after the bars.
+const heatmapCountWidth = 7
+
+func newTokenHeatmap(contentWidth int, recordSets ...[]totalUsage) tokenHeatmap {
+	h := tokenHeatmap{}
+	for _, records := range recordSets {
+		for _, m := range records {
+			if m.isSubSessionMarker() {
+				continue
+			}
+			h.labelWidth = max(h.labelWidth, lipgloss.Width(m.label))
+			h.maxTokens = max(h.maxTokens, m.totalInput()+m.OutputTokens)
+		}
+	}
+	h.labelWidth = min(h.labelWidth, contentWidth/3)
+	h.barWidth = max(1, contentWidth-h.labelWidth-heatmapCountWidth-2)
+	return h
+}
+
+func (h tokenHeatmap) legend() string {
+	return styles.InfoStyle.Render("█") + valueStyle().Render(" input  ") +
+		styles.SuccessStyle.Render("█") + valueStyle().Render(" output")
+}
+
+func (h tokenHeatmap) renderLine(u totalUsage) string {
+	label := toolcommon.TruncateText(u.label, h.labelWidth)
+	label += strings.Repeat(" ", max(0, h.labelWidth-lipgloss.Width(label)))
+
+	input := h.cells(u.totalInput())
+	output := min(h.cells(u.OutputTokens), h.barWidth-input)
+	bar := styles.InfoStyle.Render(strings.Repeat("█", input)) +
+		styles.SuccessStyle.Render(strings.Repeat("█", output)) +
+		strings.Repeat(" ", max(0, h.barWidth-input-output))
+
+	return fmt.Sprintf("%s %s %s", accentStyle().Render(label), bar,
+		valueStyle().Render(formatTokenCount(u.totalInput()+u.OutputTokens)))
+}
+
+// cells returns the width of the bar for the given number of tokens, at
+// least one cell for any token so that small messages still show.
+func (h tokenHeatmap) cells(tokens int64) int {
+	if tokens <= 0 || h.maxTokens <= 0 {
+		return 0
+	}
+	return max(1, int(tokens*int64(h.barWidth)/h.maxTokens))
+}
+
 func (d *costDialog) applyScrolling(allLines []string, contentWidth, maxHeight int) string {
 	const headerLines = 3 // title + separator + space
 	const footerLines = 2 // space + help
@@ -312,7 +506,7 @@ func (d *costDialog) applyScrolling(allLines []string, contentWidth, maxHeight i
 
 	scrollableContent := d.scrollview.View()
 	parts := append(allLines[:headerLines], scrollableContent)
-	parts = append(parts, "", RenderHelpKeys(regionWidth, "↑↓", "scroll", "c", "copy", "Esc", "close"))
+	parts = append(parts, "", RenderHelpKeys(regionWidth, "↑↓", "scroll", "c", "copy", "v", "copy csv", "s", "save csv", "b", "sub-sessions", "h", "heatmap", "Esc", "close"))
 	return lipgloss.JoinVertical(lipgloss.Left, parts...)
 }
 
@@ -330,7 +524,20 @@ func (d *costDialog) renderPlainText() string {
 	}
 
 	lines = append(lines, "Session Cost Details", "", "Total", formatCost(data.total.cost),
-		inputLine, fmt.Sprintf("output: %s", formatTokenCount(data.total.OutputTokens)), "")
+		inputLine, fmt.Sprintf("output: %s", formatTokenCount(data.total.OutputTokens)))
+	if data.words > 0 {
+		lines = append(lines, fmt.Sprintf("words: %s", formatWords(data.words)))
+	}
+	if len(data.subSessionMessages) > 0 {
+		lines = append(lines, fmt.Sprintf("sub-sessions: %s", formatCost(data.subSessions.cost)))
+	}
+	lines = append(lines, "", "History")
+	if comparison, average := d.historyComparison(); comparison != "" {
+		lines = append(lines, comparison, average)
+	} else {
+		lines = append(lines, noHistoryText)
+	}
+	lines = append(lines, "")
 
 	if len(data.models) > 0 {
 		lines = append(lines, "By Model")
@@ -341,9 +548,15 @@ func (d *costDialog) renderPlainText() string {
 		lines = append(lines, "")
 	}
 
-	if len(data.messages) > 0 {
-		lines = append(lines, "By Message")
-		for _, m := range data.messages {
+	addRecords := func(title string, records []totalUsage) {
+		if len(records) == 0 {
+			return
+		}
+		if lines[len(lines)-1] != "" {
+			lines = append(lines, "")
+		}
+		lines = append(lines, title)
+		for _, m := range records {
 			if m.isSubSessionMarker() {
 				lines = append(lines, m.label)
 			} else {
@@ -352,10 +565,67 @@ func (d *costDialog) renderPlainText() string {
 			}
 		}
 	}
+	addRecords("By Message", data.messages)
+	addRecords("By Sub-session", data.subSessionMessages)
 
 	return strings.Join(lines, "\n")
 }
 
+// renderCSV returns the total, per-model, per-task and per-message usage
+// as CSV, with a header row.
+func (d *costDialog) renderCSV() string {
+	data := d.gatherCostData()
+
+	var sb strings.Builder
+	w := csv.NewWriter(&sb)
+	_ = w.Write([]string{"scope", "label", "cost", "input_tokens", "output_tokens", "cached_input_tokens", "cache_write_tokens"})
+	writeRow := func(scope string, u totalUsage) {
+		_ = w.Write([]string{
+			scope,
+			u.label,
+			strconv.FormatFloat(u.cost, 'f', 6, 64),
+			strconv.FormatInt(u.InputTokens, 10),
+			strconv.FormatInt(u.OutputTokens, 10),
+			strconv.FormatInt(u.CachedInputTokens, 10),
+			strconv.FormatInt(u.CacheWriteTokens, 10),
+		})
+	}
+
+	total := data.total
+	total.label = cmp.Or(d.session.Title, "session")
+	writeRow("total", total)
+	for _, m := range data.models {
+		writeRow("model", m)
+	}
+	for _, t := range data.tasks {
+		writeRow("task", t)
+	}
+	for _, m := range append(data.messages, data.subSessionMessages...) {
+		if !m.isSubSessionMarker() {
+			writeRow("message", m)
+		}
+	}
+	w.Flush()
+	return sb.String()
+}
+
+// saveCSV writes the CSV of the cost details to a file of the working
+// directory.
+func (d *costDialog) saveCSV() tea.Cmd {
+	content := d.renderCSV()
+	filename := fmt.Sprintf("cagent-cost-%s.csv", time.Now().Format("2006-01-02-150405"))
+	return func() tea.Msg {
+		if err := os.WriteFile(filename, []byte(content), 0o644); err != nil {
+			return notification.ShowMsg{Text: fmt.Sprintf("Failed to save cost details: %v", err), Type: notification.TypeError}
+		}
+		path, err := filepath.Abs(filename)
+		if err != nil {
+			path = filename
+		}
+		return notification.ShowMsg{Text: "Cost details saved to " + path, Type: notification.TypeSuccess}
+	}
+}
+
 // Style getters - use functions to pick up theme changes dynamically
 func sectionStyle() lipgloss.Style {
 	return lipgloss.NewStyle().Bold(true).Foreground(styles.TextSecondary)
@@ -404,6 +674,17 @@ func formatTokenCount(count int64) string {
 	}
 }
 
+// formatWords formats a number of words along with the estimated time it
+// takes to read them.
+func formatWords(words int64) string {
+	minutes := (words + readingWordsPerMinute - 1) / readingWordsPerMinute
+	readingTime := fmt.Sprintf("%d min", minutes)
+	if minutes >= 60 {
+		readingTime = fmt.Sprintf("%dh %02dm", minutes/60, minutes%60)
+	}
+	return fmt.Sprintf("%s (~%s read)", formatTokenCount(words), readingTime)
+}
+
 func padRight(s string) string {
 	const width = 8
 	if len(s) >= width {
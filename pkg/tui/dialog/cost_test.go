@@ -1,14 +1,18 @@
 package dialog
 
 import (
+	"encoding/csv"
+	"strings"
 	"testing"
 
+	tea "charm.land/bubbletea/v2"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/docker/cagent/pkg/chat"
 	"github.com/docker/cagent/pkg/session"
 	"github.com/docker/cagent/pkg/tools"
+	"github.com/docker/cagent/pkg/tui/messages"
 )
 
 func TestNewCostDialog(t *testing.T) {
@@ -16,7 +20,7 @@ func TestNewCostDialog(t *testing.T) {
 
 	sess := session.New()
 
-	dialog := NewCostDialog(sess)
+	dialog := NewCostDialog(sess, false, nil)
 
 	require.NotNil(t, dialog)
 }
@@ -56,7 +60,7 @@ func TestCostDialogView(t *testing.T) {
 		},
 	})
 
-	dialog := NewCostDialog(sess)
+	dialog := NewCostDialog(sess, false, nil)
 	// Set a large enough window size
 	dialog.SetSize(100, 50)
 	view := dialog.View()
@@ -92,7 +96,7 @@ func TestCostDialogWithToolCalls(t *testing.T) {
 		},
 	})
 
-	dialog := NewCostDialog(sess)
+	dialog := NewCostDialog(sess, false, nil)
 	// Set a large enough window size
 	dialog.SetSize(100, 50)
 	view := dialog.View()
@@ -107,7 +111,7 @@ func TestCostDialogEmptySession(t *testing.T) {
 
 	sess := session.New()
 
-	dialog := NewCostDialog(sess)
+	dialog := NewCostDialog(sess, false, nil)
 	// Set a large enough window size
 	dialog.SetSize(100, 50)
 	view := dialog.View()
@@ -181,7 +185,7 @@ func TestCostDialogCompactionCostRendersInView(t *testing.T) {
 		Cost:    0.002,
 	})
 
-	dialog := NewCostDialog(sess)
+	dialog := NewCostDialog(sess, false, nil)
 	dialog.SetSize(100, 50)
 	view := dialog.View()
 
@@ -258,6 +262,103 @@ func TestCostDialogWithSubSessions(t *testing.T) {
 	assert.Contains(t, data.messages[4].label, "$0.0030") // sub-session total cost
 }
 
+func TestCostDialogSeparateSubSessions(t *testing.T) {
+	t.Parallel()
+
+	sess := session.New()
+	sess.AddMessage(&session.Message{
+		AgentName: "root",
+		Message: chat.Message{
+			Role:  chat.MessageRoleAssistant,
+			Model: "gpt-4o",
+			Usage: &chat.Usage{InputTokens: 1000, OutputTokens: 200},
+			Cost:  0.005,
+		},
+	})
+	subSess := session.New()
+	subSess.AddMessage(&session.Message{
+		AgentName: "sub-agent",
+		Message: chat.Message{
+			Role:  chat.MessageRoleAssistant,
+			Model: "gpt-4o-mini",
+			Usage: &chat.Usage{InputTokens: 500, OutputTokens: 100},
+			Cost:  0.003,
+		},
+	})
+	sess.AddSubSession(subSess)
+	sess.AddMessage(&session.Message{
+		AgentName: "root",
+		Message: chat.Message{
+			Role:  chat.MessageRoleAssistant,
+			Model: "gpt-4o",
+			Usage: &chat.Usage{InputTokens: 1500, OutputTokens: 50},
+			Cost:  0.002,
+		},
+	})
+
+	data := (&costDialog{session: sess, separateSubSessions: true}).gatherCostData()
+
+	assert.InDelta(t, 0.010, data.total.cost, 0.0001)
+	require.Len(t, data.messages, 2)
+	assert.Equal(t, "#1 [root]", data.messages[0].label)
+	assert.Equal(t, "#3 [root]", data.messages[1].label)
+
+	require.Len(t, data.subSessionMessages, 3)
+	assert.True(t, data.subSessionMessages[0].isSubSessionMarker())
+	assert.Equal(t, "#2 [sub-agent]", data.subSessionMessages[1].label)
+	assert.True(t, data.subSessionMessages[2].isSubSessionMarker())
+	assert.InDelta(t, 0.003, data.subSessions.cost, 0.0001)
+	require.Len(t, data.tasks, 1)
+	assert.InDelta(t, 0.003, data.tasks[0].cost, 0.0001)
+
+	d := NewCostDialog(sess, true, nil)
+	d.SetSize(100, 50)
+	view := d.View()
+	assert.Contains(t, view, "By Sub-session")
+	assert.Contains(t, view, "sub-sessions:")
+
+	// The toggle switches back to the sub-sessions within the parent's
+	// messages, and asks to persist the choice.
+	_, cmd := d.Update(tea.KeyPressMsg{Code: 'b', Text: "b"})
+	require.NotNil(t, cmd)
+	assert.IsType(t, messages.ToggleSeparateSubSessionCostsMsg{}, cmd())
+	assert.NotContains(t, d.View(), "By Sub-session")
+}
+
+func TestCostDialogHeatmap(t *testing.T) {
+	t.Parallel()
+
+	sess := session.New()
+	for _, tokens := range []int64{1000, 40000} {
+		sess.AddMessage(&session.Message{
+			AgentName: "root",
+			Message: chat.Message{
+				Role:  chat.MessageRoleAssistant,
+				Model: "gpt-4o",
+				Usage: &chat.Usage{InputTokens: tokens, OutputTokens: 200},
+				Cost:  0.001,
+			},
+		})
+	}
+
+	d := NewCostDialog(sess, false, nil)
+	d.SetSize(100, 50)
+	assert.NotContains(t, d.View(), "█")
+
+	_, cmd := d.Update(tea.KeyPressMsg{Code: 'h', Text: "h"})
+	assert.Nil(t, cmd)
+	view := d.View()
+	assert.Contains(t, view, "█")
+	assert.Contains(t, view, " input")
+	assert.Contains(t, view, "40.2K")
+
+	data := d.(*costDialog).gatherCostData()
+	h := newTokenHeatmap(60, data.messages)
+	assert.Equal(t, h.barWidth, h.cells(40200), "the largest message fills the bar")
+	assert.Equal(t, 1, h.cells(1), "any token shows at least one cell")
+	assert.Equal(t, 0, h.cells(0))
+}
+
 func TestCostDialogSubSessionRendersInView(t *testing.T) {
 	t.Parallel()
 
@@ -293,7 +394,7 @@ func TestCostDialogSubSessionRendersInView(t *testing.T) {
 	})
 	sess.AddSubSession(subSess)
 
-	dialog := NewCostDialog(sess)
+	dialog := NewCostDialog(sess, false, nil)
 	dialog.SetSize(100, 50)
 	view := dialog.View()
 
@@ -349,3 +450,122 @@ func TestFormatTokenCount(t *testing.T) {
 		assert.Equal(t, tt.expected, result, "formatTokenCount(%d)", tt.count)
 	}
 }
+
+func TestCostDialogWordCount(t *testing.T) {
+	t.Parallel()
+
+	sess := session.New()
+	sess.AddMessage(session.UserMessage("Write an essay"))
+	sess.AddMessage(&session.Message{
+		AgentName: "root",
+		Message: chat.Message{
+			Role:    chat.MessageRoleAssistant,
+			Content: strings.Repeat("word ", 450),
+			Usage:   &chat.Usage{OutputTokens: 600},
+		},
+	})
+
+	d := NewCostDialog(sess, false, nil).(*costDialog)
+	assert.Equal(t, int64(450), d.gatherCostData().words)
+
+	d.SetSize(100, 50)
+	assert.Contains(t, d.View(), "words:")
+	assert.Contains(t, d.renderPlainText(), "words: 450 (~3 min read)")
+}
+
+func TestCostDialogHistoryComparison(t *testing.T) {
+	t.Parallel()
+
+	sess := session.New(session.WithWorkingDir("/work/project"))
+	sess.AddMessage(&session.Message{
+		AgentName: "root",
+		Message: chat.Message{
+			Role:    chat.MessageRoleAssistant,
+			Content: "Hello",
+			Cost:    0.42,
+		},
+	})
+
+	d := NewCostDialog(sess, false, nil).(*costDialog)
+	d.SetSize(100, 50)
+	assert.Contains(t, d.View(), noHistoryText)
+	assert.Contains(t, d.renderPlainText(), noHistoryText)
+
+	history := []session.Summary{
+		{ID: sess.ID, Cost: 0.42, WorkingDir: "/work/project"},
+		{ID: "other-1", Cost: 0.1, WorkingDir: "/elsewhere"},
+		{ID: "other-2", Cost: 0.5, WorkingDir: "/elsewhere"},
+	}
+	d = NewCostDialog(sess, false, history).(*costDialog)
+	comparison, average := d.historyComparison()
+	assert.Equal(t, "this session: $0.42 (1.4× your average)", comparison)
+	assert.Equal(t, "average of $0.30 over 2 saved sessions", average)
+
+	history = append(history, session.Summary{ID: "other-3", Cost: 0.2, WorkingDir: "/work/project"})
+	d = NewCostDialog(sess, false, history).(*costDialog)
+	comparison, average = d.historyComparison()
+	assert.Equal(t, "this session: $0.42 (2.1× your average in this directory)", comparison)
+	assert.Equal(t, "average of $0.20 over 1 saved session", average)
+
+	d.SetSize(100, 50)
+	assert.Contains(t, d.renderPlainText(), comparison)
+}
+
+func TestFormatWords(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "1 (~1 min read)", formatWords(1))
+	assert.Equal(t, "200 (~1 min read)", formatWords(200))
+	assert.Equal(t, "1.5K (~8 min read)", formatWords(1500))
+	assert.Equal(t, "15.0K (~1h 15m read)", formatWords(15000))
+}
+
+func TestCostDialogRenderCSV(t *testing.T) {
+	t.Parallel()
+
+	sess := session.New()
+	sess.Title = `Fix "parser", again`
+	sess.AddMessage(&session.Message{
+		AgentName: "root",
+		Message: chat.Message{
+			Role:    chat.MessageRoleAssistant,
+			Content: "Delegating",
+			Model:   "gpt-4o",
+			Usage: &chat.Usage{
+				InputTokens:       1000,
+				OutputTokens:      200,
+				CachedInputTokens: 300,
+				CacheWriteTokens:  50,
+			},
+			Cost: 0.005,
+		},
+	})
+	subSess := session.New()
+	subSess.AddMessage(&session.Message{
+		AgentName: "worker",
+		Message: chat.Message{
+			Role:    chat.MessageRoleAssistant,
+			Content: "Done",
+			Model:   "gpt-4o-mini",
+			Usage: &chat.Usage{
+				InputTokens:  500,
+				OutputTokens: 100,
+			},
+			Cost: 0.001,
+		},
+	})
+	sess.AddSubSession(subSess)
+
+	records, err := csv.NewReader(strings.NewReader((&costDialog{session: sess}).renderCSV())).ReadAll()
+	require.NoError(t, err)
+
+	// header, total, 2 models, 1 task, 2 messages (markers are skipped)
+	require.Len(t, records, 7)
+	assert.Equal(t, []string{"scope", "label", "cost", "input_tokens", "output_tokens", "cached_input_tokens", "cache_write_tokens"}, records[0])
+	assert.Equal(t, []string{"total", `Fix "parser", again`, "0.006000", "1500", "300", "300", "50"}, records[1])
+	assert.Equal(t, "model", records[2][0])
+	assert.Equal(t, "model", records[3][0])
+	assert.Equal(t, []string{"task", "task #1", "0.001000", "500", "100", "0", "0"}, records[4])
+	assert.Equal(t, []string{"message", "#1 [root]", "0.005000", "1000", "200", "300", "50"}, records[5])
+	assert.Equal(t, []string{"message", "#2 [worker]", "0.001000", "500", "100", "0", "0"}, records[6])
+}
@@ -13,6 +13,7 @@ import (
 	tea "charm.land/bubbletea/v2"
 	"charm.land/lipgloss/v2"
 
+	"github.com/docker/cagent/pkg/runtime"
 	"github.com/docker/cagent/pkg/tools"
 	"github.com/docker/cagent/pkg/tui/core/layout"
 	"github.com/docker/cagent/pkg/tui/styles"
@@ -112,6 +113,18 @@ func NewElicitationDialog(message string, schema any, meta map[string]any) Dialo
 	return d
 }
 
+// IsFreeFormQuestion reports whether an elicitation request asks a question
+// answered with free-form text, rather than a form, a URL or an OAuth flow.
+func IsFreeFormQuestion(ev *runtime.ElicitationRequestEvent) bool {
+	if ev.Mode == "url" {
+		return false
+	}
+	if elicitationType, _ := ev.Meta["cagent/type"].(string); elicitationType == "oauth_flow" {
+		return false
+	}
+	return len(parseElicitationSchema(ev.Schema)) == 0
+}
+
 func (d *ElicitationDialog) Init() tea.Cmd {
 	if d.hasFreeFormInput() || len(d.inputs) > 0 {
 		return textinput.Blink
@@ -5,6 +5,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/docker/cagent/pkg/runtime"
 )
 
 func TestParseElicitationSchema(t *testing.T) {
@@ -573,3 +575,13 @@ func TestElicitationDialog_collectAndValidate(t *testing.T) {
 		})
 	}
 }
+
+func TestIsFreeFormQuestion(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, IsFreeFormQuestion(&runtime.ElicitationRequestEvent{Message: "Which branch?"}))
+	assert.True(t, IsFreeFormQuestion(&runtime.ElicitationRequestEvent{Schema: map[string]any(nil)}))
+	assert.False(t, IsFreeFormQuestion(&runtime.ElicitationRequestEvent{Schema: map[string]any{"type": "boolean"}}))
+	assert.False(t, IsFreeFormQuestion(&runtime.ElicitationRequestEvent{Mode: "url", URL: "https://example.com"}))
+	assert.False(t, IsFreeFormQuestion(&runtime.ElicitationRequestEvent{Meta: map[string]any{"cagent/type": "oauth_flow"}}))
+}
@@ -41,12 +41,27 @@ type filePickerDialog struct {
 	scrollview *scrollview.Model
 	keyMap     commandPaletteKeyMap
 	err        error
+	// insertContents makes the picker insert the selected file's content
+	// instead of attaching it.
+	insertContents bool
 }
 
 // NewFilePickerDialog creates a new file picker dialog for attaching files.
 // If initialPath is provided and is a directory, it starts in that directory.
 // If initialPath is a file, it starts in the file's directory with the file pre-selected.
 func NewFilePickerDialog(initialPath string) Dialog {
+	return newFilePickerDialog(initialPath)
+}
+
+// NewInsertFilePickerDialog creates a file picker dialog that inserts the
+// content of the selected file in the editor.
+func NewInsertFilePickerDialog(initialPath string) Dialog {
+	d := newFilePickerDialog(initialPath)
+	d.insertContents = true
+	return d
+}
+
+func newFilePickerDialog(initialPath string) *filePickerDialog {
 	ti := textinput.New()
 	ti.Placeholder = "Type to filter files…"
 	ti.Focus()
@@ -223,9 +238,13 @@ func (d *filePickerDialog) Update(msg tea.Msg) (layout.Model, tea.Cmd) {
 					d.loadDirectory()
 					return d, nil
 				}
+				var selected tea.Msg = messages.InsertFileRefMsg{FilePath: entry.path}
+				if d.insertContents {
+					selected = messages.InsertFileContentsMsg{FilePath: entry.path}
+				}
 				return d, tea.Sequence(
 					core.CmdHandler(CloseDialogMsg{}),
-					core.CmdHandler(messages.InsertFileRefMsg{FilePath: entry.path}),
+					core.CmdHandler(selected),
 				)
 			}
 			return d, nil
@@ -321,8 +340,13 @@ func (d *filePickerDialog) View() string {
 		scrollableContent = d.scrollview.View()
 	}
 
+	title := "Attach File"
+	if d.insertContents {
+		title = "Insert File Contents"
+	}
+
 	content := NewContent(regionWidth).
-		AddTitle("Attach File").
+		AddTitle(title).
 		AddSpace().
 		AddContent(dirLine).
 		AddContent(d.textInput.View()).
@@ -0,0 +1,35 @@
+package dialog
+
+import (
+	"github.com/docker/cagent/pkg/audio/capture"
+)
+
+// InputDeviceDialogID is the unique identifier for the input device dialog.
+const InputDeviceDialogID = "input-device"
+
+// maxInputDevices is the number of devices the dialog can list, one per
+// number key.
+const maxInputDevices = 10
+
+// NewInputDeviceDialog creates a multi-choice dialog for selecting the input
+// device /speak listens to. The result's OptionID is the device ID, and
+// skipping selects the system's default device.
+func NewInputDeviceDialog(devices []capture.Device, current string) Dialog {
+	options := make([]MultiChoiceOption, 0, min(len(devices), maxInputDevices))
+	for _, device := range devices[:min(len(devices), maxInputDevices)] {
+		label := device.Name
+		if device.ID == current {
+			label += " (current)"
+		}
+		options = append(options, MultiChoiceOption{ID: device.ID, Label: label, Value: device.Name})
+	}
+
+	return NewMultiChoiceDialog(MultiChoiceConfig{
+		DialogID:       InputDeviceDialogID,
+		Title:          "Which microphone should /speak listen to?",
+		Options:        options,
+		AllowSecondary: true,
+		SecondaryLabel: "System default",
+		PrimaryLabel:   "Use",
+	})
+}
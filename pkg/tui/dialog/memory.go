@@ -0,0 +1,150 @@
+package dialog
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/docker/cagent/pkg/memory/database"
+	"github.com/docker/cagent/pkg/tui/components/toolcommon"
+	"github.com/docker/cagent/pkg/tui/core"
+	"github.com/docker/cagent/pkg/tui/core/layout"
+	"github.com/docker/cagent/pkg/tui/messages"
+	"github.com/docker/cagent/pkg/tui/styles"
+)
+
+// memoryDialogMaxVisible is the number of memories shown at once.
+const memoryDialogMaxVisible = 12
+
+type memoryKeyMap struct {
+	Up     key.Binding
+	Down   key.Binding
+	Delete key.Binding
+	Clear  key.Binding
+	Close  key.Binding
+}
+
+// memoryDialog lists the memories stored by the current agent and lets the
+// user delete them.
+type memoryDialog struct {
+	BaseDialog
+	// entries returns the current memories. It is called on every render so
+	// the dialog follows deletions while it is open.
+	entries  func() []database.UserMemory
+	selected int
+	offset   int
+	keyMap   memoryKeyMap
+}
+
+// NewMemoryDialog creates a dialog managing the memories returned by entries.
+// Deletions are sent as DeleteMemoryMsg and ClearMemoriesMsg.
+func NewMemoryDialog(entries func() []database.UserMemory) Dialog {
+	return &memoryDialog{
+		entries: entries,
+		keyMap: memoryKeyMap{
+			Up:     key.NewBinding(key.WithKeys("up", "k")),
+			Down:   key.NewBinding(key.WithKeys("down", "j")),
+			Delete: key.NewBinding(key.WithKeys("d", "delete", "backspace")),
+			Clear:  key.NewBinding(key.WithKeys("D")),
+			Close:  key.NewBinding(key.WithKeys("esc", "q", "enter")),
+		},
+	}
+}
+
+func (d *memoryDialog) Init() tea.Cmd {
+	return nil
+}
+
+func (d *memoryDialog) Update(msg tea.Msg) (layout.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		cmd := d.SetSize(msg.Width, msg.Height)
+		return d, cmd
+
+	case tea.KeyPressMsg:
+		return d, d.handleKey(msg)
+	}
+	return d, nil
+}
+
+func (d *memoryDialog) handleKey(msg tea.KeyPressMsg) tea.Cmd {
+	entries := d.entries()
+	d.selected = max(0, min(d.selected, len(entries)-1))
+
+	switch {
+	case key.Matches(msg, d.keyMap.Close):
+		return core.CmdHandler(CloseDialogMsg{})
+	case len(entries) == 0:
+		return nil
+	case key.Matches(msg, d.keyMap.Up):
+		d.selected = max(0, d.selected-1)
+	case key.Matches(msg, d.keyMap.Down):
+		d.selected = min(len(entries)-1, d.selected+1)
+	case key.Matches(msg, d.keyMap.Delete):
+		return core.CmdHandler(messages.DeleteMemoryMsg{ID: entries[d.selected].ID})
+	case key.Matches(msg, d.keyMap.Clear):
+		return core.CmdHandler(messages.ClearMemoriesMsg{})
+	}
+	return nil
+}
+
+func (d *memoryDialog) Position() (row, col int) {
+	return d.CenterDialog(d.View())
+}
+
+func (d *memoryDialog) View() string {
+	dialogWidth := d.ComputeDialogWidth(70, 50, 100)
+	contentWidth := d.ContentWidth(dialogWidth, 2)
+	entries := d.entries()
+	d.selected = max(0, min(d.selected, len(entries)-1))
+
+	// Keep the selection in the visible window.
+	if d.selected < d.offset {
+		d.offset = d.selected
+	}
+	if d.selected >= d.offset+memoryDialogMaxVisible {
+		d.offset = d.selected - memoryDialogMaxVisible + 1
+	}
+	d.offset = max(0, min(d.offset, len(entries)-memoryDialogMaxVisible))
+
+	content := NewContent(contentWidth).
+		AddTitle(fmt.Sprintf("Memories (%d)", len(entries))).
+		AddSeparator().
+		AddSpace()
+
+	if len(entries) == 0 {
+		content.AddContent(styles.MutedStyle.Render("No memories stored"))
+	}
+	end := min(len(entries), d.offset+memoryDialogMaxVisible)
+	for i := d.offset; i < end; i++ {
+		content.AddContent(d.renderEntry(entries[i], i == d.selected, contentWidth))
+	}
+	if len(entries) > memoryDialogMaxVisible {
+		content.AddContent(styles.MutedStyle.Render(fmt.Sprintf("%d-%d of %d", d.offset+1, end, len(entries))))
+	}
+
+	content.AddSpace()
+	content.AddHelpKeys("↑↓", "navigate", "d", "delete", "D", "clear all", "Esc", "close")
+
+	return styles.DialogStyle.
+		Padding(1, 2).
+		Width(dialogWidth).
+		Render(content.Build())
+}
+
+func (d *memoryDialog) renderEntry(entry database.UserMemory, selected bool, contentWidth int) string {
+	date := entry.CreatedAt
+	if t, err := time.Parse(time.RFC3339, entry.CreatedAt); err == nil {
+		date = t.Local().Format("2006-01-02 15:04")
+	}
+	prefix := date + "  "
+	text := strings.Join(strings.Fields(entry.Memory), " ")
+	line := prefix + toolcommon.TruncateText(text, contentWidth-len(prefix))
+	if selected {
+		return styles.PaletteSelectedActionStyle.Render(line)
+	}
+	return styles.PaletteUnselectedActionStyle.Render(line)
+}
@@ -0,0 +1,47 @@
+package dialog
+
+import (
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/docker/cagent/pkg/memory/database"
+	"github.com/docker/cagent/pkg/tui/messages"
+)
+
+func TestMemoryDialog(t *testing.T) {
+	t.Parallel()
+
+	memories := []database.UserMemory{
+		{ID: "1", CreatedAt: "not a date", Memory: "likes Go"},
+		{ID: "2", Memory: "prefers\ntabs"},
+	}
+	d := NewMemoryDialog(func() []database.UserMemory { return memories })
+	d.SetSize(100, 40)
+
+	view := d.View()
+	assert.Contains(t, view, "Memories (2)")
+	assert.Contains(t, view, "not a date  likes Go")
+	assert.Contains(t, view, "prefers tabs")
+
+	_, _ = d.Update(tea.KeyPressMsg{Code: tea.KeyDown})
+	_, cmd := d.Update(tea.KeyPressMsg{Code: 'd', Text: "d"})
+	require.NotNil(t, cmd)
+	assert.Equal(t, messages.DeleteMemoryMsg{ID: "2"}, cmd())
+
+	_, cmd = d.Update(tea.KeyPressMsg{Code: 'D', Text: "D"})
+	require.NotNil(t, cmd)
+	assert.Equal(t, messages.ClearMemoriesMsg{}, cmd())
+
+	// The list follows the memories
+	memories = nil
+	assert.Contains(t, d.View(), "No memories stored")
+	_, cmd = d.Update(tea.KeyPressMsg{Code: 'd', Text: "d"})
+	assert.Nil(t, cmd)
+
+	_, cmd = d.Update(tea.KeyPressMsg{Code: tea.KeyEscape})
+	require.NotNil(t, cmd)
+	assert.Equal(t, CloseDialogMsg{}, cmd())
+}
@@ -540,12 +540,14 @@ func (d *modelPickerDialog) findSelectedLine(allModelLines []string) int {
 func (d *modelPickerDialog) renderModel(model runtime.ModelChoice, selected bool, maxWidth int) string {
 	nameStyle, descStyle := styles.PaletteUnselectedActionStyle, styles.PaletteUnselectedDescStyle
 	alloyBadgeStyle, defaultBadgeStyle, currentBadgeStyle := styles.BadgeAlloyStyle, styles.BadgeDefaultStyle, styles.BadgeCurrentStyle
+	noToolsBadgeStyle := styles.BadgeNoToolsStyle
 	if selected {
 		nameStyle, descStyle = styles.PaletteSelectedActionStyle, styles.PaletteSelectedDescStyle
 		// Keep badge colors visible on selection background
 		alloyBadgeStyle = alloyBadgeStyle.Background(styles.MobyBlue)
 		defaultBadgeStyle = defaultBadgeStyle.Background(styles.MobyBlue)
 		currentBadgeStyle = currentBadgeStyle.Background(styles.MobyBlue)
+		noToolsBadgeStyle = noToolsBadgeStyle.Background(styles.MobyBlue)
 	}
 
 	// Check if this is an alloy model (no provider but has comma-separated models)
@@ -556,6 +558,9 @@ func (d *modelPickerDialog) renderModel(model runtime.ModelChoice, selected bool
 	if isAlloy {
 		badgeWidth += lipgloss.Width(" (alloy)")
 	}
+	if model.NoTools {
+		badgeWidth += lipgloss.Width(" (no tools)")
+	}
 	if model.IsCurrent {
 		badgeWidth += lipgloss.Width(" (current)")
 	} else if model.IsDefault {
@@ -579,10 +584,16 @@ func (d *modelPickerDialog) renderModel(model runtime.ModelChoice, selected bool
 		desc = model.Ref
 	}
 
+	// Aliases point at their resolved model: "fast → openai/gpt-4o-mini"
+	separator := " • "
+	if model.AliasOf != "" && desc != "" {
+		separator = " → "
+	}
+
 	// Calculate available width for name and description
 	separatorWidth := 0
 	if desc != "" {
-		separatorWidth = lipgloss.Width(" • ")
+		separatorWidth = lipgloss.Width(separator)
 	}
 
 	// Maximum width for name (leaving space for badges and description)
@@ -605,6 +616,9 @@ func (d *modelPickerDialog) renderModel(model runtime.ModelChoice, selected bool
 	if isAlloy {
 		nameParts = append(nameParts, alloyBadgeStyle.Render(" (alloy)"))
 	}
+	if model.NoTools {
+		nameParts = append(nameParts, noToolsBadgeStyle.Render(" (no tools)"))
+	}
 	if model.IsCurrent {
 		nameParts = append(nameParts, currentBadgeStyle.Render(" (current)"))
 	} else if model.IsDefault {
@@ -618,7 +632,7 @@ func (d *modelPickerDialog) renderModel(model runtime.ModelChoice, selected bool
 		remainingWidth := maxWidth - nameWidth - separatorWidth
 		if remainingWidth > 0 {
 			truncatedDesc := toolcommon.TruncateText(desc, remainingWidth)
-			return name + descStyle.Render(" • "+truncatedDesc)
+			return name + descStyle.Render(separator+truncatedDesc)
 		}
 		// No room for description
 		return name
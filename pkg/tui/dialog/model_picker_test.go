@@ -163,6 +163,31 @@ func TestModelPickerViewShowsSelection(t *testing.T) {
 	require.NotEqual(t, view1, view2, "view should change after navigation")
 }
 
+func TestModelPickerFlagsModelsWithoutTools(t *testing.T) {
+	t.Parallel()
+
+	d := &modelPickerDialog{}
+	withTools := d.renderModel(runtime.ModelChoice{Name: "GPT-4o", Provider: "openai", Model: "gpt-4o", IsCatalog: true}, false, 80)
+	withoutTools := d.renderModel(runtime.ModelChoice{Name: "Gemma 2B", Provider: "ollama", Model: "gemma:2b", IsCatalog: true, NoTools: true}, true, 80)
+
+	assert.NotContains(t, withTools, "(no tools)")
+	assert.Contains(t, withoutTools, "(no tools)")
+	assert.Contains(t, withoutTools, "ollama/gemma:2b")
+}
+
+func TestModelPickerShowsAliasTarget(t *testing.T) {
+	t.Parallel()
+
+	d := &modelPickerDialog{}
+	alias := d.renderModel(runtime.ModelChoice{Name: "fast", Ref: "fast", Provider: "openai", Model: "gpt-4o-mini", AliasOf: "openai/gpt-4o-mini"}, false, 80)
+	named := d.renderModel(runtime.ModelChoice{Name: "sonnet", Ref: "sonnet", Provider: "anthropic", Model: "claude-sonnet-4-0"}, false, 80)
+
+	assert.Contains(t, alias, "fast")
+	assert.Contains(t, alias, "→ openai/gpt-4o-mini")
+	assert.NotContains(t, named, "→")
+	assert.Contains(t, named, "• anthropic/claude-sonnet-4-0")
+}
+
 func TestModelPickerPageNavigation(t *testing.T) {
 	t.Parallel()
 
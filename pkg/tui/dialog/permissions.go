@@ -1,36 +1,56 @@
 package dialog
 
 import (
+	"maps"
+	"slices"
+
 	"charm.land/bubbles/v2/key"
 	tea "charm.land/bubbletea/v2"
 	"charm.land/lipgloss/v2"
 
 	"github.com/docker/cagent/pkg/runtime"
+	"github.com/docker/cagent/pkg/session"
 	"github.com/docker/cagent/pkg/tui/components/scrollview"
+	"github.com/docker/cagent/pkg/tui/components/toolcommon"
 	"github.com/docker/cagent/pkg/tui/core"
 	"github.com/docker/cagent/pkg/tui/core/layout"
+	"github.com/docker/cagent/pkg/tui/messages"
 	"github.com/docker/cagent/pkg/tui/styles"
 )
 
 // permissionsDialog displays the configured tool permissions (allow/deny patterns).
 type permissionsDialog struct {
 	BaseDialog
-	permissions *runtime.PermissionsInfo
-	yoloEnabled bool
-	closeKey    key.Binding
-	scrollview  *scrollview.Model
+	permissions  *runtime.PermissionsInfo
+	agent        runtime.AgentDetails
+	yoloEnabled  bool
+	envOverrides map[string]string
+	approvals    func() []session.RememberedApproval
+	selected     int
+	closeKey     key.Binding
+	selectKey    key.Binding
+	revokeKey    key.Binding
+	scrollview   *scrollview.Model
 }
 
-// NewPermissionsDialog creates a new dialog showing tool permission rules.
-func NewPermissionsDialog(perms *runtime.PermissionsInfo, yoloEnabled bool) Dialog {
+// NewPermissionsDialog creates a new dialog showing tool permission rules,
+// the toolset approval policy of the current agent, the session's remembered
+// approvals and its environment overrides. The approvals are read on every
+// render, so revoked ones disappear.
+func NewPermissionsDialog(perms *runtime.PermissionsInfo, agent runtime.AgentDetails, yoloEnabled bool, envOverrides map[string]string, approvals func() []session.RememberedApproval) Dialog {
 	return &permissionsDialog{
-		permissions: perms,
-		yoloEnabled: yoloEnabled,
+		permissions:  perms,
+		agent:        agent,
+		yoloEnabled:  yoloEnabled,
+		envOverrides: envOverrides,
+		approvals:    approvals,
 		scrollview: scrollview.New(
 			scrollview.WithKeyMap(scrollview.ReadOnlyScrollKeyMap()),
 			scrollview.WithReserveScrollbarSpace(true),
 		),
-		closeKey: key.NewBinding(key.WithKeys("esc", "enter", "q"), key.WithHelp("Esc", "close")),
+		closeKey:  key.NewBinding(key.WithKeys("esc", "enter", "q"), key.WithHelp("Esc", "close")),
+		selectKey: key.NewBinding(key.WithKeys("tab"), key.WithHelp("Tab", "select")),
+		revokeKey: key.NewBinding(key.WithKeys("x", "delete"), key.WithHelp("x", "revoke")),
 	}
 }
 
@@ -49,8 +69,20 @@ func (d *permissionsDialog) Update(msg tea.Msg) (layout.Model, tea.Cmd) {
 		return d, cmd
 
 	case tea.KeyPressMsg:
-		if key.Matches(msg, d.closeKey) {
+		switch {
+		case key.Matches(msg, d.closeKey):
 			return d, core.CmdHandler(CloseDialogMsg{})
+		case key.Matches(msg, d.selectKey):
+			if n := len(d.approvals()); n > 0 {
+				d.selected = (d.selected + 1) % n
+			}
+		case key.Matches(msg, d.revokeKey):
+			approvals := d.approvals()
+			if d.selected < len(approvals) {
+				signature := approvals[d.selected].Signature()
+				d.selected = max(0, min(d.selected, len(approvals)-2))
+				return d, core.CmdHandler(messages.RevokeApprovalMsg{Signature: signature})
+			}
 		}
 	}
 	return d, nil
@@ -106,6 +138,15 @@ func (d *permissionsDialog) renderContent(contentWidth, maxHeight int) string {
 			lines = append(lines, "")
 		}
 
+		// Toolsets section
+		if len(d.permissions.AllowToolsets) > 0 {
+			lines = append(lines, d.renderSectionHeader("Toolsets", "All tools of these toolsets are auto-approved"), "")
+			for _, toolset := range d.permissions.AllowToolsets {
+				lines = append(lines, d.renderPattern(toolset, false))
+			}
+			lines = append(lines, "")
+		}
+
 		// Ask section
 		if len(d.permissions.Ask) > 0 {
 			lines = append(lines, d.renderSectionHeader("Ask", "Always requires confirmation, even for read-only tools"), "")
@@ -116,11 +157,42 @@ func (d *permissionsDialog) renderContent(contentWidth, maxHeight int) string {
 		}
 
 		// If all are empty
-		if len(d.permissions.Allow) == 0 && len(d.permissions.Ask) == 0 && len(d.permissions.Deny) == 0 {
+		if len(d.permissions.Allow) == 0 && len(d.permissions.Ask) == 0 && len(d.permissions.Deny) == 0 && len(d.permissions.AllowToolsets) == 0 {
 			lines = append(lines, styles.MutedStyle.Render("No permission patterns configured."), "")
 		}
 	}
 
+	// Toolset approval policy declared by the current agent
+	if len(d.agent.ApprovedToolsets) > 0 || len(d.agent.GatedToolsets) > 0 {
+		lines = append(lines, d.renderSectionHeader("Agent "+d.agent.Name, "Toolset policy of the current agent"), "")
+		for _, toolset := range d.agent.ApprovedToolsets {
+			lines = append(lines, d.renderPattern(toolset, false))
+		}
+		for _, toolset := range d.agent.GatedToolsets {
+			lines = append(lines, d.renderAskPattern(toolset))
+		}
+		lines = append(lines, "")
+	}
+
+	// Approvals remembered for this session, until they expire
+	if approvals := d.approvals(); len(approvals) > 0 {
+		d.selected = min(d.selected, len(approvals)-1)
+		lines = append(lines, d.renderSectionHeader("Remembered", "Approved earlier in this session"), "")
+		for i, approval := range approvals {
+			lines = append(lines, d.renderApproval(approval, i == d.selected, contentWidth))
+		}
+		lines = append(lines, "")
+	}
+
+	// Session environment overrides, values are never displayed
+	if len(d.envOverrides) > 0 {
+		lines = append(lines, d.renderSectionHeader("Environment", "Set with /env for this session's tools"), "")
+		for _, name := range slices.Sorted(maps.Keys(d.envOverrides)) {
+			lines = append(lines, d.renderEnvOverride(name))
+		}
+		lines = append(lines, "")
+	}
+
 	// Apply scrolling
 	return d.applyScrolling(lines, contentWidth, maxHeight)
 }
@@ -165,6 +237,31 @@ func (d *permissionsDialog) renderAskPattern(pattern string) string {
 	return style.Render(icon) + "  " + lipgloss.NewStyle().Foreground(styles.Highlight).Render(pattern)
 }
 
+func (d *permissionsDialog) renderApproval(approval session.RememberedApproval, selected bool, contentWidth int) string {
+	icon := lipgloss.NewStyle().Foreground(styles.Success).Render("✓")
+	if selected {
+		icon = lipgloss.NewStyle().Foreground(styles.Highlight).Render("›")
+	}
+
+	expiry := "for this session"
+	if !approval.ExpiresAt.IsZero() {
+		expiry = "until " + approval.ExpiresAt.Format("15:04")
+	}
+	suffix := styles.MutedStyle.Render(" (" + expiry + ")")
+
+	name := approval.ToolName
+	if approval.Arguments != "" {
+		name += " " + approval.Arguments
+	}
+	available := max(1, contentWidth-3-lipgloss.Width(suffix))
+	return icon + "  " + lipgloss.NewStyle().Foreground(styles.Highlight).Render(toolcommon.TruncateText(name, available)) + suffix
+}
+
+func (d *permissionsDialog) renderEnvOverride(name string) string {
+	icon := lipgloss.NewStyle().Foreground(styles.TextSecondary).Render("$")
+	return icon + "  " + lipgloss.NewStyle().Foreground(styles.Highlight).Render(name) + styles.MutedStyle.Render("=••••••")
+}
+
 func (d *permissionsDialog) applyScrolling(allLines []string, contentWidth, maxHeight int) string {
 	const headerLines = 3 // title + separator + space
 	const footerLines = 2 // space + help
@@ -184,6 +281,10 @@ func (d *permissionsDialog) applyScrolling(allLines []string, contentWidth, maxH
 
 	scrollableContent := d.scrollview.View()
 	parts := append(allLines[:headerLines], scrollableContent)
-	parts = append(parts, "", RenderHelpKeys(regionWidth, "↑↓", "scroll", "Esc", "close"))
+	if len(d.approvals()) > 0 {
+		parts = append(parts, "", RenderHelpKeys(regionWidth, "↑↓", "scroll", "Tab", "select", "x", "revoke", "Esc", "close"))
+	} else {
+		parts = append(parts, "", RenderHelpKeys(regionWidth, "↑↓", "scroll", "Esc", "close"))
+	}
 	return lipgloss.JoinVertical(lipgloss.Left, parts...)
 }
@@ -0,0 +1,60 @@
+package dialog
+
+import (
+	"testing"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/docker/cagent/pkg/runtime"
+	"github.com/docker/cagent/pkg/session"
+	"github.com/docker/cagent/pkg/tui/messages"
+)
+
+func TestPermissionsDialog_RevokeApproval(t *testing.T) {
+	t.Parallel()
+
+	sess := session.New()
+	sess.RememberApproval(session.RememberedApproval{ToolName: "shell"})
+	sess.RememberApproval(session.RememberedApproval{ToolName: "edit_file", ExpiresAt: time.Now().Add(time.Hour)})
+	approvals := func() []session.RememberedApproval { return sess.GetRememberedApprovals(time.Now()) }
+
+	d := NewPermissionsDialog(nil, runtime.AgentDetails{}, false, nil, approvals)
+	d.SetSize(100, 50)
+
+	view := d.View()
+	assert.Contains(t, view, "Remembered")
+	assert.Contains(t, view, "shell")
+	assert.Contains(t, view, "for this session")
+	assert.Contains(t, view, "until ")
+
+	// Tab selects the next approval, x revokes it
+	_, _ = d.Update(tea.KeyPressMsg{Code: tea.KeyTab})
+	_, cmd := d.Update(tea.KeyPressMsg{Code: 'x', Text: "x"})
+	require.NotNil(t, cmd)
+	assert.Equal(t, messages.RevokeApprovalMsg{Signature: "shell"}, cmd())
+
+	sess.RevokeApproval("shell")
+	assert.NotContains(t, d.View(), "for this session")
+}
+
+func TestPermissionsDialog_AgentToolsetPolicy(t *testing.T) {
+	t.Parallel()
+
+	agent := runtime.AgentDetails{
+		Name:             "reviewer",
+		ApprovedToolsets: []string{"think"},
+		GatedToolsets:    []string{"filesystem"},
+	}
+	approvals := func() []session.RememberedApproval { return nil }
+
+	d := NewPermissionsDialog(nil, agent, false, nil, approvals)
+	d.SetSize(100, 50)
+
+	view := d.View()
+	assert.Contains(t, view, "Agent reviewer")
+	assert.Contains(t, view, "think")
+	assert.Contains(t, view, "filesystem")
+}
@@ -0,0 +1,332 @@
+package dialog
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"charm.land/bubbles/v2/key"
+	"charm.land/bubbles/v2/textinput"
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/docker/cagent/pkg/tui/components/toolcommon"
+	"github.com/docker/cagent/pkg/tui/core"
+	"github.com/docker/cagent/pkg/tui/core/layout"
+	"github.com/docker/cagent/pkg/tui/messages"
+	"github.com/docker/cagent/pkg/tui/service/tuistate"
+	"github.com/docker/cagent/pkg/tui/styles"
+)
+
+// promptLibraryMaxVisible is the number of prompt templates shown at once.
+const promptLibraryMaxVisible = 12
+
+// placeholderPattern matches the {placeholder} markers of a prompt template.
+var placeholderPattern = regexp.MustCompile(`\{([A-Za-z_][A-Za-z0-9_-]*)\}`)
+
+// promptPlaceholders returns the distinct placeholders of a prompt template,
+// in order of first appearance.
+func promptPlaceholders(content string) []string {
+	var names []string
+	for _, match := range placeholderPattern.FindAllStringSubmatch(content, -1) {
+		if !slices.Contains(names, match[1]) {
+			names = append(names, match[1])
+		}
+	}
+	return names
+}
+
+// fillPromptTemplate replaces the placeholders of a prompt template with
+// their values. Placeholders without a value are left as is.
+func fillPromptTemplate(content string, values map[string]string) string {
+	return placeholderPattern.ReplaceAllStringFunc(content, func(match string) string {
+		if value, ok := values[match[1:len(match)-1]]; ok {
+			return value
+		}
+		return match
+	})
+}
+
+// insertPromptCmd inserts the template in the editor, asking for the values of
+// its placeholders first if it has any.
+func insertPromptCmd(t tuistate.PromptTemplate) tea.Cmd {
+	insert := core.CmdHandler(messages.InsertPromptMsg{Content: t.Content})
+	if placeholders := promptPlaceholders(t.Content); len(placeholders) > 0 {
+		insert = core.CmdHandler(OpenDialogMsg{Model: NewPromptPlaceholdersDialog(t, placeholders)})
+	}
+	return tea.Sequence(core.CmdHandler(CloseDialogMsg{}), insert)
+}
+
+type promptLibraryKeyMap struct {
+	Up     key.Binding
+	Down   key.Binding
+	Insert key.Binding
+	Delete key.Binding
+	Close  key.Binding
+}
+
+// promptLibraryDialog lists the saved prompt templates and inserts the
+// selected one in the editor.
+type promptLibraryDialog struct {
+	BaseDialog
+	// entries returns the current templates. It is called on every render so
+	// the dialog follows deletions while it is open.
+	entries  func() []tuistate.PromptTemplate
+	selected int
+	offset   int
+	keyMap   promptLibraryKeyMap
+}
+
+// NewPromptLibraryDialog creates a dialog listing the prompt templates
+// returned by entries. Deletions are sent as DeletePromptTemplateMsg.
+func NewPromptLibraryDialog(entries func() []tuistate.PromptTemplate) Dialog {
+	return &promptLibraryDialog{
+		entries: entries,
+		keyMap: promptLibraryKeyMap{
+			Up:     key.NewBinding(key.WithKeys("up", "k")),
+			Down:   key.NewBinding(key.WithKeys("down", "j")),
+			Insert: key.NewBinding(key.WithKeys("enter")),
+			Delete: key.NewBinding(key.WithKeys("d", "delete", "backspace")),
+			Close:  key.NewBinding(key.WithKeys("esc", "q")),
+		},
+	}
+}
+
+func (d *promptLibraryDialog) Init() tea.Cmd {
+	return nil
+}
+
+func (d *promptLibraryDialog) Update(msg tea.Msg) (layout.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		cmd := d.SetSize(msg.Width, msg.Height)
+		return d, cmd
+
+	case tea.KeyPressMsg:
+		return d, d.handleKey(msg)
+	}
+	return d, nil
+}
+
+func (d *promptLibraryDialog) handleKey(msg tea.KeyPressMsg) tea.Cmd {
+	entries := d.entries()
+	d.selected = max(0, min(d.selected, len(entries)-1))
+
+	switch {
+	case key.Matches(msg, d.keyMap.Close):
+		return core.CmdHandler(CloseDialogMsg{})
+	case len(entries) == 0:
+		return nil
+	case key.Matches(msg, d.keyMap.Up):
+		d.selected = max(0, d.selected-1)
+	case key.Matches(msg, d.keyMap.Down):
+		d.selected = min(len(entries)-1, d.selected+1)
+	case key.Matches(msg, d.keyMap.Insert):
+		return insertPromptCmd(entries[d.selected])
+	case key.Matches(msg, d.keyMap.Delete):
+		return core.CmdHandler(messages.DeletePromptTemplateMsg{Name: entries[d.selected].Name})
+	}
+	return nil
+}
+
+func (d *promptLibraryDialog) Position() (row, col int) {
+	return d.CenterDialog(d.View())
+}
+
+func (d *promptLibraryDialog) View() string {
+	dialogWidth := d.ComputeDialogWidth(60, 40, 80)
+	contentWidth := d.ContentWidth(dialogWidth, 2)
+	entries := d.entries()
+	d.selected = max(0, min(d.selected, len(entries)-1))
+
+	// Keep the selection in the visible window.
+	if d.selected < d.offset {
+		d.offset = d.selected
+	}
+	if d.selected >= d.offset+promptLibraryMaxVisible {
+		d.offset = d.selected - promptLibraryMaxVisible + 1
+	}
+	d.offset = max(0, min(d.offset, len(entries)-promptLibraryMaxVisible))
+
+	content := NewContent(contentWidth).
+		AddTitle(fmt.Sprintf("Prompts (%d)", len(entries))).
+		AddSeparator().
+		AddSpace()
+
+	if len(entries) == 0 {
+		content.AddContent(styles.MutedStyle.Render("No prompts"))
+	}
+	end := min(len(entries), d.offset+promptLibraryMaxVisible)
+	for i := d.offset; i < end; i++ {
+		line := toolcommon.TruncateText(entries[i].Name, contentWidth)
+		if i == d.selected {
+			content.AddContent(styles.PaletteSelectedActionStyle.Render(line))
+		} else {
+			content.AddContent(styles.PaletteUnselectedActionStyle.Render(line))
+		}
+	}
+	if len(entries) > promptLibraryMaxVisible {
+		content.AddContent(styles.MutedStyle.Render(fmt.Sprintf("%d-%d of %d", d.offset+1, end, len(entries))))
+	}
+
+	if len(entries) > 0 {
+		preview, _, _ := strings.Cut(entries[d.selected].Content, "\n")
+		content.AddSpace()
+		content.AddContent(styles.MutedStyle.Render(toolcommon.TruncateText(preview, contentWidth)))
+	}
+
+	content.AddSpace()
+	content.AddHelpKeys("↑↓", "navigate", "enter", "insert", "d", "delete", "Esc", "close")
+
+	return styles.DialogStyle.
+		Padding(1, 2).
+		Width(dialogWidth).
+		Render(content.Build())
+}
+
+type promptPlaceholdersKeyMap struct {
+	Previous key.Binding
+	Next     key.Binding
+	Insert   key.Binding
+	Close    key.Binding
+}
+
+// promptPlaceholdersDialog asks for the values of the placeholders of a
+// prompt template, then inserts the filled in prompt in the editor.
+type promptPlaceholdersDialog struct {
+	BaseDialog
+	template     tuistate.PromptTemplate
+	placeholders []string
+	inputs       []textinput.Model
+	current      int
+	keyMap       promptPlaceholdersKeyMap
+}
+
+// NewPromptPlaceholdersDialog creates a dialog with one input per
+// placeholder of the template.
+func NewPromptPlaceholdersDialog(t tuistate.PromptTemplate, placeholders []string) Dialog {
+	inputs := make([]textinput.Model, len(placeholders))
+	for i, name := range placeholders {
+		inputs[i] = textinput.New()
+		inputs[i].SetStyles(styles.DialogInputStyle)
+		inputs[i].Placeholder = name
+		inputs[i].CharLimit = 2000
+	}
+	inputs[0].Focus()
+
+	return &promptPlaceholdersDialog{
+		template:     t,
+		placeholders: placeholders,
+		inputs:       inputs,
+		keyMap: promptPlaceholdersKeyMap{
+			Previous: key.NewBinding(key.WithKeys("up", "shift+tab")),
+			Next:     key.NewBinding(key.WithKeys("down", "tab")),
+			Insert:   key.NewBinding(key.WithKeys("enter")),
+			Close:    key.NewBinding(key.WithKeys("esc")),
+		},
+	}
+}
+
+func (d *promptPlaceholdersDialog) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+func (d *promptPlaceholdersDialog) Update(msg tea.Msg) (layout.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		cmd := d.SetSize(msg.Width, msg.Height)
+		return d, cmd
+
+	case tea.PasteMsg:
+		var cmd tea.Cmd
+		d.inputs[d.current], cmd = d.inputs[d.current].Update(msg)
+		return d, cmd
+
+	case tea.KeyPressMsg:
+		if cmd := HandleQuit(msg); cmd != nil {
+			return d, cmd
+		}
+
+		switch {
+		case key.Matches(msg, d.keyMap.Close):
+			return d, core.CmdHandler(CloseDialogMsg{})
+		case key.Matches(msg, d.keyMap.Previous):
+			d.focus(d.current - 1)
+			return d, nil
+		case key.Matches(msg, d.keyMap.Next):
+			d.focus(d.current + 1)
+			return d, nil
+		case key.Matches(msg, d.keyMap.Insert):
+			// Enter moves to the next placeholder, and inserts the prompt
+			// from the last one.
+			if d.current < len(d.inputs)-1 {
+				d.focus(d.current + 1)
+				return d, nil
+			}
+			return d, tea.Sequence(
+				core.CmdHandler(CloseDialogMsg{}),
+				core.CmdHandler(messages.InsertPromptMsg{Content: d.filled()}),
+			)
+		}
+
+		var cmd tea.Cmd
+		d.inputs[d.current], cmd = d.inputs[d.current].Update(msg)
+		return d, cmd
+	}
+	return d, nil
+}
+
+// focus moves the focus to the input at index i, if there is one.
+func (d *promptPlaceholdersDialog) focus(i int) {
+	if i < 0 || i >= len(d.inputs) {
+		return
+	}
+	d.inputs[d.current].Blur()
+	d.current = i
+	d.inputs[d.current].Focus()
+}
+
+// filled returns the template with the placeholders replaced by the values
+// entered so far.
+func (d *promptPlaceholdersDialog) filled() string {
+	values := make(map[string]string, len(d.placeholders))
+	for i, name := range d.placeholders {
+		values[name] = d.inputs[i].Value()
+	}
+	return fillPromptTemplate(d.template.Content, values)
+}
+
+func (d *promptPlaceholdersDialog) Position() (row, col int) {
+	return d.CenterDialog(d.View())
+}
+
+func (d *promptPlaceholdersDialog) View() string {
+	dialogWidth := d.ComputeDialogWidth(60, 40, 80)
+	contentWidth := d.ContentWidth(dialogWidth, 2)
+
+	content := NewContent(contentWidth).
+		AddTitle("Prompt: " + d.template.Name).
+		AddSeparator().
+		AddSpace()
+
+	for i, name := range d.placeholders {
+		label := styles.DialogContentStyle
+		if i == d.current {
+			label = label.Bold(true)
+		}
+		d.inputs[i].SetWidth(contentWidth)
+		content.AddContent(label.Render(name))
+		content.AddContent(d.inputs[i].View())
+		if i < len(d.placeholders)-1 {
+			content.AddSpace()
+		}
+	}
+
+	content.AddSpace()
+	content.AddHelpKeys("↑↓", "navigate", "enter", "next/insert", "Esc", "cancel")
+
+	return styles.DialogStyle.
+		Padding(1, 2).
+		Width(dialogWidth).
+		Render(content.Build())
+}
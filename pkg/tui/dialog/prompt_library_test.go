@@ -0,0 +1,89 @@
+package dialog
+
+import (
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/docker/cagent/pkg/tui/messages"
+	"github.com/docker/cagent/pkg/tui/service/tuistate"
+)
+
+func TestPromptPlaceholders(t *testing.T) {
+	t.Parallel()
+
+	content := `Write {kind} tests for {file}, then run the {kind} suite. Keep {"json": true} and {1} as is.`
+	assert.Equal(t, []string{"kind", "file"}, promptPlaceholders(content))
+	assert.Empty(t, promptPlaceholders("Review this PR"))
+
+	filled := fillPromptTemplate(content, map[string]string{"kind": "unit", "file": "main.go"})
+	assert.Equal(t, `Write unit tests for main.go, then run the unit suite. Keep {"json": true} and {1} as is.`, filled)
+	assert.Equal(t, "Hi {name}", fillPromptTemplate("Hi {name}", nil))
+}
+
+func TestPromptLibraryDialog(t *testing.T) {
+	t.Parallel()
+
+	prompts := []tuistate.PromptTemplate{
+		{Name: "review", Content: "Review this PR"},
+		{Name: "tests", Content: "Write tests for {file}"},
+	}
+	d := NewPromptLibraryDialog(func() []tuistate.PromptTemplate { return prompts })
+	d.SetSize(100, 40)
+
+	view := d.View()
+	assert.Contains(t, view, "Prompts (2)")
+	assert.Contains(t, view, "review")
+	assert.Contains(t, view, "Review this PR")
+
+	_, _ = d.Update(tea.KeyPressMsg{Code: tea.KeyDown})
+	assert.Contains(t, d.View(), "Write tests for {file}")
+	_, cmd := d.Update(tea.KeyPressMsg{Code: 'd', Text: "d"})
+	require.NotNil(t, cmd)
+	assert.Equal(t, messages.DeletePromptTemplateMsg{Name: "tests"}, cmd())
+
+	_, cmd = d.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	require.NotNil(t, cmd)
+
+	// The list follows the templates
+	prompts = nil
+	assert.Contains(t, d.View(), "No prompts")
+	_, cmd = d.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	assert.Nil(t, cmd)
+
+	_, cmd = d.Update(tea.KeyPressMsg{Code: tea.KeyEscape})
+	require.NotNil(t, cmd)
+	assert.Equal(t, CloseDialogMsg{}, cmd())
+}
+
+func TestPromptPlaceholdersDialog(t *testing.T) {
+	t.Parallel()
+
+	template := tuistate.PromptTemplate{Name: "tests", Content: "Write {kind} tests for {file}"}
+	d := NewPromptPlaceholdersDialog(template, promptPlaceholders(template.Content)).(*promptPlaceholdersDialog)
+	d.SetSize(100, 40)
+
+	view := d.View()
+	assert.Contains(t, view, "Prompt: tests")
+	assert.Contains(t, view, "kind")
+	assert.Contains(t, view, "file")
+
+	typeText := func(s string) {
+		for _, r := range s {
+			_, _ = d.Update(tea.KeyPressMsg{Code: r, Text: string(r)})
+		}
+	}
+
+	typeText("unit")
+	// Enter on the first placeholder moves to the next one
+	_, cmd := d.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	assert.Nil(t, cmd)
+	assert.Equal(t, 1, d.current)
+	typeText("main.go")
+
+	assert.Equal(t, "Write unit tests for main.go", d.filled())
+	_, cmd = d.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	require.NotNil(t, cmd)
+}
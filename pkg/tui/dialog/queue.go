@@ -0,0 +1,208 @@
+package dialog
+
+import (
+	"fmt"
+	"strings"
+
+	"charm.land/bubbles/v2/key"
+	"charm.land/bubbles/v2/textarea"
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/docker/cagent/pkg/tui/components/toolcommon"
+	"github.com/docker/cagent/pkg/tui/core"
+	"github.com/docker/cagent/pkg/tui/core/layout"
+	"github.com/docker/cagent/pkg/tui/messages"
+	"github.com/docker/cagent/pkg/tui/styles"
+)
+
+type queueKeyMap struct {
+	Up       key.Binding
+	Down     key.Binding
+	MoveUp   key.Binding
+	MoveDown key.Binding
+	Edit     key.Binding
+	Remove   key.Binding
+	Close    key.Binding
+	Save     key.Binding
+	Cancel   key.Binding
+}
+
+// queueDialog lists the queued messages and lets the user reorder, edit or
+// remove them before they are sent.
+type queueDialog struct {
+	BaseDialog
+	// entries returns the current queue. It is called on every render so the
+	// dialog follows messages leaving the queue while it is open.
+	entries  func() []messages.QueuedEntry
+	selected int
+	keyMap   queueKeyMap
+
+	// editingID is the ID of the queued message being edited, if editing.
+	editing   bool
+	editingID int
+	input     textarea.Model
+}
+
+// NewQueueDialog creates a dialog managing the queued messages returned by
+// entries. Changes are sent as RemoveQueuedMsg, MoveQueuedMsg and
+// EditQueuedMsg, which name the messages by ID so that they never apply to
+// another message if the queue moved on in the meantime.
+func NewQueueDialog(entries func() []messages.QueuedEntry) Dialog {
+	ta := textarea.New()
+	ta.SetStyles(styles.InputStyle)
+	ta.Prompt = ""
+	ta.CharLimit = -1
+	ta.ShowLineNumbers = false
+	ta.SetHeight(4)
+	ta.KeyMap.InsertNewline.SetKeys("shift+enter", "ctrl+j")
+
+	return &queueDialog{
+		entries: entries,
+		input:   ta,
+		keyMap: queueKeyMap{
+			Up:       key.NewBinding(key.WithKeys("up", "k")),
+			Down:     key.NewBinding(key.WithKeys("down", "j")),
+			MoveUp:   key.NewBinding(key.WithKeys("shift+up", "K")),
+			MoveDown: key.NewBinding(key.WithKeys("shift+down", "J")),
+			Edit:     key.NewBinding(key.WithKeys("enter", "e")),
+			Remove:   key.NewBinding(key.WithKeys("d", "delete", "backspace")),
+			Close:    key.NewBinding(key.WithKeys("esc", "q")),
+			Save:     key.NewBinding(key.WithKeys("enter")),
+			Cancel:   key.NewBinding(key.WithKeys("esc")),
+		},
+	}
+}
+
+func (d *queueDialog) Init() tea.Cmd {
+	return nil
+}
+
+func (d *queueDialog) Update(msg tea.Msg) (layout.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		cmd := d.SetSize(msg.Width, msg.Height)
+		return d, cmd
+
+	case tea.KeyPressMsg:
+		if d.editing {
+			return d, d.handleEditKey(msg)
+		}
+		return d, d.handleListKey(msg)
+	}
+
+	if d.editing {
+		var cmd tea.Cmd
+		d.input, cmd = d.input.Update(msg)
+		return d, cmd
+	}
+	return d, nil
+}
+
+func (d *queueDialog) handleListKey(msg tea.KeyPressMsg) tea.Cmd {
+	entries := d.entries()
+	d.selected = max(0, min(d.selected, len(entries)-1))
+
+	switch {
+	case key.Matches(msg, d.keyMap.Close):
+		return core.CmdHandler(CloseDialogMsg{})
+	case len(entries) == 0:
+		return nil
+	case key.Matches(msg, d.keyMap.MoveUp):
+		if d.selected == 0 {
+			return nil
+		}
+		d.selected--
+		return core.CmdHandler(messages.MoveQueuedMsg{ID: entries[d.selected+1].ID, Offset: -1})
+	case key.Matches(msg, d.keyMap.MoveDown):
+		if d.selected == len(entries)-1 {
+			return nil
+		}
+		d.selected++
+		return core.CmdHandler(messages.MoveQueuedMsg{ID: entries[d.selected-1].ID, Offset: 1})
+	case key.Matches(msg, d.keyMap.Up):
+		d.selected = max(0, d.selected-1)
+	case key.Matches(msg, d.keyMap.Down):
+		d.selected = min(len(entries)-1, d.selected+1)
+	case key.Matches(msg, d.keyMap.Remove):
+		return core.CmdHandler(messages.RemoveQueuedMsg{ID: entries[d.selected].ID})
+	case key.Matches(msg, d.keyMap.Edit):
+		d.editing = true
+		d.editingID = entries[d.selected].ID
+		d.input.SetValue(entries[d.selected].Content)
+		return d.input.Focus()
+	}
+	return nil
+}
+
+func (d *queueDialog) handleEditKey(msg tea.KeyPressMsg) tea.Cmd {
+	switch {
+	case key.Matches(msg, d.keyMap.Cancel):
+		d.stopEditing()
+		return nil
+	case key.Matches(msg, d.keyMap.Save):
+		edit := messages.EditQueuedMsg{ID: d.editingID, Content: d.input.Value()}
+		d.stopEditing()
+		return core.CmdHandler(edit)
+	}
+
+	var cmd tea.Cmd
+	d.input, cmd = d.input.Update(msg)
+	return cmd
+}
+
+func (d *queueDialog) stopEditing() {
+	d.editing = false
+	d.editingID = 0
+	d.input.Blur()
+	d.input.Reset()
+}
+
+func (d *queueDialog) Position() (row, col int) {
+	return d.CenterDialog(d.View())
+}
+
+func (d *queueDialog) View() string {
+	dialogWidth := d.ComputeDialogWidth(60, 40, 90)
+	contentWidth := d.ContentWidth(dialogWidth, 2)
+	entries := d.entries()
+	d.selected = max(0, min(d.selected, len(entries)-1))
+
+	content := NewContent(contentWidth).
+		AddTitle(fmt.Sprintf("Queued Messages (%d)", len(entries))).
+		AddSeparator().
+		AddSpace()
+
+	if len(entries) == 0 {
+		content.AddContent(styles.MutedStyle.Render("No queued messages"))
+	}
+	for i, entry := range entries {
+		if d.editing && entry.ID == d.editingID {
+			d.input.SetWidth(contentWidth)
+			content.AddContent(d.input.View())
+			continue
+		}
+		content.AddContent(d.renderEntry(i, entry.Content, i == d.selected, contentWidth))
+	}
+
+	content.AddSpace()
+	if d.editing {
+		content.AddHelpKeys("Enter", "save", "Shift+Enter", "newline", "Esc", "cancel")
+	} else {
+		content.AddHelpKeys("↑↓", "navigate", "Shift+↑↓", "move", "Enter", "edit", "d", "remove", "Esc", "close")
+	}
+
+	return styles.DialogStyle.
+		Padding(1, 2).
+		Width(dialogWidth).
+		Render(content.Build())
+}
+
+func (d *queueDialog) renderEntry(i int, entry string, selected bool, contentWidth int) string {
+	prefix := fmt.Sprintf("%d. ", i+1)
+	preview := strings.Join(strings.Fields(entry), " ")
+	line := prefix + toolcommon.TruncateText(preview, contentWidth-len(prefix))
+	if selected {
+		return styles.PaletteSelectedActionStyle.Render(line)
+	}
+	return styles.PaletteUnselectedActionStyle.Render(line)
+}
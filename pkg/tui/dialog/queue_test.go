@@ -0,0 +1,55 @@
+package dialog
+
+import (
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/docker/cagent/pkg/tui/messages"
+)
+
+func TestQueueDialog(t *testing.T) {
+	t.Parallel()
+
+	queue := []messages.QueuedEntry{{ID: 1, Content: "first"}, {ID: 2, Content: "second"}, {ID: 3, Content: "third"}}
+	d := NewQueueDialog(func() []messages.QueuedEntry { return queue })
+	d.SetSize(100, 40)
+
+	view := d.View()
+	assert.Contains(t, view, "Queued Messages (3)")
+	assert.Contains(t, view, "1. first")
+	assert.Contains(t, view, "3. third")
+
+	// Moving the selected entry down follows it
+	_, cmd := d.Update(tea.KeyPressMsg{Code: tea.KeyDown, Mod: tea.ModShift})
+	require.NotNil(t, cmd)
+	assert.Equal(t, messages.MoveQueuedMsg{ID: 1, Offset: 1}, cmd())
+
+	_, cmd = d.Update(tea.KeyPressMsg{Code: 'd', Text: "d"})
+	require.NotNil(t, cmd)
+	assert.Equal(t, messages.RemoveQueuedMsg{ID: 2}, cmd())
+
+	// Editing
+	_, _ = d.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	for _, r := range "!" {
+		_, _ = d.Update(tea.KeyPressMsg{Code: r, Text: string(r)})
+	}
+	// The first message is sent while editing: the edit still names the
+	// message being edited.
+	queue = queue[1:]
+	_, cmd = d.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	require.NotNil(t, cmd)
+	assert.Equal(t, messages.EditQueuedMsg{ID: 2, Content: "second!"}, cmd())
+
+	// The list follows the queue
+	queue = nil
+	assert.Contains(t, d.View(), "No queued messages")
+	_, cmd = d.Update(tea.KeyPressMsg{Code: 'd', Text: "d"})
+	assert.Nil(t, cmd)
+
+	_, cmd = d.Update(tea.KeyPressMsg{Code: tea.KeyEscape})
+	require.NotNil(t, cmd)
+	assert.Equal(t, CloseDialogMsg{}, cmd())
+}
@@ -29,6 +29,7 @@ type sessionBrowserKeyMap struct {
 	Star       key.Binding
 	FilterStar key.Binding
 	CopyID     key.Binding
+	Duplicate  key.Binding
 }
 
 // Session browser dialog dimension constants
@@ -81,6 +82,7 @@ func NewSessionBrowserDialog(sessions []session.Summary) Dialog {
 			Star:       key.NewBinding(key.WithKeys("ctrl+s")),
 			FilterStar: key.NewBinding(key.WithKeys("ctrl+f")),
 			CopyID:     key.NewBinding(key.WithKeys("ctrl+y")),
+			Duplicate:  key.NewBinding(key.WithKeys("ctrl+d")),
 		},
 		openedAt: time.Now(),
 	}
@@ -186,6 +188,15 @@ func (d *sessionBrowserDialog) Update(msg tea.Msg) (layout.Model, tea.Cmd) {
 			d.filterSessions()
 			return d, nil
 
+		case key.Matches(msg, d.keyMap.Duplicate):
+			if d.selected >= 0 && d.selected < len(d.filtered) {
+				return d, tea.Sequence(
+					core.CmdHandler(CloseDialogMsg{}),
+					core.CmdHandler(messages.DuplicateSessionMsg{SessionID: d.filtered[d.selected].ID}),
+				)
+			}
+			return d, nil
+
 		case key.Matches(msg, d.keyMap.CopyID):
 			if d.selected >= 0 && d.selected < len(d.filtered) {
 				sessionID := d.filtered[d.selected].ID
@@ -332,7 +343,7 @@ func (d *sessionBrowserDialog) View() string {
 		AddSeparator().
 		AddContent(idFooter).
 		AddSpace().
-		AddHelpKeys("↑/↓", "navigate", "ctrl+s", "star", "ctrl+f", filterDesc, "ctrl+y", "copy id", "enter", "load", "esc", "close").
+		AddHelpKeys("↑/↓", "navigate", "ctrl+s", "star", "ctrl+f", filterDesc, "ctrl+y", "copy id", "ctrl+d", "duplicate", "enter", "load", "esc", "close").
 		Build()
 
 	return styles.DialogStyle.Width(dialogWidth).Render(content)
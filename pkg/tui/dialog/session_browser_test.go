@@ -10,6 +10,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/docker/cagent/pkg/session"
+	"github.com/docker/cagent/pkg/tui/messages"
 )
 
 func TestSessionBrowserNavigation(t *testing.T) {
@@ -95,6 +96,30 @@ func TestSessionBrowserNavigationWithCtrl(t *testing.T) {
 	require.False(t, key.Matches(ctrlY, d.keyMap.Up), "ctrl+y should not match keyMap.Up")
 }
 
+func TestSessionBrowserDuplicate(t *testing.T) {
+	sessions := []session.Summary{
+		{ID: "1", Title: "Session 1", CreatedAt: time.Now()},
+		{ID: "2", Title: "Session 2", CreatedAt: time.Now()},
+	}
+
+	d := NewSessionBrowserDialog(sessions).(*sessionBrowserDialog)
+	d.Init()
+	d.Update(tea.WindowSizeMsg{Width: 100, Height: 50})
+	d.Update(tea.KeyPressMsg{Code: tea.KeyDown})
+
+	_, cmd := d.Update(tea.KeyPressMsg{Code: 'd', Mod: tea.ModCtrl})
+	require.NotNil(t, cmd)
+
+	var found bool
+	for _, msg := range collectMsgs(cmd) {
+		if dup, ok := msg.(messages.DuplicateSessionMsg); ok {
+			found = true
+			require.Equal(t, d.filtered[1].ID, dup.SessionID)
+		}
+	}
+	require.True(t, found, "ctrl+d should duplicate the selected session")
+}
+
 func TestSessionBrowserViewShowsSelection(t *testing.T) {
 	sessions := []session.Summary{
 		{ID: "1", Title: "Session 1", CreatedAt: time.Now()},
@@ -0,0 +1,236 @@
+package dialog
+
+import (
+	"fmt"
+	"strings"
+
+	"charm.land/bubbles/v2/key"
+	"charm.land/bubbles/v2/textinput"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+
+	"github.com/docker/cagent/pkg/tui/components/toolcommon"
+	"github.com/docker/cagent/pkg/tui/core"
+	"github.com/docker/cagent/pkg/tui/core/layout"
+	"github.com/docker/cagent/pkg/tui/messages"
+	"github.com/docker/cagent/pkg/tui/styles"
+)
+
+// SessionsOverviewKey opens, and closes, the sessions overview.
+const SessionsOverviewKey = "ctrl+]"
+
+type sessionsOverviewKeyMap struct {
+	Up     key.Binding
+	Down   key.Binding
+	Switch key.Binding
+	Reply  key.Binding
+	Close  key.Binding
+	Send   key.Binding
+	Cancel key.Binding
+}
+
+// sessionsOverviewDialog lists all the open sessions with their status and
+// usage, and switches to the selected one. The questions that sessions wait
+// on can be answered from the list, without switching to them.
+type sessionsOverviewDialog struct {
+	BaseDialog
+	// entries returns the current sessions. It is called on every render so
+	// the stats stay live while the dialog is open.
+	entries  func() []messages.SessionStats
+	selected int
+	keyMap   sessionsOverviewKeyMap
+
+	// replyingTo is the ID of the session being answered, if replying.
+	replying   bool
+	replyingTo string
+	input      textinput.Model
+}
+
+// NewSessionsOverviewDialog creates a dialog listing the sessions returned by
+// entries, with the active one selected. Switches are sent as SwitchTabMsg
+// and answers as ReplyToSessionMsg.
+func NewSessionsOverviewDialog(entries func() []messages.SessionStats) Dialog {
+	ti := textinput.New()
+	ti.SetStyles(styles.DialogInputStyle)
+	ti.Prompt = ""
+	ti.Placeholder = "Type your answer"
+	ti.CharLimit = defaultCharLimit
+
+	d := &sessionsOverviewDialog{
+		entries: entries,
+		input:   ti,
+		keyMap: sessionsOverviewKeyMap{
+			Up:     key.NewBinding(key.WithKeys("up", "k")),
+			Down:   key.NewBinding(key.WithKeys("down", "j")),
+			Switch: key.NewBinding(key.WithKeys("enter")),
+			Reply:  key.NewBinding(key.WithKeys("r")),
+			Close:  key.NewBinding(key.WithKeys("esc", "q", SessionsOverviewKey)),
+			Send:   key.NewBinding(key.WithKeys("enter")),
+			Cancel: key.NewBinding(key.WithKeys("esc")),
+		},
+	}
+	for i, s := range entries() {
+		if s.IsActive {
+			d.selected = i
+		}
+	}
+	return d
+}
+
+func (d *sessionsOverviewDialog) Init() tea.Cmd {
+	return nil
+}
+
+func (d *sessionsOverviewDialog) Update(msg tea.Msg) (layout.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		cmd := d.SetSize(msg.Width, msg.Height)
+		return d, cmd
+
+	case tea.KeyPressMsg:
+		if d.replying {
+			return d, d.handleReplyKey(msg)
+		}
+		return d, d.handleKey(msg)
+	}
+
+	if d.replying {
+		var cmd tea.Cmd
+		d.input, cmd = d.input.Update(msg)
+		return d, cmd
+	}
+	return d, nil
+}
+
+func (d *sessionsOverviewDialog) handleKey(msg tea.KeyPressMsg) tea.Cmd {
+	entries := d.entries()
+	d.selected = max(0, min(d.selected, len(entries)-1))
+
+	switch {
+	case key.Matches(msg, d.keyMap.Close):
+		return core.CmdHandler(CloseDialogMsg{})
+	case len(entries) == 0:
+		return nil
+	case key.Matches(msg, d.keyMap.Up):
+		d.selected = max(0, d.selected-1)
+	case key.Matches(msg, d.keyMap.Down):
+		d.selected = min(len(entries)-1, d.selected+1)
+	case key.Matches(msg, d.keyMap.Switch):
+		return tea.Sequence(
+			core.CmdHandler(CloseDialogMsg{}),
+			core.CmdHandler(messages.SwitchTabMsg{SessionID: entries[d.selected].SessionID}),
+		)
+	case key.Matches(msg, d.keyMap.Reply):
+		if entries[d.selected].Question == "" {
+			return nil
+		}
+		d.replying = true
+		d.replyingTo = entries[d.selected].SessionID
+		return d.input.Focus()
+	}
+	return nil
+}
+
+func (d *sessionsOverviewDialog) handleReplyKey(msg tea.KeyPressMsg) tea.Cmd {
+	switch {
+	case key.Matches(msg, d.keyMap.Cancel):
+		d.stopReplying()
+		return nil
+	case key.Matches(msg, d.keyMap.Send):
+		answer := strings.TrimSpace(d.input.Value())
+		if answer == "" {
+			return nil
+		}
+		reply := messages.ReplyToSessionMsg{SessionID: d.replyingTo, Answer: answer}
+		d.stopReplying()
+		return core.CmdHandler(reply)
+	}
+
+	var cmd tea.Cmd
+	d.input, cmd = d.input.Update(msg)
+	return cmd
+}
+
+func (d *sessionsOverviewDialog) stopReplying() {
+	d.replying = false
+	d.replyingTo = ""
+	d.input.Blur()
+	d.input.Reset()
+}
+
+func (d *sessionsOverviewDialog) Position() (row, col int) {
+	return d.CenterDialog(d.View())
+}
+
+func (d *sessionsOverviewDialog) View() string {
+	dialogWidth := d.ComputeDialogWidth(70, 50, 100)
+	contentWidth := d.ContentWidth(dialogWidth, 2)
+	entries := d.entries()
+	d.selected = max(0, min(d.selected, len(entries)-1))
+
+	content := NewContent(contentWidth).
+		AddTitle(fmt.Sprintf("Sessions (%d)", len(entries))).
+		AddSeparator().
+		AddSpace()
+
+	for i, s := range entries {
+		status := sessionStatus(s)
+		title := toolcommon.TruncateText(s.Title, max(10, contentWidth-lipgloss.Width(status)-1))
+		gap := max(1, contentWidth-lipgloss.Width(title)-lipgloss.Width(status))
+		line := title + strings.Repeat(" ", gap) + status
+
+		if i == d.selected {
+			content.AddContent(styles.PaletteSelectedActionStyle.Render(line))
+		} else {
+			content.AddContent(styles.PaletteUnselectedActionStyle.Render(line))
+		}
+		content.AddContent(styles.MutedStyle.Render(toolcommon.TruncateText(sessionDetails(s), contentWidth)))
+		if s.Question != "" {
+			content.AddContent(styles.WarningStyle.Render(toolcommon.TruncateText("  ? "+s.Question, contentWidth)))
+		}
+		if d.replying && s.SessionID == d.replyingTo {
+			d.input.SetWidth(contentWidth)
+			content.AddContent(d.input.View())
+		}
+	}
+
+	content.AddSpace()
+	switch {
+	case d.replying:
+		content.AddHelpKeys("Enter", "send", "Esc", "cancel")
+	case len(entries) > 0 && entries[d.selected].Question != "":
+		content.AddHelpKeys("↑↓", "navigate", "enter", "switch", "r", "reply", "Esc", "close")
+	default:
+		content.AddHelpKeys("↑↓", "navigate", "enter", "switch", "Esc", "close")
+	}
+
+	return styles.DialogStyle.
+		Padding(1, 2).
+		Width(dialogWidth).
+		Render(content.Build())
+}
+
+// sessionStatus describes what a session is doing.
+func sessionStatus(s messages.SessionStats) string {
+	switch {
+	case s.Scratchpad:
+		return styles.MutedStyle.Render("notes")
+	case s.NeedsAttention:
+		return styles.WarningStyle.Render("needs attention")
+	case s.IsQueued:
+		return styles.InfoStyle.Render("queued")
+	case s.IsRunning:
+		return styles.SuccessStyle.Render("running")
+	default:
+		return styles.MutedStyle.Render("idle")
+	}
+}
+
+// sessionDetails describes the working directory and usage of a session.
+func sessionDetails(s messages.SessionStats) string {
+	if s.Scratchpad {
+		return "  Not sent to any agent"
+	}
+	return fmt.Sprintf("  %s · %d messages · %s tokens · %s",
+		styles.DisplayPath(s.WorkingDir), s.Messages, formatTokenCount(s.Tokens), formatCost(s.Cost))
+}
@@ -0,0 +1,73 @@
+package dialog
+
+import (
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/docker/cagent/pkg/tui/messages"
+)
+
+func TestSessionsOverviewDialog(t *testing.T) {
+	t.Parallel()
+
+	sessions := []messages.SessionStats{
+		{TabInfo: messages.TabInfo{SessionID: "a", Title: "Refactor"}, WorkingDir: "/src/app", Messages: 12, Tokens: 4200, Cost: 0.12},
+		{TabInfo: messages.TabInfo{SessionID: "b", Title: "Docs", IsActive: true, NeedsAttention: true}, WorkingDir: "/src/docs"},
+		{TabInfo: messages.TabInfo{SessionID: "c", Title: "Notes"}, Scratchpad: true},
+	}
+	d := NewSessionsOverviewDialog(func() []messages.SessionStats { return sessions })
+	d.SetSize(120, 40)
+
+	view := d.View()
+	assert.Contains(t, view, "Sessions (3)")
+	assert.Contains(t, view, "needs attention")
+	assert.Contains(t, view, "/src/app · 12 messages · 4.2K tokens · $0.12")
+
+	// The stats are live
+	sessions[0].IsRunning = true
+	assert.Contains(t, d.View(), "running")
+
+	// The active session is selected first
+	_, _ = d.Update(tea.KeyPressMsg{Code: tea.KeyUp})
+	_, cmd := d.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	require.NotNil(t, cmd)
+	assert.Contains(t, collectMsgs(cmd), messages.SwitchTabMsg{SessionID: "a"})
+
+	_, cmd = d.Update(tea.KeyPressMsg{Code: ']', Mod: tea.ModCtrl})
+	require.NotNil(t, cmd)
+	assert.Equal(t, CloseDialogMsg{}, cmd())
+}
+
+func TestSessionsOverviewDialog_Reply(t *testing.T) {
+	t.Parallel()
+
+	sessions := []messages.SessionStats{
+		{TabInfo: messages.TabInfo{SessionID: "a", Title: "Refactor", IsActive: true}},
+		{TabInfo: messages.TabInfo{SessionID: "b", Title: "Docs", NeedsAttention: true}, Question: "Which branch?"},
+	}
+	d := NewSessionsOverviewDialog(func() []messages.SessionStats { return sessions })
+	d.SetSize(120, 40)
+
+	// Only sessions waiting on a question can be answered.
+	_, cmd := d.Update(tea.KeyPressMsg{Code: 'r', Text: "r"})
+	assert.Nil(t, cmd)
+	assert.NotContains(t, d.View(), "cancel")
+
+	_, _ = d.Update(tea.KeyPressMsg{Code: tea.KeyDown})
+	view := d.View()
+	assert.Contains(t, view, "? Which branch?")
+	assert.Contains(t, view, "reply")
+
+	_, _ = d.Update(tea.KeyPressMsg{Code: 'r', Text: "r"})
+	assert.Contains(t, d.View(), "cancel")
+	for _, r := range "main" {
+		_, _ = d.Update(tea.KeyPressMsg{Code: r, Text: string(r)})
+	}
+	_, cmd = d.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	require.NotNil(t, cmd)
+	assert.Equal(t, messages.ReplyToSessionMsg{SessionID: "b", Answer: "main"}, cmd())
+	assert.NotContains(t, d.View(), "cancel")
+}
@@ -0,0 +1,175 @@
+package dialog
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"charm.land/bubbles/v2/key"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+
+	"github.com/docker/cagent/pkg/runtime"
+	"github.com/docker/cagent/pkg/tui/components/toolcommon"
+	"github.com/docker/cagent/pkg/tui/core"
+	"github.com/docker/cagent/pkg/tui/core/layout"
+	"github.com/docker/cagent/pkg/tui/styles"
+)
+
+// SettingRow is a toggle shown in the settings dialog.
+type SettingRow struct {
+	// Key is the single key that toggles the setting directly.
+	Key   string
+	Label string
+	// Value reports the current state. It is called on every render so the
+	// dialog reflects toggles applied while it is open.
+	Value func() bool
+	// Toggle is the message sent to flip the setting.
+	Toggle tea.Msg
+}
+
+type settingsKeyMap struct {
+	Up     key.Binding
+	Down   key.Binding
+	Toggle key.Binding
+	Close  key.Binding
+}
+
+// settingsDialog consolidates the TUI toggles in a single place.
+type settingsDialog struct {
+	BaseDialog
+	rows      []SettingRow
+	variables map[string]string
+	retry     *runtime.RetryPolicy
+	selected  int
+	keyMap    settingsKeyMap
+}
+
+// NewSettingsDialog creates a dialog listing the given settings with their
+// current value. Settings are toggled with their key, or with Enter/Space on
+// the selected row; the dialog stays open so several can be changed at once.
+// The variables of the session, set with /set, and the retry policy of the
+// current agent, if known, are listed below them.
+func NewSettingsDialog(rows []SettingRow, variables map[string]string, retry *runtime.RetryPolicy) Dialog {
+	return &settingsDialog{
+		rows:      rows,
+		variables: variables,
+		retry:     retry,
+		keyMap: settingsKeyMap{
+			Up:     key.NewBinding(key.WithKeys("up", "k"), key.WithHelp("↑↓", "navigate")),
+			Down:   key.NewBinding(key.WithKeys("down", "j")),
+			Toggle: key.NewBinding(key.WithKeys("enter", "space"), key.WithHelp("Enter", "toggle")),
+			Close:  key.NewBinding(key.WithKeys("esc", "q"), key.WithHelp("Esc", "close")),
+		},
+	}
+}
+
+func (d *settingsDialog) Init() tea.Cmd {
+	return nil
+}
+
+func (d *settingsDialog) Update(msg tea.Msg) (layout.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		cmd := d.SetSize(msg.Width, msg.Height)
+		return d, cmd
+
+	case tea.KeyPressMsg:
+		switch {
+		case key.Matches(msg, d.keyMap.Close):
+			return d, core.CmdHandler(CloseDialogMsg{})
+		case key.Matches(msg, d.keyMap.Up):
+			d.selected = max(0, d.selected-1)
+			return d, nil
+		case key.Matches(msg, d.keyMap.Down):
+			d.selected = min(len(d.rows)-1, d.selected+1)
+			return d, nil
+		case key.Matches(msg, d.keyMap.Toggle):
+			return d, d.toggle(d.selected)
+		}
+
+		for i, row := range d.rows {
+			if msg.String() == row.Key {
+				d.selected = i
+				return d, d.toggle(i)
+			}
+		}
+	}
+	return d, nil
+}
+
+func (d *settingsDialog) toggle(idx int) tea.Cmd {
+	if idx < 0 || idx >= len(d.rows) {
+		return nil
+	}
+	return core.CmdHandler(d.rows[idx].Toggle)
+}
+
+func (d *settingsDialog) Position() (row, col int) {
+	return d.CenterDialog(d.View())
+}
+
+func (d *settingsDialog) View() string {
+	dialogWidth := d.ComputeDialogWidth(50, 40, 64)
+	contentWidth := d.ContentWidth(dialogWidth, 2)
+
+	content := NewContent(contentWidth).
+		AddTitle("Settings").
+		AddSeparator().
+		AddSpace()
+
+	for i, row := range d.rows {
+		content.AddContent(d.renderRow(row, i == d.selected, contentWidth))
+	}
+
+	content.AddSpace().
+		AddContent(styles.BoldStyle.Render("Session variables"))
+	if len(d.variables) == 0 {
+		content.AddContent(styles.MutedStyle.Render("None · define one with /set NAME=value"))
+	}
+	for _, name := range slices.Sorted(maps.Keys(d.variables)) {
+		line := toolcommon.TruncateText("$"+name+" = "+strings.Join(strings.Fields(d.variables[name]), " "), contentWidth)
+		content.AddContent(styles.SecondaryStyle.Render(line))
+	}
+
+	content.AddSpace().
+		AddContent(styles.BoldStyle.Render("Model retries"))
+	if d.retry == nil {
+		content.AddContent(styles.MutedStyle.Render("Unknown for this agent"))
+	} else {
+		content.AddContent(styles.SecondaryStyle.Render(fmt.Sprintf("%d retries on 5xx and timeouts · backoff %s to %s",
+			d.retry.Retries, d.retry.BaseDelay, d.retry.MaxDelay))).
+			AddContent(styles.SecondaryStyle.Render(fmt.Sprintf("Fallback kept %s after a rate limit", d.retry.Cooldown))).
+			AddContent(styles.MutedStyle.Width(contentWidth).Render("Tune with fallback.retries and fallback.cooldown in the agent config"))
+	}
+
+	content.AddSpace().
+		AddHelpKeys("↑↓", "navigate", "Enter", "toggle", "Esc", "close")
+
+	return styles.DialogStyle.
+		Padding(1, 2).
+		Width(dialogWidth).
+		Render(content.Build())
+}
+
+func (d *settingsDialog) renderRow(row SettingRow, selected bool, contentWidth int) string {
+	var value string
+	if row.Value() {
+		value = lipgloss.NewStyle().Foreground(styles.Success).Render("ON")
+	} else {
+		value = lipgloss.NewStyle().Foreground(styles.TextSecondary).Render("OFF")
+	}
+
+	keyHint := styles.MutedStyle.Render("[" + row.Key + "] ")
+	label := row.Label
+	if selected {
+		label = styles.PaletteSelectedActionStyle.Render(label)
+	} else {
+		label = styles.PaletteUnselectedActionStyle.Render(label)
+	}
+
+	left := keyHint + label
+	gap := max(1, contentWidth-lipgloss.Width(left)-lipgloss.Width(value))
+	return left + lipgloss.NewStyle().Width(gap).Render("") + value
+}
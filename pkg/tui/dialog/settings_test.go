@@ -0,0 +1,82 @@
+package dialog
+
+import (
+	"testing"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/docker/cagent/pkg/runtime"
+)
+
+type toggleAMsg struct{}
+
+type toggleBMsg struct{}
+
+func TestSettingsDialog_Toggle(t *testing.T) {
+	t.Parallel()
+
+	a, b := false, true
+	d := NewSettingsDialog([]SettingRow{
+		{Key: "a", Label: "Setting A", Value: func() bool { return a }, Toggle: toggleAMsg{}},
+		{Key: "b", Label: "Setting B", Value: func() bool { return b }, Toggle: toggleBMsg{}},
+	}, nil, nil)
+	d.SetSize(100, 40)
+
+	view := d.View()
+	assert.Contains(t, view, "Setting A")
+	assert.Contains(t, view, "OFF")
+	assert.Contains(t, view, "ON")
+
+	// Direct key toggles the matching row
+	_, cmd := d.Update(tea.KeyPressMsg{Code: 'b', Text: "b"})
+	require.NotNil(t, cmd)
+	assert.Equal(t, toggleBMsg{}, cmd())
+
+	// Enter toggles the selected row, which followed the last direct key
+	_, cmd = d.Update(tea.KeyPressMsg{Code: tea.KeyUp})
+	assert.Nil(t, cmd)
+	_, cmd = d.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	require.NotNil(t, cmd)
+	assert.Equal(t, toggleAMsg{}, cmd())
+
+	// The dialog reflects the new value on the next render
+	a = true
+	assert.NotContains(t, d.View(), "OFF")
+
+	_, cmd = d.Update(tea.KeyPressMsg{Code: tea.KeyEscape})
+	require.NotNil(t, cmd)
+	assert.Equal(t, CloseDialogMsg{}, cmd())
+}
+
+func TestSettingsDialog_Variables(t *testing.T) {
+	t.Parallel()
+
+	d := NewSettingsDialog(nil, nil, nil)
+	d.SetSize(100, 40)
+	assert.Contains(t, d.View(), "/set NAME=value")
+
+	d = NewSettingsDialog(nil, map[string]string{"PROJECT": "cagent", "ENV": "staging"}, nil)
+	d.SetSize(100, 40)
+	view := d.View()
+	assert.Contains(t, view, "$PROJECT = cagent")
+	assert.Contains(t, view, "$ENV = staging")
+	assert.NotContains(t, view, "/set NAME=value")
+}
+
+func TestSettingsDialog_RetryPolicy(t *testing.T) {
+	t.Parallel()
+
+	d := NewSettingsDialog(nil, nil, nil)
+	d.SetSize(100, 40)
+	assert.Contains(t, d.View(), "Unknown for this agent")
+
+	policy := runtime.EffectiveRetryPolicy(5, 3*time.Minute)
+	d = NewSettingsDialog(nil, nil, &policy)
+	d.SetSize(100, 40)
+	view := d.View()
+	assert.Contains(t, view, "5 retries on 5xx and timeouts")
+	assert.Contains(t, view, "Fallback kept 3m0s after a rate limit")
+}
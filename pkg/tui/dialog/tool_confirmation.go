@@ -2,6 +2,7 @@ package dialog
 
 import (
 	"encoding/json"
+	"fmt"
 	"strings"
 
 	"charm.land/bubbles/v2/key"
@@ -12,6 +13,8 @@ import (
 	"github.com/docker/cagent/pkg/runtime"
 	"github.com/docker/cagent/pkg/tools"
 	"github.com/docker/cagent/pkg/tui/components/messages"
+	"github.com/docker/cagent/pkg/tui/components/notification"
+	"github.com/docker/cagent/pkg/tui/components/tool/editfile"
 	"github.com/docker/cagent/pkg/tui/core"
 	"github.com/docker/cagent/pkg/tui/core/layout"
 	tuimessages "github.com/docker/cagent/pkg/tui/messages"
@@ -43,6 +46,7 @@ type ToolConfirmationResponse struct {
 type toolConfirmationDialog struct {
 	BaseDialog
 	msg               *runtime.ToolCallConfirmationEvent
+	editedArguments   string // arguments of the tool call edited by the user, if any
 	keyMap            toolConfirmationKeyMap
 	sessionState      *service.SessionState
 	scrollView        messages.Model
@@ -72,10 +76,10 @@ func (d *toolConfirmationDialog) SetSize(width, height int) tea.Cmd {
 	separator := d.renderSeparator(contentWidth)
 	separatorHeight := lipgloss.Height(separator)
 
-	question := styles.DialogQuestionStyle.Width(contentWidth).Render("Do you want to allow this tool call?")
+	question := styles.DialogQuestionStyle.Width(contentWidth).Render(d.question())
 	questionHeight := lipgloss.Height(question)
 
-	options := RenderHelpKeys(contentWidth, "Y", "yes", "N", "no", "T", d.alwaysAllowHelpText(), "A", "all tools")
+	options := d.renderOptions(contentWidth)
 	optionsHeight := lipgloss.Height(options)
 
 	// Calculate available height for scroll view
@@ -87,6 +91,24 @@ func (d *toolConfirmationDialog) SetSize(width, height int) tea.Cmd {
 	return nil
 }
 
+// question returns the confirmation question.
+func (d *toolConfirmationDialog) question() string {
+	if d.editedArguments != "" {
+		return "Do you want to apply your edited version of this change?"
+	}
+	return "Do you want to allow this tool call?"
+}
+
+// renderOptions renders the action keys consistently for View and hit-testing.
+func (d *toolConfirmationDialog) renderOptions(contentWidth int) string {
+	bindings := []string{"Y", "yes", "N", "no"}
+	if canEditToolCall(d.msg.ToolCall) {
+		bindings = append(bindings, "E", "edit")
+	}
+	bindings = append(bindings, "T", d.alwaysAllowHelpText(), "A", "all tools")
+	return RenderHelpKeys(contentWidth, bindings...)
+}
+
 // renderSeparator renders the separator line consistently.
 func (d *toolConfirmationDialog) renderSeparator(contentWidth int) string {
 	return RenderSeparator(contentWidth)
@@ -115,6 +137,7 @@ type toolConfirmationKeyMap struct {
 	No       key.Binding
 	All      key.Binding
 	ThisTool key.Binding
+	Edit     key.Binding
 }
 
 // defaultToolConfirmationKeyMap returns default key bindings
@@ -136,6 +159,10 @@ func defaultToolConfirmationKeyMap() toolConfirmationKeyMap {
 			key.WithKeys("t", "T"),
 			key.WithHelp("T", "always allow this tool"),
 		),
+		Edit: key.NewBinding(
+			key.WithKeys("e", "E"),
+			key.WithHelp("E", "edit then apply"),
+		),
 	}
 }
 
@@ -167,18 +194,24 @@ func buildPermissionPattern(toolCall tools.ToolCall) string {
 	return toolName
 }
 
-// NewToolConfirmationDialog creates a new tool confirmation dialog
-func NewToolConfirmationDialog(msg *runtime.ToolCallConfirmationEvent, sessionState *service.SessionState) Dialog {
+// newToolConfirmationView creates the scrollable view of the tool call.
+func newToolConfirmationView(toolCall tools.ToolCall, toolDefinition tools.Tool, sessionState *service.SessionState) messages.Model {
 	// Create scrollable view with minimal initial size (will be updated in SetSize)
 	scrollView := messages.NewScrollableView(1, 1, sessionState)
 
 	// Add the tool call message to the view
 	scrollView.AddOrUpdateToolCall(
 		"", // agentName - empty for dialog context
-		msg.ToolCall,
-		msg.ToolDefinition,
+		toolCall,
+		toolDefinition,
 		types.ToolStatusConfirmation,
 	)
+	return scrollView
+}
+
+// NewToolConfirmationDialog creates a new tool confirmation dialog
+func NewToolConfirmationDialog(msg *runtime.ToolCallConfirmationEvent, sessionState *service.SessionState) Dialog {
+	scrollView := newToolConfirmationView(msg.ToolCall, msg.ToolDefinition, sessionState)
 
 	// Build and cache the permission pattern for display and use
 	pattern := buildPermissionPattern(msg.ToolCall)
@@ -197,14 +230,19 @@ func (d *toolConfirmationDialog) Init() tea.Cmd {
 	return d.scrollView.Init()
 }
 
-// executeAction dispatches a confirmation action by key ("Y", "N", "T", "A").
+// executeAction dispatches a confirmation action by key ("Y", "N", "E", "T", "A").
 func (d *toolConfirmationDialog) executeAction(action string) (layout.Model, tea.Cmd) {
 	switch action {
 	case "Y":
 		return d, tea.Sequence(
 			core.CmdHandler(CloseDialogMsg{}),
-			core.CmdHandler(RuntimeResumeMsg{Request: runtime.ResumeApprove()}),
+			core.CmdHandler(RuntimeResumeMsg{Request: d.withEditedArguments(runtime.ResumeApprove())}),
 		)
+	case "E":
+		if !canEditToolCall(d.msg.ToolCall) {
+			return d, nil
+		}
+		return d, editToolCallCmd(d.toolCall(), d.msg.ToolDefinition)
 	case "N":
 		return d, core.CmdHandler(OpenDialogMsg{
 			Model: NewToolRejectionReasonDialog(),
@@ -212,13 +250,13 @@ func (d *toolConfirmationDialog) executeAction(action string) (layout.Model, tea
 	case "T":
 		return d, tea.Sequence(
 			core.CmdHandler(CloseDialogMsg{}),
-			core.CmdHandler(RuntimeResumeMsg{Request: runtime.ResumeApproveTool(d.permissionPattern)}),
+			core.CmdHandler(RuntimeResumeMsg{Request: d.withEditedArguments(runtime.ResumeApproveTool(d.permissionPattern))}),
 		)
 	case "A":
 		d.sessionState.SetYoloMode(true)
 		return d, tea.Sequence(
 			core.CmdHandler(CloseDialogMsg{}),
-			core.CmdHandler(RuntimeResumeMsg{Request: runtime.ResumeApproveSession()}),
+			core.CmdHandler(RuntimeResumeMsg{Request: d.withEditedArguments(runtime.ResumeApproveSession())}),
 		)
 	}
 	return d, nil
@@ -251,6 +289,8 @@ func (d *toolConfirmationDialog) Update(msg tea.Msg) (layout.Model, tea.Cmd) {
 			return d.executeAction("A")
 		case key.Matches(msg, d.keyMap.ThisTool):
 			return d.executeAction("T")
+		case key.Matches(msg, d.keyMap.Edit):
+			return d.executeAction("E")
 		}
 
 		// Forward scrolling keys to the scroll view
@@ -264,11 +304,48 @@ func (d *toolConfirmationDialog) Update(msg tea.Msg) (layout.Model, tea.Cmd) {
 		updatedScrollView, cmd := d.scrollView.Update(msg)
 		d.scrollView = updatedScrollView.(messages.Model)
 		return d, cmd
+
+	case toolCallEditedMsg:
+		return d, d.handleEdited(msg)
 	}
 
 	return d, nil
 }
 
+// toolCall returns the tool call to confirm, with the user's edits.
+func (d *toolConfirmationDialog) toolCall() tools.ToolCall {
+	toolCall := d.msg.ToolCall
+	if d.editedArguments != "" {
+		toolCall.Function.Arguments = d.editedArguments
+	}
+	return toolCall
+}
+
+// withEditedArguments makes the approval run the tool call edited by the
+// user, whichever way it is approved.
+func (d *toolConfirmationDialog) withEditedArguments(request runtime.ResumeRequest) runtime.ResumeRequest {
+	request.Arguments = d.editedArguments
+	return request
+}
+
+// handleEdited shows the change edited by the user, to be approved with Y.
+func (d *toolConfirmationDialog) handleEdited(msg toolCallEditedMsg) tea.Cmd {
+	if msg.toolCallID != d.msg.ToolCall.ID {
+		return nil
+	}
+	if msg.err != nil {
+		return notification.ErrorCmd(fmt.Sprintf("Failed to edit the change: %v", msg.err))
+	}
+	if msg.arguments == "" {
+		return nil
+	}
+
+	d.editedArguments = msg.arguments
+	editfile.InvalidateCache(d.msg.ToolCall.ID)
+	d.scrollView = newToolConfirmationView(d.toolCall(), d.msg.ToolDefinition, d.sessionState)
+	return tea.Batch(d.scrollView.Init(), d.SetSize(d.Width(), d.Height()))
+}
+
 // handleMouseClick handles mouse clicks on the action buttons (Y/N/T/A).
 func (d *toolConfirmationDialog) handleMouseClick(msg tea.MouseClickMsg) (layout.Model, tea.Cmd) {
 	dialogRow, dialogCol := d.Position()
@@ -282,7 +359,7 @@ func (d *toolConfirmationDialog) handleMouseClick(msg tea.MouseClickMsg) (layout
 
 	// Render the help keys and strip ANSI to get plain text for hit-testing.
 	_, contentWidth := d.dialogDimensions()
-	options := RenderHelpKeys(contentWidth, "Y", "yes", "N", "no", "T", d.alwaysAllowHelpText(), "A", "all tools")
+	options := d.renderOptions(contentWidth)
 	optionsPlain := ansi.Strip(options)
 
 	// Content starts after left border + padding.
@@ -297,9 +374,9 @@ func (d *toolConfirmationDialog) handleMouseClick(msg tea.MouseClickMsg) (layout
 	}
 
 	// Walk backward from the click position to find the nearest action key.
-	// The plain text looks like: "Y yes  N no  T always allow...  A all tools"
+	// The plain text looks like: "Y yes  N no  E edit  T always allow...  A all tools"
 	// Each region starts with its uppercase action key.
-	actionKeys := "YNTA"
+	actionKeys := "YNETA"
 	for i := relX; i >= 0; i-- {
 		if strings.ContainsRune(actionKeys, rune(optionsPlain[i])) {
 			return d.executeAction(string(optionsPlain[i]))
@@ -332,8 +409,8 @@ func (d *toolConfirmationDialog) View() string {
 	}
 
 	// Confirmation prompt
-	question := styles.DialogQuestionStyle.Width(contentWidth).Render("Do you want to allow this tool call?")
-	options := RenderHelpKeys(contentWidth, "Y", "yes", "N", "no", "T", d.alwaysAllowHelpText(), "A", "all tools")
+	question := styles.DialogQuestionStyle.Width(contentWidth).Render(d.question())
+	options := d.renderOptions(contentWidth)
 
 	parts = append(parts, "", question, "", options)
 
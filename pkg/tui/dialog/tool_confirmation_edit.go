@@ -0,0 +1,107 @@
+package dialog
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/docker/cagent/pkg/tools"
+	"github.com/docker/cagent/pkg/tools/builtin"
+	"github.com/docker/cagent/pkg/tui/core"
+)
+
+// toolCallEditedMsg is sent when the user closes the external editor opened
+// to edit the change proposed by an edit_file tool call.
+type toolCallEditedMsg struct {
+	toolCallID string
+	// arguments are the arguments of the edited tool call, or "" when the
+	// user didn't change anything.
+	arguments string
+	err       error
+}
+
+// canEditToolCall reports whether the user can edit the change proposed by
+// the tool call before applying it.
+func canEditToolCall(toolCall tools.ToolCall) bool {
+	return toolCall.Function.Name == builtin.ToolNameEditFile
+}
+
+// proposedEditFileContent returns the content of the file edited by an
+// edit_file tool call, before and after the edits. The path is resolved
+// against the working directory of the tool, like the tool itself does.
+func proposedEditFileContent(toolCall tools.ToolCall, toolDefinition tools.Tool) (args builtin.EditFileArgs, original, proposed string, err error) {
+	if err := json.Unmarshal([]byte(toolCall.Function.Arguments), &args); err != nil {
+		return args, "", "", fmt.Errorf("invalid arguments: %w", err)
+	}
+	content, err := os.ReadFile(builtin.ResolvePath(toolDefinition.WorkingDir, args.Path))
+	if err != nil {
+		return args, "", "", err
+	}
+
+	original = string(content)
+	proposed = original
+	for i, edit := range args.Edits {
+		if !strings.Contains(proposed, edit.OldText) {
+			return args, "", "", fmt.Errorf("edit %d: old text not found", i+1)
+		}
+		proposed = strings.Replace(proposed, edit.OldText, edit.NewText, 1)
+	}
+	return args, original, proposed, nil
+}
+
+// editToolCallCmd opens the content proposed by an edit_file tool call in
+// the external editor. The edited content is sent back as a single edit
+// replacing the whole file.
+func editToolCallCmd(toolCall tools.ToolCall, toolDefinition tools.Tool) tea.Cmd {
+	args, original, proposed, err := proposedEditFileContent(toolCall, toolDefinition)
+	if err != nil {
+		return editFailedCmd(toolCall.ID, err)
+	}
+
+	// Keep the extension so that the editor picks the right syntax highlighting.
+	tmpFile, err := os.CreateTemp("", "cagent-edit-*"+filepath.Ext(args.Path))
+	if err != nil {
+		return editFailedCmd(toolCall.ID, err)
+	}
+	tmpPath := tmpFile.Name()
+	_, err = tmpFile.WriteString(proposed)
+	tmpFile.Close()
+	if err != nil {
+		os.Remove(tmpPath)
+		return editFailedCmd(toolCall.ID, err)
+	}
+
+	return tea.ExecProcess(core.ExternalEditorCommand(tmpPath), func(err error) tea.Msg {
+		defer os.Remove(tmpPath)
+		if err != nil {
+			return toolCallEditedMsg{toolCallID: toolCall.ID, err: err}
+		}
+
+		edited, err := os.ReadFile(tmpPath)
+		if err != nil {
+			return toolCallEditedMsg{toolCallID: toolCall.ID, err: err}
+		}
+		if string(edited) == proposed {
+			return toolCallEditedMsg{toolCallID: toolCall.ID}
+		}
+
+		arguments, err := json.Marshal(builtin.EditFileArgs{
+			Path:  args.Path,
+			Edits: []builtin.Edit{{OldText: original, NewText: string(edited)}},
+		})
+		if err != nil {
+			return toolCallEditedMsg{toolCallID: toolCall.ID, err: err}
+		}
+		return toolCallEditedMsg{toolCallID: toolCall.ID, arguments: string(arguments)}
+	})
+}
+
+func editFailedCmd(toolCallID string, err error) tea.Cmd {
+	return func() tea.Msg {
+		return toolCallEditedMsg{toolCallID: toolCallID, err: err}
+	}
+}
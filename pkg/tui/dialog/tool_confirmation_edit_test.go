@@ -0,0 +1,99 @@
+package dialog
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/docker/cagent/pkg/runtime"
+	"github.com/docker/cagent/pkg/session"
+	"github.com/docker/cagent/pkg/tools"
+	"github.com/docker/cagent/pkg/tools/builtin"
+	"github.com/docker/cagent/pkg/tui/service"
+)
+
+func editFileToolCall(t *testing.T, path string, edits ...builtin.Edit) tools.ToolCall {
+	t.Helper()
+
+	arguments, err := json.Marshal(builtin.EditFileArgs{Path: path, Edits: edits})
+	require.NoError(t, err)
+	return tools.ToolCall{
+		ID:       "call_1",
+		Function: tools.FunctionCall{Name: builtin.ToolNameEditFile, Arguments: string(arguments)},
+	}
+}
+
+func TestProposedEditFileContent(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "main.go")
+	require.NoError(t, os.WriteFile(path, []byte("one\ntwo\nthree\n"), 0o644))
+
+	_, original, proposed, err := proposedEditFileContent(editFileToolCall(t, path,
+		builtin.Edit{OldText: "one", NewText: "1"},
+		builtin.Edit{OldText: "three", NewText: "3"},
+	), tools.Tool{})
+	require.NoError(t, err)
+	assert.Equal(t, "one\ntwo\nthree\n", original)
+	assert.Equal(t, "1\ntwo\n3\n", proposed)
+
+	_, _, _, err = proposedEditFileContent(editFileToolCall(t, path, builtin.Edit{OldText: "four", NewText: "4"}), tools.Tool{})
+	require.ErrorContains(t, err, "edit 1: old text not found")
+}
+
+func TestProposedEditFileContent_RelativeToToolWorkingDir(t *testing.T) {
+	workingDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(workingDir, "main.go"), []byte("one\n"), 0o644))
+
+	args, original, proposed, err := proposedEditFileContent(
+		editFileToolCall(t, "main.go", builtin.Edit{OldText: "one", NewText: "1"}),
+		tools.Tool{Name: builtin.ToolNameEditFile, WorkingDir: workingDir},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, "main.go", args.Path, "the tool resolves the path itself")
+	assert.Equal(t, "one\n", original)
+	assert.Equal(t, "1\n", proposed)
+}
+
+func TestToolConfirmationApprovesEditedArguments(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "main.go")
+	require.NoError(t, os.WriteFile(path, []byte("one\n"), 0o644))
+
+	toolCall := editFileToolCall(t, path, builtin.Edit{OldText: "one", NewText: "1"})
+	d := NewToolConfirmationDialog(&runtime.ToolCallConfirmationEvent{ToolCall: toolCall}, service.NewSessionState(session.New())).(*toolConfirmationDialog)
+	d.SetSize(100, 50)
+	assert.Contains(t, d.View(), "edit")
+
+	// A failed edit is reported and leaves the tool call untouched.
+	_, cmd := d.Update(toolCallEditedMsg{toolCallID: "call_1", err: errors.New("boom")})
+	require.NotNil(t, cmd)
+	assert.Empty(t, d.editedArguments)
+
+	edited := editFileToolCall(t, path, builtin.Edit{OldText: "one\n", NewText: "uno\n"}).Function.Arguments
+	d.Update(toolCallEditedMsg{toolCallID: "call_1", arguments: edited})
+	assert.Equal(t, edited, d.toolCall().Function.Arguments)
+	assert.Contains(t, d.View(), "edited version")
+
+	// Every way of approving runs the edited version.
+	for _, key := range []rune{'y', 't', 'a'} {
+		_, cmd = d.Update(tea.KeyPressMsg{Code: key, Text: string(key)})
+		require.NotNil(t, cmd)
+		var resume *RuntimeResumeMsg
+		for _, msg := range collectMsgs(cmd) {
+			if r, ok := msg.(RuntimeResumeMsg); ok {
+				resume = &r
+			}
+		}
+		require.NotNil(t, resume)
+		assert.Equal(t, edited, resume.Request.Arguments, "key %c", key)
+	}
+}
+
+func TestToolConfirmationEditOnlyForEditFile(t *testing.T) {
+	assert.True(t, canEditToolCall(tools.ToolCall{Function: tools.FunctionCall{Name: builtin.ToolNameEditFile}}))
+	assert.False(t, canEditToolCall(tools.ToolCall{Function: tools.FunctionCall{Name: "shell"}}))
+}
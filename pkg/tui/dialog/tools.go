@@ -0,0 +1,141 @@
+package dialog
+
+import (
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+
+	"github.com/docker/cagent/pkg/runtime"
+	"github.com/docker/cagent/pkg/tui/components/scrollview"
+	"github.com/docker/cagent/pkg/tui/components/toolcommon"
+	"github.com/docker/cagent/pkg/tui/core"
+	"github.com/docker/cagent/pkg/tui/core/layout"
+	"github.com/docker/cagent/pkg/tui/styles"
+)
+
+// toolsDialog is a quick reference of the tools the current agent can use
+// right now. It goes away on any key but the scrolling ones.
+type toolsDialog struct {
+	BaseDialog
+	agent      runtime.AgentDetails
+	scrollview *scrollview.Model
+}
+
+// NewToolsDialog creates a dialog listing the tools of agent, with the first
+// line of their descriptions, and its toolsets that are still loading or
+// failed to start.
+func NewToolsDialog(agent runtime.AgentDetails) Dialog {
+	return &toolsDialog{
+		agent: agent,
+		scrollview: scrollview.New(
+			scrollview.WithKeyMap(scrollview.ReadOnlyScrollKeyMap()),
+			scrollview.WithReserveScrollbarSpace(true),
+		),
+	}
+}
+
+func (d *toolsDialog) Init() tea.Cmd {
+	return nil
+}
+
+func (d *toolsDialog) Update(msg tea.Msg) (layout.Model, tea.Cmd) {
+	if handled, cmd := d.scrollview.Update(msg); handled {
+		return d, cmd
+	}
+
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		cmd := d.SetSize(msg.Width, msg.Height)
+		return d, cmd
+
+	case tea.KeyPressMsg:
+		return d, core.CmdHandler(CloseDialogMsg{})
+	}
+	return d, nil
+}
+
+func (d *toolsDialog) dialogSize() (dialogWidth, maxHeight, contentWidth int) {
+	dialogWidth = d.ComputeDialogWidth(60, 40, 80)
+	maxHeight = min(d.Height()*70/100, 30)
+	contentWidth = d.ContentWidth(dialogWidth, 2) - d.scrollview.ReservedCols()
+	return dialogWidth, maxHeight, contentWidth
+}
+
+func (d *toolsDialog) Position() (row, col int) {
+	dialogWidth, maxHeight, _ := d.dialogSize()
+	return CenterPosition(d.Width(), d.Height(), dialogWidth, maxHeight)
+}
+
+func (d *toolsDialog) View() string {
+	dialogWidth, maxHeight, contentWidth := d.dialogSize()
+	content := d.renderContent(contentWidth, maxHeight)
+	return styles.DialogStyle.Padding(1, 2).Width(dialogWidth).Render(content)
+}
+
+func (d *toolsDialog) renderContent(contentWidth, maxHeight int) string {
+	lines := []string{
+		RenderTitle("Tools of "+d.agent.Name, contentWidth, styles.DialogTitleStyle),
+		RenderSeparator(contentWidth),
+		"",
+	}
+
+	if len(d.agent.ToolNames) == 0 {
+		lines = append(lines, styles.MutedStyle.Render("No tools available."))
+	}
+
+	nameWidth := 0
+	for _, name := range d.agent.ToolNames {
+		nameWidth = max(nameWidth, lipgloss.Width(name))
+	}
+	nameWidth = min(nameWidth, contentWidth/2)
+	nameStyle := lipgloss.NewStyle().Foreground(styles.Highlight).Width(nameWidth)
+	for _, name := range d.agent.ToolNames {
+		line := nameStyle.Render(toolcommon.TruncateText(name, nameWidth))
+		if description := d.agent.ToolDescriptions[name]; description != "" {
+			if available := contentWidth - nameWidth - 2; available > 0 {
+				line += "  " + styles.MutedStyle.Render(toolcommon.TruncateText(description, available))
+			}
+		}
+		lines = append(lines, line)
+	}
+
+	// The toolsets not contributing any tool yet explain the missing ones
+	var pending []string
+	for _, ts := range d.agent.Toolsets {
+		switch ts.Status {
+		case runtime.ToolsetLoading:
+			pending = append(pending, styles.MutedStyle.Render(toolcommon.TruncateText("⋯ "+ts.Name+": loading", contentWidth)))
+		case runtime.ToolsetFailed:
+			failure := "✗ " + ts.Name + ": " + strings.Join(strings.Fields(ts.Error), " ")
+			pending = append(pending, styles.ErrorStyle.Render(toolcommon.TruncateText(failure, contentWidth)))
+		}
+	}
+	if len(pending) > 0 {
+		lines = append(lines, "")
+		lines = append(lines, pending...)
+	}
+
+	return d.applyScrolling(lines, contentWidth, maxHeight)
+}
+
+func (d *toolsDialog) applyScrolling(allLines []string, contentWidth, maxHeight int) string {
+	const headerLines = 3 // title + separator + space
+	const footerLines = 2 // space + help
+
+	visibleLines := max(1, min(len(allLines)-headerLines, maxHeight-headerLines-footerLines-4))
+	contentLines := allLines[headerLines:]
+
+	regionWidth := contentWidth + d.scrollview.ReservedCols()
+	d.scrollview.SetSize(regionWidth, visibleLines)
+
+	// Y offset: border(1) + padding(1) + headerLines(3) = 5
+	dialogRow, dialogCol := d.Position()
+	d.scrollview.SetPosition(dialogCol+3, dialogRow+2+headerLines)
+
+	d.scrollview.SetContent(contentLines, len(contentLines))
+
+	parts := append(allLines[:headerLines], d.scrollview.View())
+	parts = append(parts, "", RenderHelpKeys(regionWidth, "↑↓", "scroll", "any key", "close"))
+	return lipgloss.JoinVertical(lipgloss.Left, parts...)
+}
@@ -0,0 +1,48 @@
+package dialog
+
+import (
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/docker/cagent/pkg/runtime"
+)
+
+func TestToolsDialog(t *testing.T) {
+	t.Parallel()
+
+	d := NewToolsDialog(runtime.AgentDetails{
+		Name:             "root",
+		ToolNames:        []string{"read_file", "shell"},
+		ToolDescriptions: map[string]string{"read_file": "Read the content of a file"},
+		Toolsets: []runtime.ToolsetStatus{
+			{Name: "filesystem", Status: runtime.ToolsetLoaded},
+			{Name: "mcp(github)", Status: runtime.ToolsetLoading},
+			{Name: "mcp(jira)", Status: runtime.ToolsetFailed, Error: "connection refused"},
+		},
+	})
+	d.SetSize(120, 40)
+
+	view := d.View()
+	assert.Contains(t, view, "Tools of root")
+	assert.Contains(t, view, "read_file")
+	assert.Contains(t, view, "Read the content of a file")
+	assert.Contains(t, view, "shell")
+	assert.Contains(t, view, "mcp(github): loading")
+	assert.Contains(t, view, "mcp(jira): connection refused")
+	assert.NotContains(t, view, "filesystem")
+
+	_, cmd := d.Update(tea.KeyPressMsg{Code: 'x', Text: "x"})
+	require.NotNil(t, cmd)
+	assert.Equal(t, CloseDialogMsg{}, cmd(), "any key closes the dialog")
+}
+
+func TestToolsDialogNoTools(t *testing.T) {
+	t.Parallel()
+
+	d := NewToolsDialog(runtime.AgentDetails{Name: "root"})
+	d.SetSize(120, 40)
+	assert.Contains(t, d.View(), "No tools available.")
+}
@@ -960,7 +960,7 @@ func (d *workingDirPickerDialog) renderPinnedEntry(entry dirEntry, selected bool
 
 	prefix := styles.StarredStyle.Render("★") + " "
 	availableWidth := maxWidth - dirPickerStarPrefixWidth
-	displayPath := truncatePath(entry.path, availableWidth)
+	displayPath := truncatePath(styles.DisplayPath(entry.path), availableWidth)
 
 	return prefix + nameStyle.Render(displayPath)
 }
@@ -978,7 +978,7 @@ func (d *workingDirPickerDialog) renderBrowseEntry(entry dirEntry, selected bool
 		prefix := styles.StarIndicator(d.favoriteSet[entry.path])
 		suffixText := "  (use this dir)"
 		suffix := styles.MutedStyle.Render(suffixText)
-		name := truncatePath(entry.path, availableWidth-len(suffixText))
+		name := truncatePath(styles.DisplayPath(entry.path), availableWidth-len(suffixText))
 		return prefix + nameStyle.Render(name) + suffix
 
 	case entryParentDir:
@@ -1032,7 +1032,7 @@ func (d *workingDirPickerDialog) renderRecentEntry(entry dirEntry, selected bool
 	}
 
 	availableWidth := maxWidth - dirPickerIndentPrefixWidth
-	displayPath := truncatePath(entry.path, availableWidth)
+	displayPath := truncatePath(styles.DisplayPath(entry.path), availableWidth)
 	indent := strings.Repeat(" ", dirPickerIndentPrefixWidth)
 
 	return indent + nameStyle.Render(displayPath)
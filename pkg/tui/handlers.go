@@ -3,30 +3,41 @@ package tui
 import (
 	"cmp"
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"os"
 	"os/exec"
+	"path/filepath"
 	goruntime "runtime"
+	"slices"
 	"strings"
+	"time"
 
 	tea "charm.land/bubbletea/v2"
 	"github.com/atotto/clipboard"
 
 	"github.com/docker/cagent/pkg/app"
+	"github.com/docker/cagent/pkg/audio/capture"
 	"github.com/docker/cagent/pkg/browser"
 	"github.com/docker/cagent/pkg/evaluation"
+	"github.com/docker/cagent/pkg/memory/database"
 	"github.com/docker/cagent/pkg/modelsdev"
+	"github.com/docker/cagent/pkg/runtime"
 	"github.com/docker/cagent/pkg/session"
+	"github.com/docker/cagent/pkg/telemetry"
 	"github.com/docker/cagent/pkg/tools"
 	mcptools "github.com/docker/cagent/pkg/tools/mcp"
+	"github.com/docker/cagent/pkg/tui/components/editor"
 	"github.com/docker/cagent/pkg/tui/components/markdown"
+	"github.com/docker/cagent/pkg/tui/components/message"
 	"github.com/docker/cagent/pkg/tui/components/notification"
 	"github.com/docker/cagent/pkg/tui/components/tool/editfile"
 	"github.com/docker/cagent/pkg/tui/core"
 	"github.com/docker/cagent/pkg/tui/dialog"
 	"github.com/docker/cagent/pkg/tui/messages"
 	"github.com/docker/cagent/pkg/tui/page/chat"
+	"github.com/docker/cagent/pkg/tui/service/tuistate"
 	"github.com/docker/cagent/pkg/tui/styles"
 	"github.com/docker/cagent/pkg/userconfig"
 )
@@ -94,10 +105,113 @@ func (m *appModel) handleBranchFromEdit(msg messages.BranchFromEditMsg) (tea.Mod
 		core.CmdHandler(messages.SendMsg{
 			Content:     msg.Content,
 			Attachments: msg.Attachments,
+			Raw:         true,
 		}),
 	)
 }
 
+// handleDuplicateSession stores a deep copy of the session and opens it in a
+// new tab, leaving the original untouched.
+func (m *appModel) handleDuplicateSession(sessionID string) (tea.Model, tea.Cmd) {
+	store := m.application.SessionStore()
+	if store == nil {
+		return m, notification.ErrorCmd("No session store configured")
+	}
+	if sessionID == "" {
+		sess := m.application.Session()
+		if len(sess.Messages) == 0 {
+			return m, notification.InfoCmd("Nothing to duplicate yet")
+		}
+		sessionID = sess.ID
+	}
+	if err := m.supervisor.CanSpawn(); err != nil {
+		return m, notification.WarningCmd("Cannot open a new tab: " + err.Error())
+	}
+
+	duplicated, err := store.CopySession(context.Background(), sessionID)
+	if err != nil {
+		return m, notification.ErrorCmd(fmt.Sprintf("Failed to duplicate session: %v", err))
+	}
+
+	model, cmd := m.handleLoadSession(duplicated.ID)
+	return model, tea.Batch(cmd, notification.SuccessCmd("Opened a copy of the session"))
+}
+
+// handleReloadSession re-reads the current session from the session store and
+// rebuilds the chat page, for when the stored session is the source of truth
+// (changed by another instance, or to discard the in-memory state).
+func (m *appModel) handleReloadSession(confirmed bool) (tea.Model, tea.Cmd) {
+	store := m.application.SessionStore()
+	if store == nil {
+		return m, notification.ErrorCmd("No session store configured")
+	}
+	if m.chatPage.IsWorking() {
+		return m, notification.WarningCmd("Cannot reload the session while the agent is working")
+	}
+
+	if queued := m.chatPage.QueueLength(); queued > 0 && !confirmed && userconfig.Get().GetConfirmDestructiveActions() {
+		question := "Reload the session and discard 1 queued message?"
+		if queued > 1 {
+			question = fmt.Sprintf("Reload the session and discard %d queued messages?", queued)
+		}
+		return m, core.CmdHandler(dialog.OpenDialogMsg{
+			Model: dialog.NewConfirmationDialog("Reload Session", question, messages.ReloadSessionMsg{Confirmed: true}),
+		})
+	}
+
+	ctx := context.Background()
+	sess, err := store.GetSession(ctx, m.application.Session().ID)
+	if err != nil {
+		return m, notification.ErrorCmd(fmt.Sprintf("Failed to reload session: %v", err))
+	}
+
+	// Keep what the user sees and is typing across the rebuild.
+	msgIndex, lineOffset := m.chatPage.CurrentScrollMark()
+	sidebarSettings := m.chatPage.GetSidebarSettings()
+	draft := m.editor.Value()
+
+	activeID := m.supervisor.ActiveID()
+	m.chatPage.Cleanup()
+	m.editor.Cleanup()
+
+	m.application.ReplaceSession(ctx, sess)
+	m.initSessionComponents(activeID, m.application, sess)
+	m.chatPage.SetSidebarSettings(sidebarSettings)
+	m.editor.SetValue(draft)
+
+	if sess.Title != "" {
+		m.supervisor.SetRunnerTitle(activeID, sess.Title)
+	}
+
+	return m, tea.Sequence(
+		m.initAndFocusComponents(),
+		core.CmdHandler(messages.JumpToBookmarkMsg{MessageIndex: msgIndex, LineOffset: lineOffset}),
+		notification.SuccessCmd("Session reloaded"),
+	)
+}
+
+// handleResetCost zeroes the cost and token counters of the current session,
+// leaving its messages untouched, and persists the reset.
+func (m *appModel) handleResetCost(confirmed bool) (tea.Model, tea.Cmd) {
+	if !confirmed && userconfig.Get().GetConfirmDestructiveActions() {
+		return m, core.CmdHandler(dialog.OpenDialogMsg{
+			Model: dialog.NewConfirmationDialog("Reset Cost", "Reset the cost of this session?", messages.ResetCostMsg{Confirmed: true}),
+		})
+	}
+
+	sess := m.application.Session()
+	sess.ResetCost()
+	if store := m.application.SessionStore(); store != nil {
+		if err := store.UpdateSession(context.Background(), sess); err != nil {
+			return m, notification.ErrorCmd(fmt.Sprintf("Failed to save session: %v", err))
+		}
+	}
+
+	updated, cmd := m.chatPage.Update(messages.CostResetMsg{})
+	m.chatPage = updated.(chat.Page)
+	return m, tea.Batch(cmd, notification.SuccessCmd("Session cost reset"))
+}
+
 func (m *appModel) handleToggleSessionStar(sessionID string) (tea.Model, tea.Cmd) {
 	store := m.application.SessionStore()
 	if store == nil {
@@ -151,6 +265,82 @@ func (m *appModel) handleRegenerateTitle() (tea.Model, tea.Cmd) {
 	return m, tea.Batch(spinnerCmd, notification.SuccessCmd("Regenerating title..."))
 }
 
+func (m *appModel) handleSetDateOverride(date string) (tea.Model, tea.Cmd) {
+	sess := m.application.Session()
+	if sess == nil {
+		return m, notification.ErrorCmd("No active session")
+	}
+	var override *time.Time
+	if date != "" {
+		parsed, err := time.Parse(time.DateOnly, date)
+		if err != nil {
+			return m, notification.ErrorCmd(fmt.Sprintf("Invalid date %q: expected YYYY-MM-DD", date))
+		}
+		override = &parsed
+	}
+
+	sess.SetDateOverride(override)
+	if store := m.application.SessionStore(); store != nil {
+		if err := store.UpdateSession(context.Background(), sess); err != nil {
+			return m, notification.ErrorCmd(fmt.Sprintf("Failed to save session: %v", err))
+		}
+	}
+
+	if override == nil {
+		return m, notification.SuccessCmd("Date override cleared, using today's date")
+	}
+	return m, notification.SuccessCmd("Date set to " + override.Format(time.DateOnly))
+}
+
+func (m *appModel) handleSetEnvOverride(assignment string) (tea.Model, tea.Cmd) {
+	sess := m.application.Session()
+	if sess == nil {
+		return m, notification.ErrorCmd("No active session")
+	}
+	name, value, ok := strings.Cut(assignment, "=")
+	name = strings.TrimSpace(name)
+	if !ok || name == "" || strings.ContainsAny(name, " \t") {
+		return m, notification.ErrorCmd("Usage: /env KEY=VALUE (or /env KEY= to unset)")
+	}
+
+	sess.SetEnvOverride(name, value)
+	// Never log the value: overrides commonly carry credentials.
+	slog.Debug("Session environment override updated", "session_id", sess.ID, "name", name, "set", value != "")
+
+	if value == "" {
+		return m, notification.SuccessCmd(fmt.Sprintf("Unset %s for this session", name))
+	}
+	return m, notification.SuccessCmd(fmt.Sprintf("Set %s for this session's tools", name))
+}
+
+func (m *appModel) handleSetVariable(assignment string) (tea.Model, tea.Cmd) {
+	sess := m.application.Session()
+	if sess == nil {
+		return m, notification.ErrorCmd("No active session")
+	}
+	name, value, ok := strings.Cut(assignment, "=")
+	name = strings.TrimPrefix(strings.TrimSpace(name), "$")
+	if !ok || !session.IsVariableName(name) {
+		return m, notification.ErrorCmd("Usage: /set NAME=value (or /set NAME= to unset)")
+	}
+
+	sess.SetVariable(name, value)
+	if value == "" {
+		return m, notification.SuccessCmd(fmt.Sprintf("Unset $%s", name))
+	}
+	return m, notification.SuccessCmd(fmt.Sprintf("$%s will be replaced with %q in your messages", name, value))
+}
+
+func (m *appModel) handleRevokeApproval(signature string) (tea.Model, tea.Cmd) {
+	sess := m.application.Session()
+	if sess == nil {
+		return m, notification.ErrorCmd("No active session")
+	}
+	sess.RevokeApproval(signature)
+	slog.Debug("Remembered tool approval revoked", "session_id", sess.ID, "signature", signature)
+	return m, notification.SuccessCmd(fmt.Sprintf("Approval of %s revoked", signature))
+}
+
 func isErrTitleGenerating(err error) bool {
 	return err != nil && err.Error() == app.ErrTitleGenerating.Error()
 }
@@ -208,9 +398,48 @@ func (m *appModel) handleCopyLastResponseToClipboard() (tea.Model, tea.Cmd) {
 	)
 }
 
+func (m *appModel) handleCopyTaskToClipboard() (tea.Model, tea.Cmd) {
+	task, ok := m.application.CurrentTaskMarkdown()
+	if !ok {
+		return m, notification.InfoCmd("No task to copy.")
+	}
+	return m, tea.Sequence(
+		tea.SetClipboard(task),
+		func() tea.Msg {
+			_ = clipboard.WriteAll(task)
+			return nil
+		},
+		notification.SuccessCmd("Task copied to clipboard."),
+	)
+}
+
+// handleCopySessionLink copies a reference to the current session that
+// `cagent run --session` opens.
+func (m *appModel) handleCopySessionLink() (tea.Model, tea.Cmd) {
+	link := session.Reference{ID: m.application.Session().ID}.URI()
+	return m, tea.Sequence(
+		tea.SetClipboard(link),
+		func() tea.Msg {
+			_ = clipboard.WriteAll(link)
+			return nil
+		},
+		notification.SuccessCmd("Session link copied to clipboard: "+link),
+	)
+}
+
 // --- Agent management ---
 
-func (m *appModel) handleSwitchAgent(agentName string) (tea.Model, tea.Cmd) {
+// handleSwitchAgent switches the current agent. While the agent is working,
+// the switch applies to the rest of the run, so it's confirmed first unless
+// the user turned that off.
+func (m *appModel) handleSwitchAgent(agentName string, confirmed bool) (tea.Model, tea.Cmd) {
+	if !confirmed && m.chatPage.IsWorking() && userconfig.Get().GetConfirmAgentSwitch() {
+		question := fmt.Sprintf("Agent '%s' is still working. Switch to '%s' and hand it the rest of the current task?", m.sessionState.CurrentAgentName(), agentName)
+		return m, core.CmdHandler(dialog.OpenDialogMsg{
+			Model: dialog.NewConfirmationDialog("Switch Agent", question, messages.SwitchAgentMsg{AgentName: agentName, Confirmed: true}),
+		})
+	}
+
 	if err := m.application.SwitchAgent(agentName); err != nil {
 		return m, notification.ErrorCmd(fmt.Sprintf("Failed to switch to agent '%s': %v", agentName, err))
 	}
@@ -220,11 +449,21 @@ func (m *appModel) handleSwitchAgent(agentName string) (tea.Model, tea.Cmd) {
 	return m, tea.Batch(cmd, notification.SuccessCmd(fmt.Sprintf("Switched to agent '%s'", agentName)))
 }
 
-func (m *appModel) handleCycleAgent() (tea.Model, tea.Cmd) {
+func (m *appModel) handleOpenAgentPicker() (tea.Model, tea.Cmd) {
 	availableAgents := m.sessionState.AvailableAgents()
 	if len(availableAgents) <= 1 {
 		return m, notification.InfoCmd("No other agents available")
 	}
+	return m, core.CmdHandler(dialog.OpenDialogMsg{
+		Model: dialog.NewAgentPickerDialog(availableAgents, m.sessionState.CurrentAgentName()),
+	})
+}
+
+func (m *appModel) handleCycleAgent() (tea.Model, tea.Cmd) {
+	availableAgents := m.sessionState.SwitchableAgents()
+	if len(availableAgents) == 0 || (len(availableAgents) == 1 && availableAgents[0].Name == m.sessionState.CurrentAgentName()) {
+		return m, notification.InfoCmd("No other agents available")
+	}
 	currentIndex := -1
 	for i, agent := range availableAgents {
 		if agent.Name == m.sessionState.CurrentAgentName() {
@@ -237,7 +476,7 @@ func (m *appModel) handleCycleAgent() (tea.Model, tea.Cmd) {
 }
 
 func (m *appModel) handleSwitchToAgentByIndex(index int) (tea.Model, tea.Cmd) {
-	availableAgents := m.sessionState.AvailableAgents()
+	availableAgents := m.sessionState.SwitchableAgents()
 	if index >= 0 && index < len(availableAgents) {
 		agentName := availableAgents[index].Name
 		if agentName != m.sessionState.CurrentAgentName() {
@@ -301,42 +540,210 @@ func (m *appModel) handleToggleHideToolResults() (tea.Model, tea.Cmd) {
 	return m, cmd
 }
 
+func (m *appModel) handleToggleFocusMode() (tea.Model, tea.Cmd) {
+	m.focusMode = !m.focusMode
+	return m, m.resizeAll()
+}
+
+func (m *appModel) handleToggleRawMarkdown() (tea.Model, tea.Cmd) {
+	updated, cmd := m.chatPage.Update(messages.ToggleRawMarkdownMsg{})
+	m.chatPage = updated.(chat.Page)
+	return m, cmd
+}
+
+// handleToggleSplitDiff switches all the tabs between the unified and the
+// split diff view, and re-renders the diffs already shown.
 func (m *appModel) handleToggleSplitDiff() (tea.Model, tea.Cmd) {
-	m.sessionState.ToggleSplitDiffView()
-	enabled := m.sessionState.SplitDiffView()
+	m.splitDiffView = !m.splitDiffView
+	for _, ss := range m.sessionStates {
+		ss.SetSplitDiffView(m.splitDiffView)
+	}
 
-	// Persist to global userconfig
-	go func() {
-		cfg, err := userconfig.Load()
-		if err != nil {
-			slog.Warn("Failed to load userconfig for split diff toggle", "error", err)
-			return
+	if m.tuiStore != nil {
+		if err := m.tuiStore.SaveSplitDiffView(context.Background(), m.splitDiffView); err != nil {
+			slog.Warn("Failed to save diff view", "error", err)
 		}
-		if cfg.Settings == nil {
-			cfg.Settings = &userconfig.Settings{}
+	}
+
+	var cmds []tea.Cmd
+	for tabID, cp := range m.chatPages {
+		updated, cmd := cp.Update(editfile.ToggleDiffViewMsg{})
+		cmds = append(cmds, cmd)
+		updated, cmd = updated.(chat.Page).Update(messages.SessionToggleChangedMsg{})
+		cmds = append(cmds, cmd)
+		if cp == m.chatPage {
+			m.chatPage = updated.(chat.Page)
 		}
-		cfg.Settings.SplitDiffView = &enabled
-		if err := cfg.Save(); err != nil {
-			slog.Warn("Failed to persist split diff setting to userconfig", "error", err)
+		m.chatPages[tabID] = updated.(chat.Page)
+	}
+	return m, tea.Batch(cmds...)
+}
+
+// sendAndStayToggle is the name send and stay is saved under in the TUI state.
+const sendAndStayToggle = "send_and_stay"
+
+func (m *appModel) handleToggleSendAndStay() (tea.Model, tea.Cmd) {
+	m.sendAndStay = !m.sendAndStay
+	for _, ed := range m.editors {
+		ed.SetSendAndStay(m.sendAndStay)
+	}
+
+	if m.tuiStore != nil {
+		if err := m.tuiStore.SaveToggle(context.Background(), sendAndStayToggle, m.sendAndStay); err != nil {
+			slog.Warn("Failed to save send and stay", "error", err)
 		}
-	}()
+	}
 
-	var cmds []tea.Cmd
-	updated, cmd := m.chatPage.Update(editfile.ToggleDiffViewMsg{})
+	if m.sendAndStay {
+		m.statusBar.SetIndicator("send & stay")
+		return m, notification.InfoCmd("Send and stay: the editor keeps your message after sending")
+	}
+	m.statusBar.SetIndicator("")
+	return m, notification.InfoCmd("Send and clear: the editor is cleared after sending")
+}
+
+func (m *appModel) handleToggleSoftWrap() (tea.Model, tea.Cmd) {
+	m.softWrap = !m.softWrap
+	enabled := m.softWrap
+	for _, ed := range m.editors {
+		ed.SetSoftWrap(enabled)
+	}
+
+	// Persist to global userconfig
+	persistSetting(func(s *userconfig.Settings) { s.SoftWrap = &enabled })
+
+	if enabled {
+		return m, notification.InfoCmd("Soft wrap on: long lines wrap to the editor width")
+	}
+	return m, notification.InfoCmd("Soft wrap off: long lines scroll horizontally")
+}
+
+func (m *appModel) handleToggleEnterNewline() (tea.Model, tea.Cmd) {
+	m.enterInsertsNewline = !m.enterInsertsNewline
+	enabled := m.enterInsertsNewline
+	for _, ed := range m.editors {
+		ed.SetEnterInsertsNewline(enabled)
+	}
+
+	// Persist to global userconfig
+	persistSetting(func(s *userconfig.Settings) { s.EnterInsertsNewline = enabled })
+
+	switch {
+	case !enabled:
+		return m, notification.InfoCmd("Enter sends, Shift+Enter inserts a newline")
+	case !m.keyboardEnhancementsSupported:
+		return m, notification.WarningCmd("Enter keeps sending: this terminal can't tell Shift+Enter apart. Use Ctrl+J for newlines")
+	default:
+		return m, notification.InfoCmd("Enter inserts a newline, Shift+Enter sends")
+	}
+}
+
+func (m *appModel) handleToggleShowThroughput() (tea.Model, tea.Cmd) {
+	enabled := !message.ShowThroughput()
+	message.SetShowThroughput(enabled)
+	updated, cmd := m.chatPage.Update(messages.ToggleShowThroughputMsg{})
 	m.chatPage = updated.(chat.Page)
-	cmds = append(cmds, cmd)
-	updated, cmd = m.chatPage.Update(messages.SessionToggleChangedMsg{})
+
+	// Persist to global userconfig
+	persistSetting(func(s *userconfig.Settings) { s.ShowThroughput = enabled })
+
+	if enabled {
+		return m, tea.Batch(cmd, notification.InfoCmd("Showing the generation speed under the responses"))
+	}
+	return m, tea.Batch(cmd, notification.InfoCmd("Hiding the generation speed of the responses"))
+}
+
+func (m *appModel) handleToggleSeparateSubSessionCosts() (tea.Model, tea.Cmd) {
+	m.separateSubSessionCosts = !m.separateSubSessionCosts
+	enabled := m.separateSubSessionCosts
+
+	// Persist to global userconfig
+	persistSetting(func(s *userconfig.Settings) { s.SeparateSubSessionCosts = enabled })
+
+	return m, nil
+}
+
+func (m *appModel) handleToggleHomeRelativePaths() (tea.Model, tea.Cmd) {
+	enabled := !styles.HomeRelativePaths()
+	styles.SetHomeRelativePaths(enabled)
+	updated, cmd := m.chatPage.Update(messages.ToggleHomeRelativePathsMsg{})
 	m.chatPage = updated.(chat.Page)
-	cmds = append(cmds, cmd)
-	return m, tea.Batch(cmds...)
+
+	// Persist to global userconfig
+	persistSetting(func(s *userconfig.Settings) { s.HomeRelativePaths = &enabled })
+
+	if enabled {
+		return m, tea.Batch(cmd, notification.InfoCmd("Showing paths under the home directory as ~/..."))
+	}
+	return m, tea.Batch(cmd, notification.InfoCmd("Showing absolute paths"))
+}
+
+func (m *appModel) handleShowThroughput() (tea.Model, tea.Cmd) {
+	last, average := m.chatPage.Throughput()
+	if last == 0 {
+		return m, notification.InfoCmd("No response speed measured yet")
+	}
+	return m, notification.InfoCmd(fmt.Sprintf("Last response: %s · session average: %s",
+		message.FormatThroughput(last), message.FormatThroughput(average)))
+}
+
+func (m *appModel) handleToggleGenerateTitles() (tea.Model, tea.Cmd) {
+	m.generateTitles = !m.generateTitles
+	enabled := m.generateTitles
+
+	// Persist to global userconfig
+	persistSetting(func(s *userconfig.Settings) { s.GenerateTitles = &enabled })
+
+	return m, nil
+}
+
+// handleSetPromptAffix shows, sets or clears the prompt prefix or suffix
+// wrapped around every plain message sent from the TUI.
+func (m *appModel) handleSetPromptAffix(kind, text string) (tea.Model, tea.Cmd) {
+	field := func(s *userconfig.Settings) *string {
+		if kind == "suffix" {
+			return &s.PromptSuffix
+		}
+		return &s.PromptPrefix
+	}
+
+	var value string
+	switch text {
+	case "":
+		if current := *field(userconfig.Get()); current != "" {
+			return m, notification.InfoCmd(fmt.Sprintf("Prompt %s: %s", kind, current))
+		}
+		return m, notification.InfoCmd(fmt.Sprintf("No prompt %s set (usage: /prompt-%s <text>)", kind, kind))
+	case "clear":
+	default:
+		value = text
+	}
+
+	if err := userconfig.UpdateSettings(func(s *userconfig.Settings) { *field(s) = value }); err != nil {
+		return m, notification.ErrorCmd(fmt.Sprintf("Failed to save prompt %s: %v", kind, err))
+	}
+	if value == "" {
+		return m, notification.SuccessCmd(fmt.Sprintf("Prompt %s cleared", kind))
+	}
+	return m, notification.SuccessCmd(fmt.Sprintf("Prompt %s set", kind))
 }
 
 // --- Dialogs ---
 
 func (m *appModel) handleShowCostDialog() (tea.Model, tea.Cmd) {
 	sess := m.application.Session()
+
+	var history []session.Summary
+	if store := m.application.SessionStore(); store != nil {
+		summaries, err := store.GetSessionSummaries(context.Background())
+		if err != nil {
+			slog.Warn("Failed to load the sessions to compare the cost with", "error", err)
+		}
+		history = summaries
+	}
+
 	return m, core.CmdHandler(dialog.OpenDialogMsg{
-		Model: dialog.NewCostDialog(sess),
+		Model: dialog.NewCostDialog(sess, m.separateSubSessionCosts, history),
 	})
 }
 
@@ -344,9 +751,345 @@ func (m *appModel) handleShowPermissionsDialog() (tea.Model, tea.Cmd) {
 	perms := m.application.PermissionsInfo()
 	sess := m.application.Session()
 	yoloEnabled := sess != nil && sess.ToolsApproved
+	var envOverrides map[string]string
+	approvals := func() []session.RememberedApproval { return nil }
+	if sess != nil {
+		envOverrides = sess.GetEnvOverrides()
+		approvals = func() []session.RememberedApproval { return sess.GetRememberedApprovals(time.Now()) }
+	}
+	return m, core.CmdHandler(dialog.OpenDialogMsg{
+		Model: dialog.NewPermissionsDialog(perms, m.sessionState.GetCurrentAgent(), yoloEnabled, envOverrides, approvals),
+	})
+}
+
+func (m *appModel) handleShowToolsDialog() (tea.Model, tea.Cmd) {
+	return m, core.CmdHandler(dialog.OpenDialogMsg{
+		Model: dialog.NewToolsDialog(m.sessionState.GetCurrentAgent()),
+	})
+}
+
+func (m *appModel) handleShowEffectiveConfig() (tea.Model, tea.Cmd) {
+	yaml, ok, err := m.application.EffectiveConfig()
+	if err != nil {
+		return m, notification.ErrorCmd(fmt.Sprintf("Failed to render the configuration: %v", err))
+	}
+	if !ok {
+		return m, notification.InfoCmd("The configuration isn't available with a remote runtime")
+	}
+	return m, core.CmdHandler(dialog.OpenDialogMsg{
+		Model: dialog.NewAttachmentPreviewDialog(editor.AttachmentPreview{
+			Title:   "Effective configuration (secrets redacted)",
+			Content: yaml,
+		}),
+	})
+}
+
+// handleEditConfig opens the team's config file, or the user config, in the
+// external editor. Once the file is saved, it offers to reload the team, or
+// reloads the user settings.
+func (m *appModel) handleEditConfig(user bool) (tea.Model, tea.Cmd) {
+	path := m.application.ConfigFile()
+	if user {
+		path = userconfig.Path()
+	} else if path == "" {
+		return m, notification.InfoCmd("Only teams loaded from a local file can be edited")
+	}
+
+	var modTime time.Time
+	if info, err := os.Stat(path); err == nil {
+		modTime = info.ModTime()
+	}
+
+	return m, tea.ExecProcess(core.ExternalEditorCommand(path), func(err error) tea.Msg {
+		if err != nil {
+			return notification.ShowMsg{Text: fmt.Sprintf("Editor error: %v", err), Type: notification.TypeError}
+		}
+		info, err := os.Stat(path)
+		if err != nil || info.ModTime().Equal(modTime) {
+			return notification.ShowMsg{Text: "Config unchanged", Type: notification.TypeInfo}
+		}
+		if user {
+			return messages.ReloadUserSettingsMsg{}
+		}
+		return messages.ReloadTeamMsg{}
+	})
+}
+
+// handleReloadTeam loads the team again from its config file and swaps the
+// runtime of the current tab for it, keeping the session.
+func (m *appModel) handleReloadTeam(confirmed bool) (tea.Model, tea.Cmd) {
+	spawner := m.supervisor.Spawner()
+	activeID := m.supervisor.ActiveID()
+	runner := m.supervisor.GetRunner(activeID)
+	if spawner == nil || runner == nil || m.application.ConfigFile() == "" {
+		return m, notification.InfoCmd("The team can't be reloaded")
+	}
+	if m.chatPage.IsWorking() {
+		return m, notification.WarningCmd("Cannot reload the team while the agent is working")
+	}
+
+	if !confirmed {
+		return m, core.CmdHandler(dialog.OpenDialogMsg{
+			Model: dialog.NewConfirmationDialog("Reload Team", "Reload the team with the edited config?", messages.ReloadTeamMsg{Confirmed: true}),
+		})
+	}
+
+	ctx := context.Background()
+	newApp, _, cleanup, err := spawner(ctx, runner.WorkingDir)
+	if err != nil {
+		return m, notification.ErrorCmd(fmt.Sprintf("Failed to reload the team: %v", err))
+	}
+
+	sess := m.application.Session()
+	sidebarSettings := m.chatPage.GetSidebarSettings()
+	draft := m.editor.Value()
+
+	m.chatPage.Cleanup()
+	m.editor.Cleanup()
+
+	m.supervisor.ReplaceRunnerApp(ctx, activeID, newApp, runner.WorkingDir, cleanup)
+	m.application = newApp
+	m.application.ReplaceSession(ctx, sess)
+	m.initSessionComponents(activeID, m.application, sess)
+	m.chatPage.SetSidebarSettings(sidebarSettings)
+	m.editor.SetValue(draft)
+
+	return m, tea.Sequence(
+		m.initAndFocusComponents(),
+		notification.SuccessCmd("Team reloaded"),
+	)
+}
+
+func (m *appModel) handleToggleTelemetry() (tea.Model, tea.Cmd) {
+	enabled := telemetry.UserOptedOut()
+	telemetry.SetUserOptOut(!enabled)
+
+	// Persist to global userconfig
+	persistSetting(func(s *userconfig.Settings) { s.Telemetry = &enabled })
+
+	switch {
+	case !enabled:
+		return m, notification.InfoCmd("Telemetry off: no usage events or traces are recorded")
+	case !telemetry.Enabled():
+		return m, notification.WarningCmd("Telemetry stays off: it is disabled by TELEMETRY_ENABLED=false")
+	case !telemetry.EventsEnabled():
+		return m, notification.InfoCmd("Telemetry on: usage events resume at the next start")
+	default:
+		return m, notification.InfoCmd("Telemetry on")
+	}
+}
+
+func (m *appModel) handleShowSettingsDialog() (tea.Model, tea.Cmd) {
+	rows := []dialog.SettingRow{
+		{Key: "y", Label: "YOLO mode", Value: m.sessionState.YoloMode, Toggle: messages.ToggleYoloMsg{}},
+		{Key: "t", Label: "Thinking", Value: m.sessionState.Thinking, Toggle: messages.ToggleThinkingMsg{}},
+		{Key: "h", Label: "Hide tool results", Value: m.sessionState.HideToolResults, Toggle: messages.ToggleHideToolResultsMsg{}},
+		{Key: "d", Label: "Split diff view", Value: m.sessionState.SplitDiffView, Toggle: messages.ToggleSplitDiffMsg{}},
+		{Key: "r", Label: "Raw markdown", Value: m.sessionState.RawMarkdown, Toggle: messages.ToggleRawMarkdownMsg{}},
+		{Key: "g", Label: "Generate session titles", Value: func() bool { return m.generateTitles }, Toggle: messages.ToggleGenerateTitlesMsg{}},
+		{Key: "s", Label: "Send and stay", Value: func() bool { return m.sendAndStay }, Toggle: messages.ToggleSendAndStayMsg{}},
+		{Key: "w", Label: "Soft wrap", Value: func() bool { return m.softWrap }, Toggle: messages.ToggleSoftWrapMsg{}},
+		{Key: "n", Label: "Enter inserts newline", Value: func() bool { return m.enterInsertsNewline }, Toggle: messages.ToggleEnterNewlineMsg{}},
+		{Key: "e", Label: "Telemetry", Value: telemetry.Enabled, Toggle: messages.ToggleTelemetryMsg{}},
+		{Key: "p", Label: "Show response speed", Value: message.ShowThroughput, Toggle: messages.ToggleShowThroughputMsg{}},
+		{Key: "~", Label: "Home-relative paths", Value: styles.HomeRelativePaths, Toggle: messages.ToggleHomeRelativePathsMsg{}},
+		{Key: "b", Label: "Separate sub-session costs", Value: func() bool { return m.separateSubSessionCosts }, Toggle: messages.ToggleSeparateSubSessionCostsMsg{}},
+	}
+	var variables map[string]string
+	if sess := m.application.Session(); sess != nil {
+		variables = sess.GetVariables()
+	}
+	var retry *runtime.RetryPolicy
+	if policy, ok := m.application.CurrentAgentRetryPolicy(); ok {
+		retry = &policy
+	}
+	return m, core.CmdHandler(dialog.OpenDialogMsg{
+		Model: dialog.NewSettingsDialog(rows, variables, retry),
+	})
+}
+
+func (m *appModel) handleShowSessionsOverview() (tea.Model, tea.Cmd) {
+	return m, core.CmdHandler(dialog.OpenDialogMsg{
+		Model: dialog.NewSessionsOverviewDialog(m.sessionStats),
+	})
+}
+
+func (m *appModel) handleShowQueueDialog() (tea.Model, tea.Cmd) {
+	if m.chatPage.QueueLength() == 0 {
+		return m, notification.InfoCmd("No messages queued")
+	}
+	return m, core.CmdHandler(dialog.OpenDialogMsg{
+		Model: dialog.NewQueueDialog(func() []messages.QueuedEntry { return m.chatPage.QueuedMessages() }),
+	})
+}
+
+// --- Request log ---
+
+func (m *appModel) handleSetRequestLog(mode string) (tea.Model, tea.Cmd) {
+	switch mode {
+	case "":
+		enabled, withBodies, path := m.application.RequestLogStatus()
+		switch {
+		case !enabled:
+			return m, notification.InfoCmd("Request log is off (usage: /log on|metadata|off)")
+		case withBodies:
+			return m, notification.InfoCmd("Logging requests to " + path)
+		default:
+			return m, notification.InfoCmd("Logging request metadata to " + path)
+		}
+	case "on", "metadata":
+		path, err := m.application.SetRequestLog(true, mode == "on")
+		if err != nil {
+			return m, notification.ErrorCmd(fmt.Sprintf("Failed to start the request log: %v", err))
+		}
+		return m, notification.SuccessCmd("Logging requests to " + path)
+	case "off":
+		if _, err := m.application.SetRequestLog(false, false); err != nil {
+			return m, notification.ErrorCmd(fmt.Sprintf("Failed to stop the request log: %v", err))
+		}
+		return m, notification.SuccessCmd("Request log stopped")
+	default:
+		return m, notification.WarningCmd("Usage: /log on|metadata|off")
+	}
+}
+
+// --- Memory ---
+
+func (m *appModel) handleShowMemoryDialog() (tea.Model, tea.Cmd) {
+	memories, ok, err := m.application.Memories(context.Background())
+	if !ok {
+		return m, notification.InfoCmd("The current agent doesn't use the memory toolset.")
+	}
+	if err != nil {
+		return m, notification.ErrorCmd(fmt.Sprintf("Failed to read memories: %v", err))
+	}
+	m.memories = memories
 	return m, core.CmdHandler(dialog.OpenDialogMsg{
-		Model: dialog.NewPermissionsDialog(perms, yoloEnabled),
+		Model: dialog.NewMemoryDialog(func() []database.UserMemory { return m.memories }),
+	})
+}
+
+func (m *appModel) handleDeleteMemory(id string) (tea.Model, tea.Cmd) {
+	if err := m.application.DeleteMemory(context.Background(), id); err != nil {
+		return m, notification.ErrorCmd(fmt.Sprintf("Failed to delete memory: %v", err))
+	}
+	m.memories = slices.DeleteFunc(m.memories, func(memory database.UserMemory) bool {
+		return memory.ID == id
 	})
+	return m, notification.SuccessCmd("Memory deleted")
+}
+
+func (m *appModel) handleClearMemories(confirmed bool) (tea.Model, tea.Cmd) {
+	count := len(m.memories)
+	if count == 0 {
+		return m, notification.InfoCmd("No memories stored")
+	}
+
+	if !confirmed && userconfig.Get().GetConfirmDestructiveActions() {
+		question := "Delete 1 memory?"
+		if count > 1 {
+			question = fmt.Sprintf("Delete all %d memories?", count)
+		}
+		return m, core.CmdHandler(dialog.OpenDialogMsg{
+			Model: dialog.NewConfirmationDialog("Clear Memories", question, messages.ClearMemoriesMsg{Confirmed: true}),
+		})
+	}
+
+	if err := m.application.ClearMemories(context.Background()); err != nil {
+		return m, notification.ErrorCmd(fmt.Sprintf("Failed to clear memories: %v", err))
+	}
+	m.memories = nil
+	return m, notification.SuccessCmd("Memories cleared")
+}
+
+// --- Bookmarks ---
+
+func (m *appModel) handleAddBookmark(label string) (tea.Model, tea.Cmd) {
+	if m.tuiStore == nil {
+		return m, notification.ErrorCmd("Bookmarks are unavailable: the TUI state could not be opened.")
+	}
+	msgIndex, lineOffset := m.chatPage.CurrentScrollMark()
+	if label == "" {
+		label = fmt.Sprintf("Message %d, line %d", msgIndex+1, lineOffset+1)
+	}
+	bookmark := tuistate.Bookmark{Label: label, MessageIndex: msgIndex, LineOffset: lineOffset}
+	if err := m.tuiStore.AddBookmark(context.Background(), m.application.Session().ID, bookmark); err != nil {
+		return m, notification.ErrorCmd(fmt.Sprintf("Failed to add bookmark: %v", err))
+	}
+	return m, notification.SuccessCmd(fmt.Sprintf("Bookmark '%s' added", label))
+}
+
+func (m *appModel) handleShowBookmarksDialog() (tea.Model, tea.Cmd) {
+	if m.tuiStore == nil {
+		return m, notification.ErrorCmd("Bookmarks are unavailable: the TUI state could not be opened.")
+	}
+	bookmarks, err := m.tuiStore.GetBookmarks(context.Background(), m.application.Session().ID)
+	if err != nil {
+		return m, notification.ErrorCmd(fmt.Sprintf("Failed to read bookmarks: %v", err))
+	}
+	if len(bookmarks) == 0 {
+		return m, notification.InfoCmd("No bookmarks in this session. Add one with /bookmark [label].")
+	}
+	m.bookmarks = bookmarks
+	return m, core.CmdHandler(dialog.OpenDialogMsg{
+		Model: dialog.NewBookmarksDialog(func() []tuistate.Bookmark { return m.bookmarks }),
+	})
+}
+
+func (m *appModel) handleDeleteBookmark(label string) (tea.Model, tea.Cmd) {
+	if m.tuiStore == nil {
+		return m, nil
+	}
+	if err := m.tuiStore.RemoveBookmark(context.Background(), m.application.Session().ID, label); err != nil {
+		return m, notification.ErrorCmd(fmt.Sprintf("Failed to delete bookmark: %v", err))
+	}
+	m.bookmarks = slices.DeleteFunc(m.bookmarks, func(b tuistate.Bookmark) bool {
+		return b.Label == label
+	})
+	return m, notification.SuccessCmd("Bookmark deleted")
+}
+
+// --- Prompt library ---
+
+func (m *appModel) handleSavePromptTemplate(name, content string) (tea.Model, tea.Cmd) {
+	if m.tuiStore == nil {
+		return m, notification.ErrorCmd("The prompt library is unavailable: the TUI state could not be opened.")
+	}
+	template := tuistate.PromptTemplate{Name: name, Content: content}
+	if err := m.tuiStore.SavePromptTemplate(context.Background(), template); err != nil {
+		return m, notification.ErrorCmd(fmt.Sprintf("Failed to save prompt: %v", err))
+	}
+	return m, notification.SuccessCmd(fmt.Sprintf("Prompt '%s' saved", name))
+}
+
+func (m *appModel) handleShowPromptLibrary() (tea.Model, tea.Cmd) {
+	if m.tuiStore == nil {
+		return m, notification.ErrorCmd("The prompt library is unavailable: the TUI state could not be opened.")
+	}
+	prompts, err := m.tuiStore.GetPromptTemplates(context.Background())
+	if err != nil {
+		return m, notification.ErrorCmd(fmt.Sprintf("Failed to read prompts: %v", err))
+	}
+	if len(prompts) == 0 {
+		return m, notification.InfoCmd("No saved prompts. Add one with /save-prompt <name> <prompt>.")
+	}
+	m.prompts = prompts
+	return m, core.CmdHandler(dialog.OpenDialogMsg{
+		Model: dialog.NewPromptLibraryDialog(func() []tuistate.PromptTemplate { return m.prompts }),
+	})
+}
+
+func (m *appModel) handleDeletePromptTemplate(name string) (tea.Model, tea.Cmd) {
+	if m.tuiStore == nil {
+		return m, nil
+	}
+	if err := m.tuiStore.RemovePromptTemplate(context.Background(), name); err != nil {
+		return m, notification.ErrorCmd(fmt.Sprintf("Failed to delete prompt: %v", err))
+	}
+	m.prompts = slices.DeleteFunc(m.prompts, func(t tuistate.PromptTemplate) bool {
+		return t.Name == name
+	})
+	return m, notification.SuccessCmd("Prompt deleted")
 }
 
 // --- MCP prompts ---
@@ -366,7 +1109,7 @@ func (m *appModel) handleMCPPrompt(promptName string, arguments map[string]strin
 	if err != nil {
 		return m, notification.ErrorCmd(fmt.Sprintf("Error executing MCP prompt '%s': %v", promptName, err))
 	}
-	return m, core.CmdHandler(messages.SendMsg{Content: promptContent})
+	return m, core.CmdHandler(messages.SendMsg{Content: promptContent, Raw: true})
 }
 
 // --- Model picker ---
@@ -384,6 +1127,48 @@ func (m *appModel) handleOpenModelPicker() (tea.Model, tea.Cmd) {
 	})
 }
 
+// handleCycleModel switches the current agent to the next configured model,
+// wrapping around at the end. Catalog models are skipped: there are too many
+// of them to cycle through.
+func (m *appModel) handleCycleModel() (tea.Model, tea.Cmd) {
+	if !m.application.SupportsModelSwitching() {
+		return m, notification.InfoCmd("Model switching is not supported with remote runtimes")
+	}
+
+	var models []runtime.ModelChoice
+	for _, model := range m.application.AvailableModels(context.Background()) {
+		if !model.IsCatalog {
+			models = append(models, model)
+		}
+	}
+	if len(models) <= 1 {
+		return m, notification.InfoCmd("No other models available")
+	}
+	// Available models come in no particular order.
+	slices.SortFunc(models, func(a, b runtime.ModelChoice) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	currentIndex := -1
+	for i, model := range models {
+		if model.IsCurrent {
+			currentIndex = i
+			break
+		}
+	}
+	next := models[(currentIndex+1)%len(models)]
+
+	// Selecting the default model clears the override, as in the model picker.
+	modelRef := next.Ref
+	if next.IsDefault {
+		modelRef = ""
+	}
+	if err := m.application.SetCurrentAgentModel(context.Background(), modelRef); err != nil {
+		return m, notification.ErrorCmd(fmt.Sprintf("Failed to change model: %v", err))
+	}
+	return m, notification.SuccessCmd(fmt.Sprintf("Model changed to %s", next.Name))
+}
+
 func (m *appModel) handleChangeModel(modelRef string) (tea.Model, tea.Cmd) {
 	if err := m.application.SetCurrentAgentModel(context.Background(), modelRef); err != nil {
 		return m, notification.ErrorCmd(fmt.Sprintf("Failed to change model: %v", err))
@@ -512,9 +1297,152 @@ func (m *appModel) handleOpenURL(url string) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// handleOpenFileAtLine opens a file referenced in the transcript in the
+// external editor, at the given line. Only the files of the session's
+// working directory are opened: the references come from the model and tool
+// output.
+func (m *appModel) handleOpenFileAtLine(path string, line, col int) (tea.Model, tea.Cmd) {
+	root := ""
+	if sess := m.application.Session(); sess != nil {
+		root = sess.WorkingDir
+	}
+	if root == "" {
+		wd, err := os.Getwd()
+		if err != nil {
+			return m, notification.ErrorCmd(fmt.Sprintf("Failed to get the working directory: %v", err))
+		}
+		root = wd
+	}
+
+	resolved, err := resolveFileUnder(root, path)
+	if err != nil {
+		return m, notification.ErrorCmd(fmt.Sprintf("Cannot open %s: %v", path, err))
+	}
+
+	return m, tea.ExecProcess(core.ExternalEditorCommandAt(resolved, line, col), func(err error) tea.Msg {
+		if err != nil {
+			return notification.ShowMsg{Text: fmt.Sprintf("Editor error: %v", err), Type: notification.TypeError}
+		}
+		return nil
+	})
+}
+
+// resolveFileUnder resolves path, relative to root unless absolute, to an
+// existing regular file under root, following symlinks.
+func resolveFileUnder(root, path string) (string, error) {
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(root, path)
+	}
+	resolved, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		return "", errors.New("no such file")
+	}
+	resolvedRoot, err := filepath.EvalSymlinks(root)
+	if err != nil {
+		return "", err
+	}
+
+	rel, err := filepath.Rel(resolvedRoot, resolved)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", errors.New("outside of the working directory")
+	}
+	info, err := os.Stat(resolved)
+	if err != nil {
+		return "", err
+	}
+	if !info.Mode().IsRegular() {
+		return "", errors.New("not a file")
+	}
+	return resolved, nil
+}
+
+// handleOpenTasksDir opens the directory the agent's tasks are saved to in
+// the system file browser.
+func (m *appModel) handleOpenTasksDir() (tea.Model, tea.Cmd) {
+	dir, hasTasks := m.application.TasksDir()
+	if dir == "" {
+		return m, notification.InfoCmd("The current agent doesn't use the tasks toolset")
+	}
+	if !hasTasks {
+		return m, notification.InfoCmd("No tasks yet")
+	}
+	if err := browser.Open(context.Background(), dir); err != nil {
+		return m, notification.ErrorCmd(fmt.Sprintf("Failed to open %s: %v", dir, err))
+	}
+	return m, notification.InfoCmd("Opened " + dir)
+}
+
+// handlePasteClipboard adds the clipboard content to the editor, as a code
+// block or as an attachment.
+func (m *appModel) handlePasteClipboard(attach bool) (tea.Model, tea.Cmd) {
+	notice, err := m.editor.PasteClipboard(attach)
+	switch {
+	case errors.Is(err, editor.ErrClipboardEmpty):
+		return m, notification.InfoCmd("The clipboard is empty")
+	case err != nil:
+		slog.Warn("failed to paste clipboard", "error", err)
+		return m, notification.ErrorCmd(fmt.Sprintf("Failed to paste the clipboard: %v", err))
+	case notice != "":
+		return m, notification.WarningCmd(notice)
+	default:
+		return m, nil
+	}
+}
+
+// handleInsertFileContents inserts a file's content in the editor as a code
+// block, or opens the file picker if no path is given.
+func (m *appModel) handleInsertFileContents(filePath string) (tea.Model, tea.Cmd) {
+	if filePath == "" {
+		return m, core.CmdHandler(dialog.OpenDialogMsg{
+			Model: dialog.NewInsertFilePickerDialog(""),
+		})
+	}
+
+	notice, err := m.editor.InsertFile(filePath)
+	switch {
+	case err != nil:
+		slog.Warn("failed to insert file", "path", filePath, "error", err)
+		return m, notification.ErrorCmd(fmt.Sprintf("Failed to insert %s: %v", filePath, err))
+	case notice != "":
+		return m, notification.WarningCmd(notice)
+	default:
+		return m, nil
+	}
+}
+
+// handleInsertLastToolResult inserts the most recent tool result in the editor
+// as a code block, to quote it in the next message.
+func (m *appModel) handleInsertLastToolResult() (tea.Model, tea.Cmd) {
+	sess := m.application.Session()
+	if sess == nil {
+		return m, notification.InfoCmd("No active session.")
+	}
+	toolName, content := sess.GetLastToolResult()
+	if content == "" {
+		return m, notification.InfoCmd("No tool result to insert.")
+	}
+	if notice := m.editor.InsertCodeBlock(content, toolName); notice != "" {
+		return m, notification.WarningCmd(notice)
+	}
+	return m, nil
+}
+
+// handleAgentCommand expands and sends an agent command. A command whose
+// instruction has placeholders and that was given no arguments first asks
+// for them.
 func (m *appModel) handleAgentCommand(command string) (tea.Model, tea.Cmd) {
-	resolvedCommand := m.application.ResolveCommand(context.Background(), command)
-	return m, core.CmdHandler(messages.SendMsg{Content: resolvedCommand})
+	ctx := context.Background()
+	name, rest, _ := strings.Cut(strings.TrimPrefix(command, "/"), " ")
+	if cmd, found := m.application.CurrentAgentCommands(ctx)[name]; found && strings.TrimSpace(rest) == "" {
+		if args := runtime.CommandArgs(cmd.Instruction); len(args) > 0 {
+			return m, core.CmdHandler(dialog.OpenDialogMsg{
+				Model: dialog.NewAgentCommandArgsDialog(name, cmd, args),
+			})
+		}
+	}
+
+	resolvedCommand := m.application.ResolveCommand(ctx, command)
+	return m, core.CmdHandler(messages.SendMsg{Content: resolvedCommand, Raw: true})
 }
 
 func (m *appModel) handleAttachFile(filePath string) (tea.Model, tea.Cmd) {
@@ -538,6 +1466,22 @@ func (m *appModel) handleAttachFile(filePath string) (tea.Model, tea.Cmd) {
 	})
 }
 
+func (m *appModel) handleAttachURL(rawURL string) (tea.Model, tea.Cmd) {
+	rawURL = strings.TrimSpace(rawURL)
+	if rawURL == "" {
+		return m, notification.ErrorCmd("Usage: /attach-url <url>")
+	}
+	if userconfig.Get().DisableURLAttachments {
+		return m, notification.WarningCmd("URL attachments are disabled in the settings")
+	}
+	if err := app.ValidateAttachmentURL(rawURL); err != nil {
+		return m, notification.ErrorCmd(fmt.Sprintf("Failed to attach %s: %v", rawURL, err))
+	}
+
+	m.editor.AttachURL(rawURL)
+	return m, notification.SuccessCmd("URL attached: " + rawURL)
+}
+
 // --- Speech-to-text ---
 
 func (m *appModel) handleStartSpeak() (tea.Model, tea.Cmd) {
@@ -579,6 +1523,43 @@ func (m *appModel) handleStopSpeak() (tea.Model, tea.Cmd) {
 	return m, tea.Batch(m.editor.SetRecording(false), notification.SuccessCmd("Stopped listening"))
 }
 
+func (m *appModel) handleShowInputDevices() (tea.Model, tea.Cmd) {
+	devices, err := capture.Devices()
+	if err != nil {
+		return m, notification.ErrorCmd(fmt.Sprintf("Failed to list the input devices: %v", err))
+	}
+	if len(devices) == 0 {
+		return m, notification.InfoCmd("No input devices found")
+	}
+	return m, core.CmdHandler(dialog.OpenDialogMsg{
+		Model: dialog.NewInputDeviceDialog(devices, userconfig.Get().SpeechInputDevice),
+	})
+}
+
+// handleInputDeviceResult switches /speak to the chosen input device and
+// remembers it in the user config.
+func (m *appModel) handleInputDeviceResult(result dialog.MultiChoiceResult) (tea.Model, tea.Cmd) {
+	if result.IsCancelled {
+		return m, nil
+	}
+	if m.transcriber.IsRunning() {
+		return m, notification.WarningCmd("Stop listening before changing the input device")
+	}
+
+	device, name := result.OptionID, result.Value
+	if result.IsSkipped {
+		device, name = "", "the system default"
+	}
+
+	settings := *userconfig.Get()
+	settings.SpeechInputDevice = device
+	m.transcriber = newTranscriber(&settings)
+
+	persistSetting(func(s *userconfig.Settings) { s.SpeechInputDevice = device })
+
+	return m, notification.SuccessCmd("/speak now listens to " + name)
+}
+
 // waitForTranscript returns a command that blocks until the next transcript
 // delta arrives and delivers it as a SpeakTranscriptMsg.
 func (m *appModel) waitForTranscript() tea.Cmd {
@@ -0,0 +1,58 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/docker/cagent/pkg/tui/components/tabbar"
+	"github.com/docker/cagent/pkg/tui/messages"
+)
+
+func TestCopySessionLinkKey(t *testing.T) {
+	t.Parallel()
+
+	m, _, _ := newTestModel()
+	m.tabBar = tabbar.New(20)
+	m.focusedPanel = PanelEditor
+
+	_, cmd := m.Update(tea.KeyPressMsg{Code: 'k', Mod: tea.ModAlt})
+	require.NotNil(t, cmd)
+	assert.Equal(t, messages.CopySessionLinkMsg{}, cmd())
+
+	// Alt+L is left to the editor, which lowercases the next word with it
+	_, cmd = m.Update(tea.KeyPressMsg{Code: 'l', Mod: tea.ModAlt})
+	assert.Nil(t, cmd)
+}
+
+func TestResolveFileUnder(t *testing.T) {
+	t.Parallel()
+
+	parent := t.TempDir()
+	root := filepath.Join(parent, "project")
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "pkg"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "pkg", "main.go"), nil, 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(parent, "secret.txt"), nil, 0o644))
+	root, err := filepath.EvalSymlinks(root)
+	require.NoError(t, err)
+
+	resolved, err := resolveFileUnder(root, "pkg/main.go")
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(root, "pkg", "main.go"), resolved)
+
+	_, err = resolveFileUnder(root, filepath.Join(root, "pkg", "main.go"))
+	require.NoError(t, err)
+
+	_, err = resolveFileUnder(root, "../secret.txt")
+	require.ErrorContains(t, err, "outside of the working directory")
+
+	_, err = resolveFileUnder(root, "pkg")
+	require.ErrorContains(t, err, "not a file")
+
+	_, err = resolveFileUnder(root, "missing.go")
+	require.ErrorContains(t, err, "no such file")
+}
@@ -2,8 +2,15 @@ package messages
 
 // Agent messages control agent switching, commands, and model selection.
 type (
-	// SwitchAgentMsg switches to a different agent.
-	SwitchAgentMsg struct{ AgentName string }
+	// SwitchAgentMsg switches to a different agent. Confirmed skips the
+	// confirmation asked when the agent is working.
+	SwitchAgentMsg struct {
+		AgentName string
+		Confirmed bool
+	}
+
+	// OpenAgentPickerMsg opens the dialog to search and switch agents.
+	OpenAgentPickerMsg struct{}
 
 	// AgentCommandMsg sends a command to the agent.
 	AgentCommandMsg struct{ Command string }
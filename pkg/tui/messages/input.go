@@ -5,12 +5,40 @@ type (
 	// AttachFileMsg attaches a file directly or opens file picker if empty/directory.
 	AttachFileMsg struct{ FilePath string }
 
+	// AttachURLMsg attaches a remote URL, fetched when the message is sent.
+	AttachURLMsg struct{ URL string }
+
 	// InsertFileRefMsg inserts @filepath reference into editor.
 	InsertFileRefMsg struct{ FilePath string }
 
+	// InsertFileContentsMsg inserts a file's content into the editor as a code
+	// block, or opens the file picker if FilePath is empty.
+	InsertFileContentsMsg struct{ FilePath string }
+
+	// SavePromptTemplateMsg saves a prompt template to the prompt library.
+	SavePromptTemplateMsg struct{ Name, Content string }
+
+	// DeletePromptTemplateMsg deletes the prompt template with the given name.
+	DeletePromptTemplateMsg struct{ Name string }
+
+	// InsertPromptMsg inserts a prompt, with its placeholders filled in, into the editor.
+	InsertPromptMsg struct{ Content string }
+
+	// InsertLastToolResultMsg inserts the most recent tool result into the
+	// editor as a code block.
+	InsertLastToolResultMsg struct{}
+
+	// PasteClipboardMsg adds the system clipboard to the editor, as a code
+	// block or, with Attach, as an attachment.
+	PasteClipboardMsg struct{ Attach bool }
+
 	// StartSpeakMsg starts speech-to-text transcription.
 	StartSpeakMsg struct{}
 
+	// ShowInputDevicesMsg opens the picker of the input device /speak
+	// listens to.
+	ShowInputDevicesMsg struct{}
+
 	// StopSpeakMsg stops speech-to-text transcription.
 	StopSpeakMsg struct{}
 
@@ -22,4 +50,19 @@ type (
 
 	// OpenURLMsg opens a URL in the browser.
 	OpenURLMsg struct{ URL string }
+
+	// OpenFileReferenceMsg opens the last path:line reference visible in the
+	// transcript in the external editor.
+	OpenFileReferenceMsg struct{}
+
+	// OpenFileAtLineMsg opens Path in the external editor at Line and Col
+	// (0 when unknown). Relative paths are relative to the session's working
+	// directory, and paths outside of it are refused.
+	OpenFileAtLineMsg struct {
+		Path      string
+		Line, Col int
+	}
+
+	// OpenTasksDirMsg opens the directory holding the agent's tasks file.
+	OpenTasksDirMsg struct{}
 )
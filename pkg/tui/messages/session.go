@@ -19,6 +19,8 @@ type Attachment struct {
 	// backing temp file is cleaned up before the message reaches the app layer.
 	// Empty for file-reference attachments that are read from disk.
 	Content string
+	// URL is a remote http(s) URL fetched when the message is sent.
+	URL string
 }
 
 // Session lifecycle messages control session state and persistence.
@@ -44,6 +46,13 @@ type (
 	// CopyLastResponseToClipboardMsg copies the last assistant response to clipboard.
 	CopyLastResponseToClipboardMsg struct{}
 
+	// CopySessionLinkMsg copies a cagent://session/<id> reference to the
+	// current session to clipboard.
+	CopySessionLinkMsg struct{}
+
+	// CopyTaskToClipboardMsg copies the markdown of the current task to clipboard.
+	CopyTaskToClipboardMsg struct{}
+
 	// ExportSessionMsg exports the session to the specified file.
 	ExportSessionMsg struct{ Filename string }
 
@@ -53,6 +62,30 @@ type (
 	// LoadSessionMsg loads a session by ID.
 	LoadSessionMsg struct{ SessionID string }
 
+	// DuplicateSessionMsg opens a deep copy of a saved session in a new tab;
+	// empty ID means current session.
+	DuplicateSessionMsg struct{ SessionID string }
+
+	// ReloadSessionMsg reloads the current session from the session store.
+	// Confirmed skips the confirmation prompt.
+	ReloadSessionMsg struct{ Confirmed bool }
+
+	// EditConfigMsg opens the team's config file, or the user config when
+	// User is set, in the external editor.
+	EditConfigMsg struct{ User bool }
+
+	// ReloadTeamMsg reloads the team from its config file, keeping the
+	// current session. Confirmed skips the confirmation prompt.
+	ReloadTeamMsg struct{ Confirmed bool }
+
+	// ReloadUserSettingsMsg reads the user settings again after the user
+	// config was edited.
+	ReloadUserSettingsMsg struct{}
+
+	// ResetCostMsg zeroes the cost and token counters of the current session.
+	// Confirmed skips the confirmation prompt.
+	ResetCostMsg struct{ Confirmed bool }
+
 	// ToggleSessionStarMsg toggles star on a session; empty ID means current session.
 	ToggleSessionStarMsg struct{ SessionID string }
 
@@ -62,11 +95,67 @@ type (
 	// RegenerateTitleMsg regenerates the session title using the AI.
 	RegenerateTitleMsg struct{}
 
+	// SetDateOverrideMsg pins the date injected into the prompt; empty Date clears it.
+	SetDateOverrideMsg struct{ Date string }
+
+	// SetEnvOverrideMsg sets a session scoped environment variable from a
+	// KEY=VALUE assignment; an empty value unsets it.
+	SetEnvOverrideMsg struct{ Assignment string }
+
+	// SetVariableMsg sets a session variable substituted in sent messages
+	// from a NAME=value assignment; an empty value unsets it.
+	SetVariableMsg struct{ Assignment string }
+
+	// RevokeApprovalMsg forgets the remembered tool approval of the current
+	// session with the given signature.
+	RevokeApprovalMsg struct{ Signature string }
+
 	// StreamCancelledMsg notifies components that the stream has been cancelled.
 	StreamCancelledMsg struct{ ShowMessage bool }
 
-	// ClearQueueMsg clears all queued messages.
-	ClearQueueMsg struct{}
+	// ClearQueueMsg clears all queued messages. Confirmed skips the confirmation prompt.
+	ClearQueueMsg struct{ Confirmed bool }
+
+	// QueuedEntry is a queued message. ID identifies it for as long as it is
+	// queued, whatever the messages sent or moved around it.
+	QueuedEntry struct {
+		ID      int
+		Content string
+	}
+
+	// RemoveQueuedMsg removes the queued message with the given ID.
+	RemoveQueuedMsg struct{ ID int }
+
+	// MoveQueuedMsg moves the queued message with the given ID by Offset
+	// positions, towards the end of the queue when positive.
+	MoveQueuedMsg struct{ ID, Offset int }
+
+	// EditQueuedMsg replaces the content of the queued message with the
+	// given ID. Nothing changes if it was sent since the edit began.
+	EditQueuedMsg struct {
+		ID      int
+		Content string
+	}
+
+	// DeleteMemoryMsg deletes the memory with the given ID from the current agent's memory.
+	DeleteMemoryMsg struct{ ID string }
+
+	// ClearMemoriesMsg deletes all the memories of the current agent. Confirmed skips the confirmation prompt.
+	ClearMemoriesMsg struct{ Confirmed bool }
+
+	// AddBookmarkMsg bookmarks the current scroll position of the transcript.
+	// An empty Label uses the position as label.
+	AddBookmarkMsg struct{ Label string }
+
+	// JumpToBookmarkMsg scrolls the transcript to a bookmarked position.
+	JumpToBookmarkMsg struct{ MessageIndex, LineOffset int }
+
+	// DeleteBookmarkMsg deletes the bookmark of the current session with the given label.
+	DeleteBookmarkMsg struct{ Label string }
+
+	// SetRequestLogMsg turns the logging of the model requests on or off.
+	// Mode is "on", "metadata" or "off"; an empty Mode shows the current state.
+	SetRequestLogMsg struct{ Mode string }
 
 	// ToggleSplitDiffMsg toggles split diff view mode.
 	ToggleSplitDiffMsg struct{}
@@ -75,8 +164,25 @@ type (
 	SendMsg struct {
 		Content     string       // Full content sent to the agent (with file contents expanded)
 		Attachments []Attachment // Attached files or inline content (e.g. pastes)
+		Raw         bool         // Content is already expanded; skip the user's prompt prefix/suffix
 	}
 
+	// RedirectMsg interrupts the running agent and continues the conversation
+	// with Content once the interrupted stream has stopped.
+	RedirectMsg struct{ Content string }
+
+	// StopAfterStepMsg lets the running agent finish its current step, the
+	// tool calls included, and stops it before its next model call.
+	StopAfterStepMsg struct{}
+
+	// TogglePauseRenderingMsg freezes the transcript on screen, the agent
+	// working on in the background, or brings it back up to date.
+	TogglePauseRenderingMsg struct{}
+
+	// ReplaySessionMsg steps through the current session read-only, starting
+	// with the first Position messages shown (or the first one when 0).
+	ReplaySessionMsg struct{ Position int }
+
 	// SendAttachmentMsg is a message for the first message with an attachment.
 	SendAttachmentMsg struct{ Content *session.Message }
 )
@@ -16,16 +16,27 @@ type SpawnSessionMsg struct {
 	WorkingDir string // The working directory for the new session
 }
 
+// ContinueInNewTabMsg requests a new tab, in the same working directory,
+// that starts from a summary of the current session.
+type ContinueInNewTabMsg struct{}
+
 // SwitchTabMsg requests switching to a different session tab.
 type SwitchTabMsg struct {
 	SessionID string // The session to switch to
 }
 
+// SwitchToPreviousTabMsg requests switching back to the previously active tab.
+type SwitchToPreviousTabMsg struct{}
+
 // CloseTabMsg requests closing a session tab.
 type CloseTabMsg struct {
 	SessionID string // The session to close
+	Confirmed bool   // Skips the confirmation asked before closing a running session
 }
 
+// OpenScratchpadMsg requests opening (or switching to) the scratchpad tab.
+type OpenScratchpadMsg struct{}
+
 // ReorderTabMsg requests moving a tab from one position to another.
 type ReorderTabMsg struct {
 	FromIdx int
@@ -39,8 +50,30 @@ type TabInfo struct {
 	IsActive       bool   // Whether this is the currently active tab
 	IsRunning      bool   // Whether the session is currently streaming
 	NeedsAttention bool   // Whether the tab needs user attention (e.g., tool confirmation)
+	IsQueued       bool   // Whether the session waits for a provider's concurrency limit
+}
+
+// SessionStats describes a session tab for the sessions overview.
+type SessionStats struct {
+	TabInfo
+	WorkingDir string
+	Scratchpad bool
+	Messages   int
+	Tokens     int64 // Input and output tokens of the last request
+	Cost       float64
+	Question   string // The question an inactive session waits on, if it can be answered with text
+}
+
+// ReplyToSessionMsg answers the question a session waits on, without
+// switching to it.
+type ReplyToSessionMsg struct {
+	SessionID string
+	Answer    string
 }
 
+// ShowSessionsOverviewMsg toggles the overview of all the open sessions.
+type ShowSessionsOverviewMsg struct{}
+
 // TabsUpdatedMsg is sent when the tab list has changed.
 type TabsUpdatedMsg struct {
 	Tabs      []TabInfo
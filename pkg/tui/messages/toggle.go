@@ -17,6 +17,33 @@ type (
 	// ToggleHideToolResultsMsg toggles hiding of tool results.
 	ToggleHideToolResultsMsg struct{}
 
+	// ToggleRawMarkdownMsg toggles showing the markdown source of messages
+	// instead of the rendered view.
+	ToggleRawMarkdownMsg struct{}
+
+	// ToggleGenerateTitlesMsg toggles LLM generation of session titles.
+	ToggleGenerateTitlesMsg struct{}
+
+	// ToggleSendAndStayMsg toggles keeping the editor content after sending.
+	ToggleSendAndStayMsg struct{}
+
+	// ToggleSoftWrapMsg toggles soft wrapping of long lines in the editor.
+	ToggleSoftWrapMsg struct{}
+
+	// ToggleEnterNewlineMsg toggles swapping Enter and Shift+Enter in the editor.
+	ToggleEnterNewlineMsg struct{}
+
+	// ToggleTelemetryMsg toggles the user's telemetry opt-out.
+	ToggleTelemetryMsg struct{}
+
+	// ToggleShowThroughputMsg toggles showing the generation speed under the
+	// responses.
+	ToggleShowThroughputMsg struct{}
+
+	// ToggleFocusModeMsg toggles focus mode, which hides the tab bar and the
+	// status bar.
+	ToggleFocusModeMsg struct{}
+
 	// ToggleSidebarMsg toggles sidebar visibility.
 	// The top-level model also handles this to persist the collapsed state.
 	ToggleSidebarMsg struct{}
@@ -25,9 +52,53 @@ type (
 	// changes so that components like the sidebar can invalidate their caches.
 	SessionToggleChangedMsg struct{}
 
+	// CostResetMsg is sent after the cost of the session was reset so that
+	// the sidebar drops its usage snapshots.
+	CostResetMsg struct{}
+
+	// ToggleSeparateSubSessionCostsMsg toggles breaking the sub-session usage
+	// out as its own section of the cost dialog.
+	ToggleSeparateSubSessionCostsMsg struct{}
+
 	// ShowCostDialogMsg shows the cost/usage dialog.
 	ShowCostDialogMsg struct{}
 
+	// ShowThroughputMsg shows the generation speed of the responses.
+	ShowThroughputMsg struct{}
+
+	// ToggleHomeRelativePathsMsg toggles showing the paths under the home
+	// directory as ~/...
+	ToggleHomeRelativePathsMsg struct{}
+
+	// ShowEffectiveConfigMsg shows the resolved configuration of the team.
+	ShowEffectiveConfigMsg struct{}
+
 	// ShowPermissionsDialogMsg shows the permissions dialog.
 	ShowPermissionsDialogMsg struct{}
+
+	// ShowToolsDialogMsg shows the tools of the current agent.
+	ShowToolsDialogMsg struct{}
+
+	// ShowSettingsDialogMsg shows the settings dialog.
+	ShowSettingsDialogMsg struct{}
+
+	// ShowQueueDialogMsg shows the queued messages dialog.
+	ShowQueueDialogMsg struct{}
+
+	// ShowBookmarksDialogMsg shows the bookmarks of the current session.
+	ShowBookmarksDialogMsg struct{}
+
+	// ShowPromptLibraryMsg shows the saved prompt templates.
+	ShowPromptLibraryMsg struct{}
+
+	// ShowMemoryDialogMsg shows the dialog listing the current agent's memories.
+	ShowMemoryDialogMsg struct{}
+
+	// SetPromptPrefixMsg sets the text prepended to every plain message.
+	// An empty Text shows the current value, "clear" removes it.
+	SetPromptPrefixMsg struct{ Text string }
+
+	// SetPromptSuffixMsg sets the text appended to every plain message.
+	// An empty Text shows the current value, "clear" removes it.
+	SetPromptSuffixMsg struct{ Text string }
 )
@@ -7,6 +7,7 @@ import (
 	"log/slog"
 	"path/filepath"
 	goruntime "runtime"
+	"slices"
 	"strings"
 
 	"charm.land/bubbles/v2/help"
@@ -16,15 +17,19 @@ import (
 
 	"github.com/docker/cagent/pkg/app"
 	"github.com/docker/cagent/pkg/chat"
+	"github.com/docker/cagent/pkg/runtime"
+	"github.com/docker/cagent/pkg/session"
 	"github.com/docker/cagent/pkg/tui/commands"
 	"github.com/docker/cagent/pkg/tui/components/messages"
 	"github.com/docker/cagent/pkg/tui/components/notification"
 	"github.com/docker/cagent/pkg/tui/components/sidebar"
 	"github.com/docker/cagent/pkg/tui/core"
 	"github.com/docker/cagent/pkg/tui/core/layout"
+	"github.com/docker/cagent/pkg/tui/dialog"
 	msgtypes "github.com/docker/cagent/pkg/tui/messages"
 	"github.com/docker/cagent/pkg/tui/service"
 	"github.com/docker/cagent/pkg/tui/styles"
+	"github.com/docker/cagent/pkg/userconfig"
 )
 
 const (
@@ -103,10 +108,20 @@ type Page interface {
 	ScrollToBottom() tea.Cmd
 	// IsWorking returns whether the agent is currently working
 	IsWorking() bool
+	// StoppingAfterStep returns whether the agent stops after its current step
+	StoppingAfterStep() bool
+	// RenderingPaused returns whether the transcript is frozen on screen
+	RenderingPaused() bool
 	// IsInlineEditing returns true if a past user message is being edited inline
 	IsInlineEditing() bool
 	// QueueLength returns the number of queued messages
 	QueueLength() int
+	// QueuedMessages returns the queued messages, in order
+	QueuedMessages() []msgtypes.QueuedEntry
+	// RemoveQueued removes the queued message with the given ID
+	RemoveQueued(id int) bool
+	// MoveQueued moves the queued message with the given ID by offset positions
+	MoveQueued(id, offset int) bool
 	// FocusMessages gives focus to the messages panel for keyboard scrolling
 	FocusMessages() tea.Cmd
 	// FocusMessageAt gives focus and selects the message at the given screen coordinates
@@ -117,12 +132,23 @@ type Page interface {
 	GetSidebarSettings() SidebarSettings
 	// SetSidebarSettings applies sidebar display settings
 	SetSidebarSettings(settings SidebarSettings)
+	// CurrentScrollMark returns the position of the top of the transcript
+	// viewport, as a message index and a line within that message
+	CurrentScrollMark() (msgIndex, lineOffset int)
+	// ScrollToMark scrolls the transcript back to a position returned by CurrentScrollMark
+	ScrollToMark(msgIndex, lineOffset int)
+	// Throughput returns the generation speed of the last response and the
+	// average over the session, in output tokens per second
+	Throughput() (last, average float64)
 }
 
 // queuedMessage represents a message waiting to be sent to the agent
 type queuedMessage struct {
+	// id identifies the message while it's queued.
+	id          int
 	content     string
 	attachments []msgtypes.Attachment
+	raw         bool
 }
 
 // maxQueuedMessages is the maximum number of messages that can be queued
@@ -144,6 +170,7 @@ type chatPage struct {
 	msgCancel       context.CancelFunc
 	streamCancelled bool
 	streamDepth     int // nesting depth of active streams (incremented on StreamStarted, decremented on StreamStopped)
+	stopAfterStep   bool
 
 	// Track whether we've received content from an assistant response
 	// Used by --exit-after-response to ensure we don't exit before receiving content
@@ -151,12 +178,31 @@ type chatPage struct {
 
 	// Message queue for enqueuing messages while agent is working
 	messageQueue []queuedMessage
+	// lastQueuedID is the ID given to the last queued message.
+	lastQueuedID int
 
 	// Editing state for branching sessions
 	editing          bool
 	branchAtPosition int
 	editAttachments  []msgtypes.Attachment // Preserved attachments from original message
 
+	// Paused rendering state: the transcript as drawn when the rendering was
+	// paused, and the position it was scrolled to
+	renderPaused bool
+	pausedView   string
+	pausedMark   struct{ msgIndex, lineOffset int }
+
+	// Replay state, the replay cursor itself lives in the messages component
+	replayPlaying bool // True while the replay advances on its own
+	replayGen     int  // Invalidates pending replay ticks
+
+	// Generation speed of the responses received in this session
+	throughput struct {
+		last         float64
+		outputTokens int64
+		seconds      float64
+	}
+
 	// Key map
 	keyMap KeyMap
 
@@ -215,27 +261,18 @@ func (p *chatPage) computeSidebarLayout() sidebarLayout {
 
 // KeyMap defines key bindings for the chat page
 type KeyMap struct {
-	Cancel          key.Binding
-	ToggleSplitDiff key.Binding
-	ToggleSidebar   key.Binding
+	Cancel        key.Binding
+	ToggleSidebar key.Binding
 }
 
 // defaultKeyMap returns the default key bindings.
-// ctrl+t is reserved for "new tab" in the tab bar,
-// so ToggleSplitDiff is disabled (available via /split-diff command instead).
+// The split diff view is toggled globally with Alt+s, see the app model.
 func defaultKeyMap() KeyMap {
-	splitDiff := key.NewBinding(
-		key.WithKeys("ctrl+t"),
-		key.WithHelp("Ctrl+t", "toggle split diff"),
-	)
-	splitDiff.SetEnabled(false)
-
 	return KeyMap{
 		Cancel: key.NewBinding(
 			key.WithKeys("esc"),
 			key.WithHelp("Esc", "interrupt"),
 		),
-		ToggleSplitDiff: splitDiff,
 		ToggleSidebar: key.NewBinding(
 			key.WithKeys("ctrl+b"),
 			key.WithHelp("Ctrl+b", "toggle sidebar"),
@@ -395,8 +432,54 @@ func (p *chatPage) Update(msg tea.Msg) (layout.Model, tea.Cmd) {
 		p.messages = model.(messages.Model)
 		return p, cmd
 
+	case msgtypes.ToggleRawMarkdownMsg:
+		model, cmd := p.messages.Update(messages.ToggleRawMarkdownMsg{})
+		p.messages = model.(messages.Model)
+		return p, cmd
+
+	case msgtypes.ToggleShowThroughputMsg:
+		model, cmd := p.messages.Update(messages.RefreshMsg{})
+		p.messages = model.(messages.Model)
+		return p, cmd
+
+	case msgtypes.ToggleHomeRelativePathsMsg:
+		model, cmd := p.messages.Update(messages.RefreshMsg{})
+		p.messages = model.(messages.Model)
+		sidebarModel, sidebarCmd := p.sidebar.Update(msg)
+		p.sidebar = sidebarModel.(sidebar.Model)
+		return p, tea.Batch(cmd, sidebarCmd)
+
 	case msgtypes.ClearQueueMsg:
-		return p.handleClearQueue()
+		return p.handleClearQueue(msg.Confirmed)
+
+	case msgtypes.StopAfterStepMsg:
+		return p, p.handleStopAfterStep()
+
+	case msgtypes.TogglePauseRenderingMsg:
+		return p, p.handleTogglePauseRendering()
+
+	case msgtypes.OpenFileReferenceMsg:
+		return p, p.handleOpenFileReference()
+
+	case msgtypes.RemoveQueuedMsg:
+		p.RemoveQueued(msg.ID)
+		return p, nil
+
+	case msgtypes.MoveQueuedMsg:
+		p.MoveQueued(msg.ID, msg.Offset)
+		return p, nil
+
+	case msgtypes.EditQueuedMsg:
+		return p.handleEditQueued(msg)
+
+	case msgtypes.RedirectMsg:
+		return p.handleRedirect(msg.Content)
+
+	case msgtypes.ReplaySessionMsg:
+		return p.handleReplay(msg.Position)
+
+	case replayTickMsg:
+		return p, p.handleReplayTick(msg)
 
 	case msgtypes.ThemeChangedMsg:
 		// Theme changed - forward to all child components to invalidate caches
@@ -495,7 +578,10 @@ func (p *chatPage) renderCollapsedSidebar(sl sidebarLayout) string {
 func (p *chatPage) View() string {
 	sl := p.computeSidebarLayout()
 
-	messagesView := p.messages.View()
+	messagesView := p.pausedView
+	if !p.renderPaused {
+		messagesView = p.messages.View()
+	}
 
 	var bodyContent string
 
@@ -582,6 +668,9 @@ func (p *chatPage) SetSize(width, height int) tea.Cmd {
 	}
 
 	cmds = append(cmds, p.messages.SetSize(sl.chatWidth, sl.chatHeight))
+	if p.renderPaused {
+		p.refreshPausedView()
+	}
 
 	return tea.Batch(cmds...)
 }
@@ -611,6 +700,7 @@ func (p *chatPage) cancelStream(showCancelMessage bool) tea.Cmd {
 	p.msgCancel = nil
 	p.streamCancelled = true
 	p.streamDepth = 0
+	p.stopAfterStep = false
 	p.setPendingResponse(false)
 	// Send StreamCancelledMsg to all components to handle cleanup
 	return tea.Batch(
@@ -622,6 +712,12 @@ func (p *chatPage) cancelStream(showCancelMessage bool) tea.Cmd {
 // handleSendMsg handles incoming messages from the editor, either processing
 // them immediately or queuing them if the agent is busy.
 func (p *chatPage) handleSendMsg(msg msgtypes.SendMsg) (layout.Model, tea.Cmd) {
+	// Agent commands with placeholders given no arguments ask for them first,
+	// right away rather than once the message leaves the queue.
+	if !msg.Raw && p.agentCommandNeedsArgs(msg.Content) {
+		return p, core.CmdHandler(msgtypes.AgentCommandMsg{Command: strings.TrimSpace(msg.Content)})
+	}
+
 	// Predefined slash commands (e.g., /yolo, /exit, /compact) execute immediately
 	// even while the agent is working - they're UI commands that don't interrupt the stream.
 	// Custom agent commands (defined in config) should still be queued.
@@ -630,8 +726,10 @@ func (p *chatPage) handleSendMsg(msg msgtypes.SendMsg) (layout.Model, tea.Cmd) {
 		return p, cmd
 	}
 
-	// If not working, process immediately
+	// If not working, process immediately, bringing a paused transcript
+	// back so the message shows up
 	if !p.working {
+		p.resumeRendering()
 		cmd := p.processMessage(msg)
 		return p, cmd
 	}
@@ -643,8 +741,10 @@ func (p *chatPage) handleSendMsg(msg msgtypes.SendMsg) (layout.Model, tea.Cmd) {
 
 	// Add to queue
 	p.messageQueue = append(p.messageQueue, queuedMessage{
+		id:          p.nextQueuedID(),
 		content:     msg.Content,
 		attachments: msg.Attachments,
+		raw:         msg.Raw,
 	})
 	p.syncQueueToSidebar()
 
@@ -658,6 +758,9 @@ func (p *chatPage) handleEditUserMessage(msg msgtypes.EditUserMessageMsg) (layou
 	if msg.SessionPosition < 0 || msg.MsgIndex < 0 {
 		return p, nil
 	}
+	if p.isReplaying() {
+		return p, notification.ErrorCmd("Replay is read-only · press Esc to exit")
+	}
 
 	p.editing = true
 	p.branchAtPosition = msg.SessionPosition
@@ -791,18 +894,77 @@ func (p *chatPage) processNextQueuedMessage() tea.Cmd {
 	msg := msgtypes.SendMsg{
 		Content:     queued.content,
 		Attachments: queued.attachments,
+		Raw:         queued.raw,
 	}
 
 	return p.processMessage(msg)
 }
 
+// handleRedirect interrupts the running agent and continues with content.
+// The message jumps the queue and is sent when the outermost stream reports
+// it has stopped, so the interrupted run never races with the new one. The
+// new run sees the history up to the last completed message; the partial
+// response that was streaming is discarded, like with Esc.
+func (p *chatPage) handleRedirect(content string) (layout.Model, tea.Cmd) {
+	if content == "" {
+		return p, notification.ErrorCmd("Usage: /redirect <message>")
+	}
+
+	if !p.working || p.msgCancel == nil {
+		cmd := p.processMessage(msgtypes.SendMsg{Content: content})
+		return p, cmd
+	}
+
+	if len(p.messageQueue) >= maxQueuedMessages {
+		return p, notification.WarningCmd(fmt.Sprintf("Queue full (max %d messages). Remove one before redirecting.", maxQueuedMessages))
+	}
+
+	p.msgCancel()
+	p.streamCancelled = true
+	p.messageQueue = slices.Insert(p.messageQueue, 0, queuedMessage{id: p.nextQueuedID(), content: content})
+	p.syncQueueToSidebar()
+
+	return p, tea.Batch(
+		core.CmdHandler(msgtypes.StreamCancelledMsg{ShowMessage: false}),
+		notification.InfoCmd("Interrupted · continuing with your message"),
+	)
+}
+
+// handleStopAfterStep asks the runtime to stop the agent once its current
+// step, a model call and the tool calls it asked for, completes. Unlike Esc,
+// no tool is cut off halfway and the last response is kept.
+func (p *chatPage) handleStopAfterStep() tea.Cmd {
+	if !p.working {
+		return notification.InfoCmd("The agent isn't working")
+	}
+	if p.stopAfterStep {
+		return nil
+	}
+	if !p.app.StopAfterCurrentStep() {
+		return notification.WarningCmd("This runtime can't stop after the current step, press Esc to interrupt")
+	}
+
+	p.stopAfterStep = true
+	return notification.InfoCmd("Will stop after the current step")
+}
+
 // handleClearQueue clears all queued messages and shows a notification.
-func (p *chatPage) handleClearQueue() (layout.Model, tea.Cmd) {
+func (p *chatPage) handleClearQueue(confirmed bool) (layout.Model, tea.Cmd) {
 	count := len(p.messageQueue)
 	if count == 0 {
 		return p, notification.InfoCmd("No messages queued")
 	}
 
+	if !confirmed && userconfig.Get().GetConfirmDestructiveActions() {
+		question := "Clear 1 queued message?"
+		if count > 1 {
+			question = fmt.Sprintf("Clear %d queued messages?", count)
+		}
+		return p, core.CmdHandler(dialog.OpenDialogMsg{
+			Model: dialog.NewConfirmationDialog("Clear Queue", question, msgtypes.ClearQueueMsg{Confirmed: true}),
+		})
+	}
+
 	p.messageQueue = nil
 	p.syncQueueToSidebar()
 
@@ -815,6 +977,33 @@ func (p *chatPage) handleClearQueue() (layout.Model, tea.Cmd) {
 	return p, notification.SuccessCmd(msg)
 }
 
+// handleEditQueued replaces the content of a queued message, unless it was
+// sent since the edit started.
+func (p *chatPage) handleEditQueued(msg msgtypes.EditQueuedMsg) (layout.Model, tea.Cmd) {
+	i := p.queuedIndex(msg.ID)
+	if i < 0 {
+		return p, notification.WarningCmd("The queued message was already sent")
+	}
+	if strings.TrimSpace(msg.Content) == "" {
+		p.RemoveQueued(msg.ID)
+		return p, nil
+	}
+	p.messageQueue[i].content = msg.Content
+	p.syncQueueToSidebar()
+	return p, nil
+}
+
+// agentCommandNeedsArgs reports whether content is an agent command, with no
+// arguments, whose instruction has argument placeholders.
+func (p *chatPage) agentCommandNeedsArgs(content string) bool {
+	content = strings.TrimSpace(content)
+	if !strings.HasPrefix(content, "/") || strings.Contains(content, " ") {
+		return false
+	}
+	cmd, found := p.app.CurrentAgentCommands(context.Background())[content[1:]]
+	return found && len(runtime.CommandArgs(cmd.Instruction)) > 0
+}
+
 // syncQueueToSidebar updates the sidebar with truncated previews of queued messages.
 func (p *chatPage) syncQueueToSidebar() {
 	previews := make([]string, len(p.messageQueue))
@@ -837,6 +1026,10 @@ func (p *chatPage) processMessage(msg msgtypes.SendMsg) tea.Cmd {
 		return cmd
 	}
 
+	if p.isReplaying() {
+		return notification.ErrorCmd("Replay is read-only · press Esc to exit")
+	}
+
 	if p.msgCancel != nil {
 		p.msgCancel()
 	}
@@ -864,15 +1057,53 @@ func (p *chatPage) processMessage(msg msgtypes.SendMsg) tea.Cmd {
 		}
 	}
 
+	// Session variables apply to everything the user types, including the
+	// arguments of agent commands.
+	content := msg.Content
+	if sess := p.app.Session(); sess != nil && !msg.Raw {
+		content = session.ExpandVariables(content, sess.GetVariables())
+	}
+
+	// Agent commands and skills are expanded by the runtime, only plain
+	// messages get the user's prompt prefix and suffix.
+	if !msg.Raw && !strings.HasPrefix(content, "/") {
+		settings := userconfig.Get()
+		content = wrapPrompt(content, settings.PromptPrefix, settings.PromptSuffix)
+	}
+
 	// Run command resolution and agent execution in a goroutine
 	// so the UI stays responsive while skill/agent commands are resolved.
 	go func() {
-		p.app.Run(ctx, p.msgCancel, p.app.ResolveInput(ctx, msg.Content), msg.Attachments)
+		p.app.Run(ctx, p.msgCancel, p.app.ResolveInput(ctx, content), msg.Attachments)
 	}()
 
 	return tea.Batch(p.messages.ScrollToBottom(), spinnerCmd, loadingCmd)
 }
 
+// handleOpenFileReference opens the last path:line reference visible in the
+// transcript in the external editor.
+func (p *chatPage) handleOpenFileReference() tea.Cmd {
+	ref, ok := p.messages.LastVisibleFileRef()
+	if !ok {
+		return notification.InfoCmd("No file:line reference in view")
+	}
+	return core.CmdHandler(msgtypes.OpenFileAtLineMsg{Path: ref.Path, Line: ref.Line, Col: ref.Col})
+}
+
+// wrapPrompt surrounds content with the configured prompt prefix and suffix,
+// each separated from the message by a blank line.
+func wrapPrompt(content, prefix, suffix string) string {
+	parts := make([]string, 0, 3)
+	if prefix = strings.TrimSpace(prefix); prefix != "" {
+		parts = append(parts, prefix)
+	}
+	parts = append(parts, content)
+	if suffix = strings.TrimSpace(suffix); suffix != "" {
+		parts = append(parts, suffix)
+	}
+	return strings.Join(parts, "\n\n")
+}
+
 // CompactSession generates a summary and compacts the session history
 func (p *chatPage) CompactSession(additionalPrompt string) tea.Cmd {
 	// Cancel any active stream without showing cancellation message
@@ -958,6 +1189,9 @@ func (p *chatPage) routeMouseEvent(msg tea.Msg, _ int) tea.Cmd {
 		}
 	}
 
+	if p.renderPaused {
+		return nil
+	}
 	model, cmd := p.messages.Update(msg)
 	p.messages = model.(messages.Model)
 	return cmd
@@ -968,6 +1202,16 @@ func (p *chatPage) IsWorking() bool {
 	return p.working
 }
 
+// StoppingAfterStep returns whether the agent stops after its current step.
+func (p *chatPage) StoppingAfterStep() bool {
+	return p.stopAfterStep
+}
+
+// RenderingPaused returns whether the transcript is frozen on screen.
+func (p *chatPage) RenderingPaused() bool {
+	return p.renderPaused
+}
+
 // IsInlineEditing returns true if a past user message is being edited inline.
 func (p *chatPage) IsInlineEditing() bool {
 	return p.messages.IsInlineEditing()
@@ -978,6 +1222,50 @@ func (p *chatPage) QueueLength() int {
 	return len(p.messageQueue)
 }
 
+// QueuedMessages returns the queued messages, in order
+func (p *chatPage) QueuedMessages() []msgtypes.QueuedEntry {
+	entries := make([]msgtypes.QueuedEntry, len(p.messageQueue))
+	for i, qm := range p.messageQueue {
+		entries[i] = msgtypes.QueuedEntry{ID: qm.id, Content: qm.content}
+	}
+	return entries
+}
+
+// RemoveQueued removes the queued message with the given ID
+func (p *chatPage) RemoveQueued(id int) bool {
+	i := p.queuedIndex(id)
+	if i < 0 {
+		return false
+	}
+	p.messageQueue = slices.Delete(p.messageQueue, i, i+1)
+	p.syncQueueToSidebar()
+	return true
+}
+
+// MoveQueued moves the queued message with the given ID by offset positions
+func (p *chatPage) MoveQueued(id, offset int) bool {
+	from := p.queuedIndex(id)
+	to := from + offset
+	if from < 0 || to < 0 || to >= len(p.messageQueue) || offset == 0 {
+		return false
+	}
+	qm := p.messageQueue[from]
+	p.messageQueue = slices.Insert(slices.Delete(p.messageQueue, from, from+1), to, qm)
+	p.syncQueueToSidebar()
+	return true
+}
+
+// queuedIndex returns the position of the queued message with the given ID,
+// or -1 when it isn't queued anymore.
+func (p *chatPage) queuedIndex(id int) int {
+	return slices.IndexFunc(p.messageQueue, func(qm queuedMessage) bool { return qm.id == id })
+}
+
+func (p *chatPage) nextQueuedID() int {
+	p.lastQueuedID++
+	return p.lastQueuedID
+}
+
 // FocusMessages gives focus to the messages panel
 func (p *chatPage) FocusMessages() tea.Cmd {
 	return p.messages.Focus()
@@ -997,3 +1285,22 @@ func (p *chatPage) BlurMessages() {
 func (p *chatPage) ScrollToBottom() tea.Cmd {
 	return p.messages.ScrollToBottom()
 }
+
+// CurrentScrollMark returns the position of the top of the transcript viewport.
+func (p *chatPage) CurrentScrollMark() (msgIndex, lineOffset int) {
+	return p.messages.CurrentScrollMark()
+}
+
+// ScrollToMark scrolls the transcript back to a position returned by CurrentScrollMark.
+func (p *chatPage) ScrollToMark(msgIndex, lineOffset int) {
+	p.messages.ScrollToMark(msgIndex, lineOffset)
+}
+
+// Throughput returns the generation speed of the last response and the
+// average over the session, in output tokens per second.
+func (p *chatPage) Throughput() (last, average float64) {
+	if p.throughput.seconds > 0 {
+		average = float64(p.throughput.outputTokens) / p.throughput.seconds
+	}
+	return p.throughput.last, average
+}
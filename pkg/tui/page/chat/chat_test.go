@@ -2,6 +2,8 @@ package chat
 
 import (
 	"testing"
+
+	"github.com/stretchr/testify/assert"
 )
 
 func TestGetEditorDisplayNameFromEnv(t *testing.T) {
@@ -110,3 +112,28 @@ func TestGetEditorDisplayNameFromEnv(t *testing.T) {
 		})
 	}
 }
+
+func TestWrapPrompt(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		prefix string
+		suffix string
+		want   string
+	}{
+		{name: "no affixes", want: "fix the bug"},
+		{name: "prefix only", prefix: "Be concise.", want: "Be concise.\n\nfix the bug"},
+		{name: "suffix only", suffix: "Answer in French.", want: "fix the bug\n\nAnswer in French."},
+		{name: "both", prefix: "Be concise.", suffix: "Answer in French.", want: "Be concise.\n\nfix the bug\n\nAnswer in French."},
+		{name: "blank affixes are ignored", prefix: "  ", suffix: "\n", want: "fix the bug"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, wrapPrompt("fix the bug", tt.prefix, tt.suffix))
+		})
+	}
+}
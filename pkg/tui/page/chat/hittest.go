@@ -16,6 +16,7 @@ const (
 	TargetSidebarResizeHandle
 	TargetSidebarStar
 	TargetSidebarTitle
+	TargetSidebarAgentDescription
 	TargetSidebarContent
 	TargetMessages
 )
@@ -124,6 +125,8 @@ func (h *HitTest) sidebarClickTarget(x, y int) MouseTarget {
 		return TargetSidebarStar
 	case sidebar.ClickTitle:
 		return TargetSidebarTitle
+	case sidebar.ClickAgentDescription:
+		return TargetSidebarAgentDescription
 	default:
 		return TargetSidebarContent
 	}
@@ -12,7 +12,6 @@ import (
 	"github.com/docker/cagent/pkg/tui/components/messages"
 	"github.com/docker/cagent/pkg/tui/components/notification"
 	"github.com/docker/cagent/pkg/tui/components/sidebar"
-	"github.com/docker/cagent/pkg/tui/components/tool/editfile"
 	"github.com/docker/cagent/pkg/tui/core"
 	"github.com/docker/cagent/pkg/tui/core/layout"
 	msgtypes "github.com/docker/cagent/pkg/tui/messages"
@@ -39,6 +38,12 @@ func (p *chatPage) handleKeyPress(msg tea.KeyPressMsg) (layout.Model, tea.Cmd) {
 		}
 	}
 
+	if p.isReplaying() {
+		if handled, cmd := p.handleReplayKey(msg); handled {
+			return p, cmd
+		}
+	}
+
 	switch {
 	case key.Matches(msg, p.keyMap.Cancel):
 		// If inline editing is active, cancel the edit first
@@ -56,12 +61,6 @@ func (p *chatPage) handleKeyPress(msg tea.KeyPressMsg) (layout.Model, tea.Cmd) {
 		p.messages = model.(messages.Model)
 		return p, cmd
 
-	case key.Matches(msg, p.keyMap.ToggleSplitDiff):
-		p.sessionState.ToggleSplitDiffView()
-		model, cmd := p.messages.Update(editfile.ToggleDiffViewMsg{})
-		p.messages = model.(messages.Model)
-		return p, cmd
-
 	case key.Matches(msg, p.keyMap.ToggleSidebar):
 		p.sidebar.ToggleCollapsed()
 		cmd := p.SetSize(p.width, p.height)
@@ -69,6 +68,9 @@ func (p *chatPage) handleKeyPress(msg tea.KeyPressMsg) (layout.Model, tea.Cmd) {
 	}
 
 	// Route keys to messages (for scrolling, etc.)
+	if p.renderPaused {
+		return p, nil
+	}
 	model, cmd := p.messages.Update(msg)
 	p.messages = model.(messages.Model)
 	return p, cmd
@@ -130,6 +132,12 @@ func (p *chatPage) handleMouseClick(msg tea.MouseClickMsg) (layout.Model, tea.Cm
 			return p, nil
 		}
 
+	case TargetSidebarAgentDescription:
+		if msg.Button == tea.MouseLeft {
+			p.sidebar.ToggleAgentDescription(msg.Y)
+			return p, nil
+		}
+
 	case TargetMessages:
 		if !p.messages.IsMouseOnScrollbar(msg.X, msg.Y) {
 			cmd := p.routeMouseEvent(msg, msg.Y)
@@ -221,6 +229,9 @@ func (p *chatPage) handleWheelCoalesced(msg msgtypes.WheelCoalescedMsg) (layout.
 		p.sidebar = model.(sidebar.Model)
 		return p, cmd
 	default:
+		if p.renderPaused {
+			return p, nil
+		}
 		model, cmd := p.messages.Update(msg)
 		p.messages = model.(messages.Model)
 		return p, cmd
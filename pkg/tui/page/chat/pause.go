@@ -0,0 +1,51 @@
+package chat
+
+import (
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/docker/cagent/pkg/tui/components/notification"
+)
+
+// Paused Rendering
+//
+// Pausing the rendering freezes the transcript on screen while the agent
+// works on: the messages component keeps receiving the runtime events, but
+// the page draws the transcript as it was when paused. Resuming shows
+// everything received in the meantime, scrolled back to where the transcript
+// was paused. The transcript ignores scrolling and clicks while paused,
+// since they would act on content that isn't shown.
+
+// handleTogglePauseRendering pauses the rendering of the transcript, or
+// resumes it.
+func (p *chatPage) handleTogglePauseRendering() tea.Cmd {
+	if p.renderPaused {
+		p.resumeRendering()
+		return nil
+	}
+	if !p.working {
+		return notification.InfoCmd("The agent isn't working")
+	}
+
+	p.renderPaused = true
+	p.pausedMark.msgIndex, p.pausedMark.lineOffset = p.messages.CurrentScrollMark()
+	p.pausedView = p.messages.View()
+	return notification.InfoCmd("Rendering paused · the agent keeps working, Alt+P to resume")
+}
+
+// resumeRendering brings the transcript up to date, scrolled to where it
+// was paused.
+func (p *chatPage) resumeRendering() {
+	if !p.renderPaused {
+		return
+	}
+	p.renderPaused = false
+	p.pausedView = ""
+	p.messages.ScrollToMark(p.pausedMark.msgIndex, p.pausedMark.lineOffset)
+}
+
+// refreshPausedView redraws the paused transcript at the position it was
+// paused, after the page is resized.
+func (p *chatPage) refreshPausedView() {
+	p.messages.ScrollToMark(p.pausedMark.msgIndex, p.pausedMark.lineOffset)
+	p.pausedView = p.messages.View()
+}
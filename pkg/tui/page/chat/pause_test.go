@@ -0,0 +1,43 @@
+package chat
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/docker/cagent/pkg/tui/components/messages"
+	"github.com/docker/cagent/pkg/tui/components/sidebar"
+	msgtypes "github.com/docker/cagent/pkg/tui/messages"
+	"github.com/docker/cagent/pkg/tui/service"
+)
+
+func TestPauseRendering_FreezesTranscript(t *testing.T) {
+	t.Parallel()
+
+	sessionState := &service.SessionState{}
+	p := &chatPage{
+		sidebar:      sidebar.New(sessionState),
+		messages:     messages.New(sessionState),
+		sessionState: sessionState,
+	}
+	p.SetSize(120, 40)
+	p.messages.AddUserMessage("Tell me a story")
+
+	p.Update(msgtypes.TogglePauseRenderingMsg{})
+	assert.False(t, p.RenderingPaused(), "nothing to pause while the agent isn't working")
+
+	p.working = true
+	p.Update(msgtypes.TogglePauseRenderingMsg{})
+	require.True(t, p.RenderingPaused())
+
+	p.messages.AddAssistantMessage()
+	p.messages.AppendToLastMessage("root", "Once upon a time")
+	view := p.View()
+	assert.Contains(t, view, "Tell me a story")
+	assert.NotContains(t, view, "Once upon a time", "the transcript is frozen")
+
+	p.Update(msgtypes.TogglePauseRenderingMsg{})
+	assert.False(t, p.RenderingPaused())
+	assert.Contains(t, p.View(), "Once upon a time", "resuming shows what was received meanwhile")
+}
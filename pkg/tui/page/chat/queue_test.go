@@ -6,7 +6,9 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/docker/cagent/pkg/paths"
 	"github.com/docker/cagent/pkg/tui/components/sidebar"
+	"github.com/docker/cagent/pkg/tui/dialog"
 	"github.com/docker/cagent/pkg/tui/messages"
 	"github.com/docker/cagent/pkg/tui/service"
 )
@@ -134,13 +136,125 @@ func TestQueueFlow_ClearQueue(t *testing.T) {
 	require.Len(t, p.messageQueue, 3)
 
 	// Clear the queue
-	_, cmd := p.handleClearQueue()
+	_, cmd := p.handleClearQueue(true)
 
 	assert.Empty(t, p.messageQueue)
 	assert.NotNil(t, cmd) // Success notification
 
 	// Clearing empty queue
-	_, cmd = p.handleClearQueue()
+	_, cmd = p.handleClearQueue(true)
 	assert.Empty(t, p.messageQueue)
 	assert.NotNil(t, cmd) // Info notification
 }
+
+func TestQueueFlow_ClearQueueAsksForConfirmation(t *testing.T) {
+	// Destructive actions are confirmed by default, with no user config
+	paths.SetConfigDir(t.TempDir())
+	t.Cleanup(func() { paths.SetConfigDir("") })
+
+	p := newTestChatPage(t)
+	p.handleSendMsg(messages.SendMsg{Content: "first"})
+	p.handleSendMsg(messages.SendMsg{Content: "second"})
+
+	_, cmd := p.handleClearQueue(false)
+	require.NotNil(t, cmd)
+
+	// The queue is kept until the user confirms
+	openMsg, ok := cmd().(dialog.OpenDialogMsg)
+	require.True(t, ok, "expected a confirmation dialog")
+	assert.NotNil(t, openMsg.Model)
+	assert.Len(t, p.messageQueue, 2)
+}
+
+func TestRedirect_JumpsQueueAndCancelsStream(t *testing.T) {
+	t.Parallel()
+
+	p := newTestChatPage(t)
+	cancelled := false
+	p.msgCancel = func() { cancelled = true }
+
+	p.handleSendMsg(messages.SendMsg{Content: "queued"})
+
+	_, cmd := p.handleRedirect("focus on X instead")
+
+	assert.True(t, cancelled, "running stream must be cancelled")
+	assert.True(t, p.streamCancelled)
+	assert.True(t, p.working, "working state is kept until the stream reports it stopped")
+	require.Len(t, p.messageQueue, 2)
+	assert.Equal(t, "focus on X instead", p.messageQueue[0].content)
+	assert.Equal(t, "queued", p.messageQueue[1].content)
+	assert.NotNil(t, cmd)
+}
+
+func TestRedirect_RespectsQueueLimit(t *testing.T) {
+	t.Parallel()
+
+	p := newTestChatPage(t)
+	cancelled := false
+	p.msgCancel = func() { cancelled = true }
+
+	for range maxQueuedMessages {
+		_, _ = p.handleSendMsg(messages.SendMsg{Content: "queued"})
+	}
+	require.Len(t, p.messageQueue, maxQueuedMessages)
+
+	_, cmd := p.handleRedirect("focus on X instead")
+
+	assert.False(t, cancelled, "a full queue must not interrupt the stream")
+	assert.Len(t, p.messageQueue, maxQueuedMessages)
+	assert.Equal(t, "queued", p.messageQueue[0].content)
+	assert.NotNil(t, cmd)
+}
+
+func TestRedirect_EmptyMessage(t *testing.T) {
+	t.Parallel()
+
+	p := newTestChatPage(t)
+	cancelled := false
+	p.msgCancel = func() { cancelled = true }
+
+	_, cmd := p.handleRedirect("")
+
+	assert.False(t, cancelled)
+	assert.Empty(t, p.messageQueue)
+	assert.NotNil(t, cmd)
+}
+
+func TestQueueFlow_EditReorderRemove(t *testing.T) {
+	t.Parallel()
+
+	p := newTestChatPage(t)
+	for _, content := range []string{"first", "second", "third"} {
+		_, _ = p.handleSendMsg(messages.SendMsg{Content: content})
+	}
+	contents := func() []string {
+		var contents []string
+		for _, entry := range p.QueuedMessages() {
+			contents = append(contents, entry.Content)
+		}
+		return contents
+	}
+	queued := p.QueuedMessages()
+	first, second, third := queued[0].ID, queued[1].ID, queued[2].ID
+
+	require.True(t, p.MoveQueued(third, -2))
+	assert.Equal(t, []string{"third", "first", "second"}, contents())
+	assert.False(t, p.MoveQueued(third, -1), "out of range moves are ignored")
+
+	_, _ = p.Update(messages.EditQueuedMsg{ID: first, Content: "first, edited"})
+	assert.Equal(t, []string{"third", "first, edited", "second"}, contents())
+
+	_, _ = p.Update(messages.RemoveQueuedMsg{ID: third})
+	assert.Equal(t, []string{"first, edited", "second"}, contents())
+	assert.Equal(t, 2, p.QueueLength())
+
+	// Once a message is sent, changes to it are rejected and never apply to
+	// the message that took its place.
+	p.messageQueue = p.messageQueue[1:]
+	_, cmd := p.Update(messages.EditQueuedMsg{ID: first, Content: "lost"})
+	assert.NotNil(t, cmd)
+	assert.False(t, p.RemoveQueued(first))
+	assert.False(t, p.MoveQueued(first, 1))
+	assert.Equal(t, []string{"second"}, contents())
+	assert.Equal(t, second, p.QueuedMessages()[0].ID)
+}
@@ -0,0 +1,127 @@
+package chat
+
+import (
+	"fmt"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/docker/cagent/pkg/tui/components/notification"
+	"github.com/docker/cagent/pkg/tui/core"
+	"github.com/docker/cagent/pkg/tui/core/layout"
+	msgtypes "github.com/docker/cagent/pkg/tui/messages"
+)
+
+// replayInterval is the delay between two messages while a replay auto-plays.
+const replayInterval = 1500 * time.Millisecond
+
+// replayTickMsg advances an auto-playing replay. It is ignored if playback
+// was paused or restarted since it was scheduled.
+type replayTickMsg struct{ gen int }
+
+// isReplaying reports whether the session is being replayed. While replaying,
+// messages past the replay cursor are hidden and nothing can be sent.
+func (p *chatPage) isReplaying() bool {
+	cursor, _ := p.messages.ReplayCursor()
+	return cursor >= 0
+}
+
+// handleReplay enters replay mode, or jumps to position if already replaying.
+// The replay only reveals messages already loaded from the session, it never
+// calls the model.
+func (p *chatPage) handleReplay(position int) (layout.Model, tea.Cmd) {
+	if p.working {
+		return p, notification.ErrorCmd("Can't replay while the agent is working")
+	}
+	if _, total := p.messages.ReplayCursor(); total == 0 {
+		return p, notification.InfoCmd("Nothing to replay")
+	}
+
+	p.replayPlaying = false
+	p.replayGen++
+	cmd := p.messages.SetReplayCursor(max(position, 1))
+
+	return p, tea.Batch(
+		cmd,
+		core.CmdHandler(msgtypes.RequestFocusMsg{Target: msgtypes.PanelMessages}),
+		p.replayStatus(),
+	)
+}
+
+// handleReplayKey handles the replay controls. It returns false for keys that
+// should keep their usual behavior, such as scrolling.
+func (p *chatPage) handleReplayKey(msg tea.KeyPressMsg) (bool, tea.Cmd) {
+	cursor, total := p.messages.ReplayCursor()
+
+	switch msg.String() {
+	case "right", "l", "space", "enter":
+		p.replayPlaying = false
+		return true, p.seekReplay(cursor + 1)
+	case "left", "h", "backspace":
+		p.replayPlaying = false
+		return true, p.seekReplay(cursor - 1)
+	case "home":
+		p.replayPlaying = false
+		return true, p.seekReplay(1)
+	case "end":
+		p.replayPlaying = false
+		return true, p.seekReplay(total)
+	case "p":
+		p.replayPlaying = !p.replayPlaying
+		p.replayGen++
+		if !p.replayPlaying {
+			return true, notification.InfoCmd("Replay paused")
+		}
+		if cursor >= total {
+			p.messages.SetReplayCursor(1)
+		}
+		return true, tea.Batch(p.replayStatus(), p.scheduleReplayTick())
+	case "esc":
+		return true, p.exitReplay()
+	}
+	return false, nil
+}
+
+// handleReplayTick reveals the next message of an auto-playing replay.
+func (p *chatPage) handleReplayTick(msg replayTickMsg) tea.Cmd {
+	if !p.replayPlaying || msg.gen != p.replayGen || !p.isReplaying() {
+		return nil
+	}
+
+	cursor, total := p.messages.ReplayCursor()
+	cmd := p.seekReplay(cursor + 1)
+	if cursor+1 >= total {
+		p.replayPlaying = false
+		return cmd
+	}
+	return tea.Batch(cmd, p.scheduleReplayTick())
+}
+
+func (p *chatPage) scheduleReplayTick() tea.Cmd {
+	gen := p.replayGen
+	return tea.Tick(replayInterval, func(time.Time) tea.Msg {
+		return replayTickMsg{gen: gen}
+	})
+}
+
+// seekReplay moves the replay cursor, keeping at least the first message shown.
+func (p *chatPage) seekReplay(position int) tea.Cmd {
+	_, total := p.messages.ReplayCursor()
+	cmd := p.messages.SetReplayCursor(min(max(position, 1), total))
+	return tea.Batch(cmd, p.replayStatus())
+}
+
+// exitReplay leaves replay mode and shows the whole session again.
+func (p *chatPage) exitReplay() tea.Cmd {
+	p.replayPlaying = false
+	p.replayGen++
+	return tea.Batch(
+		p.messages.SetReplayCursor(-1),
+		notification.InfoCmd("Replay ended"),
+	)
+}
+
+func (p *chatPage) replayStatus() tea.Cmd {
+	cursor, total := p.messages.ReplayCursor()
+	return notification.InfoCmd(fmt.Sprintf("Replay %d/%d · →/Space next · ← back · p play · Esc exit", cursor, total))
+}
@@ -35,6 +35,8 @@ import (
 //   - ToolCallEvent             → Tool execution started
 //   - ToolCallConfirmationEvent → Show confirmation dialog
 //   - ToolCallResponseEvent     → Show tool result
+//   - ToolTimeoutEvent          → Mark tool call as timed out
+//   - TransferDepthExceededEvent → Warn that a transfer was refused
 //
 // Sidebar Updates (forwarded):
 //   - TokenUsageEvent, AgentInfoEvent, TeamInfoEvent, etc.
@@ -63,6 +65,15 @@ func (p *chatPage) handleRuntimeEvent(msg tea.Msg) (bool, tea.Cmd) {
 		fallbackMsg := fmt.Sprintf("Model %s failed (%s), switching to %s", msg.FailedModel, msg.Reason, msg.FallbackModel)
 		return true, tea.Batch(sidebarCmd, notification.WarningCmd(fallbackMsg))
 
+	case *runtime.QueuedForProviderEvent:
+		return true, notification.InfoCmd(fmt.Sprintf("Queued · waiting for a free %s slot (limit %d)", msg.Provider, msg.Limit))
+
+	case *runtime.ToolsUnsupportedEvent:
+		if msg.Retried {
+			return true, notification.WarningCmd(fmt.Sprintf("%s does not support tools · retrying without them", msg.Model))
+		}
+		return true, notification.WarningCmd(fmt.Sprintf("%s does not support tools · set retry_without_tools to use it without them", msg.Model))
+
 	// ===== Stream Lifecycle Events =====
 	case *runtime.StreamStartedEvent:
 		return true, p.handleStreamStarted(msg)
@@ -96,6 +107,14 @@ func (p *chatPage) handleRuntimeEvent(msg tea.Msg) (bool, tea.Cmd) {
 	case *runtime.ToolCallResponseEvent:
 		return true, p.handleToolCallResponse(msg)
 
+	case *runtime.ToolTimeoutEvent:
+		// Sent after the response of the tool call, which already holds the error
+		toolCmd := p.messages.AddOrUpdateToolCall(msg.AgentName, msg.ToolCall, msg.ToolDefinition, types.ToolStatusTimedOut)
+		return true, tea.Batch(toolCmd, notification.WarningCmd(fmt.Sprintf("%s timed out after %s", msg.ToolDefinition.DisplayName(), msg.Timeout)))
+
+	case *runtime.TransferDepthExceededEvent:
+		return true, notification.WarningCmd(fmt.Sprintf("%s can't transfer the task to %s: transfers are nested %d deep already", msg.FromAgent, msg.ToAgent, msg.MaxDepth))
+
 	// ===== Sidebar Info Events (forwarded) =====
 	case *runtime.TokenUsageEvent:
 		p.handleTokenUsage(msg)
@@ -172,6 +191,7 @@ func (p *chatPage) handleTokenUsage(msg *runtime.TokenUsageEvent) {
 			if msg.SessionID == "" || msg.SessionID == sess.ID {
 				sess.InputTokens = msg.Usage.InputTokens
 				sess.OutputTokens = msg.Usage.OutputTokens
+				p.recordThroughput(msg.AgentName, msg.Usage.LastMessage)
 			}
 
 			// Track per-message usage for /cost dialog
@@ -187,6 +207,18 @@ func (p *chatPage) handleTokenUsage(msg *runtime.TokenUsageEvent) {
 	}
 }
 
+// recordThroughput shows the generation speed of the last response under its
+// message and adds it to the session average.
+func (p *chatPage) recordThroughput(agentName string, usage *runtime.MessageUsage) {
+	if usage == nil || usage.TokensPerSecond <= 0 {
+		return
+	}
+	p.throughput.last = usage.TokensPerSecond
+	p.throughput.outputTokens += usage.OutputTokens
+	p.throughput.seconds += float64(usage.OutputTokens) / usage.TokensPerSecond
+	p.messages.SetLastResponseThroughput(agentName, usage.TokensPerSecond)
+}
+
 func (p *chatPage) handleStreamStarted(msg *runtime.StreamStartedEvent) tea.Cmd {
 	slog.Debug("handleStreamStarted called", "agent", msg.AgentName, "session_id", msg.SessionID)
 	p.streamCancelled = false
@@ -241,6 +273,8 @@ func (p *chatPage) handleStreamStopped(msg *runtime.StreamStoppedEvent) tea.Cmd
 	// Outermost stream stopped — fully clean up.
 	p.msgCancel = nil
 	p.streamCancelled = false
+	p.stopAfterStep = false
+	p.messages.ClearStreamingMessage()
 	spinnerCmd := p.setWorking(false)
 	p.setPendingResponse(false)
 	queueCmd := p.processNextQueuedMessage()
@@ -0,0 +1,53 @@
+package tui
+
+import (
+	"context"
+	"log/slog"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/docker/cagent/pkg/runtime"
+	"github.com/docker/cagent/pkg/tools"
+	"github.com/docker/cagent/pkg/tui/components/notification"
+	"github.com/docker/cagent/pkg/tui/dialog"
+	"github.com/docker/cagent/pkg/tui/messages"
+)
+
+// sessionStats returns the stats of the sessions for the sessions overview,
+// with the questions that inactive sessions wait on.
+func (m *appModel) sessionStats() []messages.SessionStats {
+	stats := m.supervisor.SessionStats()
+	for i := range stats {
+		if ev := m.pendingQuestion(stats[i].SessionID); ev != nil {
+			stats[i].Question = ev.Message
+		}
+	}
+	return stats
+}
+
+// pendingQuestion returns the free-form question the given session waits on,
+// if any.
+func (m *appModel) pendingQuestion(sessionID string) *runtime.ElicitationRequestEvent {
+	ev, ok := m.supervisor.PendingEvent(sessionID).(*runtime.ElicitationRequestEvent)
+	if !ok || !dialog.IsFreeFormQuestion(ev) {
+		return nil
+	}
+	return ev
+}
+
+// handleReplyToSession answers the question an inactive session waits on and
+// lets it resume, without switching to it.
+func (m *appModel) handleReplyToSession(sessionID, answer string) (tea.Model, tea.Cmd) {
+	runner := m.supervisor.GetRunner(sessionID)
+	if runner == nil || runner.App == nil || m.pendingQuestion(sessionID) == nil {
+		return m, notification.WarningCmd("The session is no longer waiting for an answer")
+	}
+
+	m.supervisor.ResolvePendingEvent(sessionID)
+	content := map[string]any{"response": answer}
+	if err := runner.App.ResumeElicitation(context.Background(), tools.ElicitationActionAccept, content); err != nil {
+		slog.Error("Failed to answer session", "session_id", sessionID, "error", err)
+		return m, notification.ErrorCmd("Failed to answer the session: " + err.Error())
+	}
+	return m, notification.SuccessCmd("Answer sent")
+}
@@ -0,0 +1,35 @@
+package tui
+
+import (
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+// quietHoursTickMsg is sent every minute, when quiet hours are configured, to
+// enter or leave them.
+type quietHoursTickMsg struct{}
+
+// quietHoursCmd schedules the next quiet hours check, or returns nil when
+// quiet hours aren't configured.
+func (m *appModel) quietHoursCmd() tea.Cmd {
+	if !m.hasQuietHours {
+		return nil
+	}
+	return tea.Every(time.Minute, func(time.Time) tea.Msg {
+		return quietHoursTickMsg{}
+	})
+}
+
+// updateQuietHours enters or leaves quiet hours, depending on the time.
+// During quiet hours, the bell doesn't ring and only errors are notified.
+func (m *appModel) updateQuietHours(now time.Time) {
+	m.quiet = m.hasQuietHours && m.quietHours.Contains(now)
+	m.notification.SetQuiet(m.quiet)
+	m.statusBar.SetQuiet(m.quiet)
+}
+
+func (m *appModel) handleQuietHoursTick() (tea.Model, tea.Cmd) {
+	m.updateQuietHours(time.Now())
+	return m, m.quietHoursCmd()
+}
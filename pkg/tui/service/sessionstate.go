@@ -16,6 +16,7 @@ type SessionStateReader interface {
 	YoloMode() bool
 	Thinking() bool
 	HideToolResults() bool
+	RawMarkdown() bool
 	CurrentAgentName() string
 	PreviousMessage() *types.Message
 	SessionTitle() string
@@ -34,6 +35,7 @@ type SessionState struct {
 	yoloMode        bool
 	thinking        bool
 	hideToolResults bool
+	rawMarkdown     bool
 	sessionTitle    string
 
 	previousMessage  *types.Message
@@ -55,8 +57,8 @@ func (s *SessionState) SplitDiffView() bool {
 	return s.splitDiffView
 }
 
-func (s *SessionState) ToggleSplitDiffView() {
-	s.splitDiffView = !s.splitDiffView
+func (s *SessionState) SetSplitDiffView(splitDiffView bool) {
+	s.splitDiffView = splitDiffView
 }
 
 func (s *SessionState) YoloMode() bool {
@@ -87,6 +89,16 @@ func (s *SessionState) SetHideToolResults(hideToolResults bool) {
 	s.hideToolResults = hideToolResults
 }
 
+// RawMarkdown reports whether messages show their markdown source instead of
+// the rendered view.
+func (s *SessionState) RawMarkdown() bool {
+	return s.rawMarkdown
+}
+
+func (s *SessionState) ToggleRawMarkdown() {
+	s.rawMarkdown = !s.rawMarkdown
+}
+
 func (s *SessionState) CurrentAgentName() string {
 	return s.currentAgentName
 }
@@ -115,6 +127,23 @@ func (s *SessionState) AvailableAgents() []runtime.AgentDetails {
 	return s.availableAgents
 }
 
+// SwitchableAgents returns the available agents that are not hidden. These
+// are the agents offered by the agent picker and the Ctrl+number shortcuts.
+func (s *SessionState) SwitchableAgents() []runtime.AgentDetails {
+	return VisibleAgents(s.availableAgents)
+}
+
+// VisibleAgents returns the agents that are not hidden, in order.
+func VisibleAgents(agents []runtime.AgentDetails) []runtime.AgentDetails {
+	var visible []runtime.AgentDetails
+	for _, a := range agents {
+		if !a.Hidden {
+			visible = append(visible, a)
+		}
+	}
+	return visible
+}
+
 func (s *SessionState) SetAvailableAgents(availableAgents []runtime.AgentDetails) {
 	s.availableAgents = availableAgents
 
@@ -3,6 +3,7 @@ package supervisor
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"path/filepath"
@@ -24,7 +25,10 @@ type SessionRunner struct {
 	Title        string
 	IsRunning    bool    // True when stream is active
 	NeedsAttn    bool    // True when user attention is needed
+	IsQueued     bool    // True while waiting for a provider's concurrency limit
 	PendingEvent tea.Msg // Event that triggered attention (for replay on tab switch)
+	Scratchpad   bool    // True for the scratchpad tab, which has no App
+	dirty        bool    // True when the session changed since it was last autosaved
 	cancel       context.CancelFunc
 	cleanup      func()
 }
@@ -33,14 +37,23 @@ type SessionRunner struct {
 // It takes a working directory and returns the app, session, and cleanup function.
 type SessionSpawner func(ctx context.Context, workingDir string) (*app.App, *session.Session, func(), error)
 
+// ErrTooManySessions is returned by SpawnSession when the maximum number of
+// sessions is reached.
+var ErrTooManySessions = errors.New("too many tabs open")
+
 // Supervisor manages agent sessions.
 type Supervisor struct {
-	mu       sync.RWMutex
-	runners  map[string]*SessionRunner
-	order    []string // Maintains tab order
-	activeID string
-	spawner  SessionSpawner
-	program  *tea.Program
+	mu          sync.RWMutex
+	runners     map[string]*SessionRunner
+	order       []string // Maintains tab order
+	activeID    string
+	spawner     SessionSpawner
+	program     *tea.Program
+	maxSessions int // 0 means unlimited
+
+	// previousActiveID is the tab that was active before activeID, so that
+	// the user can go back and forth between two tabs.
+	previousActiveID string
 
 	// programReady is closed when SetProgram is called. Subscription goroutines
 	// wait on this before consuming events so that startup events (welcome message,
@@ -68,6 +81,27 @@ func (s *Supervisor) SetProgram(p *tea.Program) {
 	})
 }
 
+// SetMaxSessions sets the maximum number of sessions SpawnSession lets run at
+// once. Zero or less means unlimited.
+func (s *Supervisor) SetMaxSessions(n int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.maxSessions = max(n, 0)
+}
+
+// CanSpawn returns ErrTooManySessions, wrapped with the limit, if spawning
+// another session would exceed the maximum number of sessions.
+func (s *Supervisor) CanSpawn() error {
+	s.mu.RLock()
+	limit := s.maxSessions
+	s.mu.RUnlock()
+
+	if limit > 0 && s.Count() >= limit {
+		return fmt.Errorf("%w (the limit is %d)", ErrTooManySessions, limit)
+	}
+	return nil
+}
+
 // AddSession adds an existing session to the supervisor.
 func (s *Supervisor) AddSession(ctx context.Context, a *app.App, sess *session.Session, workingDir string, cleanup func()) string {
 	s.mu.Lock()
@@ -98,11 +132,37 @@ func (s *Supervisor) AddSession(ctx context.Context, a *app.App, sess *session.S
 	return sess.ID
 }
 
+// AddScratchpad adds a tab that isn't backed by any session: it has no App
+// and receives no runtime events. Adding it again is a no-op.
+func (s *Supervisor) AddScratchpad(id, title string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.runners[id]; ok {
+		return
+	}
+
+	s.runners[id] = &SessionRunner{
+		ID:         id,
+		Title:      title,
+		Scratchpad: true,
+	}
+	s.order = append(s.order, id)
+
+	if s.activeID == "" {
+		s.activeID = id
+	}
+	s.notifyTabsUpdated()
+}
+
 // SpawnSession creates and adds a new session.
 func (s *Supervisor) SpawnSession(ctx context.Context, workingDir string) (string, error) {
 	if s.spawner == nil {
 		return "", fmt.Errorf("session spawning is not available")
 	}
+	if err := s.CanSpawn(); err != nil {
+		return "", err
+	}
 
 	a, sess, cleanup, err := s.spawner(ctx, workingDir)
 	if err != nil {
@@ -157,7 +217,21 @@ func (s *Supervisor) handleRuntimeEvent(sessionID string, msg tea.Msg) {
 		return
 	}
 
+	if changesSession(msg) {
+		runner.dirty = true
+	}
+
+	// A queued request shows up as queued until anything else happens.
+	if _, queued := msg.(*runtime.QueuedForProviderEvent); !queued && runner.IsQueued {
+		runner.IsQueued = false
+		s.notifyTabsUpdated()
+	}
+
 	switch ev := msg.(type) {
+	case *runtime.QueuedForProviderEvent:
+		runner.IsQueued = true
+		s.notifyTabsUpdated()
+
 	case *runtime.StreamStartedEvent:
 		runner.IsRunning = true
 		runner.PendingEvent = nil // New stream supersedes any stale pending event
@@ -227,6 +301,7 @@ func (s *Supervisor) buildTabInfoLocked() []messages.TabInfo {
 			IsActive:       id == s.activeID,
 			IsRunning:      runner.IsRunning,
 			NeedsAttention: runner.NeedsAttn,
+			IsQueued:       runner.IsQueued,
 		})
 	}
 	return tabs
@@ -252,6 +327,9 @@ func (s *Supervisor) SwitchTo(sessionID string) *SessionRunner {
 		return nil
 	}
 
+	if sessionID != s.activeID {
+		s.previousActiveID = s.activeID
+	}
 	s.activeID = sessionID
 	runner.NeedsAttn = false // Clear attention flag when switching to this tab
 	s.notifyTabsUpdated()
@@ -275,6 +353,34 @@ func (s *Supervisor) ConsumePendingEvent(sessionID string) tea.Msg {
 	return event
 }
 
+// PendingEvent returns the pending event for the given session without
+// clearing it. Returns nil if no event is pending.
+func (s *Supervisor) PendingEvent(sessionID string) tea.Msg {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	runner, ok := s.runners[sessionID]
+	if !ok {
+		return nil
+	}
+	return runner.PendingEvent
+}
+
+// ResolvePendingEvent clears the pending event for the given session, and its
+// attention flag, once it was handled without switching to the session.
+func (s *Supervisor) ResolvePendingEvent(sessionID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	runner, ok := s.runners[sessionID]
+	if !ok {
+		return
+	}
+	runner.PendingEvent = nil
+	runner.NeedsAttn = false
+	s.notifyTabsUpdated()
+}
+
 // ActiveRunner returns the currently active session runner.
 func (s *Supervisor) ActiveRunner() *SessionRunner {
 	s.mu.RLock()
@@ -290,6 +396,14 @@ func (s *Supervisor) GetRunner(sessionID string) *SessionRunner {
 	return s.runners[sessionID]
 }
 
+// IsRunning reports whether the given session is currently streaming.
+func (s *Supervisor) IsRunning(sessionID string) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	runner, ok := s.runners[sessionID]
+	return ok && runner.IsRunning
+}
+
 // SetRunnerTitle updates the title of the runner for the given session ID.
 // It also triggers a tab update notification.
 func (s *Supervisor) SetRunnerTitle(sessionID, title string) {
@@ -322,6 +436,7 @@ func (s *Supervisor) ReplaceRunnerApp(ctx context.Context, sessionID string, new
 
 	// Replace app, working dir, and cleanup.
 	runner.App = newApp
+	runner.dirty = false
 	runner.WorkingDir = workingDir
 	runner.cleanup = cleanup
 
@@ -341,6 +456,53 @@ func (s *Supervisor) ReplaceRunnerApp(ctx context.Context, sessionID string, new
 	go s.subscribeWithRouting(sessionCtx, newApp, sessionID)
 }
 
+// changesSession reports whether a runtime event changes what is stored for
+// its session. Startup events don't, so that autosave doesn't save empty
+// sessions.
+func changesSession(msg tea.Msg) bool {
+	switch msg.(type) {
+	case *runtime.UserMessageEvent, *runtime.MessageAddedEvent, *runtime.TokenUsageEvent,
+		*runtime.SessionTitleEvent, *runtime.SessionSummaryEvent, *runtime.SubSessionCompletedEvent:
+		return true
+	}
+	return false
+}
+
+// SaveDirtySessions saves the sessions that changed since they were last
+// saved. Sessions that fail to save are retried on the next call.
+//
+// Running sessions are saved once they stop: the runtime changes them
+// without locking while it runs, and persists what they're made of as it
+// goes. Call it from the UI loop, which is the only one to change an idle
+// session.
+func (s *Supervisor) SaveDirtySessions(ctx context.Context) {
+	type dirtySession struct {
+		id  string
+		app *app.App
+	}
+
+	s.mu.Lock()
+	var dirty []dirtySession
+	for id, runner := range s.runners {
+		if runner.dirty && runner.App != nil && !runner.IsRunning {
+			runner.dirty = false
+			dirty = append(dirty, dirtySession{id: id, app: runner.App})
+		}
+	}
+	s.mu.Unlock()
+
+	for _, d := range dirty {
+		if err := d.app.SaveSession(ctx); err != nil {
+			slog.Warn("Failed to autosave session", "session_id", d.id, "error", err)
+			s.mu.Lock()
+			if runner, ok := s.runners[d.id]; ok && runner.App == d.app {
+				runner.dirty = true
+			}
+			s.mu.Unlock()
+		}
+	}
+}
+
 // ActiveID returns the ID of the currently active session.
 func (s *Supervisor) ActiveID() string {
 	s.mu.RLock()
@@ -348,6 +510,14 @@ func (s *Supervisor) ActiveID() string {
 	return s.activeID
 }
 
+// PreviousActiveID returns the ID of the session that was active before the
+// current one, or "" if there is none.
+func (s *Supervisor) PreviousActiveID() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.previousActiveID
+}
+
 // Spawner returns the session spawner function, or nil if none is configured.
 func (s *Supervisor) Spawner() SessionSpawner {
 	return s.spawner
@@ -397,6 +567,10 @@ func (s *Supervisor) CloseSession(sessionID string) (nextActiveID string) {
 		}
 	}
 
+	if s.previousActiveID == sessionID || s.previousActiveID == s.activeID {
+		s.previousActiveID = ""
+	}
+
 	s.notifyTabsUpdated()
 	nextActiveID = s.activeID
 	s.mu.Unlock()
@@ -409,11 +583,17 @@ func (s *Supervisor) CloseSession(sessionID string) (nextActiveID string) {
 	return nextActiveID
 }
 
-// Count returns the number of sessions.
+// Count returns the number of sessions. The scratchpad tab isn't counted.
 func (s *Supervisor) Count() int {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
-	return len(s.runners)
+	count := 0
+	for _, runner := range s.runners {
+		if !runner.Scratchpad {
+			count++
+		}
+	}
+	return count
 }
 
 // GetTabs returns the current tab info.
@@ -423,6 +603,32 @@ func (s *Supervisor) GetTabs() ([]messages.TabInfo, int) {
 	return s.buildTabInfoLocked(), s.activeIndexLocked()
 }
 
+// SessionStats returns the stats of all the sessions, in tab order.
+func (s *Supervisor) SessionStats() []messages.SessionStats {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	tabs := s.buildTabInfoLocked()
+	stats := make([]messages.SessionStats, 0, len(tabs))
+	for _, tab := range tabs {
+		runner := s.runners[tab.SessionID]
+		st := messages.SessionStats{
+			TabInfo:    tab,
+			WorkingDir: runner.WorkingDir,
+			Scratchpad: runner.Scratchpad,
+		}
+		if runner.App != nil {
+			if sess := runner.App.Session(); sess != nil {
+				st.Messages = sess.MessageCount()
+				st.Tokens = sess.InputTokens + sess.OutputTokens
+				st.Cost = sess.TotalCost()
+			}
+		}
+		stats = append(stats, st)
+	}
+	return stats
+}
+
 // ReorderTab moves the tab at fromIdx to toIdx, shifting others accordingly.
 func (s *Supervisor) ReorderTab(fromIdx, toIdx int) {
 	s.mu.Lock()
@@ -457,6 +663,7 @@ func (s *Supervisor) Shutdown() {
 	s.runners = make(map[string]*SessionRunner)
 	s.order = nil
 	s.activeID = ""
+	s.previousActiveID = ""
 	s.mu.Unlock()
 
 	// Run cleanups outside the lock so they can't deadlock.
@@ -1,9 +1,15 @@
 package supervisor
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/docker/cagent/pkg/app"
+	"github.com/docker/cagent/pkg/runtime"
+	"github.com/docker/cagent/pkg/session"
 )
 
 func newTestSupervisor(ids []string, activeID string) *Supervisor {
@@ -93,3 +99,133 @@ func TestCloseSession_TwoTabs_CloseSecond(t *testing.T) {
 	assert.Equal(t, "A", s.activeID)
 	assert.Equal(t, []string{"A"}, s.order)
 }
+
+func TestIsRunning(t *testing.T) {
+	s := newTestSupervisor([]string{"A", "B"}, "A")
+	s.runners["B"].IsRunning = true
+
+	assert.False(t, s.IsRunning("A"))
+	assert.True(t, s.IsRunning("B"))
+	assert.False(t, s.IsRunning("missing"))
+}
+
+func TestAddScratchpad(t *testing.T) {
+	s := newTestSupervisor([]string{"A"}, "A")
+
+	s.AddScratchpad("scratch", "Scratchpad")
+	s.AddScratchpad("scratch", "Scratchpad")
+
+	assert.Equal(t, []string{"A", "scratch"}, s.order)
+	assert.True(t, s.runners["scratch"].Scratchpad)
+	assert.Equal(t, "A", s.activeID)
+	assert.Equal(t, 1, s.Count())
+
+	tabs, _ := s.GetTabs()
+	assert.Equal(t, "Scratchpad", tabs[1].Title)
+}
+
+func TestSessionStats(t *testing.T) {
+	s := newTestSupervisor([]string{"A", "B"}, "B")
+	s.runners["A"].WorkingDir = "/src/a"
+	s.runners["A"].IsRunning = true
+	s.AddScratchpad("scratch", "Scratchpad")
+
+	stats := s.SessionStats()
+	require.Len(t, stats, 3)
+	assert.Equal(t, "/src/a", stats[0].WorkingDir)
+	assert.True(t, stats[0].IsRunning)
+	assert.True(t, stats[1].IsActive)
+	assert.True(t, stats[2].Scratchpad)
+}
+
+func TestSpawnSession_MaxSessions(t *testing.T) {
+	s := newTestSupervisor([]string{"A", "B"}, "A")
+	s.spawner = func(context.Context, string) (*app.App, *session.Session, func(), error) {
+		t.Fatal("spawner must not be called over the limit")
+		return nil, nil, nil, nil
+	}
+	s.AddScratchpad("scratch", "Scratchpad")
+
+	s.SetMaxSessions(3)
+	require.NoError(t, s.CanSpawn())
+
+	s.SetMaxSessions(2)
+	_, err := s.SpawnSession(t.Context(), "/tmp")
+	require.ErrorIs(t, err, ErrTooManySessions)
+	assert.Contains(t, err.Error(), "the limit is 2")
+
+	s.SetMaxSessions(0)
+	require.NoError(t, s.CanSpawn())
+}
+
+func TestHandleRuntimeEvent_MarksDirtyOnPersistedChanges(t *testing.T) {
+	s := newTestSupervisor([]string{"A"}, "A")
+
+	// Startup and streaming events don't make the session dirty.
+	s.handleRuntimeEvent("A", &runtime.AgentInfoEvent{})
+	s.handleRuntimeEvent("A", &runtime.StreamStartedEvent{})
+	s.handleRuntimeEvent("A", &runtime.AgentChoiceEvent{})
+	assert.False(t, s.runners["A"].dirty)
+
+	s.handleRuntimeEvent("A", &runtime.TokenUsageEvent{})
+	assert.True(t, s.runners["A"].dirty)
+
+	s.runners["A"].dirty = false
+	s.handleRuntimeEvent("A", &runtime.SessionTitleEvent{Title: "Renamed"})
+	assert.True(t, s.runners["A"].dirty)
+}
+
+func TestSaveDirtySessions_WaitsForRunningSessions(t *testing.T) {
+	s := newTestSupervisor([]string{"A"}, "A")
+	s.runners["A"].App = &app.App{}
+	s.runners["A"].dirty = true
+	s.runners["A"].IsRunning = true
+
+	s.SaveDirtySessions(t.Context())
+	assert.True(t, s.runners["A"].dirty, "running sessions stay dirty until they stop")
+}
+
+func TestSwitchTo_TracksPreviousTab(t *testing.T) {
+	s := newTestSupervisor([]string{"A", "B", "C"}, "A")
+	assert.Empty(t, s.PreviousActiveID())
+
+	s.SwitchTo("C")
+	assert.Equal(t, "A", s.PreviousActiveID())
+
+	// Switching back and forth ping-pongs between the two tabs.
+	s.SwitchTo(s.PreviousActiveID())
+	assert.Equal(t, "A", s.activeID)
+	assert.Equal(t, "C", s.PreviousActiveID())
+
+	// Switching to the active tab doesn't forget the previous one.
+	s.SwitchTo("A")
+	assert.Equal(t, "C", s.PreviousActiveID())
+
+	// Closing the previous tab forgets it.
+	s.CloseSession("C")
+	assert.Empty(t, s.PreviousActiveID())
+}
+
+func TestCloseSession_ForgetsPreviousTabWhenItBecomesActive(t *testing.T) {
+	// Tabs: [A, B, C], active=B, previous=A. Close B → A is active.
+	s := newTestSupervisor([]string{"A", "B", "C"}, "A")
+	s.SwitchTo("B")
+
+	s.CloseSession("B")
+
+	assert.Equal(t, "A", s.activeID)
+	assert.Empty(t, s.PreviousActiveID())
+}
+
+func TestResolvePendingEvent(t *testing.T) {
+	s := newTestSupervisor([]string{"A", "B"}, "A")
+	question := &runtime.ElicitationRequestEvent{Message: "Which branch?"}
+	s.handleRuntimeEvent("B", question)
+	require.Equal(t, question, s.PendingEvent("B"))
+	require.True(t, s.runners["B"].NeedsAttn)
+
+	s.ResolvePendingEvent("B")
+	assert.Nil(t, s.PendingEvent("B"))
+	assert.False(t, s.runners["B"].NeedsAttn)
+	assert.Nil(t, s.PendingEvent("missing"))
+}
@@ -1,9 +1,11 @@
-// Package tuistate provides persistent TUI state storage (tabs, recent/favorite directories).
+// Package tuistate provides persistent TUI state storage (tabs, recent/favorite directories,
+// one-time notices, scratchpad, toggles, transcript bookmarks).
 package tuistate
 
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"path/filepath"
 
@@ -57,6 +59,47 @@ func (s *Store) migrate() error {
 			path TEXT PRIMARY KEY,
 			added_at DATETIME DEFAULT CURRENT_TIMESTAMP
 		);
+
+		CREATE TABLE IF NOT EXISTS seen_notices (
+			id TEXT PRIMARY KEY,
+			seen_at DATETIME DEFAULT CURRENT_TIMESTAMP
+		);
+
+		CREATE TABLE IF NOT EXISTS scratchpad (
+			id INTEGER PRIMARY KEY CHECK (id = 1),
+			content TEXT NOT NULL,
+			updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+		);
+
+		CREATE TABLE IF NOT EXISTS editor_height (
+			id INTEGER PRIMARY KEY CHECK (id = 1),
+			lines INTEGER NOT NULL
+		);
+
+		CREATE TABLE IF NOT EXISTS diff_view (
+			id INTEGER PRIMARY KEY CHECK (id = 1),
+			split BOOLEAN NOT NULL
+		);
+
+		CREATE TABLE IF NOT EXISTS toggles (
+			name TEXT PRIMARY KEY,
+			enabled BOOLEAN NOT NULL
+		);
+
+		CREATE TABLE IF NOT EXISTS bookmarks (
+			session_id TEXT NOT NULL,
+			label TEXT NOT NULL,
+			message_index INTEGER NOT NULL,
+			line_offset INTEGER NOT NULL,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			PRIMARY KEY (session_id, label)
+		);
+
+		CREATE TABLE IF NOT EXISTS prompt_templates (
+			name TEXT PRIMARY KEY,
+			content TEXT NOT NULL,
+			updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+		);
 	`)
 	if err != nil {
 		return err
@@ -309,3 +352,176 @@ func (s *Store) ToggleFavoriteDir(ctx context.Context, path string) (bool, error
 	}
 	return true, s.AddFavoriteDir(ctx, path)
 }
+
+// HasSeenNotice reports whether the one-time notice with the given ID was already shown.
+func (s *Store) HasSeenNotice(ctx context.Context, id string) (bool, error) {
+	var count int
+	err := s.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM seen_notices WHERE id = ?`, id).Scan(&count)
+	return count > 0, err
+}
+
+// MarkNoticeSeen records that the one-time notice with the given ID was shown.
+func (s *Store) MarkNoticeSeen(ctx context.Context, id string) error {
+	_, err := s.db.ExecContext(ctx, `
+		INSERT OR REPLACE INTO seen_notices (id, seen_at)
+		VALUES (?, CURRENT_TIMESTAMP)
+	`, id)
+	return err
+}
+
+// GetScratchpad returns the saved scratchpad content, or "" if nothing was saved yet.
+func (s *Store) GetScratchpad(ctx context.Context) (string, error) {
+	var content string
+	err := s.db.QueryRowContext(ctx, `SELECT content FROM scratchpad WHERE id = 1`).Scan(&content)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil
+	}
+	return content, err
+}
+
+// SaveScratchpad stores the scratchpad content, replacing the previous one.
+func (s *Store) SaveScratchpad(ctx context.Context, content string) error {
+	_, err := s.db.ExecContext(ctx, `
+		INSERT OR REPLACE INTO scratchpad (id, content, updated_at)
+		VALUES (1, ?, CURRENT_TIMESTAMP)
+	`, content)
+	return err
+}
+
+// GetEditorLines returns the saved height of the editor, in lines, or 0 if
+// it was never resized.
+func (s *Store) GetEditorLines(ctx context.Context) (int, error) {
+	var lines int
+	err := s.db.QueryRowContext(ctx, `SELECT lines FROM editor_height WHERE id = 1`).Scan(&lines)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, nil
+	}
+	return lines, err
+}
+
+// SaveEditorLines stores the height of the editor, in lines.
+func (s *Store) SaveEditorLines(ctx context.Context, lines int) error {
+	_, err := s.db.ExecContext(ctx, `INSERT OR REPLACE INTO editor_height (id, lines) VALUES (1, ?)`, lines)
+	return err
+}
+
+// GetSplitDiffView returns whether the diffs are shown side by side. ok is
+// false if the diff view was never toggled.
+func (s *Store) GetSplitDiffView(ctx context.Context) (split, ok bool, err error) {
+	err = s.db.QueryRowContext(ctx, `SELECT split FROM diff_view WHERE id = 1`).Scan(&split)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, false, nil
+	}
+	return split, err == nil, err
+}
+
+// SaveSplitDiffView stores whether the diffs are shown side by side.
+func (s *Store) SaveSplitDiffView(ctx context.Context, split bool) error {
+	_, err := s.db.ExecContext(ctx, `INSERT OR REPLACE INTO diff_view (id, split) VALUES (1, ?)`, split)
+	return err
+}
+
+// GetToggle returns whether the TUI toggle with the given name is on. ok is
+// false if the toggle was never saved.
+func (s *Store) GetToggle(ctx context.Context, name string) (enabled, ok bool, err error) {
+	err = s.db.QueryRowContext(ctx, `SELECT enabled FROM toggles WHERE name = ?`, name).Scan(&enabled)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, false, nil
+	}
+	return enabled, err == nil, err
+}
+
+// SaveToggle stores whether the TUI toggle with the given name is on.
+func (s *Store) SaveToggle(ctx context.Context, name string, enabled bool) error {
+	_, err := s.db.ExecContext(ctx, `INSERT OR REPLACE INTO toggles (name, enabled) VALUES (?, ?)`, name, enabled)
+	return err
+}
+
+// Bookmark is a named position in the transcript of a session. It points at
+// a message, and a line within it, so that it survives re-renders.
+type Bookmark struct {
+	Label        string
+	MessageIndex int
+	LineOffset   int
+}
+
+// AddBookmark stores a bookmark for the session, replacing the one with the
+// same label.
+func (s *Store) AddBookmark(ctx context.Context, sessionID string, b Bookmark) error {
+	_, err := s.db.ExecContext(ctx, `
+		INSERT OR REPLACE INTO bookmarks (session_id, label, message_index, line_offset, created_at)
+		VALUES (?, ?, ?, ?, CURRENT_TIMESTAMP)
+	`, sessionID, b.Label, b.MessageIndex, b.LineOffset)
+	return err
+}
+
+// RemoveBookmark removes the bookmark of the session with the given label.
+func (s *Store) RemoveBookmark(ctx context.Context, sessionID, label string) error {
+	_, err := s.db.ExecContext(ctx, `DELETE FROM bookmarks WHERE session_id = ? AND label = ?`, sessionID, label)
+	return err
+}
+
+// GetBookmarks returns the bookmarks of the session, in transcript order.
+func (s *Store) GetBookmarks(ctx context.Context, sessionID string) ([]Bookmark, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT label, message_index, line_offset FROM bookmarks
+		WHERE session_id = ?
+		ORDER BY message_index ASC, line_offset ASC
+	`, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var bookmarks []Bookmark
+	for rows.Next() {
+		var b Bookmark
+		if err := rows.Scan(&b.Label, &b.MessageIndex, &b.LineOffset); err != nil {
+			return nil, err
+		}
+		bookmarks = append(bookmarks, b)
+	}
+	return bookmarks, rows.Err()
+}
+
+// PromptTemplate is a named, reusable prompt. Its content may contain
+// {placeholder} markers that are filled in when the template is inserted.
+type PromptTemplate struct {
+	Name    string
+	Content string
+}
+
+// SavePromptTemplate stores a prompt template, replacing the one with the
+// same name.
+func (s *Store) SavePromptTemplate(ctx context.Context, t PromptTemplate) error {
+	_, err := s.db.ExecContext(ctx, `
+		INSERT OR REPLACE INTO prompt_templates (name, content, updated_at)
+		VALUES (?, ?, CURRENT_TIMESTAMP)
+	`, t.Name, t.Content)
+	return err
+}
+
+// RemovePromptTemplate removes the prompt template with the given name.
+func (s *Store) RemovePromptTemplate(ctx context.Context, name string) error {
+	_, err := s.db.ExecContext(ctx, `DELETE FROM prompt_templates WHERE name = ?`, name)
+	return err
+}
+
+// GetPromptTemplates returns all prompt templates, sorted by name.
+func (s *Store) GetPromptTemplates(ctx context.Context) ([]PromptTemplate, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT name, content FROM prompt_templates ORDER BY name ASC`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var templates []PromptTemplate
+	for rows.Next() {
+		var t PromptTemplate
+		if err := rows.Scan(&t.Name, &t.Content); err != nil {
+			return nil, err
+		}
+		templates = append(templates, t)
+	}
+	return templates, rows.Err()
+}
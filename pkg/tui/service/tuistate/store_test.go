@@ -211,3 +211,150 @@ func TestRemoveNonexistentTab(t *testing.T) {
 	// Should not error — just a no-op.
 	require.NoError(t, store.RemoveTab(ctx, "does-not-exist"))
 }
+
+func TestSeenNotices(t *testing.T) {
+	t.Parallel()
+	store := newTestStore(t)
+	ctx := t.Context()
+
+	seen, err := store.HasSeenNotice(ctx, "some-notice")
+	require.NoError(t, err)
+	assert.False(t, seen)
+
+	require.NoError(t, store.MarkNoticeSeen(ctx, "some-notice"))
+	require.NoError(t, store.MarkNoticeSeen(ctx, "some-notice"))
+
+	seen, err = store.HasSeenNotice(ctx, "some-notice")
+	require.NoError(t, err)
+	assert.True(t, seen)
+
+	seen, err = store.HasSeenNotice(ctx, "other-notice")
+	require.NoError(t, err)
+	assert.False(t, seen)
+}
+
+func TestScratchpad(t *testing.T) {
+	t.Parallel()
+	store := newTestStore(t)
+	ctx := t.Context()
+
+	content, err := store.GetScratchpad(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, content)
+
+	require.NoError(t, store.SaveScratchpad(ctx, "first draft"))
+	require.NoError(t, store.SaveScratchpad(ctx, "second draft\nwith notes"))
+
+	content, err = store.GetScratchpad(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "second draft\nwith notes", content)
+}
+
+func TestEditorLines(t *testing.T) {
+	t.Parallel()
+	store := newTestStore(t)
+	ctx := t.Context()
+
+	lines, err := store.GetEditorLines(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, lines)
+
+	require.NoError(t, store.SaveEditorLines(ctx, 6))
+	require.NoError(t, store.SaveEditorLines(ctx, 9))
+
+	lines, err = store.GetEditorLines(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 9, lines)
+}
+
+func TestSplitDiffView(t *testing.T) {
+	t.Parallel()
+	store := newTestStore(t)
+	ctx := t.Context()
+
+	_, ok, err := store.GetSplitDiffView(ctx)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	require.NoError(t, store.SaveSplitDiffView(ctx, true))
+	split, ok, err := store.GetSplitDiffView(ctx)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.True(t, split)
+
+	require.NoError(t, store.SaveSplitDiffView(ctx, false))
+	split, ok, err = store.GetSplitDiffView(ctx)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.False(t, split)
+}
+
+func TestToggles(t *testing.T) {
+	t.Parallel()
+	store := newTestStore(t)
+	ctx := t.Context()
+
+	_, ok, err := store.GetToggle(ctx, "send_and_stay")
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	require.NoError(t, store.SaveToggle(ctx, "send_and_stay", true))
+	require.NoError(t, store.SaveToggle(ctx, "other", false))
+	enabled, ok, err := store.GetToggle(ctx, "send_and_stay")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.True(t, enabled)
+
+	require.NoError(t, store.SaveToggle(ctx, "send_and_stay", false))
+	enabled, ok, err = store.GetToggle(ctx, "send_and_stay")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.False(t, enabled)
+}
+
+func TestBookmarks(t *testing.T) {
+	t.Parallel()
+	store := newTestStore(t)
+	ctx := t.Context()
+
+	require.NoError(t, store.AddBookmark(ctx, "sess-1", Bookmark{Label: "later", MessageIndex: 8, LineOffset: 2}))
+	require.NoError(t, store.AddBookmark(ctx, "sess-1", Bookmark{Label: "first", MessageIndex: 3}))
+	require.NoError(t, store.AddBookmark(ctx, "sess-2", Bookmark{Label: "other", MessageIndex: 1}))
+
+	bookmarks, err := store.GetBookmarks(ctx, "sess-1")
+	require.NoError(t, err)
+	assert.Equal(t, []Bookmark{
+		{Label: "first", MessageIndex: 3},
+		{Label: "later", MessageIndex: 8, LineOffset: 2},
+	}, bookmarks)
+
+	// Same label replaces the bookmark
+	require.NoError(t, store.AddBookmark(ctx, "sess-1", Bookmark{Label: "first", MessageIndex: 10}))
+	require.NoError(t, store.RemoveBookmark(ctx, "sess-1", "later"))
+	bookmarks, err = store.GetBookmarks(ctx, "sess-1")
+	require.NoError(t, err)
+	assert.Equal(t, []Bookmark{{Label: "first", MessageIndex: 10}}, bookmarks)
+}
+
+func TestPromptTemplates(t *testing.T) {
+	t.Parallel()
+	store := newTestStore(t)
+	ctx := t.Context()
+
+	require.NoError(t, store.SavePromptTemplate(ctx, PromptTemplate{Name: "tests", Content: "Write tests for {file}"}))
+	require.NoError(t, store.SavePromptTemplate(ctx, PromptTemplate{Name: "review", Content: "Review this PR"}))
+
+	templates, err := store.GetPromptTemplates(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []PromptTemplate{
+		{Name: "review", Content: "Review this PR"},
+		{Name: "tests", Content: "Write tests for {file}"},
+	}, templates)
+
+	// Same name replaces the template
+	require.NoError(t, store.SavePromptTemplate(ctx, PromptTemplate{Name: "review", Content: "Review PR #{number}"}))
+	require.NoError(t, store.RemovePromptTemplate(ctx, "tests"))
+	templates, err = store.GetPromptTemplates(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []PromptTemplate{{Name: "review", Content: "Review PR #{number}"}}, templates)
+}
@@ -36,6 +36,8 @@ var agentRegistry struct {
 	indices      map[string]int
 	badgeStyles  []cachedBadgeStyle
 	accentStyles []lipgloss.Style
+	// roleStyles holds the badges of the theme's RoleColors, by role.
+	roleStyles map[string]cachedBadgeStyle
 }
 
 // SetAgentOrder updates the agent name → index mapping and rebuilds the style cache.
@@ -68,26 +70,12 @@ func rebuildAgentColorCache() {
 
 	agentRegistry.badgeStyles = make([]cachedBadgeStyle, len(badgeColors))
 	for i, bgColor := range badgeColors {
-		r, g, b := ColorToRGB(bgColor)
-		bgHex := RGBToHex(r, g, b)
-		fgHex := bestForegroundHex(
-			bgHex,
-			theme.Colors.TextBright,
-			theme.Colors.Background,
-			"#000000",
-			"#ffffff",
-		)
-		colors := AgentBadgeColors{
-			Fg: lipgloss.Color(fgHex),
-			Bg: bgColor,
-		}
-		agentRegistry.badgeStyles[i] = cachedBadgeStyle{
-			colors: colors,
-			style: BaseStyle.
-				Foreground(colors.Fg).
-				Background(colors.Bg).
-				Padding(0, 1),
-		}
+		agentRegistry.badgeStyles[i] = newCachedBadgeStyle(theme, bgColor)
+	}
+
+	agentRegistry.roleStyles = make(map[string]cachedBadgeStyle, len(theme.Colors.RoleColors))
+	for role, c := range theme.Colors.RoleColors {
+		agentRegistry.roleStyles[role] = newCachedBadgeStyle(theme, lipgloss.Color(c))
 	}
 
 	agentRegistry.accentStyles = make([]lipgloss.Style, len(accentColors))
@@ -96,6 +84,31 @@ func rebuildAgentColorCache() {
 	}
 }
 
+// newCachedBadgeStyle builds the badge style for a background color, with the
+// most readable foreground.
+func newCachedBadgeStyle(theme *Theme, bgColor color.Color) cachedBadgeStyle {
+	r, g, b := ColorToRGB(bgColor)
+	bgHex := RGBToHex(r, g, b)
+	fgHex := bestForegroundHex(
+		bgHex,
+		theme.Colors.TextBright,
+		theme.Colors.Background,
+		"#000000",
+		"#ffffff",
+	)
+	colors := AgentBadgeColors{
+		Fg: lipgloss.Color(fgHex),
+		Bg: bgColor,
+	}
+	return cachedBadgeStyle{
+		colors: colors,
+		style: BaseStyle.
+			Foreground(colors.Fg).
+			Background(colors.Bg).
+			Padding(0, 1),
+	}
+}
+
 // InvalidateAgentColorCache rebuilds the cached agent styles.
 // Call this after a theme change so colors are recalculated against the new background.
 func InvalidateAgentColorCache() {
@@ -117,6 +130,9 @@ func AgentBadgeColorsFor(agentName string) AgentBadgeColors {
 	agentRegistry.RLock()
 	defer agentRegistry.RUnlock()
 
+	if role, ok := agentRegistry.roleStyles[agentName]; ok {
+		return role.colors
+	}
 	idx, ok := lookupAgentIndex(agentName)
 	if !ok || len(agentRegistry.badgeStyles) == 0 {
 		return fallbackBadgeColors
@@ -130,6 +146,9 @@ func AgentBadgeStyleFor(agentName string) lipgloss.Style {
 	agentRegistry.RLock()
 	defer agentRegistry.RUnlock()
 
+	if role, ok := agentRegistry.roleStyles[agentName]; ok {
+		return role.style
+	}
 	idx, ok := lookupAgentIndex(agentName)
 	if !ok || len(agentRegistry.badgeStyles) == 0 {
 		return fallbackBadgeStyle
@@ -143,6 +162,9 @@ func AgentAccentStyleFor(agentName string) lipgloss.Style {
 	agentRegistry.RLock()
 	defer agentRegistry.RUnlock()
 
+	if role, ok := agentRegistry.roleStyles[agentName]; ok {
+		return BaseStyle.Foreground(role.colors.Bg)
+	}
 	idx, ok := lookupAgentIndex(agentName)
 	if !ok || len(agentRegistry.accentStyles) == 0 {
 		return fallbackAccentStyle
@@ -152,6 +152,22 @@ func TestAgentBadgeStyleFor_UsesCachedStyle(t *testing.T) {
 	}
 }
 
+func TestRoleColors_OverrideGeneratedColors(t *testing.T) {
+	SetAgentOrder([]string{"root", "helper"})
+	defer SetAgentOrder(nil)
+
+	theme := DefaultTheme()
+	theme.Colors.RoleColors = map[string]string{"root": "#ff0000", "user": "#00ff00"}
+	ApplyTheme(theme)
+	defer ApplyTheme(DefaultTheme())
+
+	r, g, b := ColorToRGB(AgentBadgeColorsFor("root").Bg)
+	assert.Equal(t, "#ff0000", RGBToHex(r, g, b))
+	r, g, b = ColorToRGB(AgentBadgeColorsFor("user").Bg)
+	assert.Equal(t, "#00ff00", RGBToHex(r, g, b))
+	assert.NotEqual(t, fallbackBadgeColors, AgentBadgeColorsFor("helper"))
+}
+
 // --- Layer 1: WCAG contrast validation across all themes ---
 
 const (
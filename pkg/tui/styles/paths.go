@@ -0,0 +1,48 @@
+package styles
+
+import (
+	"os"
+	"strings"
+	"sync/atomic"
+
+	"github.com/docker/cagent/pkg/paths"
+)
+
+// absolutePaths disables showing the paths under the home directory as
+// ~/... in the TUI.
+var absolutePaths atomic.Bool
+
+// SetHomeRelativePaths sets whether DisplayPath shows the paths under the
+// home directory relative to it.
+func SetHomeRelativePaths(enabled bool) {
+	absolutePaths.Store(!enabled)
+}
+
+// HomeRelativePaths reports whether DisplayPath shows the paths under the
+// home directory relative to it.
+func HomeRelativePaths() bool {
+	return !absolutePaths.Load()
+}
+
+// DisplayPath returns the path as it should be shown in the TUI: with the
+// home directory replaced by ~, unless home-relative paths are disabled.
+func DisplayPath(p string) string {
+	if p == "" || absolutePaths.Load() {
+		return p
+	}
+	return homeRelative(p, paths.GetHomeDir())
+}
+
+func homeRelative(p, homeDir string) string {
+	homeDir = strings.TrimSuffix(homeDir, string(os.PathSeparator))
+	switch {
+	case homeDir == "":
+		return p
+	case p == homeDir:
+		return "~"
+	case strings.HasPrefix(p, homeDir+string(os.PathSeparator)):
+		return "~" + p[len(homeDir):]
+	default:
+		return p
+	}
+}
@@ -0,0 +1,25 @@
+package styles
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHomeRelative(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		path, home, expected string
+	}{
+		{"/home/alice/src/app", "/home/alice", "~/src/app"},
+		{"/home/alice", "/home/alice", "~"},
+		{"/home/alice/src", "/home/alice/", "~/src"},
+		{"/home/alicia/src", "/home/alice", "/home/alicia/src"},
+		{"/tmp/build", "/home/alice", "/tmp/build"},
+		{"/tmp/build", "", "/tmp/build"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, homeRelative(tt.path, tt.home), tt.path)
+	}
+}
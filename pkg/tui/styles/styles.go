@@ -3,13 +3,14 @@ package styles
 import (
 	"image/color"
 	"strings"
-	"time"
 
 	"charm.land/bubbles/v2/textarea"
 	"charm.land/bubbles/v2/textinput"
 	"charm.land/lipgloss/v2"
 	"github.com/alecthomas/chroma/v2"
 	"github.com/charmbracelet/glamour/v2/ansi"
+
+	"github.com/docker/cagent/pkg/userconfig"
 )
 
 const (
@@ -90,11 +91,12 @@ var (
 // Base Styles
 const (
 	AppPadding = 1 // Symmetric left/right padding used by AppStyle and EditorStyle
-
-	// DoubleClickThreshold is the maximum time between clicks to register as a double-click
-	DoubleClickThreshold = 400 * time.Millisecond
 )
 
+// DoubleClickThreshold is the maximum time between clicks to register as a double-click.
+// It is set from the user settings when the TUI starts.
+var DoubleClickThreshold = userconfig.DefaultDoubleClickThreshold
+
 var (
 	NoStyle   = lipgloss.NewStyle()
 	BaseStyle = NoStyle.Foreground(TextPrimary)
@@ -160,6 +162,10 @@ var (
 	SelectedUserMessageStyle = UserMessageStyle.
 					BorderStyle(lipgloss.ThickBorder()).
 					BorderForeground(Success)
+
+	StreamingMessageStyle = AssistantMessageStyle.
+				BorderStyle(lipgloss.NormalBorder()).
+				BorderForeground(BorderSecondary)
 )
 
 // Dialog Styles
@@ -256,6 +262,9 @@ var (
 
 	BadgeCurrentStyle = BaseStyle.
 				Foreground(BadgeGreen)
+
+	BadgeNoToolsStyle = BaseStyle.
+				Foreground(Warning)
 )
 
 // Star Styles for session browser and sidebar
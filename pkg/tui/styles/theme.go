@@ -3,6 +3,7 @@ package styles
 import (
 	"embed"
 	"fmt"
+	"maps"
 	"os"
 	"path/filepath"
 	"slices"
@@ -136,6 +137,10 @@ type ThemeColors struct {
 
 	// Agent colors
 	AgentHues []float64 `yaml:"agent_hues,omitempty"` // Hue values (0-360) for agent color generation
+	// RoleColors sets the badge background of the message labels, by agent
+	// name, or "user" and "assistant" for the generic labels. It takes
+	// precedence over the colors generated from AgentHues.
+	RoleColors map[string]string `yaml:"role_colors,omitempty"`
 }
 
 // ChromaColors contains syntax highlighting colors (for code blocks).
@@ -215,6 +220,44 @@ func DefaultTheme() *Theme {
 // user's custom nord theme, while "nord" refers to the built-in.
 const UserThemePrefix = "user:"
 
+// TeamThemePrefix is the prefix of the refs of themes shipped with an agent
+// config, e.g. "team:acme" for a theme file named acme.yaml.
+const TeamThemePrefix = "team:"
+
+var (
+	// teamThemes maps the refs of the themes shipped with agent configs to
+	// their file paths.
+	teamThemes   = make(map[string]string)
+	teamThemesMu sync.RWMutex
+)
+
+// RegisterTeamTheme registers a theme file shipped with an agent config so it
+// can be loaded, listed and hot-reloaded like the other themes. It returns the
+// theme's ref.
+func RegisterTeamTheme(path string) (string, error) {
+	ext := filepath.Ext(path)
+	if ext != ".yaml" && ext != ".yml" {
+		return "", fmt.Errorf("theme file %q must be a .yaml or .yml file", path)
+	}
+	if _, err := os.Stat(path); err != nil {
+		return "", fmt.Errorf("theme file: %w", err)
+	}
+
+	ref := TeamThemePrefix + strings.TrimSuffix(filepath.Base(path), ext)
+	teamThemesMu.Lock()
+	teamThemes[ref] = path
+	teamThemesMu.Unlock()
+	return ref, nil
+}
+
+// teamThemePath returns the path of a theme registered with RegisterTeamTheme.
+func teamThemePath(ref string) (string, bool) {
+	teamThemesMu.RLock()
+	defer teamThemesMu.RUnlock()
+	path, ok := teamThemes[ref]
+	return path, ok
+}
+
 // ListThemeRefs returns the list of available theme references.
 // It includes all built-in themes (including "default") and user themes from ~/.cagent/themes/.
 // User themes with names matching built-in themes are prefixed with "user:" to distinguish them.
@@ -254,6 +297,11 @@ func ListThemeRefs() ([]string, error) {
 		}
 	}
 
+	// Add the themes shipped with agent configs
+	teamThemesMu.RLock()
+	refs = append(refs, slices.Sorted(maps.Keys(teamThemes))...)
+	teamThemesMu.RUnlock()
+
 	return refs, nil
 }
 
@@ -328,28 +376,17 @@ func UserThemeExists(ref string) bool {
 
 // SaveThemeToUserConfig persists the theme reference to the user config file.
 // If themeRef equals DefaultThemeRef, the setting is cleared (empty string).
+// So is it for a theme shipped with an agent config, which is only available
+// with that config and applies by default anyway.
 func SaveThemeToUserConfig(themeRef string) error {
-	cfg, err := userconfig.Load()
-	if err != nil {
-		return fmt.Errorf("loading user config: %w", err)
-	}
-
-	if cfg.Settings == nil {
-		cfg.Settings = &userconfig.Settings{}
-	}
-
-	// Clear the setting if using the default theme
-	if themeRef == DefaultThemeRef {
-		cfg.Settings.Theme = ""
-	} else {
-		cfg.Settings.Theme = themeRef
-	}
-
-	if err := cfg.Save(); err != nil {
-		return fmt.Errorf("saving user config: %w", err)
-	}
-
-	return nil
+	return userconfig.UpdateSettings(func(s *userconfig.Settings) {
+		// Clear the setting if using the default theme
+		if themeRef == DefaultThemeRef || strings.HasPrefix(themeRef, TeamThemePrefix) {
+			s.Theme = ""
+		} else {
+			s.Theme = themeRef
+		}
+	})
 }
 
 // GetPersistedThemeRef returns the theme reference persisted in user config.
@@ -410,6 +447,10 @@ func LoadTheme(ref string) (*Theme, error) {
 		return nil, fmt.Errorf("cannot load theme with empty ref; use %q instead", DefaultThemeRef)
 	}
 
+	if strings.HasPrefix(ref, TeamThemePrefix) {
+		return loadTeamTheme(ref)
+	}
+
 	// Check if this is an explicit user theme reference (user:name)
 	forceUserTheme := strings.HasPrefix(ref, UserThemePrefix)
 	baseRef := ref
@@ -490,6 +531,39 @@ func LoadTheme(ref string) (*Theme, error) {
 	return theme, nil
 }
 
+// loadTeamTheme loads a theme registered with RegisterTeamTheme. Like user
+// themes, it is re-parsed only when the file's modTime changes.
+func loadTeamTheme(ref string) (*Theme, error) {
+	path, ok := teamThemePath(ref)
+	if !ok {
+		return nil, fmt.Errorf("theme %q not found", ref)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("theme %q: %w", ref, err)
+	}
+
+	themeCacheMu.RLock()
+	cached, hasCached := themeCache[ref]
+	themeCacheMu.RUnlock()
+	if hasCached && cached.path == path && cached.modTime.Equal(info.ModTime()) {
+		return cached.theme, nil
+	}
+
+	name := strings.TrimPrefix(ref, TeamThemePrefix)
+	theme, err := loadThemeFrom(name, filepath.Dir(path))
+	if err != nil {
+		return nil, err
+	}
+	theme.Ref = ref
+
+	themeCacheMu.Lock()
+	themeCache[ref] = &themeCacheEntry{theme: theme, modTime: info.ModTime(), path: path}
+	themeCacheMu.Unlock()
+
+	return theme, nil
+}
+
 // getUserThemeFileInfo returns the path and modTime of a user theme file if it exists.
 // Returns empty path and zero time if the file doesn't exist.
 func getUserThemeFileInfo(ref string) (path string, modTime time.Time) {
@@ -763,6 +837,13 @@ func mergeColors(base, override ThemeColors) ThemeColors {
 	if len(override.AgentHues) > 0 {
 		result.AgentHues = override.AgentHues
 	}
+	if len(override.RoleColors) > 0 {
+		result.RoleColors = maps.Clone(base.RoleColors)
+		if result.RoleColors == nil {
+			result.RoleColors = make(map[string]string, len(override.RoleColors))
+		}
+		maps.Copy(result.RoleColors, override.RoleColors)
+	}
 	return result
 }
 
@@ -1015,6 +1096,10 @@ func rebuildStyles() {
 		BorderStyle(lipgloss.ThickBorder()).
 		BorderForeground(Success)
 
+	StreamingMessageStyle = AssistantMessageStyle.
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderForeground(BorderSecondary)
+
 	// Dialog styles
 	DialogStyle = BaseStyle.
 		Border(lipgloss.RoundedBorder()).
@@ -1091,6 +1176,7 @@ func rebuildStyles() {
 	BadgeAlloyStyle = BaseStyle.Foreground(BadgePurple)
 	BadgeDefaultStyle = BaseStyle.Foreground(BadgeCyan)
 	BadgeCurrentStyle = BaseStyle.Foreground(BadgeGreen)
+	BadgeNoToolsStyle = BaseStyle.Foreground(Warning)
 
 	// Star styles
 	StarredStyle = BaseStyle.Foreground(Success)
@@ -430,3 +430,29 @@ func TestAllBuiltinThemes_HaveCoreColors(t *testing.T) {
 		})
 	}
 }
+
+func TestRegisterTeamTheme(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "acme-team.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("version: 1\nname: Acme\ncolors:\n  accent: \"#123456\"\n"), 0o644))
+
+	ref, err := RegisterTeamTheme(path)
+	require.NoError(t, err)
+	assert.Equal(t, "team:acme-team", ref)
+
+	theme, err := LoadTheme(ref)
+	require.NoError(t, err)
+	assert.Equal(t, "Acme", theme.Name)
+	assert.Equal(t, ref, theme.Ref)
+	assert.Equal(t, "#123456", theme.Colors.Accent)
+
+	refs, err := ListThemeRefs()
+	require.NoError(t, err)
+	assert.Contains(t, refs, ref)
+
+	_, err = RegisterTeamTheme(filepath.Join(t.TempDir(), "acme.json"))
+	require.Error(t, err)
+	_, err = RegisterTeamTheme(filepath.Join(t.TempDir(), "missing.yaml"))
+	require.Error(t, err)
+}
@@ -116,6 +116,10 @@ func (tw *ThemeWatcher) getThemesDir() string {
 }
 
 func (tw *ThemeWatcher) findThemePath(themeRef string) (string, error) {
+	if path, ok := teamThemePath(themeRef); ok {
+		return path, nil
+	}
+
 	dir := tw.getThemesDir()
 
 	// Strip user: prefix if present to get the base filename
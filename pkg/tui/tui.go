@@ -7,7 +7,6 @@ import (
 	"fmt"
 	"log/slog"
 	"os"
-	"os/exec"
 	"path/filepath"
 	goruntime "runtime"
 	"strings"
@@ -21,6 +20,7 @@ import (
 	"github.com/docker/cagent/pkg/app"
 	"github.com/docker/cagent/pkg/audio/transcribe"
 	"github.com/docker/cagent/pkg/history"
+	"github.com/docker/cagent/pkg/memory/database"
 	"github.com/docker/cagent/pkg/runtime"
 	"github.com/docker/cagent/pkg/session"
 	"github.com/docker/cagent/pkg/tui/animation"
@@ -28,6 +28,7 @@ import (
 	"github.com/docker/cagent/pkg/tui/components/completion"
 	"github.com/docker/cagent/pkg/tui/components/editor"
 	"github.com/docker/cagent/pkg/tui/components/notification"
+	"github.com/docker/cagent/pkg/tui/components/scratchpad"
 	"github.com/docker/cagent/pkg/tui/components/spinner"
 	"github.com/docker/cagent/pkg/tui/components/statusbar"
 	"github.com/docker/cagent/pkg/tui/components/tabbar"
@@ -58,6 +59,11 @@ const (
 	resizeHandleWidth = 8
 	// appPaddingHorizontal is total horizontal padding from AppStyle (left + right)
 	appPaddingHorizontal = 2 * styles.AppPadding
+	// compactEditorThreshold is the terminal height below which the editor
+	// collapses to a single line that grows with its content
+	compactEditorThreshold = 24
+	// scratchpadTabID is the supervisor ID of the scratchpad tab
+	scratchpadTabID = "scratchpad"
 )
 
 // Model is the top-level TUI model that wraps the chat page.
@@ -82,6 +88,16 @@ type appModel struct {
 	// Shared history for command history across all editors
 	history *history.History
 
+	// Scratchpad tab, created the first time it is opened. While it is the
+	// active tab the convenience pointers above keep pointing at the last
+	// active session, but input goes to the scratchpad.
+	scratchpad       *scratchpad.Model
+	scratchpadActive bool
+
+	// focusMode hides the tab bar and the status bar to leave the whole
+	// window to the transcript and the editor.
+	focusMode bool
+
 	// UI components
 	notification notification.Manager
 	dialogMgr    dialog.Manager
@@ -89,7 +105,7 @@ type appModel struct {
 	completions  completion.Manager
 
 	// Speech-to-text
-	transcriber  *transcribe.Transcriber
+	transcriber  transcribe.Transcriber
 	transcriptCh chan string // bridges transcriber goroutine → Bubble Tea event loop
 
 	// Working state indicator (resize handle spinner)
@@ -110,6 +126,7 @@ type appModel struct {
 
 	// Editor resize state
 	editorLines      int
+	compactEditor    bool
 	isDragging       bool
 	isHoveringHandle bool
 
@@ -122,6 +139,41 @@ type appModel struct {
 	// keyboardEnhancementsSupported tracks whether the terminal supports keyboard enhancements
 	keyboardEnhancementsSupported bool
 
+	// generateTitles mirrors the generate_titles user setting shown in the
+	// settings dialog. It takes effect for sessions started after a change.
+	generateTitles bool
+
+	// splitDiffView shows the diffs of all tabs side by side. It's persisted
+	// in the tuistate store, and defaults to the split_diff_view user setting.
+	splitDiffView bool
+
+	// separateSubSessionCosts mirrors the separate_sub_session_costs user
+	// setting used by the cost dialog.
+	separateSubSessionCosts bool
+
+	// sendAndStay keeps the editor content after sending. It applies to the
+	// editors of all tabs and isn't persisted.
+	sendAndStay bool
+
+	// softWrap mirrors the soft_wrap user setting. It applies to the editors
+	// of all tabs.
+	softWrap bool
+
+	// enterInsertsNewline mirrors the enter_inserts_newline user setting. It
+	// applies to the editors of all tabs.
+	enterInsertsNewline bool
+
+	// largePaste, pasteMaxLines and pasteMaxChars mirror the large_paste,
+	// paste_max_lines and paste_max_chars user settings. They apply to the
+	// editors of all tabs.
+	largePaste    string
+	pasteMaxLines int
+	pasteMaxChars int
+
+	// keyboardEnhancementsChecked is set once the startup check for missing
+	// keyboard enhancements has run, so the notice is considered only once.
+	keyboardEnhancementsChecked bool
+
 	// pendingRestores maps runtime tab IDs (supervisor routing keys) to
 	// persisted session-store IDs. When a tab with a pending restore is first
 	// switched to, the persisted session is loaded via replaceActiveSession —
@@ -141,6 +193,26 @@ type appModel struct {
 	// previously focused tab differs from the initial tab.
 	pendingActiveTab string
 
+	// autosaveInterval is the interval between two saves of the sessions
+	// that changed (0 = disabled).
+	autosaveInterval time.Duration
+
+	// quietHours is the daily window during which the bell and the
+	// notifications other than errors are suppressed, if hasQuietHours.
+	quietHours    userconfig.QuietHours
+	hasQuietHours bool
+	// quiet is true during quiet hours.
+	quiet bool
+
+	// memories are the memories listed by the memory dialog while it is open.
+	memories []database.UserMemory
+
+	// bookmarks are the bookmarks listed by the bookmarks dialog while it is open.
+	bookmarks []tuistate.Bookmark
+
+	// prompts are the prompt templates listed by the prompt library while it is open.
+	prompts []tuistate.PromptTemplate
+
 	ready bool
 	err   error
 }
@@ -151,7 +223,8 @@ func New(ctx context.Context, spawner SessionSpawner, initialApp *app.App, initi
 	sv := supervisor.New(spawner)
 
 	// Initialize tab bar with configurable title length from user settings
-	tabTitleMaxLen := userconfig.Get().GetTabTitleMaxLength()
+	userSettings := userconfig.Get()
+	tabTitleMaxLen := userSettings.GetTabTitleMaxLength()
 	tb := tabbar.New(tabTitleMaxLen)
 
 	// Initialize tab store
@@ -190,14 +263,41 @@ func New(ctx context.Context, spawner SessionSpawner, initialApp *app.App, initi
 		notification:            notification.New(),
 		dialogMgr:               dialog.New(),
 		completions:             completion.New(),
-		transcriber:             transcribe.New(os.Getenv("OPENAI_API_KEY")),
 		workingSpinner:          spinner.New(spinner.ModeSpinnerOnly, styles.SpinnerDotsHighlightStyle),
 		focusedPanel:            PanelEditor,
 		editorLines:             3,
 	}
 
+	// Restore the editor height, the diff view and send and stay chosen in a
+	// previous run
+	m.splitDiffView = userSettings.GetSplitDiffView()
+	if ts != nil {
+		if lines, err := ts.GetEditorLines(context.Background()); err != nil {
+			slog.Warn("Failed to load editor height", "error", err)
+		} else if lines > 0 {
+			m.editorLines = lines
+		}
+		if split, ok, err := ts.GetSplitDiffView(context.Background()); err != nil {
+			slog.Warn("Failed to load diff view", "error", err)
+		} else if ok {
+			m.splitDiffView = split
+		}
+		if stay, _, err := ts.GetToggle(context.Background(), sendAndStayToggle); err != nil {
+			slog.Warn("Failed to load send and stay", "error", err)
+		} else {
+			m.sendAndStay = stay
+		}
+	}
+	initialSessionState.SetSplitDiffView(m.splitDiffView)
+
 	// Initialize status bar (pass m as help provider)
 	m.statusBar = statusbar.New(m)
+	if m.sendAndStay {
+		initialEditor.SetSendAndStay(true)
+		m.statusBar.SetIndicator("send & stay")
+	}
+	// Init starts the autosave and quiet hours tickers.
+	_ = m.applyUserSettings(userSettings)
 
 	// Add the initial session to the supervisor
 	sv.AddSession(ctx, initialApp, initialApp.Session(), initialWorkingDir, cleanup)
@@ -243,13 +343,65 @@ func (m *appModel) reapplyKeyboardEnhancements() {
 	m.editor = editorModel.(editor.Editor)
 }
 
+// keyboardEnhancementsCheckDelay is how long to wait for the terminal to
+// report keyboard enhancements before assuming they are unsupported.
+// Terminals without support never answer the query.
+const keyboardEnhancementsCheckDelay = 2 * time.Second
+
+const (
+	// keyboardEnhancementsNoticeID identifies the one-time notice in the tuistate store.
+	keyboardEnhancementsNoticeID = "keyboard_enhancements_unsupported"
+	keyboardEnhancementsDocsURL  = "https://docker.github.io/docker-agent/features/tui/#multi-line-input"
+)
+
+// checkKeyboardEnhancementsMsg is sent once after startup to check whether
+// the terminal reported keyboard enhancements.
+type checkKeyboardEnhancementsMsg struct{}
+
+func checkKeyboardEnhancementsCmd() tea.Cmd {
+	return tea.Tick(keyboardEnhancementsCheckDelay, func(time.Time) tea.Msg {
+		return checkKeyboardEnhancementsMsg{}
+	})
+}
+
+// keyboardEnhancementsNotice tells the user, once per install, that
+// Shift+Enter is not available in their terminal and Ctrl+J inserts a
+// newline instead.
+func (m *appModel) keyboardEnhancementsNotice() tea.Cmd {
+	if m.keyboardEnhancementsChecked || m.tuiStore == nil {
+		return nil
+	}
+	m.keyboardEnhancementsChecked = true
+
+	ctx := context.Background()
+	seen, err := m.tuiStore.HasSeenNotice(ctx, keyboardEnhancementsNoticeID)
+	if err != nil || seen {
+		return nil
+	}
+	if err := m.tuiStore.MarkNoticeSeen(ctx, keyboardEnhancementsNoticeID); err != nil {
+		slog.Warn("Failed to persist keyboard enhancements notice", "error", err)
+	}
+
+	return core.CmdHandler(notification.ShowMsg{
+		Text: "Your terminal doesn't support keyboard enhancements: Shift+Enter won't insert a newline, use Ctrl+J instead.\n" +
+			"See " + keyboardEnhancementsDocsURL,
+		Type:     notification.TypeInfo,
+		Duration: 10 * time.Second,
+	})
+}
+
 // initSessionComponents creates a new chat page, session state, and editor for
 // the given app and stores them in the per-session maps under tabID. The active
 // convenience pointers (m.chatPage, m.sessionState, m.editor) are also updated.
 func (m *appModel) initSessionComponents(tabID string, a *app.App, sess *session.Session) {
 	ss := service.NewSessionState(sess)
+	ss.SetSplitDiffView(m.splitDiffView)
 	cp := chat.New(a, ss)
 	ed := editor.New(a, m.history)
+	ed.SetSendAndStay(m.sendAndStay)
+	ed.SetSoftWrap(m.softWrap)
+	ed.SetEnterInsertsNewline(m.enterInsertsNewline)
+	ed.SetLargePaste(m.largePaste, m.pasteMaxLines, m.pasteMaxChars)
 
 	m.chatPages[tabID] = cp
 	m.sessionStates[tabID] = ss
@@ -346,6 +498,12 @@ func (m *appModel) restoreTabs(
 			restoredFirst = true
 			runtimeID = initialTabID
 		} else {
+			if err := sv.CanSpawn(); err != nil {
+				// Keep the remaining tabs persisted so they come back if the
+				// limit is raised.
+				slog.Warn("Not restoring the remaining tabs", "error", err)
+				break
+			}
 			a, newSess, spawnCleanup, err := spawner(ctx, saved.WorkingDir)
 			if err != nil {
 				slog.Warn("Failed to restore tab", "working_dir", saved.WorkingDir, "error", err)
@@ -394,7 +552,7 @@ func (m *appModel) Init() tea.Cmd {
 		tabID := m.pendingActiveTab
 		m.pendingActiveTab = ""
 		_, switchCmd := m.handleSwitchTab(tabID)
-		return tea.Batch(m.dialogMgr.Init(), switchCmd)
+		return tea.Batch(m.dialogMgr.Init(), checkKeyboardEnhancementsCmd(), m.autosaveCmd(), m.quietHoursCmd(), switchCmd)
 	}
 
 	// If the initial tab has a pending session restore, go through
@@ -415,22 +573,46 @@ func (m *appModel) Init() tea.Cmd {
 				cmd = tea.Batch(cmd, m.applySidebarCollapsed(activeID))
 				m.persistActiveTab(sess.ID)
 
-				return tea.Batch(m.dialogMgr.Init(), cmd)
+				return tea.Batch(m.dialogMgr.Init(), checkKeyboardEnhancementsCmd(), m.autosaveCmd(), m.quietHoursCmd(), cmd)
 			}
 		}
 	}
 
 	return tea.Batch(
 		m.dialogMgr.Init(),
+		checkKeyboardEnhancementsCmd(),
+		m.autosaveCmd(),
+		m.quietHoursCmd(),
 		m.chatPage.Init(),
 		m.editor.Init(),
 		m.editor.Focus(),
-		m.application.SendFirstMessage(),
+		m.firstMessageCmd(),
 	)
 }
 
+// firstMessageCmd sends the message given on the command line or, when there
+// is none and the session is new, the first message configured in the user
+// settings (nothing by default).
+func (m *appModel) firstMessageCmd() tea.Cmd {
+	if cmd := m.application.SendFirstMessage(); cmd != nil {
+		return cmd
+	}
+	if sess := m.application.Session(); sess == nil || sess.MessageCount() > 0 {
+		return nil
+	}
+	if content := userconfig.Get().GetFirstMessage(); content != "" {
+		return core.CmdHandler(messages.SendMsg{Content: content})
+	}
+	return nil
+}
+
 // Update handles messages.
 func (m *appModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if scratchpad.IsSaveMsg(msg) && m.scratchpad != nil {
+		_, cmd := m.scratchpad.Update(msg)
+		return m, cmd
+	}
+
 	switch msg := msg.(type) {
 	// --- Routing & Animation ---
 
@@ -473,15 +655,44 @@ func (m *appModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case messages.SpawnSessionMsg:
 		return m.handleSpawnSession(msg.WorkingDir)
 
+	case messages.ContinueInNewTabMsg:
+		return m.handleContinueInNewTab()
+
 	case messages.SwitchTabMsg:
 		return m.handleSwitchTab(msg.SessionID)
 
+	case messages.ShowSessionsOverviewMsg:
+		return m.handleShowSessionsOverview()
+
+	case messages.ReplyToSessionMsg:
+		return m.handleReplyToSession(msg.SessionID, msg.Answer)
+
+	case messages.SwitchToPreviousTabMsg:
+		previousID := m.supervisor.PreviousActiveID()
+		if previousID == "" {
+			return m, nil
+		}
+		return m.handleSwitchTab(previousID)
+
 	case messages.CloseTabMsg:
+		if !msg.Confirmed && m.supervisor.IsRunning(msg.SessionID) && userconfig.Get().GetConfirmDestructiveActions() {
+			return m, core.CmdHandler(dialog.OpenDialogMsg{
+				Model: dialog.NewConfirmationDialog("Close Tab",
+					"This session is still running. Stop it and close the tab?",
+					messages.CloseTabMsg{SessionID: msg.SessionID, Confirmed: true}),
+			})
+		}
 		return m.handleCloseTab(msg.SessionID)
 
 	case messages.ReorderTabMsg:
 		return m.handleReorderTab(msg)
 
+	case messages.OpenScratchpadMsg:
+		return m.handleOpenScratchpad()
+
+	case messages.ToggleFocusModeMsg:
+		return m.handleToggleFocusMode()
+
 	case messages.ToggleSidebarMsg:
 		if m.tuiStore != nil {
 			persistedID := m.persistedSessionID(m.supervisor.ActiveID())
@@ -550,7 +761,23 @@ func (m *appModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// Forward to editor
 		editorModel, editorCmd := m.editor.Update(msg)
 		m.editor = editorModel.(editor.Editor)
-		return m, tea.Batch(cmd, editorCmd)
+		var noticeCmd tea.Cmd
+		if !m.keyboardEnhancementsSupported {
+			noticeCmd = m.keyboardEnhancementsNotice()
+		}
+		return m, tea.Batch(cmd, editorCmd, noticeCmd)
+
+	case autosaveTickMsg:
+		return m.handleAutosave()
+
+	case quietHoursTickMsg:
+		return m.handleQuietHoursTick()
+
+	case checkKeyboardEnhancementsMsg:
+		if m.keyboardEnhancementsSupported {
+			return m, nil
+		}
+		return m, m.keyboardEnhancementsNotice()
 
 	// --- Keyboard input ---
 
@@ -563,6 +790,10 @@ func (m *appModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.dialogMgr = u.(dialog.Manager)
 			return m, cmd
 		}
+		if m.scratchpadActive {
+			_, cmd := m.scratchpad.Update(msg)
+			return m, cmd
+		}
 		// When inline editing a past message, forward paste to the chat page
 		// so the messages component can insert content into the inline textarea.
 		if m.chatPage.IsInlineEditing() {
@@ -573,7 +804,7 @@ func (m *appModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// Forward paste to editor
 		editorModel, cmd := m.editor.Update(msg)
 		m.editor = editorModel.(editor.Editor)
-		return m, cmd
+		return m, tea.Batch(cmd, m.fitCompactEditor())
 
 	// --- Mouse ---
 
@@ -605,6 +836,9 @@ func (m *appModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case dialog.MultiChoiceResultMsg:
+		if msg.DialogID == dialog.InputDeviceDialogID {
+			return m.handleInputDeviceResult(msg.Result)
+		}
 		if msg.DialogID == dialog.ToolRejectionDialogID {
 			if msg.Result.IsCancelled {
 				return m, nil
@@ -624,6 +858,9 @@ func (m *appModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case messages.BellMsg:
 		// Ring the terminal bell to alert the user that an inactive tab needs attention.
 		// The BEL character (\a) is written to stderr which is typically the terminal.
+		if m.quiet {
+			return m, nil
+		}
 		_, _ = fmt.Fprint(os.Stderr, "\a")
 		return m, nil
 
@@ -692,10 +929,19 @@ func (m *appModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return m, notification.SuccessCmd("File attached: " + msg.FilePath)
 
+	case messages.PasteClipboardMsg:
+		return m.handlePasteClipboard(msg.Attach)
+
+	case messages.InsertFileContentsMsg:
+		return m.handleInsertFileContents(msg.FilePath)
+
+	case messages.InsertLastToolResultMsg:
+		return m.handleInsertLastToolResult()
+
 	// --- Agent management ---
 
 	case messages.SwitchAgentMsg:
-		return m.handleSwitchAgent(msg.AgentName)
+		return m.handleSwitchAgent(msg.AgentName, msg.Confirmed)
 
 	// --- Session browser ---
 
@@ -708,8 +954,17 @@ func (m *appModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case messages.BranchFromEditMsg:
 		return m.handleBranchFromEdit(msg)
 
+	case messages.DuplicateSessionMsg:
+		return m.handleDuplicateSession(msg.SessionID)
+
+	case messages.ReloadSessionMsg:
+		return m.handleReloadSession(msg.Confirmed)
+
 	// --- Session commands (slash commands, command palette) ---
 
+	case messages.ResetCostMsg:
+		return m.handleResetCost(msg.Confirmed)
+
 	case messages.ToggleYoloMsg:
 		return m.handleToggleYolo()
 
@@ -722,10 +977,15 @@ func (m *appModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case messages.ToggleHideToolResultsMsg:
 		return m.handleToggleHideToolResults()
 
+	case messages.ToggleRawMarkdownMsg:
+		return m.handleToggleRawMarkdown()
+
 	case messages.ToggleSplitDiffMsg:
 		return m.handleToggleSplitDiff()
 
-	case messages.ClearQueueMsg:
+	case messages.ClearQueueMsg, messages.RemoveQueuedMsg, messages.MoveQueuedMsg, messages.EditQueuedMsg,
+		messages.RedirectMsg, messages.ReplaySessionMsg, messages.StopAfterStepMsg, messages.TogglePauseRenderingMsg,
+		messages.OpenFileReferenceMsg:
 		updated, cmd := m.chatPage.Update(msg)
 		m.chatPage = updated.(chat.Page)
 		return m, cmd
@@ -739,6 +999,12 @@ func (m *appModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case messages.CopyLastResponseToClipboardMsg:
 		return m.handleCopyLastResponseToClipboard()
 
+	case messages.CopySessionLinkMsg:
+		return m.handleCopySessionLink()
+
+	case messages.CopyTaskToClipboardMsg:
+		return m.handleCopyTaskToClipboard()
+
 	case messages.EvalSessionMsg:
 		return m.handleEvalSession(msg.Filename)
 
@@ -762,12 +1028,116 @@ func (m *appModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case messages.RegenerateTitleMsg:
 		return m.handleRegenerateTitle()
 
+	case messages.SetDateOverrideMsg:
+		return m.handleSetDateOverride(msg.Date)
+
+	case messages.SetEnvOverrideMsg:
+		return m.handleSetEnvOverride(msg.Assignment)
+
+	case messages.SetVariableMsg:
+		return m.handleSetVariable(msg.Assignment)
+
+	case messages.RevokeApprovalMsg:
+		return m.handleRevokeApproval(msg.Signature)
+
 	case messages.ShowCostDialogMsg:
 		return m.handleShowCostDialog()
 
+	case messages.ShowThroughputMsg:
+		return m.handleShowThroughput()
+
 	case messages.ShowPermissionsDialogMsg:
 		return m.handleShowPermissionsDialog()
 
+	case messages.ShowToolsDialogMsg:
+		return m.handleShowToolsDialog()
+
+	case messages.ShowEffectiveConfigMsg:
+		return m.handleShowEffectiveConfig()
+
+	case messages.EditConfigMsg:
+		return m.handleEditConfig(msg.User)
+
+	case messages.ReloadUserSettingsMsg:
+		return m.handleReloadUserSettings()
+
+	case messages.ReloadTeamMsg:
+		return m.handleReloadTeam(msg.Confirmed)
+
+	case messages.ShowSettingsDialogMsg:
+		return m.handleShowSettingsDialog()
+
+	case messages.ShowQueueDialogMsg:
+		return m.handleShowQueueDialog()
+
+	case messages.SetRequestLogMsg:
+		return m.handleSetRequestLog(msg.Mode)
+
+	case messages.AddBookmarkMsg:
+		return m.handleAddBookmark(msg.Label)
+
+	case messages.ShowBookmarksDialogMsg:
+		return m.handleShowBookmarksDialog()
+
+	case messages.JumpToBookmarkMsg:
+		m.chatPage.ScrollToMark(msg.MessageIndex, msg.LineOffset)
+		return m, nil
+
+	case messages.DeleteBookmarkMsg:
+		return m.handleDeleteBookmark(msg.Label)
+
+	case messages.SavePromptTemplateMsg:
+		return m.handleSavePromptTemplate(msg.Name, msg.Content)
+
+	case messages.ShowPromptLibraryMsg:
+		return m.handleShowPromptLibrary()
+
+	case messages.DeletePromptTemplateMsg:
+		return m.handleDeletePromptTemplate(msg.Name)
+
+	case messages.InsertPromptMsg:
+		m.editor.InsertText(msg.Content)
+		return m, nil
+
+	case messages.ShowMemoryDialogMsg:
+		return m.handleShowMemoryDialog()
+
+	case messages.DeleteMemoryMsg:
+		return m.handleDeleteMemory(msg.ID)
+
+	case messages.ClearMemoriesMsg:
+		return m.handleClearMemories(msg.Confirmed)
+
+	case messages.SetPromptPrefixMsg:
+		return m.handleSetPromptAffix("prefix", msg.Text)
+
+	case messages.SetPromptSuffixMsg:
+		return m.handleSetPromptAffix("suffix", msg.Text)
+
+	case messages.ToggleGenerateTitlesMsg:
+		return m.handleToggleGenerateTitles()
+
+	case messages.ToggleSendAndStayMsg:
+		return m.handleToggleSendAndStay()
+
+	case messages.ToggleSoftWrapMsg:
+		return m.handleToggleSoftWrap()
+
+	case messages.ToggleEnterNewlineMsg:
+		return m.handleToggleEnterNewline()
+
+	case messages.ToggleShowThroughputMsg:
+		return m.handleToggleShowThroughput()
+
+	case messages.ToggleSeparateSubSessionCostsMsg:
+		return m.handleToggleSeparateSubSessionCosts()
+
+	case messages.ToggleHomeRelativePathsMsg:
+		return m.handleToggleHomeRelativePaths()
+
+	case messages.ToggleTelemetryMsg:
+		return m.handleToggleTelemetry()
+
 	case messages.AgentCommandMsg:
 		return m.handleAgentCommand(msg.Command)
 
@@ -776,6 +1146,9 @@ func (m *appModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	// --- Model picker ---
 
+	case messages.OpenAgentPickerMsg:
+		return m.handleOpenAgentPicker()
+
 	case messages.OpenModelPickerMsg:
 		return m.handleOpenModelPicker()
 
@@ -806,13 +1179,16 @@ func (m *appModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case messages.StartSpeakMsg:
 		if !m.transcriber.IsSupported() {
-			return m, notification.InfoCmd("Speech-to-text is only supported on macOS")
+			return m, notification.InfoCmd("Speech-to-text is not supported on this platform")
 		}
 		return m.handleStartSpeak()
 
 	case messages.StopSpeakMsg:
 		return m.handleStopSpeak()
 
+	case messages.ShowInputDevicesMsg:
+		return m.handleShowInputDevices()
+
 	case messages.SpeakTranscriptMsg:
 		m.editor.InsertText(msg.Delta)
 		cmd := m.waitForTranscript()
@@ -831,6 +1207,9 @@ func (m *appModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case messages.AttachFileMsg:
 		return m.handleAttachFile(msg.FilePath)
 
+	case messages.AttachURLMsg:
+		return m.handleAttachURL(msg.URL)
+
 	case messages.SendAttachmentMsg:
 		m.application.RunWithMessage(context.Background(), nil, msg.Content)
 		return m, nil
@@ -840,6 +1219,12 @@ func (m *appModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case messages.OpenURLMsg:
 		return m.handleOpenURL(msg.URL)
 
+	case messages.OpenFileAtLineMsg:
+		return m.handleOpenFileAtLine(msg.Path, msg.Line, msg.Col)
+
+	case messages.OpenTasksDirMsg:
+		return m.handleOpenTasksDir()
+
 	// --- Elicitation ---
 
 	case messages.ElicitationResponseMsg:
@@ -1087,16 +1472,31 @@ func (m *appModel) replaceActiveSession(ctx context.Context, sess *session.Sessi
 
 // handleSpawnSession spawns a new session.
 func (m *appModel) handleSpawnSession(workingDir string) (tea.Model, tea.Cmd) {
+	// Don't let the user pick a working directory for a tab that can't be opened
+	if err := m.supervisor.CanSpawn(); err != nil {
+		return m, notification.WarningCmd("Cannot open a new tab: " + err.Error())
+	}
+
 	// If no working dir specified, open the picker
 	if workingDir == "" {
 		return m.openWorkingDirPicker()
 	}
 
-	// Spawn the new session
+	sessionID, err := m.spawnTab(workingDir)
+	if err != nil {
+		return m, notification.ErrorCmd("Failed to spawn session: " + err.Error())
+	}
+
+	// Switch to the new session
+	return m.handleSwitchTab(sessionID)
+}
+
+// spawnTab spawns a new session in workingDir and persists its tab.
+func (m *appModel) spawnTab(workingDir string) (string, error) {
 	ctx := context.Background()
 	sessionID, err := m.supervisor.SpawnSession(ctx, workingDir)
 	if err != nil {
-		return m, notification.ErrorCmd("Failed to spawn session: " + err.Error())
+		return "", err
 	}
 
 	// Persist the new tab (for new tabs, persisted ID == runtime tab ID).
@@ -1105,9 +1505,36 @@ func (m *appModel) handleSpawnSession(workingDir string) (tea.Model, tea.Cmd) {
 			slog.Warn("Failed to persist new tab", "error", err)
 		}
 	}
+	return sessionID, nil
+}
+
+// handleContinueInNewTab opens a new tab in the working directory of the
+// current session, seeded with a summary of it.
+func (m *appModel) handleContinueInNewTab() (tea.Model, tea.Cmd) {
+	summary := m.application.ContinuationSummary()
+	if summary == "" {
+		return m, notification.InfoCmd("Nothing to continue yet: wait for a response of the agent")
+	}
+	if err := m.supervisor.CanSpawn(); err != nil {
+		return m, notification.WarningCmd("Cannot open a new tab: " + err.Error())
+	}
+
+	runner := m.supervisor.GetRunner(m.supervisor.ActiveID())
+	if runner == nil {
+		return m, notification.ErrorCmd("Session not found")
+	}
+	sessionID, err := m.spawnTab(runner.WorkingDir)
+	if err != nil {
+		return m, notification.ErrorCmd("Failed to spawn session: " + err.Error())
+	}
+	if newRunner := m.supervisor.GetRunner(sessionID); newRunner != nil && newRunner.App != nil {
+		if err := newRunner.App.SeedSummary(context.Background(), summary); err != nil {
+			slog.Warn("Failed to persist the summary of the previous session", "session_id", sessionID, "error", err)
+		}
+	}
 
-	// Switch to the new session
-	return m.handleSwitchTab(sessionID)
+	model, cmd := m.handleSwitchTab(sessionID)
+	return model, tea.Batch(cmd, notification.SuccessCmd("New tab started from a summary of the previous session"))
 }
 
 // openWorkingDirPicker opens the working directory picker dialog.
@@ -1142,6 +1569,15 @@ func (m *appModel) handleSwitchTab(sessionID string) (tea.Model, tea.Cmd) {
 	// Blur current editor before switching
 	m.editor.Blur()
 
+	// The scratchpad has no runtime: keep the active session components
+	// around and only swap what is shown and where input goes.
+	if runner.Scratchpad {
+		m.scratchpadActive = true
+		m.workingSpinner.Stop()
+		return m, tea.Batch(m.scratchpad.Focus(), m.resizeAll())
+	}
+	m.leaveScratchpad()
+
 	// If this tab has a pending session restore, load it through
 	// replaceActiveSession — the same code path as the /sessions command.
 	if oldSessionID, ok := m.pendingRestores[sessionID]; ok {
@@ -1213,6 +1649,30 @@ func (m *appModel) handleSwitchTab(sessionID string) (tea.Model, tea.Cmd) {
 	return m, tea.Batch(cmds...)
 }
 
+// handleOpenScratchpad opens the scratchpad tab, adding it to the tab bar
+// the first time.
+func (m *appModel) handleOpenScratchpad() (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+	if m.scratchpad == nil {
+		m.scratchpad = scratchpad.New(m.tuiStore)
+		cmd = m.scratchpad.Init()
+	}
+	m.supervisor.AddScratchpad(scratchpadTabID, "Scratchpad")
+
+	model, switchCmd := m.handleSwitchTab(scratchpadTabID)
+	return model, tea.Batch(cmd, switchCmd)
+}
+
+// leaveScratchpad saves and blurs the scratchpad when switching away from it.
+func (m *appModel) leaveScratchpad() {
+	if !m.scratchpadActive {
+		return
+	}
+	m.scratchpadActive = false
+	m.scratchpad.Save()
+	m.scratchpad.Blur()
+}
+
 // applySidebarCollapsed applies and consumes the persisted sidebar collapsed state
 // for the given tab ID. Returns a resize command if the state was applied, nil otherwise.
 func (m *appModel) applySidebarCollapsed(sessionID string) tea.Cmd {
@@ -1307,6 +1767,15 @@ func (m *appModel) handleReorderTab(msg messages.ReorderTabMsg) (tea.Model, tea.
 func (m *appModel) handleCloseTab(sessionID string) (tea.Model, tea.Cmd) {
 	wasActive := sessionID == m.supervisor.ActiveID()
 
+	if runner := m.supervisor.GetRunner(sessionID); runner != nil && runner.Scratchpad {
+		m.leaveScratchpad()
+		nextActiveID := m.supervisor.CloseSession(sessionID)
+		if wasActive && nextActiveID != "" {
+			return m.handleSwitchTab(nextActiveID)
+		}
+		return m, nil
+	}
+
 	// Capture the working dir before closing so we can reuse it if this is the last tab.
 	var closedWorkingDir string
 	if runner := m.supervisor.GetRunner(sessionID); runner != nil {
@@ -1388,18 +1857,21 @@ func (m *appModel) resizeAll() tea.Cmd {
 	width, height := m.width, m.height
 
 	// Calculate fixed heights
-	tabBarHeight := m.tabBar.Height()
-	statusBarHeight := m.statusBar.Height()
+	tabBarHeight := m.tabBarHeight()
+	statusBarHeight := m.statusBarHeight()
 	resizeHandleHeight := 1
 
 	// Calculate editor height
 	innerWidth := width - appPaddingHorizontal
-	minLines := 4
-	maxLines := max(minLines, (height-6)/2)
-	m.editorLines = max(minLines, min(m.editorLines, maxLines))
+	compact := height < compactEditorThreshold
+	if compact && !m.compactEditor {
+		// Entering compact mode: collapse to a single line; dragging the
+		// resize handle can still expand it.
+		m.editorLines = 0
+	}
+	m.compactEditor = compact
 
-	targetEditorHeight := m.editorLines - 1
-	cmds = append(cmds, m.editor.SetSize(innerWidth, targetEditorHeight))
+	cmds = append(cmds, m.editor.SetSize(innerWidth, m.targetEditorHeight()))
 	_, editorHeight := m.editor.GetSize()
 	// The editor's View() adds MarginBottom(1) which isn't included in GetSize(),
 	// so account for it in the layout calculation.
@@ -1417,6 +1889,10 @@ func (m *appModel) resizeAll() tea.Cmd {
 	cmd = m.chatPage.SetSize(width, m.contentHeight)
 	cmds = append(cmds, cmd)
 
+	if m.scratchpad != nil {
+		cmds = append(cmds, m.scratchpad.SetSize(width, m.contentHeight))
+	}
+
 	// Update completion manager with editor height for popup positioning
 	m.completions.SetEditorBottom(editorHeight + tabBarHeight)
 	m.completions.Update(tea.WindowSizeMsg{Width: width, Height: height})
@@ -1452,12 +1928,18 @@ func (m *appModel) Bindings() []key.Binding {
 	))
 
 	// Show newline help based on keyboard enhancement support
-	if m.keyboardEnhancementsSupported {
+	switch {
+	case m.keyboardEnhancementsSupported && m.enterInsertsNewline:
+		bindings = append(bindings, key.NewBinding(
+			key.WithKeys("shift+enter"),
+			key.WithHelp("Shift+Enter", "send"),
+		))
+	case m.keyboardEnhancementsSupported:
 		bindings = append(bindings, key.NewBinding(
 			key.WithKeys("shift+enter"),
 			key.WithHelp("Shift+Enter", "newline"),
 		))
-	} else {
+	default:
 		bindings = append(bindings, key.NewBinding(
 			key.WithKeys("ctrl+j"),
 			key.WithHelp("Ctrl+j", "newline"),
@@ -1512,6 +1994,10 @@ func (m *appModel) handleKeyPress(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
 		}
 	}
 
+	if m.scratchpadActive {
+		return m.handleScratchpadKeyPress(msg)
+	}
+
 	// Completion popup gets priority when open
 	if m.completions.Open() {
 		if core.IsNavigationKey(msg) {
@@ -1527,7 +2013,7 @@ func (m *appModel) handleKeyPress(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
 
 		editorModel, cmd := m.editor.Update(msg)
 		m.editor = editorModel.(editor.Editor)
-		cmds = append(cmds, cmd)
+		cmds = append(cmds, cmd, m.fitCompactEditor())
 		return m, tea.Batch(cmds...)
 	}
 
@@ -1559,8 +2045,40 @@ func (m *appModel) handleKeyPress(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
 	case key.Matches(msg, key.NewBinding(key.WithKeys("ctrl+m"))):
 		return m.handleOpenModelPicker()
 
+	case key.Matches(msg, key.NewBinding(key.WithKeys("ctrl+shift+m"))):
+		return m.handleCycleModel()
+
 	case key.Matches(msg, key.NewBinding(key.WithKeys("ctrl+x"))):
 		return m, core.CmdHandler(messages.ClearQueueMsg{})
+
+	case key.Matches(msg, key.NewBinding(key.WithKeys(dialog.SessionsOverviewKey))):
+		return m.handleShowSessionsOverview()
+
+	// Not ctrl+f, which moves the cursor forward in the editor
+	case key.Matches(msg, key.NewBinding(key.WithKeys("alt+z"))):
+		return m.handleToggleFocusMode()
+
+	case key.Matches(msg, key.NewBinding(key.WithKeys("f5"))):
+		return m, core.CmdHandler(messages.ReloadSessionMsg{})
+
+	// Not alt+l, which lowercases the next word in the editor
+	case key.Matches(msg, key.NewBinding(key.WithKeys("alt+k"))):
+		return m, core.CmdHandler(messages.CopySessionLinkMsg{})
+
+	case key.Matches(msg, key.NewBinding(key.WithKeys("alt+s"))):
+		return m, core.CmdHandler(messages.ToggleSplitDiffMsg{})
+
+	case key.Matches(msg, key.NewBinding(key.WithKeys("alt+x"))):
+		return m, core.CmdHandler(messages.StopAfterStepMsg{})
+
+	case key.Matches(msg, key.NewBinding(key.WithKeys("alt+p"))):
+		return m, core.CmdHandler(messages.TogglePauseRenderingMsg{})
+
+	case key.Matches(msg, key.NewBinding(key.WithKeys("alt+t"))):
+		return m, core.CmdHandler(messages.ShowToolsDialogMsg{})
+
+	case key.Matches(msg, key.NewBinding(key.WithKeys("alt+o"))):
+		return m, core.CmdHandler(messages.OpenFileReferenceMsg{})
 	}
 
 	// History search is a modal state — capture all remaining keys before normal routing
@@ -1581,6 +2099,13 @@ func (m *appModel) handleKeyPress(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
 			return m, cmd
 		}
 
+	// Grow or shrink the editor from the keyboard
+	case m.focusedPanel == PanelEditor && key.Matches(msg, key.NewBinding(key.WithKeys("ctrl+up"))):
+		return m, m.resizeEditorBy(1)
+
+	case m.focusedPanel == PanelEditor && key.Matches(msg, key.NewBinding(key.WithKeys("ctrl+down"))):
+		return m, m.resizeEditorBy(-1)
+
 	// Toggle sidebar (propagates to content view regardless of focus)
 	case key.Matches(msg, key.NewBinding(key.WithKeys("ctrl+b"))):
 		updated, cmd := m.chatPage.Update(msg)
@@ -1610,7 +2135,7 @@ func (m *appModel) handleKeyPress(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
 	case PanelEditor:
 		editorModel, cmd := m.editor.Update(msg)
 		m.editor = editorModel.(editor.Editor)
-		return m, cmd
+		return m, tea.Batch(cmd, m.fitCompactEditor())
 	case PanelContent:
 		updated, cmd := m.chatPage.Update(msg)
 		m.chatPage = updated.(chat.Page)
@@ -1620,6 +2145,25 @@ func (m *appModel) handleKeyPress(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// handleScratchpadKeyPress handles keys while the scratchpad tab is active.
+// Only quitting and suspending are kept, everything else is typed into the
+// scratchpad. Session shortcuts such as the command palette are left out as
+// they would act on a session that isn't visible.
+func (m *appModel) handleScratchpadKeyPress(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, key.NewBinding(key.WithKeys("ctrl+c"))):
+		return m, core.CmdHandler(dialog.OpenDialogMsg{
+			Model: dialog.NewExitConfirmationDialog(),
+		})
+
+	case key.Matches(msg, key.NewBinding(key.WithKeys("ctrl+z"))):
+		return m, tea.Suspend
+	}
+
+	_, cmd := m.scratchpad.Update(msg)
+	return m, cmd
+}
+
 // parseCtrlNumberKey checks if msg is ctrl+1 through ctrl+9 and returns the index (0-8), or -1 if not matched
 func parseCtrlNumberKey(msg tea.KeyPressMsg) int {
 	s := msg.String()
@@ -1660,6 +2204,9 @@ func (m *appModel) handleMouseClick(msg tea.MouseClickMsg) (tea.Model, tea.Cmd)
 	}
 
 	region := m.hitTestRegion(msg.Y)
+	if m.scratchpadActive && region != regionTabBar {
+		return m, nil
+	}
 
 	switch region {
 	case regionContent:
@@ -1669,6 +2216,10 @@ func (m *appModel) handleMouseClick(msg tea.MouseClickMsg) (tea.Model, tea.Cmd)
 
 	case regionResizeHandle:
 		if msg.Button == tea.MouseLeft {
+			// Clicking "N queued" opens the queue instead of starting a drag
+			if m.isOnQueueStatus(msg.X) {
+				return m.handleShowQueueDialog()
+			}
 			m.isDragging = true
 		}
 		return m, nil
@@ -1699,7 +2250,7 @@ func (m *appModel) handleMouseClick(msg tea.MouseClickMsg) (tea.Model, tea.Cmd)
 		return m, tea.Batch(cmd, m.editor.Focus())
 
 	case regionStatusBar:
-		if msg.Button == tea.MouseLeft && m.statusBar.ClickedNewTab(msg.X) {
+		if msg.Button == tea.MouseLeft && !m.focusMode && m.statusBar.ClickedNewTab(msg.X) {
 			return m.handleSpawnSession("")
 		}
 	}
@@ -1752,6 +2303,7 @@ func (m *appModel) handleMouseMotion(msg tea.MouseMotionMsg) (tea.Model, tea.Cmd
 func (m *appModel) handleMouseRelease(msg tea.MouseReleaseMsg) (tea.Model, tea.Cmd) {
 	if m.isDragging {
 		m.isDragging = false
+		m.persistEditorLines()
 		return m, nil
 	}
 
@@ -1801,6 +2353,10 @@ func (m *appModel) handleWheelCoalesced(msg messages.WheelCoalescedMsg) (tea.Mod
 		return m, cmd
 	}
 
+	if m.scratchpadActive {
+		return m, nil
+	}
+
 	region := m.hitTestRegion(msg.Y)
 	switch region {
 	case regionContent:
@@ -1828,7 +2384,7 @@ const (
 
 // hitTestRegion determines which layout region a Y coordinate falls in.
 func (m *appModel) hitTestRegion(y int) layoutRegion {
-	tabBarHeight := m.tabBar.Height()
+	tabBarHeight := m.tabBarHeight()
 
 	resizeHandleTop := m.contentHeight
 	tabBarTop := resizeHandleTop + 1
@@ -1852,16 +2408,71 @@ func (m *appModel) hitTestRegion(y int) layoutRegion {
 
 // editorTop returns the Y coordinate where the editor starts.
 func (m *appModel) editorTop() int {
-	return m.contentHeight + 1 + m.tabBar.Height()
+	return m.contentHeight + 1 + m.tabBarHeight()
+}
+
+// tabBarHeight returns the height of the tab bar, 0 when it's hidden in
+// focus mode.
+func (m *appModel) tabBarHeight() int {
+	if m.focusMode {
+		return 0
+	}
+	return m.tabBar.Height()
+}
+
+// statusBarHeight returns the height of the status bar, 0 when it's hidden
+// in focus mode.
+func (m *appModel) statusBarHeight() int {
+	if m.focusMode {
+		return 0
+	}
+	return m.statusBar.Height()
+}
+
+// editorLineBounds returns the minimum and maximum editor height in lines.
+// In compact mode the editor shrinks down to a single line of input.
+func (m *appModel) editorLineBounds() (minLines, maxLines int) {
+	minLines = 4
+	if m.compactEditor {
+		minLines = 2
+	}
+	return minLines, max(minLines, (m.height-6)/2)
+}
+
+// targetEditorHeight clamps editorLines to the current bounds and returns the
+// height to give the editor. In compact mode the editor grows with its content
+// until it reaches the maximum height.
+func (m *appModel) targetEditorHeight() int {
+	minLines, maxLines := m.editorLineBounds()
+	m.editorLines = max(minLines, min(m.editorLines, maxLines))
+
+	lines := m.editorLines
+	if m.compactEditor {
+		contentLines := m.editor.ContentLines() + m.editor.BannerHeight() + 1
+		lines = max(lines, min(contentLines, maxLines))
+	}
+	return lines - 1
+}
+
+// fitCompactEditor re-runs the layout when the content of the compact editor
+// no longer matches its current height.
+func (m *appModel) fitCompactEditor() tea.Cmd {
+	if !m.compactEditor {
+		return nil
+	}
+	_, editorHeight := m.editor.GetSize()
+	if editorHeight-styles.EditorStyle.GetVerticalFrameSize() == m.targetEditorHeight() {
+		return nil
+	}
+	return m.resizeAll()
 }
 
 // handleEditorResize adjusts editor height based on drag position.
 func (m *appModel) handleEditorResize(y int) tea.Cmd {
 	// Calculate target lines from drag position
 	editorPadding := styles.EditorStyle.GetVerticalFrameSize()
-	targetLines := m.height - y - 1 - editorPadding - m.tabBar.Height()
-	minLines := 4
-	maxLines := max(minLines, (m.height-6)/2)
+	targetLines := m.height - y - 1 - editorPadding - m.tabBarHeight()
+	minLines, maxLines := m.editorLineBounds()
 	newLines := max(minLines, min(targetLines, maxLines))
 	if newLines != m.editorLines {
 		m.editorLines = newLines
@@ -1870,6 +2481,29 @@ func (m *appModel) handleEditorResize(y int) tea.Cmd {
 	return nil
 }
 
+// resizeEditorBy grows or shrinks the editor by delta lines, within the same
+// bounds as dragging the resize handle, and remembers its new height.
+func (m *appModel) resizeEditorBy(delta int) tea.Cmd {
+	minLines, maxLines := m.editorLineBounds()
+	newLines := max(minLines, min(m.editorLines+delta, maxLines))
+	if newLines == m.editorLines {
+		return nil
+	}
+	m.editorLines = newLines
+	m.persistEditorLines()
+	return m.resizeAll()
+}
+
+// persistEditorLines writes the editor height to the tuistate store.
+func (m *appModel) persistEditorLines() {
+	if m.tuiStore == nil {
+		return
+	}
+	if err := m.tuiStore.SaveEditorLines(context.Background(), m.editorLines); err != nil {
+		slog.Warn("Failed to save editor height", "error", err)
+	}
+}
+
 // renderResizeHandle renders the draggable separator between content and bottom panel.
 func (m *appModel) renderResizeHandle(width int) string {
 	if width <= 0 {
@@ -1895,31 +2529,58 @@ func (m *appModel) renderResizeHandle(width int) string {
 		lipgloss.WithWhitespaceStyle(styles.ResizeHandleStyle),
 	)
 
-	var result string
+	// Truncate right side and append the status (handle stays centered)
+	result := fullLine
+	if suffix := m.resizeHandleSuffix(); suffix != "" {
+		result = lipgloss.NewStyle().MaxWidth(innerWidth-lipgloss.Width(suffix)).Render(fullLine) + suffix
+	}
+
+	return lipgloss.NewStyle().Padding(0, styles.AppPadding).Render(result)
+}
+
+// resizeHandleSuffix returns the working/queue status shown at the right end
+// of the resize handle, or "" when there is none.
+func (m *appModel) resizeHandleSuffix() string {
 	switch {
 	case m.chatPage.IsWorking():
-		// Truncate right side and append spinner (handle stays centered)
 		workingText := "Working…"
+		if m.chatPage.StoppingAfterStep() {
+			workingText = "Stopping after this step…"
+		}
+		if m.chatPage.RenderingPaused() {
+			workingText += " · rendering paused"
+		}
 		if queueLen := m.chatPage.QueueLength(); queueLen > 0 {
-			workingText = fmt.Sprintf("Working… (%d queued)", queueLen)
+			workingText = fmt.Sprintf("%s (%d queued)", workingText, queueLen)
 		}
 		suffix := " " + m.workingSpinner.View() + " " + styles.SpinnerDotsHighlightStyle.Render(workingText)
 		cancelKeyPart := styles.HighlightWhiteStyle.Render("Esc")
-		suffix += " (" + cancelKeyPart + " to interrupt)"
-		suffixWidth := lipgloss.Width(suffix)
-		result = lipgloss.NewStyle().MaxWidth(innerWidth-suffixWidth).Render(fullLine) + suffix
+		return suffix + " (" + cancelKeyPart + " to interrupt)"
 
 	case m.chatPage.QueueLength() > 0:
 		queueText := fmt.Sprintf("%d queued", m.chatPage.QueueLength())
-		suffix := " " + styles.WarningStyle.Render(queueText) + " "
-		suffixWidth := lipgloss.Width(suffix)
-		result = lipgloss.NewStyle().MaxWidth(innerWidth-suffixWidth).Render(fullLine) + suffix
+		return " " + styles.WarningStyle.Render(queueText) + " "
+
+	case m.chatPage.RenderingPaused():
+		return " " + styles.WarningStyle.Render("Rendering paused") + styles.MutedStyle.Render(" (") + styles.HighlightWhiteStyle.Render("Alt+p") + styles.MutedStyle.Render(" to resume)") + " "
+
+	case m.focusMode:
+		// The status bar is hidden, remind how to bring it back
+		return " " + styles.MutedStyle.Render("Focus mode (") + styles.HighlightWhiteStyle.Render("Alt+z") + styles.MutedStyle.Render(" to exit)") + " "
 
 	default:
-		result = fullLine
+		return ""
 	}
+}
 
-	return lipgloss.NewStyle().Padding(0, styles.AppPadding).Render(result)
+// isOnQueueStatus reports whether x is on the status at the right end of the
+// resize handle while messages are queued.
+func (m *appModel) isOnQueueStatus(x int) bool {
+	if m.chatPage.QueueLength() == 0 {
+		return false
+	}
+	suffixStart := m.width - styles.AppPadding - lipgloss.Width(m.resizeHandleSuffix())
+	return x >= suffixStart && x < m.width-styles.AppPadding
 }
 
 // View renders the model.
@@ -1942,19 +2603,35 @@ func (m *appModel) View() tea.View {
 	}
 
 	// Content area (messages + sidebar) -- swaps per tab
-	contentView := m.chatPage.View()
+	var contentView string
+	if m.scratchpadActive {
+		contentView = lipgloss.NewStyle().Height(m.contentHeight).Render(m.scratchpad.View())
+	} else {
+		contentView = m.chatPage.View()
+	}
 
 	// Resize handle (between content and bottom panel)
 	resizeHandle := m.renderResizeHandle(m.width)
 
-	// Tab bar (above editor)
-	tabBarView := m.tabBar.View()
-
-	// Editor (fixed position, per-session state)
-	editorView := m.editor.View()
+	// Tab bar (above editor) and status bar, hidden in focus mode
+	var tabBarView, statusBarView string
+	if !m.focusMode {
+		tabBarView = m.tabBar.View()
+		statusBarView = m.statusBar.View()
+	}
 
-	// Status bar
-	statusBarView := m.statusBar.View()
+	// Editor (fixed position, per-session state). The scratchpad has no
+	// editor, keep its space so the layout doesn't move when switching tabs.
+	var editorView string
+	if m.scratchpadActive {
+		_, editorHeight := m.editor.GetSize()
+		editorView = lipgloss.NewStyle().
+			Height(editorHeight+1).
+			Padding(0, styles.AppPadding).
+			Render(styles.MutedStyle.Render("Scratchpad notes are saved automatically and never sent to an agent."))
+	} else {
+		editorView = m.editor.View()
+	}
 
 	// Combine: content | resize handle | [tab bar] | editor | status bar
 	viewParts := []string{
@@ -2005,7 +2682,9 @@ func (m *appModel) View() tea.View {
 // terminal multiplexers (tmux) can detect activity in the pane.
 func (m *appModel) windowTitle() string {
 	title := "cagent"
-	if sessionTitle := m.sessionState.SessionTitle(); sessionTitle != "" {
+	if m.scratchpadActive {
+		title = "Scratchpad - cagent"
+	} else if sessionTitle := m.sessionState.SessionTitle(); sessionTitle != "" {
 		title = sessionTitle + " - cagent"
 	}
 	if m.chatPage.IsWorking() {
@@ -2022,6 +2701,9 @@ func (m *appModel) cleanupAll() {
 	}
 	m.transcriber.Stop()
 	m.closeTranscriptCh()
+	if m.scratchpad != nil {
+		m.scratchpad.Save()
+	}
 	for _, cp := range m.chatPages {
 		cp.Cleanup()
 	}
@@ -2041,7 +2723,7 @@ func (m *appModel) persistedSessionID(tabID string) string {
 	if persistedID, ok := m.pendingRestores[tabID]; ok {
 		return persistedID
 	}
-	if runner := m.supervisor.GetRunner(tabID); runner != nil {
+	if runner := m.supervisor.GetRunner(tabID); runner != nil && !runner.Scratchpad {
 		return runner.App.Session().ID
 	}
 	return tabID
@@ -2059,7 +2741,7 @@ func (m *appModel) findTabByPersistedID(persistedID string) string {
 	// Check live sessions.
 	tabs, _ := m.supervisor.GetTabs()
 	for _, tab := range tabs {
-		if runner := m.supervisor.GetRunner(tab.SessionID); runner != nil {
+		if runner := m.supervisor.GetRunner(tab.SessionID); runner != nil && !runner.Scratchpad {
 			if runner.App.Session().ID == persistedID {
 				return tab.SessionID
 			}
@@ -2086,20 +2768,7 @@ func (m *appModel) openExternalEditor() (tea.Model, tea.Cmd) {
 	}
 	tmpFile.Close()
 
-	// Get the editor command (VISUAL, EDITOR, or platform default)
-	editorCmd := cmp.Or(os.Getenv("VISUAL"), os.Getenv("EDITOR"))
-	if editorCmd == "" {
-		if goruntime.GOOS == "windows" {
-			editorCmd = "notepad"
-		} else {
-			editorCmd = "vi"
-		}
-	}
-
-	// Parse editor command (may include arguments like "code --wait")
-	parts := strings.Fields(editorCmd)
-	args := append(parts[1:], tmpPath)
-	cmd := exec.Command(parts[0], args...)
+	cmd := core.ExternalEditorCommand(tmpPath)
 
 	ed := m.editor
 	return m, tea.ExecProcess(cmd, func(err error) tea.Msg {
@@ -10,7 +10,6 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
-	"github.com/docker/cagent/pkg/audio/transcribe"
 	"github.com/docker/cagent/pkg/tui/components/completion"
 	"github.com/docker/cagent/pkg/tui/components/editor"
 	"github.com/docker/cagent/pkg/tui/components/notification"
@@ -19,11 +18,13 @@ import (
 	"github.com/docker/cagent/pkg/tui/messages"
 	"github.com/docker/cagent/pkg/tui/page/chat"
 	"github.com/docker/cagent/pkg/tui/service"
+	"github.com/docker/cagent/pkg/userconfig"
 )
 
 // mockChatPage implements chat.Page for testing.
 type mockChatPage struct {
 	cleanupCalled bool
+	working       bool
 }
 
 func (m *mockChatPage) Init() tea.Cmd                            { return nil }
@@ -35,14 +36,22 @@ func (m *mockChatPage) Cleanup()                                 { m.cleanupCall
 func (m *mockChatPage) SetSessionStarred(bool)                   {}
 func (m *mockChatPage) SetTitleRegenerating(bool) tea.Cmd        { return nil }
 func (m *mockChatPage) ScrollToBottom() tea.Cmd                  { return nil }
-func (m *mockChatPage) IsWorking() bool                          { return false }
+func (m *mockChatPage) IsWorking() bool                          { return m.working }
+func (m *mockChatPage) StoppingAfterStep() bool                  { return false }
+func (m *mockChatPage) RenderingPaused() bool                    { return false }
 func (m *mockChatPage) IsInlineEditing() bool                    { return false }
 func (m *mockChatPage) QueueLength() int                         { return 0 }
+func (m *mockChatPage) QueuedMessages() []messages.QueuedEntry   { return nil }
+func (m *mockChatPage) RemoveQueued(int) bool                    { return false }
+func (m *mockChatPage) MoveQueued(int, int) bool                 { return false }
 func (m *mockChatPage) FocusMessages() tea.Cmd                   { return nil }
 func (m *mockChatPage) FocusMessageAt(int, int) tea.Cmd          { return nil }
 func (m *mockChatPage) BlurMessages()                            {}
 func (m *mockChatPage) GetSidebarSettings() chat.SidebarSettings { return chat.SidebarSettings{} }
 func (m *mockChatPage) SetSidebarSettings(chat.SidebarSettings)  {}
+func (m *mockChatPage) CurrentScrollMark() (int, int)            { return 0, 0 }
+func (m *mockChatPage) ScrollToMark(int, int)                    {}
+func (m *mockChatPage) Throughput() (float64, float64)           { return 0, 0 }
 func (m *mockChatPage) Bindings() []key.Binding                  { return nil }
 func (m *mockChatPage) Help() help.KeyMap                        { return nil }
 
@@ -64,9 +73,11 @@ func (m *mockEditor) Value() string                          { return "" }
 func (m *mockEditor) SetValue(string)                        {}
 func (m *mockEditor) InsertText(string)                      {}
 func (m *mockEditor) AttachFile(string) error                { return nil }
+func (m *mockEditor) AttachURL(string)                       {}
 func (m *mockEditor) Cleanup()                               { m.cleanupCalled = true }
 func (m *mockEditor) GetSize() (int, int)                    { return 0, 0 }
 func (m *mockEditor) BannerHeight() int                      { return 0 }
+func (m *mockEditor) ContentLines() int                      { return 1 }
 func (m *mockEditor) AttachmentAt(int) (editor.AttachmentPreview, bool) {
 	return editor.AttachmentPreview{}, false
 }
@@ -75,6 +86,13 @@ func (m *mockEditor) IsRecording() bool                           { return false
 func (m *mockEditor) IsHistorySearchActive() bool                 { return false }
 func (m *mockEditor) EnterHistorySearch() (layout.Model, tea.Cmd) { return m, nil }
 func (m *mockEditor) SendContent() tea.Cmd                        { return nil }
+func (m *mockEditor) SetSendAndStay(bool)                         {}
+func (m *mockEditor) SetSoftWrap(bool)                            {}
+func (m *mockEditor) SetEnterInsertsNewline(bool)                 {}
+func (m *mockEditor) SetLargePaste(string, int, int)              {}
+func (m *mockEditor) PasteClipboard(bool) (string, error)         { return "", nil }
+func (m *mockEditor) InsertFile(string) (string, error)           { return "", nil }
+func (m *mockEditor) InsertCodeBlock(string, string) string       { return "" }
 
 // collectMsgs executes a command (or batch/sequence of commands) and collects all returned messages.
 func collectMsgs(cmd tea.Cmd) []tea.Msg {
@@ -138,7 +156,7 @@ func newTestModel() (*appModel, *mockChatPage, *mockEditor) {
 		pendingSidebarCollapsed: map[string]bool{},
 		chatPage:                page,
 		editor:                  ed,
-		transcriber:             transcribe.New(""),
+		transcriber:             newTranscriber(&userconfig.Settings{}),
 		notification:            notification.New(),
 		dialogMgr:               dialog.New(),
 		completions:             completion.New(),
@@ -0,0 +1,108 @@
+package tui
+
+import (
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/docker/cagent/pkg/session"
+	"github.com/docker/cagent/pkg/tui/components/statusbar"
+	"github.com/docker/cagent/pkg/tui/components/tabbar"
+	"github.com/docker/cagent/pkg/tui/dialog"
+	"github.com/docker/cagent/pkg/tui/messages"
+	"github.com/docker/cagent/pkg/tui/service"
+)
+
+func TestToggleFocusMode_HidesTabAndStatusBars(t *testing.T) {
+	t.Parallel()
+
+	m, _, _ := newTestModel()
+	m.tabBar = tabbar.New(20)
+	m.statusBar = statusbar.New(m)
+	m.width, m.height = 100, 40
+	m.resizeAll()
+	contentHeight := m.contentHeight
+
+	m.Update(messages.ToggleFocusModeMsg{})
+	assert.True(t, m.focusMode)
+	assert.Greater(t, m.contentHeight, contentHeight, "the content takes the space of the hidden bars")
+	assert.Equal(t, regionResizeHandle, m.hitTestRegion(m.editorTop()-1), "no tab bar between the handle and the editor")
+	assert.Contains(t, m.resizeHandleSuffix(), "Focus mode")
+
+	m.Update(messages.ToggleFocusModeMsg{})
+	assert.False(t, m.focusMode)
+	assert.Equal(t, contentHeight, m.contentHeight)
+	assert.Empty(t, m.resizeHandleSuffix())
+
+	// Ctrl+F is left to the editor, which moves the cursor forward with it
+	m.focusedPanel = PanelEditor
+	m.Update(tea.KeyPressMsg{Code: 'f', Mod: tea.ModCtrl})
+	assert.False(t, m.focusMode)
+	m.Update(tea.KeyPressMsg{Code: 'z', Mod: tea.ModAlt})
+	assert.True(t, m.focusMode)
+}
+
+func TestResizeEditorBy_ClampsToBounds(t *testing.T) {
+	t.Parallel()
+
+	m, _, _ := newTestModel()
+	m.tabBar = tabbar.New(20)
+	m.statusBar = statusbar.New(m)
+	m.width, m.height = 100, 40
+	m.editorLines = 4
+	m.focusedPanel = PanelEditor
+	m.resizeAll()
+	minLines, maxLines := m.editorLineBounds()
+
+	m.Update(tea.KeyPressMsg{Code: tea.KeyUp, Mod: tea.ModCtrl})
+	assert.Equal(t, 5, m.editorLines)
+
+	for range maxLines {
+		m.resizeEditorBy(1)
+	}
+	assert.Equal(t, maxLines, m.editorLines)
+
+	for range maxLines {
+		m.Update(tea.KeyPressMsg{Code: tea.KeyDown, Mod: tea.ModCtrl})
+	}
+	assert.Equal(t, minLines, m.editorLines)
+}
+
+func TestToggleSplitDiff_AppliesToAllTabs(t *testing.T) {
+	t.Parallel()
+
+	m, _, _ := newTestModel()
+	first, second := service.NewSessionState(session.New()), service.NewSessionState(session.New())
+	m.sessionStates = map[string]*service.SessionState{"test": first, "other": second}
+	m.splitDiffView = false
+	first.SetSplitDiffView(false)
+	second.SetSplitDiffView(false)
+
+	m.Update(messages.ToggleSplitDiffMsg{})
+	assert.True(t, m.splitDiffView)
+	assert.True(t, first.SplitDiffView())
+	assert.True(t, second.SplitDiffView())
+
+	m.Update(messages.ToggleSplitDiffMsg{})
+	assert.False(t, m.splitDiffView)
+	assert.False(t, first.SplitDiffView())
+	assert.False(t, second.SplitDiffView())
+}
+
+func TestSwitchAgent_ConfirmsWhileWorking(t *testing.T) {
+	t.Parallel()
+
+	m, page, _ := newTestModel()
+	m.sessionState = service.NewSessionState(session.New())
+	page.working = true
+
+	_, cmd := m.Update(messages.SwitchAgentMsg{AgentName: "reviewer"})
+	msgs := collectMsgs(cmd)
+	require.Len(t, msgs, 1)
+	open, ok := msgs[0].(dialog.OpenDialogMsg)
+	require.True(t, ok, "switching while working opens a confirmation")
+	assert.Contains(t, open.Model.View(), "reviewer")
+}
@@ -21,6 +21,7 @@ const (
 	MessageTypeToolResult
 	MessageTypeWelcome
 	MessageTypeLoading
+	MessageTypeSubSession // Collapsible transcript of a transferred task
 )
 
 const UserMessageEditLabel = "✎"
@@ -34,6 +35,9 @@ const (
 	ToolStatusRunning
 	ToolStatusCompleted
 	ToolStatusError
+	// ToolStatusTimedOut is a tool call aborted by the runtime because it
+	// ran for too long.
+	ToolStatusTimedOut
 )
 
 // Message represents a single message in the chat
@@ -48,6 +52,8 @@ type Message struct {
 	// SessionPosition is the index of this message in session.Messages (when known).
 	// Used for operations like branching on edits.
 	SessionPosition *int
+	// TokensPerSecond is the generation speed of assistant messages, when known.
+	TokensPerSecond float64
 }
 
 func Agent(typ MessageType, agentName, content string) *Message {
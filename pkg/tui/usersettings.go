@@ -0,0 +1,142 @@
+package tui
+
+import (
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+
+	"github.com/docker/cagent/pkg/audio/transcribe"
+	"github.com/docker/cagent/pkg/tui/components/markdown"
+	"github.com/docker/cagent/pkg/tui/components/message"
+	"github.com/docker/cagent/pkg/tui/components/notification"
+	"github.com/docker/cagent/pkg/tui/components/reasoningblock"
+	"github.com/docker/cagent/pkg/tui/components/toolcommon"
+	"github.com/docker/cagent/pkg/tui/styles"
+	"github.com/docker/cagent/pkg/userconfig"
+)
+
+// applyUserSettings applies the user settings that can change while the TUI
+// runs, at startup and when the user config is edited. It returns the
+// commands starting the autosave and quiet hours tickers when they were off.
+func (m *appModel) applyUserSettings(settings *userconfig.Settings) tea.Cmd {
+	m.supervisor.SetMaxSessions(settings.GetMaxTabs())
+	styles.DoubleClickThreshold = settings.GetDoubleClickThreshold()
+	markdown.SetRenderDiagrams(settings.RenderDiagrams)
+	message.SetShowThroughput(settings.ShowThroughput)
+	reasoningblock.SetAutoCollapse(settings.GetAutoCollapseReasoning())
+	styles.SetHomeRelativePaths(settings.GetHomeRelativePaths())
+	for toolName, renderer := range settings.ToolResultRenderers {
+		if err := toolcommon.RegisterResultRendererByName(toolName, renderer); err != nil {
+			slog.Warn("Ignoring tool result renderer", "tool", toolName, "error", err)
+		}
+	}
+	message.SetRoleLabels(settings.RoleLabels)
+	m.notification.SetPosition(settings.GetNotificationPosition())
+	for _, t := range []notification.Type{notification.TypeSuccess, notification.TypeWarning, notification.TypeInfo, notification.TypeError} {
+		m.notification.SetDuration(t, settings.GetNotificationDuration(t.String()))
+	}
+
+	if m.transcriber == nil || !m.transcriber.IsRunning() {
+		m.transcriber = newTranscriber(settings)
+	}
+
+	m.generateTitles = settings.GetGenerateTitles()
+	m.separateSubSessionCosts = settings.SeparateSubSessionCosts
+	m.softWrap = settings.GetSoftWrap()
+	m.enterInsertsNewline = settings.EnterInsertsNewline
+	m.largePaste = settings.GetLargePaste()
+	m.pasteMaxLines = settings.GetPasteMaxLines()
+	m.pasteMaxChars = settings.GetPasteMaxChars()
+	for _, ed := range m.editors {
+		ed.SetSoftWrap(m.softWrap)
+		ed.SetEnterInsertsNewline(m.enterInsertsNewline)
+		ed.SetLargePaste(m.largePaste, m.pasteMaxLines, m.pasteMaxChars)
+	}
+
+	// The tickers that are running pick the new settings up when they fire.
+	var cmds []tea.Cmd
+	wasAutosaving := m.autosaveInterval > 0
+	m.autosaveInterval = settings.GetAutosaveInterval()
+	if !wasAutosaving {
+		cmds = append(cmds, m.autosaveCmd())
+	}
+	hadQuietHours := m.hasQuietHours
+	m.quietHours, m.hasQuietHours = settings.GetQuietHours()
+	m.updateQuietHours(time.Now())
+	if !hadQuietHours {
+		cmds = append(cmds, m.quietHoursCmd())
+	}
+	return tea.Batch(cmds...)
+}
+
+// newTranscriber creates the speech-to-text transcriber of the user settings,
+// falling back to the default backend when the configured one is unknown.
+func newTranscriber(settings *userconfig.Settings) transcribe.Transcriber {
+	opts := transcribe.Options{
+		APIKey: os.Getenv("OPENAI_API_KEY"),
+		Device: settings.SpeechInputDevice,
+	}
+	t, err := transcribe.New(settings.SpeechBackend, opts)
+	if err != nil {
+		slog.Warn("Using the default speech-to-text backend", "error", err)
+		t, _ = transcribe.New("", opts)
+	}
+	return t
+}
+
+// handleReloadUserSettings reads the user config again after it was edited.
+// The runtime settings apply to the tabs opened from then on.
+func (m *appModel) handleReloadUserSettings() (tea.Model, tea.Cmd) {
+	cmd := m.applyUserSettings(userconfig.Get())
+	return m, tea.Batch(cmd, notification.SuccessCmd("User settings reloaded. Runtime settings apply to new tabs."))
+}
+
+// settingsWriter persists the changes of the user settings in the background,
+// one at a time and in the order they were made, so that toggling a setting
+// twice in a row always leaves the last value on disk.
+var settingsWriter struct {
+	mu      sync.Mutex
+	pending []func(*userconfig.Settings)
+	running bool
+}
+
+// persistSetting saves a change of the user settings to the user config
+// without blocking the UI.
+func persistSetting(update func(*userconfig.Settings)) {
+	settingsWriter.mu.Lock()
+	defer settingsWriter.mu.Unlock()
+
+	settingsWriter.pending = append(settingsWriter.pending, update)
+	if !settingsWriter.running {
+		settingsWriter.running = true
+		go writeSettings()
+	}
+}
+
+// writeSettings applies the pending changes until there are none left. The
+// changes queued while the config is written are saved together.
+func writeSettings() {
+	for {
+		settingsWriter.mu.Lock()
+		updates := settingsWriter.pending
+		settingsWriter.pending = nil
+		if len(updates) == 0 {
+			settingsWriter.running = false
+			settingsWriter.mu.Unlock()
+			return
+		}
+		settingsWriter.mu.Unlock()
+
+		err := userconfig.UpdateSettings(func(settings *userconfig.Settings) {
+			for _, update := range updates {
+				update(settings)
+			}
+		})
+		if err != nil {
+			slog.Warn("Failed to persist the user settings", "error", err)
+		}
+	}
+}
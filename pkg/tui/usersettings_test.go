@@ -0,0 +1,57 @@
+package tui
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/docker/cagent/pkg/paths"
+	"github.com/docker/cagent/pkg/tui/components/statusbar"
+	"github.com/docker/cagent/pkg/tui/messages"
+	"github.com/docker/cagent/pkg/tui/service/supervisor"
+	"github.com/docker/cagent/pkg/userconfig"
+)
+
+func TestReloadUserSettings(t *testing.T) {
+	paths.SetConfigDir(t.TempDir())
+	t.Cleanup(func() { paths.SetConfigDir("") })
+
+	m, _, _ := newTestModel()
+	m.supervisor = supervisor.New(nil)
+	m.statusBar = statusbar.New(m)
+	m.autosaveInterval = userconfig.DefaultAutosaveInterval
+	m.generateTitles = true
+	m.softWrap = true
+
+	require.NoError(t, os.WriteFile(userconfig.Path(), []byte(`settings:
+  soft_wrap: false
+  generate_titles: false
+  autosave_interval: 5
+`), 0o600))
+
+	_, cmd := m.Update(messages.ReloadUserSettingsMsg{})
+	require.NotNil(t, cmd)
+
+	assert.False(t, m.softWrap)
+	assert.False(t, m.generateTitles)
+	assert.Equal(t, 5*time.Second, m.autosaveInterval)
+}
+
+func TestPersistSetting_KeepsTheLastChange(t *testing.T) {
+	paths.SetConfigDir(t.TempDir())
+	t.Cleanup(func() { paths.SetConfigDir("") })
+
+	for i := range 10 {
+		enabled := i%2 == 0
+		persistSetting(func(s *userconfig.Settings) { s.SoftWrap = &enabled })
+	}
+	persistSetting(func(s *userconfig.Settings) { s.ShowThroughput = true })
+
+	assert.Eventually(t, func() bool {
+		settings := userconfig.Get()
+		return settings.ShowThroughput && !settings.GetSoftWrap()
+	}, 5*time.Second, 10*time.Millisecond)
+}
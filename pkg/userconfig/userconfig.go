@@ -11,7 +11,9 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/goccy/go-yaml"
 	"github.com/natefinch/atomic"
@@ -42,19 +44,179 @@ type Settings struct {
 	// HideToolResults hides tool call results in the TUI by default
 	HideToolResults bool `yaml:"hide_tool_results,omitempty"`
 	// SplitDiffView enables side-by-side split diff rendering for file edits.
-	// Defaults to true when not set.
+	// Defaults to true when not set. Once the view is toggled in the TUI, the
+	// toggled view is remembered instead.
 	SplitDiffView *bool `yaml:"split_diff_view,omitempty"`
 	// Theme is the default theme reference (e.g., "dark", "light")
 	// Theme files are loaded from ~/.cagent/themes/<theme>.yaml
 	Theme string `yaml:"theme,omitempty"`
 	// YOLO enables auto-approve mode for all tool calls globally
 	YOLO bool `yaml:"YOLO,omitempty"`
+	// YOLODirs enables auto-approve mode for the new sessions whose working
+	// directory is one of these directories, or below one of them. A leading
+	// "~" is expanded to the home directory.
+	YOLODirs []string `yaml:"yolo_dirs,omitempty"`
 	// TabTitleMaxLength is the maximum display length for tab titles in the TUI.
 	// Titles longer than this are truncated with an ellipsis. Defaults to 20.
 	TabTitleMaxLength int `yaml:"tab_title_max_length,omitempty"`
 	// RestoreTabs restores previously open tabs when launching the TUI.
 	// Defaults to false when not set (user must explicitly opt-in).
 	RestoreTabs *bool `yaml:"restore_tabs,omitempty"`
+	// ResumeLastSession loads the most recent session instead of a new one
+	// when launching the TUI, as with --continue.
+	ResumeLastSession bool `yaml:"resume_last_session,omitempty"`
+	// AutosaveInterval is the number of seconds between two saves of the
+	// sessions that changed. 0 disables autosave. Defaults to 30.
+	AutosaveInterval *int `yaml:"autosave_interval,omitempty"`
+	// StreamFlushInterval is the number of milliseconds during which the
+	// streamed responses are batched before being rendered in the TUI.
+	// Lower values show the text sooner, higher ones use less CPU. 0 renders
+	// every chunk as it arrives. Defaults to 50.
+	StreamFlushInterval *int `yaml:"stream_flush_interval,omitempty"`
+	// MaxTabs is the maximum number of tabs that can be open at once in the
+	// TUI. Defaults to 16.
+	MaxTabs int `yaml:"max_tabs,omitempty"`
+	// DoubleClickThreshold is the maximum number of milliseconds between two
+	// clicks for them to count as a double-click in the TUI. Defaults to 400.
+	DoubleClickThreshold int `yaml:"double_click_threshold,omitempty"`
+	// NotificationPosition is where the TUI shows notifications: "top" or
+	// "bottom" followed by "-left", "-center" or "-right". Defaults to
+	// "bottom-right".
+	NotificationPosition string `yaml:"notification_position,omitempty"`
+	// NotificationDurations is the number of seconds notifications stay on
+	// screen, per type ("info", "success", "warning" or "error"). Defaults to
+	// 3 seconds, and 5 seconds for errors.
+	NotificationDurations map[string]int `yaml:"notification_durations,omitempty"`
+	// ConfirmDestructiveActions asks for confirmation before destructive TUI
+	// actions such as clearing the message queue. Defaults to true when not set.
+	ConfirmDestructiveActions *bool `yaml:"confirm_destructive_actions,omitempty"`
+	// ConfirmAgentSwitch asks for confirmation before switching agents while
+	// the current one is working. Defaults to true when not set.
+	ConfirmAgentSwitch *bool `yaml:"confirm_agent_switch,omitempty"`
+	// GenerateTitles uses an extra LLM call to generate session titles.
+	// When false, the first user message is used as the title instead.
+	// Defaults to true when not set.
+	GenerateTitles *bool `yaml:"generate_titles,omitempty"`
+	// LargePaste is what the editor does with the pastes over PasteMaxLines
+	// lines or PasteMaxChars characters: "attach" adds them as an attachment,
+	// "collapse" shows them as a "[pasted N lines]" token that's expanded
+	// when sending, and "inline" inserts them as is. Defaults to "attach".
+	LargePaste string `yaml:"large_paste,omitempty"`
+	// PasteMaxLines is the number of lines over which a paste is large.
+	// Defaults to 5.
+	PasteMaxLines int `yaml:"paste_max_lines,omitempty"`
+	// PasteMaxChars is the number of characters over which a paste is large.
+	// Defaults to 500.
+	PasteMaxChars int `yaml:"paste_max_chars,omitempty"`
+	// SoftWrap wraps long lines in the editor. When false, long lines scroll
+	// horizontally instead. Defaults to true when not set.
+	SoftWrap *bool `yaml:"soft_wrap,omitempty"`
+	// EnterInsertsNewline swaps the Enter and Shift+Enter keys in the editor:
+	// Enter inserts a newline and Shift+Enter sends. It only applies in
+	// terminals supporting keyboard enhancements. Defaults to false.
+	EnterInsertsNewline bool `yaml:"enter_inserts_newline,omitempty"`
+	// Telemetry sends anonymous usage events and records OpenTelemetry
+	// spans. Setting TELEMETRY_ENABLED=false also disables it. Defaults to
+	// true when not set.
+	Telemetry *bool `yaml:"telemetry,omitempty"`
+	// HomeRelativePaths shows the paths under the home directory as ~/...
+	// in the TUI. Defaults to true when not set.
+	HomeRelativePaths *bool `yaml:"home_relative_paths,omitempty"`
+	// RenderDiagrams renders the mermaid flowcharts of the responses as ASCII
+	// diagrams. This is best-effort: the diagrams that can't be rendered are
+	// shown as code.
+	RenderDiagrams bool `yaml:"render_diagrams,omitempty"`
+	// ShowThroughput shows the generation speed, in output tokens per
+	// second, under the responses in the TUI.
+	ShowThroughput bool `yaml:"show_throughput,omitempty"`
+	// SeparateSubSessionCosts breaks the usage of the sub-sessions (tasks
+	// transferred to sub-agents) out of the parent's messages in the cost
+	// dialog, as their own section.
+	SeparateSubSessionCosts bool `yaml:"separate_sub_session_costs,omitempty"`
+	// RoleLabels replaces the labels shown above the messages in the TUI, by
+	// agent name, "assistant" for the messages without an agent name and
+	// "user" for the user's messages, which aren't labeled otherwise.
+	RoleLabels map[string]string `yaml:"role_labels,omitempty"`
+	// PromptPrefix is prepended to every plain message sent from the TUI.
+	PromptPrefix string `yaml:"prompt_prefix,omitempty"`
+	// PromptSuffix is appended to every plain message sent from the TUI.
+	PromptSuffix string `yaml:"prompt_suffix,omitempty"`
+	// ProviderConcurrency caps the number of concurrent requests sent to a
+	// provider (e.g. "anthropic": 2) across all the sessions of the TUI.
+	ProviderConcurrency map[string]int `yaml:"provider_concurrency,omitempty"`
+	// ToolResultLimits caps the size, in bytes, of the tool results sent back
+	// to the model, per toolset type (e.g. "shell": 16384). "*" applies to
+	// every other toolset type.
+	ToolResultLimits map[string]int `yaml:"tool_result_limits,omitempty"`
+	// ToolResultRenderers renders the results of the tools with the given
+	// names with a built-in renderer: "json" pretty-prints JSON results and
+	// "table" shows arrays of objects as tables.
+	ToolResultRenderers map[string]string `yaml:"tool_result_renderers,omitempty"`
+	// ToolTimeouts is the number of seconds a tool call can run before it's
+	// aborted, per toolset type (e.g. "mcp": 120). "*" applies to every other
+	// toolset type but the interactive ones, and 0 disables the timeout.
+	// Tool calls don't time out by default.
+	ToolTimeouts map[string]int `yaml:"tool_timeouts,omitempty"`
+	// RememberApprovals makes approving a tool call also approve the next
+	// calls to the same tool without asking: "session" remembers approvals
+	// for the rest of the session, a duration such as "15m" for that long.
+	// Off when empty.
+	RememberApprovals string `yaml:"remember_approvals,omitempty"`
+	// RememberApprovalsByArguments only remembers an approval for the calls
+	// with the same arguments.
+	RememberApprovalsByArguments bool `yaml:"remember_approvals_by_arguments,omitempty"`
+	// RetryWithoutTools sends a request again without tools when the model
+	// rejects it because it doesn't support tool calling.
+	RetryWithoutTools bool `yaml:"retry_without_tools,omitempty"`
+	// FirstMessage controls what the TUI sends when it starts a new session
+	// without a message on the command line: "silent" (the default) waits
+	// for the user, "greet" asks the agent to introduce itself and "prompt"
+	// sends FirstMessagePrompt.
+	FirstMessage string `yaml:"first_message,omitempty"`
+	// FirstMessagePrompt is sent at startup when FirstMessage is "prompt".
+	FirstMessagePrompt string `yaml:"first_message_prompt,omitempty"`
+	// DisableURLAttachments refuses to attach remote URLs to the messages,
+	// so the TUI never fetches them.
+	DisableURLAttachments bool `yaml:"disable_url_attachments,omitempty"`
+	// QuietHours is a daily window, in local time, e.g. "22:00-07:00",
+	// during which the TUI doesn't ring the terminal bell and only shows
+	// error notifications. Off when empty.
+	QuietHours string `yaml:"quiet_hours,omitempty"`
+	// AutoCollapseReasoning collapses the reasoning blocks expanded in the
+	// TUI once the agent starts answering. Defaults to true when not set.
+	AutoCollapseReasoning *bool `yaml:"auto_collapse_reasoning,omitempty"`
+	// SpeechBackend is the speech-to-text backend used by /speak. Defaults
+	// to "openai".
+	SpeechBackend string `yaml:"speech_backend,omitempty"`
+	// SpeechInputDevice is the ID of the input device /speak listens to, as
+	// listed by /input-device. Defaults to the system's default input device.
+	SpeechInputDevice string `yaml:"speech_input_device,omitempty"`
+}
+
+// First message behaviors, see Settings.FirstMessage.
+const (
+	FirstMessageSilent = "silent"
+	FirstMessageGreet  = "greet"
+	FirstMessagePrompt = "prompt"
+)
+
+// greetingPrompt is sent at startup when FirstMessage is "greet".
+const greetingPrompt = "Hi! Briefly introduce yourself and tell me what you can help with."
+
+// GetFirstMessage returns the message to send when the TUI starts a new
+// session, or "" to wait for the user.
+func (s *Settings) GetFirstMessage() string {
+	if s == nil {
+		return ""
+	}
+	switch s.FirstMessage {
+	case FirstMessageGreet:
+		return greetingPrompt
+	case FirstMessagePrompt:
+		return strings.TrimSpace(s.FirstMessagePrompt)
+	default:
+		return ""
+	}
 }
 
 // DefaultTabTitleMaxLength is the default maximum tab title length when not configured.
@@ -68,6 +230,139 @@ func (s *Settings) GetTabTitleMaxLength() int {
 	return s.TabTitleMaxLength
 }
 
+// DefaultAutosaveInterval is the default interval between two autosaves.
+const DefaultAutosaveInterval = 30 * time.Second
+
+// GetAutosaveInterval returns the interval between two autosaves, or 0 when
+// autosave is disabled.
+func (s *Settings) GetAutosaveInterval() time.Duration {
+	if s == nil || s.AutosaveInterval == nil {
+		return DefaultAutosaveInterval
+	}
+	return time.Duration(max(*s.AutosaveInterval, 0)) * time.Second
+}
+
+// DefaultStreamFlushInterval is the default interval between two renders of
+// the streamed responses.
+const DefaultStreamFlushInterval = 50 * time.Millisecond
+
+// GetStreamFlushInterval returns the interval between two renders of the
+// streamed responses, or 0 when every chunk is rendered as it arrives.
+func (s *Settings) GetStreamFlushInterval() time.Duration {
+	if s == nil || s.StreamFlushInterval == nil {
+		return DefaultStreamFlushInterval
+	}
+	return time.Duration(max(*s.StreamFlushInterval, 0)) * time.Millisecond
+}
+
+// DefaultMaxTabs is the default maximum number of open tabs when not configured.
+const DefaultMaxTabs = 16
+
+// GetMaxTabs returns the configured maximum number of open tabs, falling back to the default.
+func (s *Settings) GetMaxTabs() int {
+	if s == nil || s.MaxTabs <= 0 {
+		return DefaultMaxTabs
+	}
+	return s.MaxTabs
+}
+
+// The values of the large_paste setting.
+const (
+	LargePasteAttach   = "attach"
+	LargePasteCollapse = "collapse"
+	LargePasteInline   = "inline"
+)
+
+// GetLargePaste returns what the editor does with large pastes, falling back
+// to attaching them for unknown values.
+func (s *Settings) GetLargePaste() string {
+	if s == nil {
+		return LargePasteAttach
+	}
+	switch s.LargePaste {
+	case LargePasteCollapse, LargePasteInline:
+		return s.LargePaste
+	default:
+		return LargePasteAttach
+	}
+}
+
+// DefaultPasteMaxLines is the default number of lines over which a paste is
+// large.
+const DefaultPasteMaxLines = 5
+
+// GetPasteMaxLines returns the number of lines over which a paste is large,
+// falling back to the default.
+func (s *Settings) GetPasteMaxLines() int {
+	if s == nil || s.PasteMaxLines <= 0 {
+		return DefaultPasteMaxLines
+	}
+	return s.PasteMaxLines
+}
+
+// DefaultPasteMaxChars is the default number of characters over which a
+// paste is large.
+const DefaultPasteMaxChars = 500
+
+// GetPasteMaxChars returns the number of characters over which a paste is
+// large, falling back to the default.
+func (s *Settings) GetPasteMaxChars() int {
+	if s == nil || s.PasteMaxChars <= 0 {
+		return DefaultPasteMaxChars
+	}
+	return s.PasteMaxChars
+}
+
+// DefaultDoubleClickThreshold is the default maximum time between two clicks
+// of a double-click.
+const DefaultDoubleClickThreshold = 400 * time.Millisecond
+
+// GetDoubleClickThreshold returns the configured double-click threshold, falling back to the default.
+func (s *Settings) GetDoubleClickThreshold() time.Duration {
+	if s == nil || s.DoubleClickThreshold <= 0 {
+		return DefaultDoubleClickThreshold
+	}
+	return time.Duration(s.DoubleClickThreshold) * time.Millisecond
+}
+
+// DefaultNotificationPosition is the default position of the notifications.
+const DefaultNotificationPosition = "bottom-right"
+
+// GetNotificationPosition returns the configured notification position,
+// falling back to the default when it isn't set or isn't valid.
+func (s *Settings) GetNotificationPosition() string {
+	if s == nil {
+		return DefaultNotificationPosition
+	}
+	vertical, horizontal, _ := strings.Cut(s.NotificationPosition, "-")
+	if (vertical != "top" && vertical != "bottom") ||
+		(horizontal != "left" && horizontal != "center" && horizontal != "right") {
+		return DefaultNotificationPosition
+	}
+	return s.NotificationPosition
+}
+
+const (
+	// DefaultNotificationDuration is how long notifications stay on screen by default.
+	DefaultNotificationDuration = 3 * time.Second
+	// DefaultErrorNotificationDuration is how long error notifications stay on screen by default.
+	DefaultErrorNotificationDuration = 5 * time.Second
+)
+
+// GetNotificationDuration returns how long the notifications of the given
+// type ("info", "success", "warning" or "error") stay on screen.
+func (s *Settings) GetNotificationDuration(kind string) time.Duration {
+	if s != nil {
+		if seconds := s.NotificationDurations[kind]; seconds > 0 {
+			return time.Duration(seconds) * time.Second
+		}
+	}
+	if kind == "error" {
+		return DefaultErrorNotificationDuration
+	}
+	return DefaultNotificationDuration
+}
+
 // GetSplitDiffView returns whether split diff view is enabled, defaulting to true.
 func (s *Settings) GetSplitDiffView() bool {
 	if s == nil || s.SplitDiffView == nil {
@@ -76,6 +371,163 @@ func (s *Settings) GetSplitDiffView() bool {
 	return *s.SplitDiffView
 }
 
+// GetConfirmDestructiveActions returns whether destructive actions require confirmation, defaulting to true.
+func (s *Settings) GetConfirmDestructiveActions() bool {
+	if s == nil || s.ConfirmDestructiveActions == nil {
+		return true
+	}
+	return *s.ConfirmDestructiveActions
+}
+
+// GetConfirmAgentSwitch returns whether switching agents while the current
+// one is working requires confirmation, defaulting to true.
+func (s *Settings) GetConfirmAgentSwitch() bool {
+	if s == nil || s.ConfirmAgentSwitch == nil {
+		return true
+	}
+	return *s.ConfirmAgentSwitch
+}
+
+// GetGenerateTitles returns whether session titles are generated by the model, defaulting to true.
+func (s *Settings) GetGenerateTitles() bool {
+	if s == nil || s.GenerateTitles == nil {
+		return true
+	}
+	return *s.GenerateTitles
+}
+
+// GetTelemetry returns whether telemetry is enabled, defaulting to true.
+func (s *Settings) GetTelemetry() bool {
+	if s == nil || s.Telemetry == nil {
+		return true
+	}
+	return *s.Telemetry
+}
+
+// GetToolTimeouts returns the ToolTimeouts as durations.
+func (s *Settings) GetToolTimeouts() map[string]time.Duration {
+	if s == nil || len(s.ToolTimeouts) == 0 {
+		return nil
+	}
+	timeouts := make(map[string]time.Duration, len(s.ToolTimeouts))
+	for toolsetType, seconds := range s.ToolTimeouts {
+		timeouts[toolsetType] = time.Duration(max(seconds, 0)) * time.Second
+	}
+	return timeouts
+}
+
+// GetRememberApprovals returns how long approvals are remembered, zero for
+// the rest of the session, and whether they are remembered at all.
+func (s *Settings) GetRememberApprovals() (time.Duration, bool) {
+	if s == nil || s.RememberApprovals == "" {
+		return 0, false
+	}
+	if s.RememberApprovals == "session" {
+		return 0, true
+	}
+	window, err := time.ParseDuration(s.RememberApprovals)
+	if err != nil || window <= 0 {
+		slog.Warn("Ignoring invalid remember_approvals setting", "value", s.RememberApprovals, "error", err)
+		return 0, false
+	}
+	return window, true
+}
+
+// IsYOLODir reports whether dir is one of YOLODirs, or below one of them.
+func (s *Settings) IsYOLODir(dir string) bool {
+	if s == nil || len(s.YOLODirs) == 0 || dir == "" {
+		return false
+	}
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return false
+	}
+	for _, yoloDir := range s.YOLODirs {
+		if rest, ok := strings.CutPrefix(yoloDir, "~"); ok && (rest == "" || rest[0] == '/' || rest[0] == filepath.Separator) {
+			yoloDir = paths.GetHomeDir() + rest
+		}
+		yoloDir, err := filepath.Abs(yoloDir)
+		if err != nil {
+			continue
+		}
+		rel, err := filepath.Rel(yoloDir, dir)
+		if err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return true
+		}
+	}
+	return false
+}
+
+// QuietHours is a daily window of local time. It wraps around midnight when
+// End is before Start.
+type QuietHours struct {
+	// Start and End are offsets from midnight.
+	Start, End time.Duration
+}
+
+// Contains reports whether t falls within the window.
+func (q QuietHours) Contains(t time.Time) bool {
+	offset := time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute
+	if q.Start <= q.End {
+		return offset >= q.Start && offset < q.End
+	}
+	return offset >= q.Start || offset < q.End
+}
+
+// GetQuietHours returns the quiet hours window and whether it's set.
+func (s *Settings) GetQuietHours() (QuietHours, bool) {
+	if s == nil || s.QuietHours == "" {
+		return QuietHours{}, false
+	}
+	start, end, ok := strings.Cut(s.QuietHours, "-")
+	if ok {
+		var q QuietHours
+		var errStart, errEnd error
+		q.Start, errStart = parseTimeOfDay(start)
+		q.End, errEnd = parseTimeOfDay(end)
+		if errStart == nil && errEnd == nil && q.Start != q.End {
+			return q, true
+		}
+	}
+	slog.Warn("Ignoring invalid quiet_hours setting, expected HH:MM-HH:MM", "value", s.QuietHours)
+	return QuietHours{}, false
+}
+
+// parseTimeOfDay parses a HH:MM time of day into an offset from midnight.
+func parseTimeOfDay(value string) (time.Duration, error) {
+	t, err := time.Parse("15:04", strings.TrimSpace(value))
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+// GetSoftWrap returns whether long lines are soft-wrapped in the editor, defaulting to true.
+func (s *Settings) GetSoftWrap() bool {
+	if s == nil || s.SoftWrap == nil {
+		return true
+	}
+	return *s.SoftWrap
+}
+
+// GetAutoCollapseReasoning returns whether expanded reasoning blocks
+// collapse once the agent starts answering, defaulting to true.
+func (s *Settings) GetAutoCollapseReasoning() bool {
+	if s == nil || s.AutoCollapseReasoning == nil {
+		return true
+	}
+	return *s.AutoCollapseReasoning
+}
+
+// GetHomeRelativePaths returns whether paths under the home directory are
+// shown as ~/..., defaulting to true.
+func (s *Settings) GetHomeRelativePaths() bool {
+	if s == nil || s.HomeRelativePaths == nil {
+		return true
+	}
+	return *s.HomeRelativePaths
+}
+
 // CredentialHelper contains configuration for a credential helper command
 // that retrieves Docker credentials (DOCKER_TOKEN) from an external source.
 type CredentialHelper struct {
@@ -207,6 +659,36 @@ func (c *Config) migrateFromLegacy(legacyPath string) bool {
 	return true
 }
 
+// updateMu serializes UpdateSettings calls, so that concurrent updates
+// don't overwrite each other's changes.
+var updateMu sync.Mutex
+
+// UpdateSettings loads the configuration, applies update to its settings and
+// saves it. Calls are serialized so that no update is lost, whatever the
+// goroutine they are made from.
+func UpdateSettings(update func(*Settings)) error {
+	return updateSettingsAt(Path(), legacyAliasesPath(), update)
+}
+
+func updateSettingsAt(configPath, legacyPath string, update func(*Settings)) error {
+	updateMu.Lock()
+	defer updateMu.Unlock()
+
+	cfg, err := loadFrom(configPath, legacyPath)
+	if err != nil {
+		return fmt.Errorf("loading user config: %w", err)
+	}
+	if cfg.Settings == nil {
+		cfg.Settings = &Settings{}
+	}
+	update(cfg.Settings)
+
+	if err := cfg.saveTo(configPath); err != nil {
+		return fmt.Errorf("saving user config: %w", err)
+	}
+	return nil
+}
+
 // Save saves the configuration to the config file
 func (c *Config) Save() error {
 	return c.saveTo(Path())
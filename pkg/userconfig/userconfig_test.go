@@ -3,13 +3,16 @@ package userconfig
 import (
 	"os"
 	"path/filepath"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/goccy/go-yaml"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/docker/cagent/pkg/config/latest"
+	"github.com/docker/cagent/pkg/paths"
 )
 
 func TestConfig_Empty(t *testing.T) {
@@ -171,6 +174,32 @@ func TestConfig_SaveAndLoad(t *testing.T) {
 	assert.Equal(t, config.Aliases["myagent"].Path, loaded.Aliases["myagent"].Path)
 }
 
+func TestUpdateSettings_Concurrent(t *testing.T) {
+	t.Parallel()
+
+	configFile := filepath.Join(t.TempDir(), "config.yaml")
+
+	var wg sync.WaitGroup
+	for _, update := range []func(*Settings){
+		func(s *Settings) { s.ShowThroughput = true },
+		func(s *Settings) { s.EnterInsertsNewline = true },
+		func(s *Settings) { s.SeparateSubSessionCosts = true },
+		func(s *Settings) { s.Theme = "dark" },
+	} {
+		wg.Go(func() {
+			assert.NoError(t, updateSettingsAt(configFile, "", update))
+		})
+	}
+	wg.Wait()
+
+	loaded, err := loadFrom(configFile, "")
+	require.NoError(t, err)
+	assert.True(t, loaded.Settings.ShowThroughput)
+	assert.True(t, loaded.Settings.EnterInsertsNewline)
+	assert.True(t, loaded.Settings.SeparateSubSessionCosts)
+	assert.Equal(t, "dark", loaded.Settings.Theme)
+}
+
 func TestConfig_MigrateFromLegacy(t *testing.T) {
 	t.Parallel()
 
@@ -838,3 +867,275 @@ func TestSettings_RestoreTabs(t *testing.T) {
 		})
 	}
 }
+
+func TestSettings_GetConfirmDestructiveActions(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		settings *Settings
+		expected bool
+	}{
+		{"nil settings", nil, true},
+		{"empty settings", &Settings{}, true},
+		{"explicitly disabled", &Settings{ConfirmDestructiveActions: boolPtr(false)}, false},
+		{"explicitly enabled", &Settings{ConfirmDestructiveActions: boolPtr(true)}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.expected, tt.settings.GetConfirmDestructiveActions())
+		})
+	}
+}
+
+func TestSettings_GetGenerateTitles(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		settings *Settings
+		expected bool
+	}{
+		{"nil settings", nil, true},
+		{"empty settings", &Settings{}, true},
+		{"explicitly disabled", &Settings{GenerateTitles: boolPtr(false)}, false},
+		{"explicitly enabled", &Settings{GenerateTitles: boolPtr(true)}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.expected, tt.settings.GetGenerateTitles())
+		})
+	}
+}
+
+func TestSettings_GetSoftWrap(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, (*Settings)(nil).GetSoftWrap())
+	assert.True(t, (&Settings{}).GetSoftWrap())
+	assert.False(t, (&Settings{SoftWrap: boolPtr(false)}).GetSoftWrap())
+}
+
+func TestSettings_GetHomeRelativePaths(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, (*Settings)(nil).GetHomeRelativePaths())
+	assert.True(t, (&Settings{}).GetHomeRelativePaths())
+	assert.False(t, (&Settings{HomeRelativePaths: boolPtr(false)}).GetHomeRelativePaths())
+}
+
+func TestSettings_GetMaxTabs(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, DefaultMaxTabs, (*Settings)(nil).GetMaxTabs())
+	assert.Equal(t, DefaultMaxTabs, (&Settings{MaxTabs: -1}).GetMaxTabs())
+	assert.Equal(t, 4, (&Settings{MaxTabs: 4}).GetMaxTabs())
+}
+
+func TestSettings_GetLargePaste(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, LargePasteAttach, (*Settings)(nil).GetLargePaste())
+	assert.Equal(t, LargePasteAttach, (&Settings{LargePaste: "bogus"}).GetLargePaste())
+	assert.Equal(t, LargePasteCollapse, (&Settings{LargePaste: "collapse"}).GetLargePaste())
+	assert.Equal(t, DefaultPasteMaxLines, (&Settings{}).GetPasteMaxLines())
+	assert.Equal(t, 50, (&Settings{PasteMaxLines: 50}).GetPasteMaxLines())
+	assert.Equal(t, DefaultPasteMaxChars, (&Settings{PasteMaxChars: -1}).GetPasteMaxChars())
+}
+
+func TestSettings_GetFirstMessage(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		settings *Settings
+		expected string
+	}{
+		{"nil settings", nil, ""},
+		{"empty settings", &Settings{}, ""},
+		{"silent", &Settings{FirstMessage: FirstMessageSilent, FirstMessagePrompt: "ignored"}, ""},
+		{"greet", &Settings{FirstMessage: FirstMessageGreet}, greetingPrompt},
+		{"prompt", &Settings{FirstMessage: FirstMessagePrompt, FirstMessagePrompt: " Check the build \n"}, "Check the build"},
+		{"prompt without text", &Settings{FirstMessage: FirstMessagePrompt}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.expected, tt.settings.GetFirstMessage())
+		})
+	}
+}
+
+func TestSettings_GetAutosaveInterval(t *testing.T) {
+	t.Parallel()
+
+	intPtr := func(v int) *int { return &v }
+
+	assert.Equal(t, DefaultAutosaveInterval, (*Settings)(nil).GetAutosaveInterval())
+	assert.Equal(t, DefaultAutosaveInterval, (&Settings{}).GetAutosaveInterval())
+	assert.Equal(t, time.Duration(0), (&Settings{AutosaveInterval: intPtr(0)}).GetAutosaveInterval())
+	assert.Equal(t, time.Duration(0), (&Settings{AutosaveInterval: intPtr(-5)}).GetAutosaveInterval())
+	assert.Equal(t, 5*time.Second, (&Settings{AutosaveInterval: intPtr(5)}).GetAutosaveInterval())
+}
+
+func TestSettings_GetStreamFlushInterval(t *testing.T) {
+	t.Parallel()
+
+	intPtr := func(v int) *int { return &v }
+
+	assert.Equal(t, DefaultStreamFlushInterval, (*Settings)(nil).GetStreamFlushInterval())
+	assert.Equal(t, DefaultStreamFlushInterval, (&Settings{}).GetStreamFlushInterval())
+	assert.Equal(t, time.Duration(0), (&Settings{StreamFlushInterval: intPtr(0)}).GetStreamFlushInterval())
+	assert.Equal(t, 100*time.Millisecond, (&Settings{StreamFlushInterval: intPtr(100)}).GetStreamFlushInterval())
+}
+
+func TestSettings_GetDoubleClickThreshold(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, DefaultDoubleClickThreshold, (*Settings)(nil).GetDoubleClickThreshold())
+	assert.Equal(t, DefaultDoubleClickThreshold, (&Settings{DoubleClickThreshold: -1}).GetDoubleClickThreshold())
+	assert.Equal(t, 800*time.Millisecond, (&Settings{DoubleClickThreshold: 800}).GetDoubleClickThreshold())
+}
+
+func TestSettings_GetNotificationPosition(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, DefaultNotificationPosition, (*Settings)(nil).GetNotificationPosition())
+	assert.Equal(t, DefaultNotificationPosition, (&Settings{NotificationPosition: "middle-right"}).GetNotificationPosition())
+	assert.Equal(t, DefaultNotificationPosition, (&Settings{NotificationPosition: "top"}).GetNotificationPosition())
+	assert.Equal(t, "top-center", (&Settings{NotificationPosition: "top-center"}).GetNotificationPosition())
+}
+
+func TestSettings_GetNotificationDuration(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, DefaultNotificationDuration, (*Settings)(nil).GetNotificationDuration("info"))
+	assert.Equal(t, DefaultErrorNotificationDuration, (*Settings)(nil).GetNotificationDuration("error"))
+
+	s := &Settings{NotificationDurations: map[string]int{"info": 1, "error": 10, "warning": -1}}
+	assert.Equal(t, time.Second, s.GetNotificationDuration("info"))
+	assert.Equal(t, 10*time.Second, s.GetNotificationDuration("error"))
+	assert.Equal(t, DefaultNotificationDuration, s.GetNotificationDuration("warning"))
+}
+
+func TestSettings_GetTelemetry(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, (*Settings)(nil).GetTelemetry())
+	assert.True(t, (&Settings{}).GetTelemetry())
+	assert.False(t, (&Settings{Telemetry: boolPtr(false)}).GetTelemetry())
+}
+
+func TestSettings_GetToolTimeouts(t *testing.T) {
+	t.Parallel()
+
+	assert.Nil(t, (*Settings)(nil).GetToolTimeouts())
+	assert.Nil(t, (&Settings{}).GetToolTimeouts())
+	assert.Equal(t, map[string]time.Duration{
+		"mcp":   2 * time.Minute,
+		"shell": 0,
+	}, (&Settings{ToolTimeouts: map[string]int{"mcp": 120, "shell": -1}}).GetToolTimeouts())
+}
+
+func TestSettings_GetRememberApprovals(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		value   string
+		window  time.Duration
+		enabled bool
+	}{
+		{"", 0, false},
+		{"session", 0, true},
+		{"15m", 15 * time.Minute, true},
+		{"soon", 0, false},
+		{"-5m", 0, false},
+	}
+	for _, tt := range tests {
+		window, enabled := (&Settings{RememberApprovals: tt.value}).GetRememberApprovals()
+		assert.Equal(t, tt.window, window, tt.value)
+		assert.Equal(t, tt.enabled, enabled, tt.value)
+	}
+	_, enabled := (*Settings)(nil).GetRememberApprovals()
+	assert.False(t, enabled)
+}
+
+func TestSettings_GetQuietHours(t *testing.T) {
+	t.Parallel()
+
+	at := func(hour, minute int) time.Time {
+		return time.Date(2025, 1, 1, hour, minute, 0, 0, time.Local)
+	}
+
+	overnight, ok := (&Settings{QuietHours: "22:00-07:30"}).GetQuietHours()
+	require.True(t, ok)
+	assert.True(t, overnight.Contains(at(23, 0)))
+	assert.True(t, overnight.Contains(at(0, 0)))
+	assert.True(t, overnight.Contains(at(7, 29)))
+	assert.False(t, overnight.Contains(at(7, 30)))
+	assert.False(t, overnight.Contains(at(12, 0)))
+
+	lunch, ok := (&Settings{QuietHours: "12:00 - 13:00"}).GetQuietHours()
+	require.True(t, ok)
+	assert.True(t, lunch.Contains(at(12, 30)))
+	assert.False(t, lunch.Contains(at(13, 0)))
+	assert.False(t, lunch.Contains(at(11, 59)))
+
+	for _, value := range []string{"", "22:00", "25:00-07:00", "08:00-08:00"} {
+		_, ok := (&Settings{QuietHours: value}).GetQuietHours()
+		assert.False(t, ok, value)
+	}
+	_, ok = (*Settings)(nil).GetQuietHours()
+	assert.False(t, ok)
+}
+
+func TestSettings_IsYOLODir(t *testing.T) {
+	t.Parallel()
+
+	scratch := t.TempDir()
+	settings := &Settings{YOLODirs: []string{scratch, "~/sandbox"}}
+
+	assert.True(t, settings.IsYOLODir(scratch))
+	assert.True(t, settings.IsYOLODir(filepath.Join(scratch, "project")))
+	assert.True(t, settings.IsYOLODir(filepath.Join(paths.GetHomeDir(), "sandbox", "demo")))
+	assert.False(t, settings.IsYOLODir(scratch+"-other"))
+	assert.False(t, settings.IsYOLODir(filepath.Dir(scratch)))
+	assert.False(t, settings.IsYOLODir(""))
+	assert.False(t, (&Settings{}).IsYOLODir(scratch))
+	assert.False(t, (*Settings)(nil).IsYOLODir(scratch))
+}
+
+func TestLoadWorkspace(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	workspace, err := LoadWorkspace(dir)
+	require.NoError(t, err)
+	assert.Equal(t, &Workspace{}, workspace)
+
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, ".cagent"), 0o755))
+	require.NoError(t, os.WriteFile(WorkspacePath(dir), []byte(`default_agent: reviewer
+permissions:
+  allow: ["read_*"]
+  allow_toolsets: [think]
+env:
+  GOFLAGS: -mod=vendor
+`), 0o644))
+
+	workspace, err = LoadWorkspace(dir)
+	require.NoError(t, err)
+	assert.Equal(t, "reviewer", workspace.DefaultAgent)
+	require.NotNil(t, workspace.Permissions)
+	assert.Equal(t, []string{"read_*"}, workspace.Permissions.Allow)
+	assert.Equal(t, []string{"think"}, workspace.Permissions.AllowToolsets)
+	assert.Equal(t, map[string]string{"GOFLAGS": "-mod=vendor"}, workspace.Env)
+
+	require.NoError(t, os.WriteFile(WorkspacePath(dir), []byte("env: [\n"), 0o644))
+	_, err = LoadWorkspace(dir)
+	require.Error(t, err)
+}
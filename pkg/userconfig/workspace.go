@@ -0,0 +1,53 @@
+package userconfig
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/goccy/go-yaml"
+
+	"github.com/docker/cagent/pkg/config/latest"
+)
+
+// Workspace is the configuration of the work done in a directory, read from
+// .cagent/config.yaml in that directory. It's meant to be committed with a
+// project so that everybody working on it gets the same conventions. Its
+// settings take precedence over the user config and the agent config.
+type Workspace struct {
+	// DefaultAgent is the agent the sessions started in the directory start
+	// on, unless one is chosen with --agent.
+	DefaultAgent string `yaml:"default_agent,omitempty"`
+	// Permissions auto-approves, asks for or denies the tool calls of the
+	// sessions started in the directory. It has the format of the
+	// permissions of the agent config and is checked before them.
+	Permissions *latest.PermissionsConfig `yaml:"permissions,omitempty"`
+	// Env sets environment variables for the tools run by the sessions
+	// started in the directory.
+	Env map[string]string `yaml:"env,omitempty"`
+}
+
+// WorkspacePath returns the path to the workspace config of dir.
+func WorkspacePath(dir string) string {
+	return filepath.Join(dir, ".cagent", "config.yaml")
+}
+
+// LoadWorkspace loads the workspace config of dir, returning an empty one
+// when dir has none.
+func LoadWorkspace(dir string) (*Workspace, error) {
+	workspace := &Workspace{}
+
+	data, err := os.ReadFile(WorkspacePath(dir))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return workspace, nil
+		}
+		return nil, fmt.Errorf("failed to read workspace config: %w", err)
+	}
+
+	if err := yaml.Unmarshal(data, workspace); err != nil {
+		return nil, fmt.Errorf("failed to parse workspace config: %w", err)
+	}
+
+	return workspace, nil
+}